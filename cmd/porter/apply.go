@@ -0,0 +1,50 @@
+package main
+
+import (
+	"get.porter.sh/porter/pkg/porter"
+	"github.com/spf13/cobra"
+)
+
+func buildApplyCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.BatchApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a set of credential sets, parameter sets and installations",
+		Long: `Apply every credential set, parameter set and installation document found in the specified files and directories.
+
+Documents are identified by their schemaType field, and documents without a schemaType are skipped. Only files with a .yaml, .yml or .json extension are read from a directory.
+
+Credential and parameter sets are applied first. Installations are then reconciled, up to --parallelism at a time, running their bundle when changes are detected. The output of each installation is printed together, in dependency order. An installation is only reconciled after the installations listed in its dependsOn field have completed successfully. Each dependency may be the name of an installation in the same namespace, NAMESPACE/NAME, or a label selector KEY=VALUE that matches the installations with that label in the same batch.
+
+When the namespace is not set in a document, the namespace specified with --namespace is used.`,
+		Example: `  porter apply -f ./environment/
+  porter apply -f creds.yaml -f mybuns.yaml
+  porter apply -f ./environment/ --dry-run
+  porter apply -f ./environment/ --parallelism 2 -o json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(p.Context)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.BatchApply(cmd.Context(), opts)
+		},
+	}
+	cmd.Annotations = map[string]string{
+		"group": "resource",
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVarP(&opts.Files, "file", "f", nil,
+		"File or directory containing the documents to apply. May be specified multiple times.")
+	f.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"Namespace in which the documents are defined. Defaults to the namespace defined in each document.")
+	f.BoolVar(&opts.Force, "force", false,
+		"Force the bundles to be executed when no changes are detected.")
+	f.BoolVar(&opts.DryRun, "dry-run", false,
+		"Print what would be applied without saving any documents or executing any bundles.")
+	f.IntVar(&opts.Parallelism, "parallelism", porter.BatchApplyDefaultParallelism,
+		"Maximum number of installations to reconcile at the same time.")
+	f.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format for the summary.  Allowed values: plaintext, json, yaml")
+	return cmd
+}
@@ -20,6 +20,7 @@ func buildCredentialsCommands(p *porter.Porter) *cobra.Command {
 	cmd.AddCommand(buildCredentialsDeleteCommand(p))
 	cmd.AddCommand(buildCredentialsShowCommand(p))
 	cmd.AddCommand(buildCredentialsCreateCommand(p))
+	cmd.AddCommand(buildCredentialsValidateCommand(p))
 
 	return cmd
 }
@@ -243,3 +244,37 @@ func buildCredentialsCreateCommand(p *porter.Porter) *cobra.Command {
 
 	return cmd
 }
+
+func buildCredentialsValidateCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.CredentialValidateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate NAME",
+		Short: "Validate a credential set against a bundle",
+		Long: `Validate a credential set against a bundle without running the bundle.
+
+Every credential in the set is resolved, and Porter checks that the credentials that the bundle requires are defined in the set. Credentials that the bundle does not use are ignored.
+All the problems that are found are reported together.
+
+By default, the credentials used by every action of the bundle are validated. Use --action to only validate the credentials used by an action.`,
+		Example: `  porter credentials validate myset --reference ghcr.io/getporter/examples/porter-hello:v0.2.0
+  porter credentials validate myset --reference ghcr.io/getporter/examples/porter-hello:v0.2.0 --action upgrade
+  porter credentials validate myset --file myapp/porter.yaml --namespace dev`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(cmd.Context(), args, p)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ValidateCredentialSet(cmd.Context(), opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"Namespace in which the credential set is defined. Defaults to the global namespace.")
+	f.StringVar(&opts.Action, "action", "",
+		"Only validate the credentials used by the specified action. Defaults to every action.")
+	addBundleDefinitionFlags(f, &opts.BundleDefinitionOptions)
+	addBundlePullFlags(f, &opts.BundlePullOptions)
+
+	return cmd
+}
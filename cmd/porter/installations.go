@@ -20,6 +20,8 @@ func buildInstallationCommands(p *porter.Porter) *cobra.Command {
 	cmd.AddCommand(buildInstallationsListCommand(p))
 	cmd.AddCommand(buildInstallationShowCommand(p))
 	cmd.AddCommand(buildInstallationApplyCommand(p))
+	cmd.AddCommand(buildInstallationDriftCommand(p))
+	cmd.AddCommand(buildInstallationRollbackCommand(p))
 	cmd.AddCommand(buildInstallationOutputsCommands(p))
 	cmd.AddCommand(buildInstallationDeleteCommand(p))
 	cmd.AddCommand(buildInstallationLogCommands(p))
@@ -108,6 +110,78 @@ Optional output formats include json and yaml.
 	return &cmd
 }
 
+func buildInstallationDriftCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.DriftOptions{}
+
+	cmd := cobra.Command{
+		Use:   "drift [INSTALLATION]",
+		Short: "Show how installations have changed since their last run",
+		Long: `Compare the desired state of installations with their last run, and report exactly which parameters, credentials, parameter sets, credential sets or bundle version have changed.
+
+An installation that is out of sync is executed the next time that it is applied. Adding or removing parameter sets and credential sets is reported, but only puts the installation out of sync when the resolved parameters or credentials change. When no installation name is specified, every installation in the namespace is checked.
+
+Sensitive parameter and credential values are never included in the output, only that they have changed.
+
+Optional output formats include json and yaml.`,
+		Example: `  porter installation drift
+  porter installation drift mysql --namespace dev
+  porter installation drift --all-namespaces -o json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.PrintInstallationsDrift(cmd.Context(), opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"Namespace in which the installation is defined. Defaults to the global namespace.")
+	f.BoolVar(&opts.AllNamespaces, "all-namespaces", false,
+		"Check installations in all namespaces.")
+	f.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format.  Allowed values: plaintext, json, yaml")
+
+	return &cmd
+}
+
+func buildInstallationRollbackCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.RollbackOptions{}
+
+	cmd := cobra.Command{
+		Use:   "rollback INSTALLATION",
+		Short: "Roll back an installation to a previous run",
+		Long: `Roll back an installation to the bundle, parameters, parameter sets and credential sets used by a previous successful install or upgrade run.
+
+The installation is updated to match the previous run, and then upgraded. Sensitive parameter values are restored from the secret store. The new run records the ID of the run that was restored.
+
+Use porter installation runs list to find the ID of a previous run.`,
+		Example: `  porter installation rollback mysql --previous
+  porter installation rollback mysql --to-run 01G1VJGY43HT3KZN82DS6DDPWK --namespace dev
+  porter installation rollback mysql --previous --dry-run -o json`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.RollbackInstallation(cmd.Context(), opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"Namespace in which the installation is defined. Defaults to the global namespace.")
+	f.StringVar(&opts.RunID, "to-run", "",
+		"ID of the run to roll back to.")
+	f.BoolVar(&opts.Previous, "previous", false,
+		"Roll back to the successful install or upgrade run before the current one.")
+	f.BoolVar(&opts.DryRun, "dry-run", false,
+		"Print the action that would be executed, and the restored parameters. The bundle is not executed.")
+	f.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format for --dry-run.  Allowed values: plaintext, json, yaml")
+
+	return &cmd
+}
+
 func buildInstallationApplyCommand(p *porter.Porter) *cobra.Command {
 	opts := porter.ApplyOptions{}
 
@@ -124,6 +198,7 @@ You can use the show command to create the initial file:
 `,
 		Example: `  porter installation apply myapp.yaml
   porter installation apply myapp.yaml --dry-run
+  porter installation apply myapp.yaml --dry-run -o json
   porter installation apply myapp.yaml --force`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(p.Context, args)
@@ -139,7 +214,9 @@ You can use the show command to create the initial file:
 	f.BoolVar(&opts.Force, "force", false,
 		"Force the bundle to be executed when no changes are detected.")
 	f.BoolVar(&opts.DryRun, "dry-run", false,
-		"Evaluate if the bundle would be executed based on the changes in the file.")
+		"Print the action that would be executed, and the resolved parameters, based on the changes in the file. The bundle is not executed.")
+	f.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format for --dry-run.  Allowed values: plaintext, json, yaml")
 	return &cmd
 }
 
@@ -149,7 +226,7 @@ func buildInstallationDeleteCommand(p *porter.Porter) *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "delete [INSTALLATION]",
 		Short: "Delete an installation",
-		Long:  "Deletes all records and outputs associated with an installation",
+		Long:  "Deletes all records and outputs associated with an installation, and the secrets that Porter created for its sensitive parameters and outputs when the secrets plugin supports deleting secrets.",
 		Example: `  porter installation delete
   porter installation delete wordpress
   porter installation delete --force
@@ -180,6 +257,8 @@ func buildInstallationRunsCommands(p *porter.Porter) *cobra.Command {
 	}
 
 	cmd.AddCommand(buildInstallationRunsListCommand(p))
+	cmd.AddCommand(buildInstallationRunsShowCommand(p))
+	cmd.AddCommand(buildInstallationRunsDiffCommand(p))
 
 	return cmd
 }
@@ -213,6 +292,64 @@ func buildInstallationRunsListCommand(p *porter.Porter) *cobra.Command {
 	return &cmd
 }
 
+func buildInstallationRunsShowCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.RunShowOptions{}
+
+	cmd := cobra.Command{
+		Use:   "show RUN_ID",
+		Short: "Show a run of an Installation",
+		Long: `Show a run of an Installation, including the result of each step that it ran.
+
+For each step, the mixin, description, status, duration, exit code and the names of the outputs that it generated are printed. Steps are only recorded for bundles built with a version of Porter that supports the step report.
+
+Use porter installation runs list to find the ID of a run.`,
+		Example: `  porter installations runs show 01FZVC5AVP8Z7A78CSCP1EJ604
+  porter installations runs show 01FZVC5AVP8Z7A78CSCP1EJ604 --output json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ShowInstallationRun(cmd.Context(), opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format.  Allowed values: plaintext, json, yaml")
+
+	return &cmd
+}
+
+func buildInstallationRunsDiffCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.RunDiffOptions{}
+
+	cmd := cobra.Command{
+		Use:   "diff RUN_A RUN_B",
+		Short: "Compare two runs of an Installation",
+		Long: `Compare two runs of an Installation.
+
+The bundle reference and digest, action, parameters, credential mappings, parameter and credential digests, outputs and final status of the runs are compared, and only the differences are printed. Sensitive parameter and output values are compared but never printed.
+
+Use porter installation runs list to find the ID of a run.`,
+		Example: `  porter installations runs diff 01FZVC5AVP8Z7A78CSCP1EJ604 01FZVC5AVP8Z7A78CSCP1EJ605
+  porter installations runs diff 01FZVC5AVP8Z7A78CSCP1EJ604 01FZVC5AVP8Z7A78CSCP1EJ605 --output json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.PrintRunDiff(cmd.Context(), opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format.  Allowed values: plaintext, json, yaml")
+
+	return &cmd
+}
+
 func buildInstallationInstallCommand(p *porter.Porter) *cobra.Command {
 	opts := porter.NewInstallOptions()
 	cmd := &cobra.Command{
@@ -224,6 +361,8 @@ The first argument is the name of the installation to create. This defaults to t
 
 Once a bundle has been successfully installed, the install action cannot be repeated. This is a precaution to avoid accidentally overwriting an existing installation. If you need to re-run install, which is common when authoring a bundle, you can use the --force flag to by-pass this check.
 
+When an action fails, use the --resume flag to run it again starting from the step that failed. The steps completed by the failed run are skipped and their outputs are restored.
+
 Porter uses the docker driver as the default runtime for executing a bundle image, but an alternate driver may be supplied via '--driver/-d' or the PORTER_RUNTIME_DRIVER environment variable.
 For example, the 'debug' driver may be specified, which simply logs the info given to it and then exits.
 
@@ -241,6 +380,7 @@ The docker driver runs the bundle container using the local Docker host. To use
   porter installation install --credential-set azure --credential-set kubernetes
   porter installation install --driver debug
   porter installation install --label env=dev --label owner=myuser
+  porter installation install MyApp --resume
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(cmd.Context(), args, p)
@@ -257,6 +397,8 @@ The docker driver runs the bundle container using the local Docker host. To use
 	f.StringSliceVarP(&opts.Labels, "label", "l", nil,
 		"Associate the specified labels with the installation. May be specified multiple times.")
 	f.BoolVar(&opts.VerifyBundleBeforeExecution, "verify-bundle", false, "Verify the bundle signature before executing")
+	f.BoolVar(&opts.Resume, "resume", false,
+		"Resume the installation's last run, which failed, skipping the steps that it completed. The bundle must not have changed since the failed run.")
 	addBundleActionFlags(f, opts)
 	f.StringVar(&opts.DependenciesVersionStrategy, "dependencies-version-strategy", "",
 		"Strategy for resolving dependency version ranges. Allowed values: exact, max-patch, max-minor, min.")
@@ -280,6 +422,8 @@ func buildInstallationUpgradeCommand(p *porter.Porter) *cobra.Command {
 
 The first argument is the installation name to upgrade. This defaults to the name of the bundle.
 
+When an action fails, use the --resume flag to run it again starting from the step that failed. The steps completed by the failed run are skipped and their outputs are restored.
+
 Porter uses the docker driver as the default runtime for executing a bundle image, but an alternate driver may be supplied via '--driver/-d' or the PORTER_RUNTIME_DRIVER environment variable.
 For example, the 'debug' driver may be specified, which simply logs the info given to it and then exits.
 
@@ -296,6 +440,7 @@ The docker driver runs the bundle container using the local Docker host. To use
   porter installation upgrade --param config=@config.json
   porter installation upgrade --credential-set azure --credential-set kubernetes
   porter installation upgrade --driver debug
+  porter installation upgrade MyApp --resume
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(cmd.Context(), args, p)
@@ -313,6 +458,8 @@ The docker driver runs the bundle container using the local Docker host. To use
 		"Version to which the installation should be upgraded. This represents the version of the bundle, which assumes the convention of setting the bundle tag to its version.")
 	f.BoolVar(&opts.ForceUpgrade, "force-upgrade", false,
 		"Force the upgrade to run even if the current installation is marked as failed.")
+	f.BoolVar(&opts.Resume, "resume", false,
+		"Resume the installation's last run, which failed, skipping the steps that it completed. The bundle must not have changed since the failed run.")
 	addBundleActionFlags(f, opts)
 	f.StringVar(&opts.DependenciesVersionStrategy, "dependencies-version-strategy", "",
 		"Strategy for resolving dependency version ranges. Allowed values: exact, max-patch, max-minor, min.")
@@ -29,9 +29,12 @@ func buildInstallationLogShowCommand(p *porter.Porter) *cobra.Command {
 		Short: "Show the logs from an installation",
 		Long: `Show the logs from an installation.
 
-Either display the logs from a specific run of a bundle with --run, or use --installation to display the logs from its most recent run.`,
+Either display the logs from a specific run of a bundle with --run, or use --installation to display the logs from its most recent run.
+
+Logs are saved periodically while a bundle is running. Use --follow to print the logs of a run that is in progress as they are saved, until the run completes.`,
 		Example: `  porter installation logs show --installation wordpress --namespace dev
-  porter installations logs show --run 01EZSWJXFATDE24XDHS5D5PWK6`,
+  porter installations logs show --run 01EZSWJXFATDE24XDHS5D5PWK6
+  porter installations logs show --installation wordpress --follow`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate(p.Context)
 		},
@@ -47,6 +50,8 @@ Either display the logs from a specific run of a bundle with --run, or use --ins
 		"The installation that generated the logs.")
 	f.StringVarP(&opts.RunID, "run", "r", "",
 		"The bundle run that generated the logs.")
+	f.BoolVarP(&opts.Follow, "follow", "f", false,
+		"Follow the logs of a run that is in progress until it completes.")
 
 	return cmd
 }
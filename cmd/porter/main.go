@@ -233,13 +233,16 @@ Try our QuickStart https://porter.sh/quickstart to learn how to use Porter.
 	cmd.AddCommand(buildRunCommand(p))
 	cmd.AddCommand(buildBundleCommands(p))
 	cmd.AddCommand(buildInstallationCommands(p))
+	cmd.AddCommand(buildApplyCommand(p))
 	cmd.AddCommand(buildMixinCommands(p))
 	cmd.AddCommand(buildPluginsCommands(p))
 	cmd.AddCommand(buildCredentialsCommands(p))
 	cmd.AddCommand(buildParametersCommands(p))
+	cmd.AddCommand(buildSecretsCommands(p))
 	cmd.AddCommand(buildConfigCommands(p))
 	cmd.AddCommand(buildCompletionCommand(p))
 	cmd.AddCommand(buildMCPCommand(p))
+	cmd.AddCommand(buildOperatorCommands(p))
 	//use -ldflags "-X main.includeGRPCServer=true" during build to include
 	grpcServer, _ := strconv.ParseBool(includeGRPCServer)
 	if grpcServer {
@@ -16,6 +16,7 @@ import (
 
 func TestCommandWiring(t *testing.T) {
 	testcases := []string{
+		"apply",
 		"build",
 		"create",
 		"install",
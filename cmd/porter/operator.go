@@ -0,0 +1,66 @@
+package main
+
+import (
+	"get.porter.sh/porter/pkg/operator"
+	"get.porter.sh/porter/pkg/porter"
+	"github.com/spf13/cobra"
+)
+
+func buildOperatorCommands(p *porter.Porter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator",
+		Short: "Continuously reconcile installations",
+		Long:  "Commands for running Porter as a long-running process that keeps installations in sync with their desired state.",
+	}
+
+	cmd.AddCommand(buildOperatorRunCommand(p))
+
+	return cmd
+}
+
+func buildOperatorRunCommand(p *porter.Porter) *cobra.Command {
+	opts := operator.Options{}
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Periodically reconcile drifted installations",
+		Long: `Periodically reconcile drifted installations.
+
+Every interval, the installations in the namespace are listed and compared with their last run, the same way as porter installation apply. Installations that are out of sync are reconciled by running the install, upgrade or uninstall action, up to --concurrency installations at a time. Uninstalled installations, and installations that do not reference a bundle in a registry, are ignored.
+
+When an installation fails to reconcile, it is not retried until the backoff expires. The backoff starts at --backoff and doubles after each consecutive failure, up to --max-backoff.
+
+Prometheus metrics are served from http://localhost:PORT/metrics, where PORT is --metrics-port. Set --metrics-port to 0 to disable the metrics.
+
+The command runs until it is interrupted.`,
+		Example: `  porter operator run
+  porter operator run --all-namespaces --interval 10m --concurrency 2
+  porter operator run --namespace prod --backoff 5m --max-backoff 2h --metrics-port 0
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return operator.NewOperator(p, opts).Run(cmd.Context())
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"Namespace of the installations to reconcile. Defaults to the global namespace.")
+	f.BoolVar(&opts.AllNamespaces, "all-namespaces", false,
+		"Reconcile the installations in all namespaces.")
+	f.DurationVar(&opts.Interval, "interval", operator.DefaultInterval,
+		"Time between checking the installations for drift.")
+	f.Float64Var(&opts.Jitter, "jitter", operator.DefaultJitter,
+		"Fraction of the interval, between 0 and 1, that is randomly added to each interval.")
+	f.IntVar(&opts.Concurrency, "concurrency", operator.DefaultConcurrency,
+		"Maximum number of installations to reconcile at the same time.")
+	f.DurationVar(&opts.Backoff, "backoff", operator.DefaultBackoff,
+		"Time to wait before retrying an installation that failed to reconcile. Doubles after each consecutive failure.")
+	f.DurationVar(&opts.MaxBackoff, "max-backoff", operator.DefaultMaxBackoff,
+		"Maximum time to wait before retrying an installation that failed to reconcile.")
+	f.IntVar(&opts.MetricsPort, "metrics-port", operator.DefaultMetricsPort,
+		"Port to serve the Prometheus metrics on. Set to 0 to disable the metrics.")
+
+	return cmd
+}
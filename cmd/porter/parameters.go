@@ -20,6 +20,7 @@ func buildParametersCommands(p *porter.Porter) *cobra.Command {
 	cmd.AddCommand(buildParametersDeleteCommand(p))
 	cmd.AddCommand(buildParametersShowCommand(p))
 	cmd.AddCommand(buildParametersCreateCommand(p))
+	cmd.AddCommand(buildParametersValidateCommand(p))
 
 	return cmd
 }
@@ -242,3 +243,37 @@ func buildParametersCreateCommand(p *porter.Porter) *cobra.Command {
 
 	return cmd
 }
+
+func buildParametersValidateCommand(p *porter.Porter) *cobra.Command {
+	opts := porter.ParameterValidateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate NAME",
+		Short: "Validate a parameter set against a bundle",
+		Long: `Validate a parameter set against a bundle without running the bundle.
+
+Every parameter in the set is resolved, and Porter checks that the parameters that the bundle requires are defined in the set, and that each value matches the type and constraints of its definition in the bundle. Parameters that the bundle does not use are resolved but not checked against a definition.
+All the problems that are found are reported together.
+
+By default, the parameters used by every action of the bundle are validated. Use --action to only validate the parameters used by an action.`,
+		Example: `  porter parameters validate myset --reference ghcr.io/getporter/examples/porter-hello:v0.2.0
+  porter parameters validate myset --reference ghcr.io/getporter/examples/porter-hello:v0.2.0 --action upgrade
+  porter parameters validate myset --file myapp/porter.yaml --namespace dev`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(cmd.Context(), args, p)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ValidateParameterSet(cmd.Context(), opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"Namespace in which the parameter set is defined. Defaults to the global namespace.")
+	f.StringVar(&opts.Action, "action", "",
+		"Only validate the parameters used by the specified action. Defaults to every action.")
+	addBundleDefinitionFlags(f, &opts.BundleDefinitionOptions)
+	addBundlePullFlags(f, &opts.BundlePullOptions)
+
+	return cmd
+}
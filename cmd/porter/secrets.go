@@ -0,0 +1,58 @@
+package main
+
+import (
+	"get.porter.sh/porter/pkg/porter"
+	"github.com/spf13/cobra"
+)
+
+func buildSecretsCommands(p *porter.Porter) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "secrets",
+		Aliases:     []string{"secret"},
+		Annotations: map[string]string{"group": "resource"},
+		Short:       "Secrets commands",
+		Long:        "Manage the secrets stored by Porter's secrets plugins.",
+	}
+
+	cmd.AddCommand(buildSecretsRotateKeyCommand(p))
+
+	return cmd
+}
+
+func buildSecretsRotateKeyCommand(p *porter.Porter) *cobra.Command {
+	var opts porter.RotateSecretsKeyOptions
+	cmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt secrets with a new key",
+		Long: `Re-encrypt the secrets stored by the encrypted-filesystem secrets plugin with a new key.
+
+The secrets configuration in the config file must use the encrypted-filesystem plugin, and its key is used to decrypt the secrets. By default the default-secrets configuration is used.
+
+Every secret is decrypted before any of them are changed, so the command fails without modifying the secrets when one cannot be decrypted with the current key. Secrets that are already encrypted with the new key are skipped, so the command can be repeated when it is interrupted.
+
+After the secrets are re-encrypted, update the key-file, key-env or passphrase-env of the secrets configuration to the new key, and distribute the new key to the machines that share the secrets, such as your CI runners.`,
+		Example: `  porter secrets rotate-key --new-key-file ~/.porter/secrets-2.key --generate-key
+  porter secrets rotate-key --secrets team-secrets --new-key-env NEW_PORTER_SECRETS_KEY
+  porter secrets rotate-key --new-passphrase-env NEW_PORTER_SECRETS_PASSPHRASE
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.RotateSecretsKey(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Secrets, "secrets", "",
+		"Name of the secrets configuration in the config file. Defaults to the default-secrets configuration.")
+	flags.StringVar(&opts.NewKeyFile, "new-key-file", "",
+		"Path to a file containing the new base64 encoded 256-bit key.")
+	flags.StringVar(&opts.NewKeyEnv, "new-key-env", "",
+		"Name of the environment variable containing the new base64 encoded 256-bit key.")
+	flags.StringVar(&opts.NewPassphraseEnv, "new-passphrase-env", "",
+		"Name of the environment variable containing the new passphrase from which the key is derived.")
+	flags.BoolVar(&opts.GenerateKey, "generate-key", false,
+		"Generate a random key in the file specified by --new-key-file. The file must not already exist.")
+	return cmd
+}
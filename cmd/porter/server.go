@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	grpc "get.porter.sh/porter/pkg/grpc"
@@ -30,10 +32,35 @@ func buildServerRunCommand(p *porter.Porter) *cobra.Command {
 		Short: "Run the gRPC server",
 		Long: `Run the gRPC server for porter.
 
-This command starts the gRPC server for porter which is able to expose limited porter functionality via RPC.
-Currently only data operations are supported, creation of resources such as installations, credential sets, or parameter sets is not supported.
+This command starts the gRPC server for porter which is able to expose porter functionality via RPC.
+The server can read installations, runs, logs and outputs, run bundle actions on installations, and manage credential sets and parameter sets.
 
 A list of the supported RPCs can be found at <link?>
+
+When --gateway-port is specified, the installation RPCs are also served as REST routes by an HTTP/JSON gateway on that port, for example GET /v1/namespaces/NAMESPACE/installations. The routes without /namespaces/NAMESPACE use the global namespace. The health of the server is served from /healthz. Requests to the gateway go through the same authentication, authorization and metrics as gRPC requests. The gateway does not request client certificates, so --token-file is required when the gateway is enabled with --client-ca.
+
+By default, the server does not authenticate clients. Clients authenticate with a client certificate when --client-ca is specified, and with a bearer token when --token-file is specified. The identity of a client is the common name of its certificate, or the identity of its token in the token file. Clients of the gateway authenticate with a bearer token.
+
+The token file lists the SHA-256 hash of each token that is accepted, and the identity of the token:
+
+  tokens:
+    - identity: ci
+      sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+
+The authorization file grants identities permissions in namespaces. The read permission allows reading installations, runs, logs, outputs, credential sets and parameter sets. The run permission allows running bundle actions, and the write permission allows changing credential sets and parameter sets. Use * to match every identity, namespace or permission, and "" for the global namespace. Requests across all namespaces, including getting the logs of a run by its id, require a rule for the * namespace. When authentication is enabled without an authorization file, every authenticated client has access to all namespaces.
+
+  rules:
+    - identities: [ci]
+      namespaces: [dev, staging]
+      permissions: [read, run]
+    - identities: ["*"]
+      namespaces: ["*"]
+      permissions: [read]
+`,
+		Example: `  porter api-server run
+  porter api-server run --gateway-port 8080 --tls-cert server.crt --tls-key server.key --token-file tokens.yaml
+  porter api-server run --tls-cert server.crt --tls-key server.key --client-ca ca.crt --authz-file authz.yaml
+  porter api-server run --tls-cert server.crt --tls-key server.key --token-file tokens.yaml --authz-file authz.yaml
 `,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return opts.Validate()
@@ -48,11 +75,30 @@ A list of the supported RPCs can be found at <link?>
 			serverShutdownTimeout := time.Duration(time.Second * 30)
 			sd, _ := signals.NewShutdown(serverShutdownTimeout, cmd.Context())
 			sd.Graceful(stopCh, grpcServer, cmd.Context())
+
+			// Stop the gateway after the gRPC server, so that it is not left serving requests
+			closeCtx, cancel := context.WithTimeout(context.WithoutCancel(cmd.Context()), serverShutdownTimeout)
+			defer cancel()
+			if closeErr := srv.Close(closeCtx); closeErr != nil && err == nil {
+				err = fmt.Errorf("could not stop the HTTP/JSON gateway: %w", closeErr)
+			}
 			return err
 		},
 	}
 	f := cmd.Flags()
 	f.Int64VarP(&opts.Port, "port", "p", 3001, "Port to run the server on")
 	f.StringVarP(&opts.ServiceName, "service-name", "s", "api-server", "Server service name")
+	f.Int64Var(&opts.GatewayPort, "gateway-port", 0,
+		"Port to serve the HTTP/JSON gateway on. The gateway is disabled when it is not specified.")
+	f.StringVar(&opts.TLSCertFile, "tls-cert", "",
+		"Path to the TLS certificate of the server. When not specified, the server does not use TLS.")
+	f.StringVar(&opts.TLSKeyFile, "tls-key", "",
+		"Path to the private key of the TLS certificate of the server.")
+	f.StringVar(&opts.ClientCAFile, "client-ca", "",
+		"Path to the certificate authorities that sign client certificates. Clients must authenticate with a certificate signed by one of them.")
+	f.StringVar(&opts.TokenFile, "token-file", "",
+		"Path to the file of bearer tokens that clients may authenticate with.")
+	f.StringVar(&opts.AuthorizationFile, "authz-file", "",
+		"Path to the file of rules that grant authenticated clients permissions in namespaces.")
 	return cmd
 }
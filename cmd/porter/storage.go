@@ -18,6 +18,9 @@ func buildStorageCommand(p *porter.Porter) *cobra.Command {
 
 	cmd.AddCommand(buildStorageMigrateCommand(p))
 	cmd.AddCommand(buildStorageFixPermissionsCommand(p))
+	cmd.AddCommand(buildStorageExportCommand(p))
+	cmd.AddCommand(buildStorageImportCommand(p))
+	cmd.AddCommand(buildStoragePruneCommand(p))
 
 	return &cmd
 }
@@ -72,3 +75,116 @@ func buildStorageFixPermissionsCommand(p *porter.Porter) *cobra.Command {
 		},
 	}
 }
+
+func buildStorageExportCommand(p *porter.Porter) *cobra.Command {
+	var opts porter.StorageExportOptions
+	cmd := &cobra.Command{
+		Use:   "export FILE",
+		Short: "Export Porter's data to an archive",
+		Long: `Export installations, runs, results, outputs, credential sets and parameter sets to a storage archive.
+
+The archive can be imported with porter storage import into any storage plugin, for example to move data from the mongodb-docker plugin to a production MongoDB server, or to restore a backup.
+
+Sensitive values are kept in your secret store and are not included in the archive, only the references to the secrets are exported.`,
+		Example: `  porter storage export porter-backup.tgz
+  porter storage export dev-backup.tgz --namespace dev
+  porter storage export backup.tgz --namespace dev --namespace test
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ExportStorage(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&opts.Namespaces, "namespace", "n", nil,
+		"Only export data from the specified namespace. May be specified multiple times. Defaults to all namespaces.")
+	return cmd
+}
+
+func buildStorageImportCommand(p *porter.Porter) *cobra.Command {
+	var opts porter.StorageImportOptions
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import Porter's data from an archive",
+		Long: `Import installations, runs, results, outputs, credential sets and parameter sets from a storage archive created by porter storage export.
+
+By default, the import fails without making any changes when an installation, credential set or parameter set in the archive already exists.
+Use --on-conflict skip to keep the existing documents, or --on-conflict overwrite to replace them with the documents from the archive.
+Runs, results and outputs are never modified after they are recorded, so only the ones that do not already exist are imported.`,
+		Example: `  porter storage import porter-backup.tgz
+  porter storage import porter-backup.tgz --namespace dev
+  porter storage import porter-backup.tgz --on-conflict skip
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.ImportStorage(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&opts.Namespaces, "namespace", "n", nil,
+		"Only import data from the specified namespace. May be specified multiple times. Defaults to all namespaces in the archive.")
+	flags.StringVar(&opts.OnConflict, "on-conflict", porter.ImportConflictFail,
+		"Action to take when a document in the archive already exists. Allowed values: fail, skip, overwrite.")
+	return cmd
+}
+
+func buildStoragePruneCommand(p *porter.Porter) *cobra.Command {
+	var opts porter.StoragePruneOptions
+	var keepRuns int
+	var maxAge string
+	var keepFailed bool
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove runs that have expired",
+		Long: `Remove the runs that have expired according to the retention policy, along with their results, outputs and logs.
+The secrets that Porter created for the sensitive parameters and outputs of the removed runs are deleted from the secret store, when the secrets plugin supports deleting secrets.
+
+The retention policy is defined in the retention section of the config file. Flags that are specified override the config file, including --keep-runs 0 and --keep-failed=false.
+A run expires when it is not one of the most recent --keep-runs runs of its installation, or when it is older than --max-age.
+The most recent run, the most recent successful run, and runs that have not completed are always kept for each installation.
+Use --keep-failed to keep failed runs for troubleshooting.`,
+		Example: `  porter storage prune
+  porter storage prune --dry-run
+  porter storage prune --keep-runs 10 --keep-failed
+  porter storage prune --max-age 720h --namespace dev -o json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Only the retention flags that were specified override the config file
+			flags := cmd.Flags()
+			if flags.Changed("keep-runs") {
+				opts.KeepRuns = &keepRuns
+			}
+			if flags.Changed("max-age") {
+				opts.MaxAge = &maxAge
+			}
+			if flags.Changed("keep-failed") {
+				opts.KeepFailed = &keepFailed
+			}
+			return opts.Validate(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return p.PruneStorage(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&opts.Namespaces, "namespace", "n", nil,
+		"Only prune runs from the specified namespace. May be specified multiple times. Defaults to all namespaces.")
+	flags.IntVar(&keepRuns, "keep-runs", 0,
+		"Number of most recent runs to keep for each installation. Defaults to retention.keep-runs in the config file.")
+	flags.StringVar(&maxAge, "max-age", "",
+		"Remove runs older than the specified duration, for example 720h. Defaults to retention.max-age in the config file.")
+	flags.BoolVar(&keepFailed, "keep-failed", false,
+		"Keep failed runs regardless of their age. Defaults to retention.keep-failed in the config file.")
+	flags.BoolVar(&opts.DryRun, "dry-run", false,
+		"Report the runs that would be removed without removing them.")
+	flags.StringVarP(&opts.RawFormat, "output", "o", "plaintext",
+		"Specify an output format.  Allowed values: plaintext, json, yaml")
+	return cmd
+}
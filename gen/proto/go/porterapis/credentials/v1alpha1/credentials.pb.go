@@ -0,0 +1,758 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: credentials/v1alpha1/credentials.proto
+
+package credentialsv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Strategy maps a credential to the source that its value is resolved from
+type Strategy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Source strategy and hint, for example env: AZURE_TOKEN
+	Source        map[string]string `protobuf:"bytes,2,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Strategy) Reset() {
+	*x = Strategy{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Strategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strategy) ProtoMessage() {}
+
+func (x *Strategy) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strategy.ProtoReflect.Descriptor instead.
+func (*Strategy) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Strategy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Strategy) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type CredentialSetStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialSetStatus) Reset() {
+	*x = CredentialSetStatus{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialSetStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialSetStatus) ProtoMessage() {}
+
+func (x *CredentialSetStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialSetStatus.ProtoReflect.Descriptor instead.
+func (*CredentialSetStatus) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CredentialSetStatus) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *CredentialSetStatus) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type CredentialSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaType    string                 `protobuf:"bytes,1,opt,name=schemaType,proto3" json:"schemaType,omitempty"`
+	SchemaVersion string                 `protobuf:"bytes,2,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Credentials   []*Strategy            `protobuf:"bytes,6,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	Status        *CredentialSetStatus   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialSet) Reset() {
+	*x = CredentialSet{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialSet) ProtoMessage() {}
+
+func (x *CredentialSet) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialSet.ProtoReflect.Descriptor instead.
+func (*CredentialSet) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CredentialSet) GetSchemaType() string {
+	if x != nil {
+		return x.SchemaType
+	}
+	return ""
+}
+
+func (x *CredentialSet) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *CredentialSet) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CredentialSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CredentialSet) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CredentialSet) GetCredentials() []*Strategy {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *CredentialSet) GetStatus() *CredentialSetStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ListCredentialSetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AllNamespaces bool                   `protobuf:"varint,4,opt,name=allNamespaces,proto3" json:"allNamespaces,omitempty"`
+	Skip          int64                  `protobuf:"varint,5,opt,name=skip,proto3" json:"skip,omitempty"`
+	Limit         int64                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCredentialSetsRequest) Reset() {
+	*x = ListCredentialSetsRequest{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCredentialSetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCredentialSetsRequest) ProtoMessage() {}
+
+func (x *ListCredentialSetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCredentialSetsRequest.ProtoReflect.Descriptor instead.
+func (*ListCredentialSetsRequest) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCredentialSetsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListCredentialSetsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListCredentialSetsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListCredentialSetsRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.AllNamespaces
+	}
+	return false
+}
+
+func (x *ListCredentialSetsRequest) GetSkip() int64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListCredentialSetsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCredentialSetsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CredentialSets []*CredentialSet       `protobuf:"bytes,1,rep,name=credentialSets,proto3" json:"credentialSets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListCredentialSetsResponse) Reset() {
+	*x = ListCredentialSetsResponse{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCredentialSetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCredentialSetsResponse) ProtoMessage() {}
+
+func (x *ListCredentialSetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCredentialSetsResponse.ProtoReflect.Descriptor instead.
+func (*ListCredentialSetsResponse) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCredentialSetsResponse) GetCredentialSets() []*CredentialSet {
+	if x != nil {
+		return x.CredentialSets
+	}
+	return nil
+}
+
+type GetCredentialSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCredentialSetRequest) Reset() {
+	*x = GetCredentialSetRequest{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCredentialSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredentialSetRequest) ProtoMessage() {}
+
+func (x *GetCredentialSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredentialSetRequest.ProtoReflect.Descriptor instead.
+func (*GetCredentialSetRequest) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCredentialSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetCredentialSetRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type GetCredentialSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialSet *CredentialSet         `protobuf:"bytes,1,opt,name=credentialSet,proto3" json:"credentialSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCredentialSetResponse) Reset() {
+	*x = GetCredentialSetResponse{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCredentialSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCredentialSetResponse) ProtoMessage() {}
+
+func (x *GetCredentialSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCredentialSetResponse.ProtoReflect.Descriptor instead.
+func (*GetCredentialSetResponse) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCredentialSetResponse) GetCredentialSet() *CredentialSet {
+	if x != nil {
+		return x.CredentialSet
+	}
+	return nil
+}
+
+type ApplyCredentialSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialSet *CredentialSet         `protobuf:"bytes,1,opt,name=credentialSet,proto3" json:"credentialSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCredentialSetRequest) Reset() {
+	*x = ApplyCredentialSetRequest{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCredentialSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCredentialSetRequest) ProtoMessage() {}
+
+func (x *ApplyCredentialSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCredentialSetRequest.ProtoReflect.Descriptor instead.
+func (*ApplyCredentialSetRequest) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApplyCredentialSetRequest) GetCredentialSet() *CredentialSet {
+	if x != nil {
+		return x.CredentialSet
+	}
+	return nil
+}
+
+type ApplyCredentialSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialSet *CredentialSet         `protobuf:"bytes,1,opt,name=credentialSet,proto3" json:"credentialSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCredentialSetResponse) Reset() {
+	*x = ApplyCredentialSetResponse{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCredentialSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCredentialSetResponse) ProtoMessage() {}
+
+func (x *ApplyCredentialSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCredentialSetResponse.ProtoReflect.Descriptor instead.
+func (*ApplyCredentialSetResponse) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApplyCredentialSetResponse) GetCredentialSet() *CredentialSet {
+	if x != nil {
+		return x.CredentialSet
+	}
+	return nil
+}
+
+type DeleteCredentialSetRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Delete the credential set even if it is used by an installation
+	Force         bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCredentialSetRequest) Reset() {
+	*x = DeleteCredentialSetRequest{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCredentialSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCredentialSetRequest) ProtoMessage() {}
+
+func (x *DeleteCredentialSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCredentialSetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCredentialSetRequest) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteCredentialSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteCredentialSetRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteCredentialSetRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type DeleteCredentialSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCredentialSetResponse) Reset() {
+	*x = DeleteCredentialSetResponse{}
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCredentialSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCredentialSetResponse) ProtoMessage() {}
+
+func (x *DeleteCredentialSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_credentials_v1alpha1_credentials_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCredentialSetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCredentialSetResponse) Descriptor() ([]byte, []int) {
+	return file_credentials_v1alpha1_credentials_proto_rawDescGZIP(), []int{10}
+}
+
+var File_credentials_v1alpha1_credentials_proto protoreflect.FileDescriptor
+
+const file_credentials_v1alpha1_credentials_proto_rawDesc = "" +
+	"\n" +
+	"&credentials/v1alpha1/credentials.proto\x12\x14credentials.v1alpha1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9d\x01\n" +
+	"\bStrategy\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12B\n" +
+	"\x06source\x18\x02 \x03(\v2*.credentials.v1alpha1.Strategy.SourceEntryR\x06source\x1a9\n" +
+	"\vSourceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\x13CredentialSetStatus\x124\n" +
+	"\acreated\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"\x90\x03\n" +
+	"\rCredentialSet\x12\x1e\n" +
+	"\n" +
+	"schemaType\x18\x01 \x01(\tR\n" +
+	"schemaType\x12$\n" +
+	"\rschemaVersion\x18\x02 \x01(\tR\rschemaVersion\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12G\n" +
+	"\x06labels\x18\x05 \x03(\v2/.credentials.v1alpha1.CredentialSet.LabelsEntryR\x06labels\x12@\n" +
+	"\vcredentials\x18\x06 \x03(\v2\x1e.credentials.v1alpha1.StrategyR\vcredentials\x12A\n" +
+	"\x06status\x18\a \x01(\v2).credentials.v1alpha1.CredentialSetStatusR\x06status\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xad\x02\n" +
+	"\x19ListCredentialSetsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12S\n" +
+	"\x06labels\x18\x03 \x03(\v2;.credentials.v1alpha1.ListCredentialSetsRequest.LabelsEntryR\x06labels\x12$\n" +
+	"\rallNamespaces\x18\x04 \x01(\bR\rallNamespaces\x12\x12\n" +
+	"\x04skip\x18\x05 \x01(\x03R\x04skip\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x03R\x05limit\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
+	"\x1aListCredentialSetsResponse\x12K\n" +
+	"\x0ecredentialSets\x18\x01 \x03(\v2#.credentials.v1alpha1.CredentialSetR\x0ecredentialSets\"K\n" +
+	"\x17GetCredentialSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"e\n" +
+	"\x18GetCredentialSetResponse\x12I\n" +
+	"\rcredentialSet\x18\x01 \x01(\v2#.credentials.v1alpha1.CredentialSetR\rcredentialSet\"f\n" +
+	"\x19ApplyCredentialSetRequest\x12I\n" +
+	"\rcredentialSet\x18\x01 \x01(\v2#.credentials.v1alpha1.CredentialSetR\rcredentialSet\"g\n" +
+	"\x1aApplyCredentialSetResponse\x12I\n" +
+	"\rcredentialSet\x18\x01 \x01(\v2#.credentials.v1alpha1.CredentialSetR\rcredentialSet\"d\n" +
+	"\x1aDeleteCredentialSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"\x1d\n" +
+	"\x1bDeleteCredentialSetResponseB\xf4\x01\n" +
+	"\x18com.credentials.v1alpha1B\x10CredentialsProtoP\x01ZUget.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1;credentialsv1alpha1\xa2\x02\x03CXX\xaa\x02\x14Credentials.V1alpha1\xca\x02\x14Credentials\\V1alpha1\xe2\x02 Credentials\\V1alpha1\\GPBMetadata\xea\x02\x15Credentials::V1alpha1b\x06proto3"
+
+var (
+	file_credentials_v1alpha1_credentials_proto_rawDescOnce sync.Once
+	file_credentials_v1alpha1_credentials_proto_rawDescData []byte
+)
+
+func file_credentials_v1alpha1_credentials_proto_rawDescGZIP() []byte {
+	file_credentials_v1alpha1_credentials_proto_rawDescOnce.Do(func() {
+		file_credentials_v1alpha1_credentials_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_credentials_v1alpha1_credentials_proto_rawDesc), len(file_credentials_v1alpha1_credentials_proto_rawDesc)))
+	})
+	return file_credentials_v1alpha1_credentials_proto_rawDescData
+}
+
+var file_credentials_v1alpha1_credentials_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_credentials_v1alpha1_credentials_proto_goTypes = []any{
+	(*Strategy)(nil),                    // 0: credentials.v1alpha1.Strategy
+	(*CredentialSetStatus)(nil),         // 1: credentials.v1alpha1.CredentialSetStatus
+	(*CredentialSet)(nil),               // 2: credentials.v1alpha1.CredentialSet
+	(*ListCredentialSetsRequest)(nil),   // 3: credentials.v1alpha1.ListCredentialSetsRequest
+	(*ListCredentialSetsResponse)(nil),  // 4: credentials.v1alpha1.ListCredentialSetsResponse
+	(*GetCredentialSetRequest)(nil),     // 5: credentials.v1alpha1.GetCredentialSetRequest
+	(*GetCredentialSetResponse)(nil),    // 6: credentials.v1alpha1.GetCredentialSetResponse
+	(*ApplyCredentialSetRequest)(nil),   // 7: credentials.v1alpha1.ApplyCredentialSetRequest
+	(*ApplyCredentialSetResponse)(nil),  // 8: credentials.v1alpha1.ApplyCredentialSetResponse
+	(*DeleteCredentialSetRequest)(nil),  // 9: credentials.v1alpha1.DeleteCredentialSetRequest
+	(*DeleteCredentialSetResponse)(nil), // 10: credentials.v1alpha1.DeleteCredentialSetResponse
+	nil,                                 // 11: credentials.v1alpha1.Strategy.SourceEntry
+	nil,                                 // 12: credentials.v1alpha1.CredentialSet.LabelsEntry
+	nil,                                 // 13: credentials.v1alpha1.ListCredentialSetsRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),       // 14: google.protobuf.Timestamp
+}
+var file_credentials_v1alpha1_credentials_proto_depIdxs = []int32{
+	11, // 0: credentials.v1alpha1.Strategy.source:type_name -> credentials.v1alpha1.Strategy.SourceEntry
+	14, // 1: credentials.v1alpha1.CredentialSetStatus.created:type_name -> google.protobuf.Timestamp
+	14, // 2: credentials.v1alpha1.CredentialSetStatus.modified:type_name -> google.protobuf.Timestamp
+	12, // 3: credentials.v1alpha1.CredentialSet.labels:type_name -> credentials.v1alpha1.CredentialSet.LabelsEntry
+	0,  // 4: credentials.v1alpha1.CredentialSet.credentials:type_name -> credentials.v1alpha1.Strategy
+	1,  // 5: credentials.v1alpha1.CredentialSet.status:type_name -> credentials.v1alpha1.CredentialSetStatus
+	13, // 6: credentials.v1alpha1.ListCredentialSetsRequest.labels:type_name -> credentials.v1alpha1.ListCredentialSetsRequest.LabelsEntry
+	2,  // 7: credentials.v1alpha1.ListCredentialSetsResponse.credentialSets:type_name -> credentials.v1alpha1.CredentialSet
+	2,  // 8: credentials.v1alpha1.GetCredentialSetResponse.credentialSet:type_name -> credentials.v1alpha1.CredentialSet
+	2,  // 9: credentials.v1alpha1.ApplyCredentialSetRequest.credentialSet:type_name -> credentials.v1alpha1.CredentialSet
+	2,  // 10: credentials.v1alpha1.ApplyCredentialSetResponse.credentialSet:type_name -> credentials.v1alpha1.CredentialSet
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_credentials_v1alpha1_credentials_proto_init() }
+func file_credentials_v1alpha1_credentials_proto_init() {
+	if File_credentials_v1alpha1_credentials_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_credentials_v1alpha1_credentials_proto_rawDesc), len(file_credentials_v1alpha1_credentials_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_credentials_v1alpha1_credentials_proto_goTypes,
+		DependencyIndexes: file_credentials_v1alpha1_credentials_proto_depIdxs,
+		MessageInfos:      file_credentials_v1alpha1_credentials_proto_msgTypes,
+	}.Build()
+	File_credentials_v1alpha1_credentials_proto = out.File
+	file_credentials_v1alpha1_credentials_proto_goTypes = nil
+	file_credentials_v1alpha1_credentials_proto_depIdxs = nil
+}
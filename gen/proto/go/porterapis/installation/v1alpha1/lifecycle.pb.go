@@ -0,0 +1,1187 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: installation/v1alpha1/lifecycle.proto
+
+package installationv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BundleActionOptions are the options common to the requests that run a bundle
+type BundleActionOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bundle reference, for example ghcr.io/getporter/examples/porter-hello:v0.2.0.
+	// Defaults to the bundle last used by the installation.
+	Reference string `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Parameter values, keyed by the parameter name
+	Parameters     map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ParameterSets  []string          `protobuf:"bytes,3,rep,name=parameterSets,proto3" json:"parameterSets,omitempty"`
+	CredentialSets []string          `protobuf:"bytes,4,rep,name=credentialSets,proto3" json:"credentialSets,omitempty"`
+	// Driver used to run the bundle. Defaults to the driver in the Porter configuration.
+	Driver                string `protobuf:"bytes,5,opt,name=driver,proto3" json:"driver,omitempty"`
+	AllowDockerHostAccess bool   `protobuf:"varint,6,opt,name=allowDockerHostAccess,proto3" json:"allowDockerHostAccess,omitempty"`
+	InsecureRegistry      bool   `protobuf:"varint,7,opt,name=insecureRegistry,proto3" json:"insecureRegistry,omitempty"`
+	// Run the bundle even if the installation has an incomplete run
+	ForceRun      bool `protobuf:"varint,8,opt,name=forceRun,proto3" json:"forceRun,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleActionOptions) Reset() {
+	*x = BundleActionOptions{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleActionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleActionOptions) ProtoMessage() {}
+
+func (x *BundleActionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleActionOptions.ProtoReflect.Descriptor instead.
+func (*BundleActionOptions) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BundleActionOptions) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *BundleActionOptions) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *BundleActionOptions) GetParameterSets() []string {
+	if x != nil {
+		return x.ParameterSets
+	}
+	return nil
+}
+
+func (x *BundleActionOptions) GetCredentialSets() []string {
+	if x != nil {
+		return x.CredentialSets
+	}
+	return nil
+}
+
+func (x *BundleActionOptions) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *BundleActionOptions) GetAllowDockerHostAccess() bool {
+	if x != nil {
+		return x.AllowDockerHostAccess
+	}
+	return false
+}
+
+func (x *BundleActionOptions) GetInsecureRegistry() bool {
+	if x != nil {
+		return x.InsecureRegistry
+	}
+	return false
+}
+
+func (x *BundleActionOptions) GetForceRun() bool {
+	if x != nil {
+		return x.ForceRun
+	}
+	return false
+}
+
+// Lifecycle actions
+type InstallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Options       *BundleActionOptions   `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallRequest) Reset() {
+	*x = InstallRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallRequest) ProtoMessage() {}
+
+func (x *InstallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallRequest.ProtoReflect.Descriptor instead.
+func (*InstallRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InstallRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InstallRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *InstallRequest) GetOptions() *BundleActionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *InstallRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type UpgradeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Options   *BundleActionOptions   `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	// Version of the bundle to upgrade to
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Upgrade the installation even if its last run failed
+	ForceUpgrade  bool `protobuf:"varint,5,opt,name=forceUpgrade,proto3" json:"forceUpgrade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeRequest) Reset() {
+	*x = UpgradeRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeRequest) ProtoMessage() {}
+
+func (x *UpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpgradeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpgradeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UpgradeRequest) GetOptions() *BundleActionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *UpgradeRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpgradeRequest) GetForceUpgrade() bool {
+	if x != nil {
+		return x.ForceUpgrade
+	}
+	return false
+}
+
+type InvokeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Options   *BundleActionOptions   `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	// Name of the custom action to invoke
+	Action        string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeRequest) Reset() {
+	*x = InvokeRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeRequest) ProtoMessage() {}
+
+func (x *InvokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeRequest.ProtoReflect.Descriptor instead.
+func (*InvokeRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InvokeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InvokeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *InvokeRequest) GetOptions() *BundleActionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *InvokeRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type UninstallRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Options   *BundleActionOptions   `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	// Delete the installation after it is uninstalled
+	Delete bool `protobuf:"varint,4,opt,name=delete,proto3" json:"delete,omitempty"`
+	// Delete the installation even if the uninstall action fails
+	ForceDelete   bool `protobuf:"varint,5,opt,name=forceDelete,proto3" json:"forceDelete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UninstallRequest) Reset() {
+	*x = UninstallRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UninstallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UninstallRequest) ProtoMessage() {}
+
+func (x *UninstallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UninstallRequest.ProtoReflect.Descriptor instead.
+func (*UninstallRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UninstallRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UninstallRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UninstallRequest) GetOptions() *BundleActionOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *UninstallRequest) GetDelete() bool {
+	if x != nil {
+		return x.Delete
+	}
+	return false
+}
+
+func (x *UninstallRequest) GetForceDelete() bool {
+	if x != nil {
+		return x.ForceDelete
+	}
+	return false
+}
+
+type ApplyInstallationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Installation document, in YAML or JSON
+	Document string `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	// Namespace of the installation when it is not set in the document
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Run the bundle even if the installation is in sync
+	Force         bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyInstallationRequest) Reset() {
+	*x = ApplyInstallationRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyInstallationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyInstallationRequest) ProtoMessage() {}
+
+func (x *ApplyInstallationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyInstallationRequest.ProtoReflect.Descriptor instead.
+func (*ApplyInstallationRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ApplyInstallationRequest) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *ApplyInstallationRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ApplyInstallationRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// ActionResult is the outcome of the run that was executed by an action
+type ActionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=runId,proto3" json:"runId,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionResult) Reset() {
+	*x = ActionResult{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResult) ProtoMessage() {}
+
+func (x *ActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ActionResult) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *ActionResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ActionResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ActionResponse is streamed while an action runs. Each message contains
+// either output from the bundle, or the result once the action completes.
+type ActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Log           string                 `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	Result        *ActionResult          `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionResponse) Reset() {
+	*x = ActionResponse{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResponse) ProtoMessage() {}
+
+func (x *ActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResponse.ProtoReflect.Descriptor instead.
+func (*ActionResponse) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ActionResponse) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *ActionResponse) GetResult() *ActionResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Installation details
+type GetInstallationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstallationRequest) Reset() {
+	*x = GetInstallationRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstallationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstallationRequest) ProtoMessage() {}
+
+func (x *GetInstallationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstallationRequest.ProtoReflect.Descriptor instead.
+func (*GetInstallationRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetInstallationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetInstallationRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type GetInstallationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Installation  *Installation          `protobuf:"bytes,1,opt,name=installation,proto3" json:"installation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstallationResponse) Reset() {
+	*x = GetInstallationResponse{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstallationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstallationResponse) ProtoMessage() {}
+
+func (x *GetInstallationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstallationResponse.ProtoReflect.Descriptor instead.
+func (*GetInstallationResponse) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetInstallationResponse) GetInstallation() *Installation {
+	if x != nil {
+		return x.Installation
+	}
+	return nil
+}
+
+// Runs
+type Run struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Bundle        string                 `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Parameters    *structpb.Struct       `protobuf:"bytes,5,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	Started       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=started,proto3" json:"started,omitempty"`
+	Stopped       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	RollbackTo    string                 `protobuf:"bytes,9,opt,name=rollbackTo,proto3" json:"rollbackTo,omitempty"`
+	ResumedFrom   string                 `protobuf:"bytes,10,opt,name=resumedFrom,proto3" json:"resumedFrom,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Run) Reset() {
+	*x = Run{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Run) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Run) ProtoMessage() {}
+
+func (x *Run) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Run.ProtoReflect.Descriptor instead.
+func (*Run) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Run) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Run) GetBundle() string {
+	if x != nil {
+		return x.Bundle
+	}
+	return ""
+}
+
+func (x *Run) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Run) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Run) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Run) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *Run) GetStopped() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Stopped
+	}
+	return nil
+}
+
+func (x *Run) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Run) GetRollbackTo() string {
+	if x != nil {
+		return x.RollbackTo
+	}
+	return ""
+}
+
+func (x *Run) GetResumedFrom() string {
+	if x != nil {
+		return x.ResumedFrom
+	}
+	return ""
+}
+
+type ListRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunsRequest) Reset() {
+	*x = ListRunsRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunsRequest) ProtoMessage() {}
+
+func (x *ListRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRunsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListRunsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*Run                 `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunsResponse) Reset() {
+	*x = ListRunsResponse{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunsResponse) ProtoMessage() {}
+
+func (x *ListRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListRunsResponse) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListRunsResponse) GetRuns() []*Run {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+// Logs
+type GetLogsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Run to get the logs of. Defaults to the last run of the installation.
+	RunId         string `protobuf:"bytes,3,opt,name=runId,proto3" json:"runId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogsRequest) Reset() {
+	*x = GetLogsRequest{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsRequest) ProtoMessage() {}
+
+func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetLogsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetLogsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetLogsRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type GetLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          string                 `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogsResponse) Reset() {
+	*x = GetLogsResponse{}
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsResponse) ProtoMessage() {}
+
+func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_installation_v1alpha1_lifecycle_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+	return file_installation_v1alpha1_lifecycle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetLogsResponse) GetLogs() string {
+	if x != nil {
+		return x.Logs
+	}
+	return ""
+}
+
+var File_installation_v1alpha1_lifecycle_proto protoreflect.FileDescriptor
+
+const file_installation_v1alpha1_lifecycle_proto_rawDesc = "" +
+	"\n" +
+	"%installation/v1alpha1/lifecycle.proto\x12\x15installation.v1alpha1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a(installation/v1alpha1/installation.proto\"\xb2\x03\n" +
+	"\x13BundleActionOptions\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12Z\n" +
+	"\n" +
+	"parameters\x18\x02 \x03(\v2:.installation.v1alpha1.BundleActionOptions.ParametersEntryR\n" +
+	"parameters\x12$\n" +
+	"\rparameterSets\x18\x03 \x03(\tR\rparameterSets\x12&\n" +
+	"\x0ecredentialSets\x18\x04 \x03(\tR\x0ecredentialSets\x12\x16\n" +
+	"\x06driver\x18\x05 \x01(\tR\x06driver\x124\n" +
+	"\x15allowDockerHostAccess\x18\x06 \x01(\bR\x15allowDockerHostAccess\x12*\n" +
+	"\x10insecureRegistry\x18\a \x01(\bR\x10insecureRegistry\x12\x1a\n" +
+	"\bforceRun\x18\b \x01(\bR\bforceRun\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8e\x02\n" +
+	"\x0eInstallRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12D\n" +
+	"\aoptions\x18\x03 \x01(\v2*.installation.v1alpha1.BundleActionOptionsR\aoptions\x12I\n" +
+	"\x06labels\x18\x04 \x03(\v21.installation.v1alpha1.InstallRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc6\x01\n" +
+	"\x0eUpgradeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12D\n" +
+	"\aoptions\x18\x03 \x01(\v2*.installation.v1alpha1.BundleActionOptionsR\aoptions\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\"\n" +
+	"\fforceUpgrade\x18\x05 \x01(\bR\fforceUpgrade\"\x9f\x01\n" +
+	"\rInvokeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12D\n" +
+	"\aoptions\x18\x03 \x01(\v2*.installation.v1alpha1.BundleActionOptionsR\aoptions\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\"\xc4\x01\n" +
+	"\x10UninstallRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12D\n" +
+	"\aoptions\x18\x03 \x01(\v2*.installation.v1alpha1.BundleActionOptionsR\aoptions\x12\x16\n" +
+	"\x06delete\x18\x04 \x01(\bR\x06delete\x12 \n" +
+	"\vforceDelete\x18\x05 \x01(\bR\vforceDelete\"j\n" +
+	"\x18ApplyInstallationRequest\x12\x1a\n" +
+	"\bdocument\x18\x01 \x01(\tR\bdocument\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"T\n" +
+	"\fActionResult\x12\x14\n" +
+	"\x05runId\x18\x01 \x01(\tR\x05runId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"_\n" +
+	"\x0eActionResponse\x12\x10\n" +
+	"\x03log\x18\x01 \x01(\tR\x03log\x12;\n" +
+	"\x06result\x18\x02 \x01(\v2#.installation.v1alpha1.ActionResultR\x06result\"J\n" +
+	"\x16GetInstallationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"b\n" +
+	"\x17GetInstallationResponse\x12G\n" +
+	"\finstallation\x18\x01 \x01(\v2#.installation.v1alpha1.InstallationR\finstallation\"\xde\x02\n" +
+	"\x03Run\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06bundle\x18\x02 \x01(\tR\x06bundle\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x127\n" +
+	"\n" +
+	"parameters\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"parameters\x124\n" +
+	"\astarted\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\astarted\x124\n" +
+	"\astopped\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\astopped\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"rollbackTo\x18\t \x01(\tR\n" +
+	"rollbackTo\x12 \n" +
+	"\vresumedFrom\x18\n" +
+	" \x01(\tR\vresumedFrom\"C\n" +
+	"\x0fListRunsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"B\n" +
+	"\x10ListRunsResponse\x12.\n" +
+	"\x04runs\x18\x01 \x03(\v2\x1a.installation.v1alpha1.RunR\x04runs\"X\n" +
+	"\x0eGetLogsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05runId\x18\x03 \x01(\tR\x05runId\"%\n" +
+	"\x0fGetLogsResponse\x12\x12\n" +
+	"\x04logs\x18\x01 \x01(\tR\x04logsB\xfc\x01\n" +
+	"\x19com.installation.v1alpha1B\x11InstallationProtoP\x01ZWget.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1;installationv1alpha1\xa2\x02\x03IXX\xaa\x02\x15Installation.V1alpha1\xca\x02\x15Installation\\V1alpha1\xe2\x02!Installation\\V1alpha1\\GPBMetadata\xea\x02\x16Installation::V1alpha1b\x06proto3"
+
+var (
+	file_installation_v1alpha1_lifecycle_proto_rawDescOnce sync.Once
+	file_installation_v1alpha1_lifecycle_proto_rawDescData []byte
+)
+
+func file_installation_v1alpha1_lifecycle_proto_rawDescGZIP() []byte {
+	file_installation_v1alpha1_lifecycle_proto_rawDescOnce.Do(func() {
+		file_installation_v1alpha1_lifecycle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_installation_v1alpha1_lifecycle_proto_rawDesc), len(file_installation_v1alpha1_lifecycle_proto_rawDesc)))
+	})
+	return file_installation_v1alpha1_lifecycle_proto_rawDescData
+}
+
+var file_installation_v1alpha1_lifecycle_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_installation_v1alpha1_lifecycle_proto_goTypes = []any{
+	(*BundleActionOptions)(nil),      // 0: installation.v1alpha1.BundleActionOptions
+	(*InstallRequest)(nil),           // 1: installation.v1alpha1.InstallRequest
+	(*UpgradeRequest)(nil),           // 2: installation.v1alpha1.UpgradeRequest
+	(*InvokeRequest)(nil),            // 3: installation.v1alpha1.InvokeRequest
+	(*UninstallRequest)(nil),         // 4: installation.v1alpha1.UninstallRequest
+	(*ApplyInstallationRequest)(nil), // 5: installation.v1alpha1.ApplyInstallationRequest
+	(*ActionResult)(nil),             // 6: installation.v1alpha1.ActionResult
+	(*ActionResponse)(nil),           // 7: installation.v1alpha1.ActionResponse
+	(*GetInstallationRequest)(nil),   // 8: installation.v1alpha1.GetInstallationRequest
+	(*GetInstallationResponse)(nil),  // 9: installation.v1alpha1.GetInstallationResponse
+	(*Run)(nil),                      // 10: installation.v1alpha1.Run
+	(*ListRunsRequest)(nil),          // 11: installation.v1alpha1.ListRunsRequest
+	(*ListRunsResponse)(nil),         // 12: installation.v1alpha1.ListRunsResponse
+	(*GetLogsRequest)(nil),           // 13: installation.v1alpha1.GetLogsRequest
+	(*GetLogsResponse)(nil),          // 14: installation.v1alpha1.GetLogsResponse
+	nil,                              // 15: installation.v1alpha1.BundleActionOptions.ParametersEntry
+	nil,                              // 16: installation.v1alpha1.InstallRequest.LabelsEntry
+	(*Installation)(nil),             // 17: installation.v1alpha1.Installation
+	(*structpb.Struct)(nil),          // 18: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),    // 19: google.protobuf.Timestamp
+}
+var file_installation_v1alpha1_lifecycle_proto_depIdxs = []int32{
+	15, // 0: installation.v1alpha1.BundleActionOptions.parameters:type_name -> installation.v1alpha1.BundleActionOptions.ParametersEntry
+	0,  // 1: installation.v1alpha1.InstallRequest.options:type_name -> installation.v1alpha1.BundleActionOptions
+	16, // 2: installation.v1alpha1.InstallRequest.labels:type_name -> installation.v1alpha1.InstallRequest.LabelsEntry
+	0,  // 3: installation.v1alpha1.UpgradeRequest.options:type_name -> installation.v1alpha1.BundleActionOptions
+	0,  // 4: installation.v1alpha1.InvokeRequest.options:type_name -> installation.v1alpha1.BundleActionOptions
+	0,  // 5: installation.v1alpha1.UninstallRequest.options:type_name -> installation.v1alpha1.BundleActionOptions
+	6,  // 6: installation.v1alpha1.ActionResponse.result:type_name -> installation.v1alpha1.ActionResult
+	17, // 7: installation.v1alpha1.GetInstallationResponse.installation:type_name -> installation.v1alpha1.Installation
+	18, // 8: installation.v1alpha1.Run.parameters:type_name -> google.protobuf.Struct
+	19, // 9: installation.v1alpha1.Run.started:type_name -> google.protobuf.Timestamp
+	19, // 10: installation.v1alpha1.Run.stopped:type_name -> google.protobuf.Timestamp
+	10, // 11: installation.v1alpha1.ListRunsResponse.runs:type_name -> installation.v1alpha1.Run
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_installation_v1alpha1_lifecycle_proto_init() }
+func file_installation_v1alpha1_lifecycle_proto_init() {
+	if File_installation_v1alpha1_lifecycle_proto != nil {
+		return
+	}
+	file_installation_v1alpha1_installation_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_installation_v1alpha1_lifecycle_proto_rawDesc), len(file_installation_v1alpha1_lifecycle_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_installation_v1alpha1_lifecycle_proto_goTypes,
+		DependencyIndexes: file_installation_v1alpha1_lifecycle_proto_depIdxs,
+		MessageInfos:      file_installation_v1alpha1_lifecycle_proto_msgTypes,
+	}.Build()
+	File_installation_v1alpha1_lifecycle_proto = out.File
+	file_installation_v1alpha1_lifecycle_proto_goTypes = nil
+	file_installation_v1alpha1_lifecycle_proto_depIdxs = nil
+}
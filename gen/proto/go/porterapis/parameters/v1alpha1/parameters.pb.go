@@ -0,0 +1,760 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: parameters/v1alpha1/parameters.proto
+
+package parametersv1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Strategy maps a parameter to the source that its value is resolved from
+type Strategy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Source strategy and hint, for example env: DATABASE_URL
+	Source        map[string]string `protobuf:"bytes,2,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Strategy) Reset() {
+	*x = Strategy{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Strategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strategy) ProtoMessage() {}
+
+func (x *Strategy) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strategy.ProtoReflect.Descriptor instead.
+func (*Strategy) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Strategy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Strategy) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type ParameterSetStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParameterSetStatus) Reset() {
+	*x = ParameterSetStatus{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParameterSetStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterSetStatus) ProtoMessage() {}
+
+func (x *ParameterSetStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterSetStatus.ProtoReflect.Descriptor instead.
+func (*ParameterSetStatus) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParameterSetStatus) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *ParameterSetStatus) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+type ParameterSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaType    string                 `protobuf:"bytes,1,opt,name=schemaType,proto3" json:"schemaType,omitempty"`
+	SchemaVersion string                 `protobuf:"bytes,2,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Parameters    []*Strategy            `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Status        *ParameterSetStatus    `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParameterSet) Reset() {
+	*x = ParameterSet{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParameterSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterSet) ProtoMessage() {}
+
+func (x *ParameterSet) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterSet.ProtoReflect.Descriptor instead.
+func (*ParameterSet) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ParameterSet) GetSchemaType() string {
+	if x != nil {
+		return x.SchemaType
+	}
+	return ""
+}
+
+func (x *ParameterSet) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *ParameterSet) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ParameterSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParameterSet) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ParameterSet) GetParameters() []*Strategy {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ParameterSet) GetStatus() *ParameterSetStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ListParameterSetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AllNamespaces bool                   `protobuf:"varint,4,opt,name=allNamespaces,proto3" json:"allNamespaces,omitempty"`
+	Skip          int64                  `protobuf:"varint,5,opt,name=skip,proto3" json:"skip,omitempty"`
+	Limit         int64                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParameterSetsRequest) Reset() {
+	*x = ListParameterSetsRequest{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParameterSetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParameterSetsRequest) ProtoMessage() {}
+
+func (x *ListParameterSetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParameterSetsRequest.ProtoReflect.Descriptor instead.
+func (*ListParameterSetsRequest) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListParameterSetsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListParameterSetsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListParameterSetsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListParameterSetsRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.AllNamespaces
+	}
+	return false
+}
+
+func (x *ListParameterSetsRequest) GetSkip() int64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListParameterSetsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListParameterSetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParameterSets []*ParameterSet        `protobuf:"bytes,1,rep,name=parameterSets,proto3" json:"parameterSets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParameterSetsResponse) Reset() {
+	*x = ListParameterSetsResponse{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParameterSetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParameterSetsResponse) ProtoMessage() {}
+
+func (x *ListParameterSetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParameterSetsResponse.ProtoReflect.Descriptor instead.
+func (*ListParameterSetsResponse) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListParameterSetsResponse) GetParameterSets() []*ParameterSet {
+	if x != nil {
+		return x.ParameterSets
+	}
+	return nil
+}
+
+type GetParameterSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetParameterSetRequest) Reset() {
+	*x = GetParameterSetRequest{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetParameterSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetParameterSetRequest) ProtoMessage() {}
+
+func (x *GetParameterSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetParameterSetRequest.ProtoReflect.Descriptor instead.
+func (*GetParameterSetRequest) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetParameterSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetParameterSetRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type GetParameterSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParameterSet  *ParameterSet          `protobuf:"bytes,1,opt,name=parameterSet,proto3" json:"parameterSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetParameterSetResponse) Reset() {
+	*x = GetParameterSetResponse{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetParameterSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetParameterSetResponse) ProtoMessage() {}
+
+func (x *GetParameterSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetParameterSetResponse.ProtoReflect.Descriptor instead.
+func (*GetParameterSetResponse) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetParameterSetResponse) GetParameterSet() *ParameterSet {
+	if x != nil {
+		return x.ParameterSet
+	}
+	return nil
+}
+
+type ApplyParameterSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParameterSet  *ParameterSet          `protobuf:"bytes,1,opt,name=parameterSet,proto3" json:"parameterSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyParameterSetRequest) Reset() {
+	*x = ApplyParameterSetRequest{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyParameterSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyParameterSetRequest) ProtoMessage() {}
+
+func (x *ApplyParameterSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyParameterSetRequest.ProtoReflect.Descriptor instead.
+func (*ApplyParameterSetRequest) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApplyParameterSetRequest) GetParameterSet() *ParameterSet {
+	if x != nil {
+		return x.ParameterSet
+	}
+	return nil
+}
+
+type ApplyParameterSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParameterSet  *ParameterSet          `protobuf:"bytes,1,opt,name=parameterSet,proto3" json:"parameterSet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyParameterSetResponse) Reset() {
+	*x = ApplyParameterSetResponse{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyParameterSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyParameterSetResponse) ProtoMessage() {}
+
+func (x *ApplyParameterSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyParameterSetResponse.ProtoReflect.Descriptor instead.
+func (*ApplyParameterSetResponse) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ApplyParameterSetResponse) GetParameterSet() *ParameterSet {
+	if x != nil {
+		return x.ParameterSet
+	}
+	return nil
+}
+
+type DeleteParameterSetRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Delete the parameter set even if it is used by an installation
+	Force         bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteParameterSetRequest) Reset() {
+	*x = DeleteParameterSetRequest{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteParameterSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteParameterSetRequest) ProtoMessage() {}
+
+func (x *DeleteParameterSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteParameterSetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteParameterSetRequest) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteParameterSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteParameterSetRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteParameterSetRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type DeleteParameterSetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteParameterSetResponse) Reset() {
+	*x = DeleteParameterSetResponse{}
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteParameterSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteParameterSetResponse) ProtoMessage() {}
+
+func (x *DeleteParameterSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parameters_v1alpha1_parameters_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteParameterSetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteParameterSetResponse) Descriptor() ([]byte, []int) {
+	return file_parameters_v1alpha1_parameters_proto_rawDescGZIP(), []int{10}
+}
+
+var File_parameters_v1alpha1_parameters_proto protoreflect.FileDescriptor
+
+const file_parameters_v1alpha1_parameters_proto_rawDesc = "" +
+	"\n" +
+	"$parameters/v1alpha1/parameters.proto\x12\x13parameters.v1alpha1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9c\x01\n" +
+	"\bStrategy\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12A\n" +
+	"\x06source\x18\x02 \x03(\v2).parameters.v1alpha1.Strategy.SourceEntryR\x06source\x1a9\n" +
+	"\vSourceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x01\n" +
+	"\x12ParameterSetStatus\x124\n" +
+	"\acreated\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bmodified\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\"\x88\x03\n" +
+	"\fParameterSet\x12\x1e\n" +
+	"\n" +
+	"schemaType\x18\x01 \x01(\tR\n" +
+	"schemaType\x12$\n" +
+	"\rschemaVersion\x18\x02 \x01(\tR\rschemaVersion\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12E\n" +
+	"\x06labels\x18\x05 \x03(\v2-.parameters.v1alpha1.ParameterSet.LabelsEntryR\x06labels\x12=\n" +
+	"\n" +
+	"parameters\x18\x06 \x03(\v2\x1d.parameters.v1alpha1.StrategyR\n" +
+	"parameters\x12?\n" +
+	"\x06status\x18\a \x01(\v2'.parameters.v1alpha1.ParameterSetStatusR\x06status\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaa\x02\n" +
+	"\x18ListParameterSetsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12Q\n" +
+	"\x06labels\x18\x03 \x03(\v29.parameters.v1alpha1.ListParameterSetsRequest.LabelsEntryR\x06labels\x12$\n" +
+	"\rallNamespaces\x18\x04 \x01(\bR\rallNamespaces\x12\x12\n" +
+	"\x04skip\x18\x05 \x01(\x03R\x04skip\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x03R\x05limit\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
+	"\x19ListParameterSetsResponse\x12G\n" +
+	"\rparameterSets\x18\x01 \x03(\v2!.parameters.v1alpha1.ParameterSetR\rparameterSets\"J\n" +
+	"\x16GetParameterSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"`\n" +
+	"\x17GetParameterSetResponse\x12E\n" +
+	"\fparameterSet\x18\x01 \x01(\v2!.parameters.v1alpha1.ParameterSetR\fparameterSet\"a\n" +
+	"\x18ApplyParameterSetRequest\x12E\n" +
+	"\fparameterSet\x18\x01 \x01(\v2!.parameters.v1alpha1.ParameterSetR\fparameterSet\"b\n" +
+	"\x19ApplyParameterSetResponse\x12E\n" +
+	"\fparameterSet\x18\x01 \x01(\v2!.parameters.v1alpha1.ParameterSetR\fparameterSet\"c\n" +
+	"\x19DeleteParameterSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"\x1c\n" +
+	"\x1aDeleteParameterSetResponseB\xec\x01\n" +
+	"\x17com.parameters.v1alpha1B\x0fParametersProtoP\x01ZSget.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1;parametersv1alpha1\xa2\x02\x03PXX\xaa\x02\x13Parameters.V1alpha1\xca\x02\x13Parameters\\V1alpha1\xe2\x02\x1fParameters\\V1alpha1\\GPBMetadata\xea\x02\x14Parameters::V1alpha1b\x06proto3"
+
+var (
+	file_parameters_v1alpha1_parameters_proto_rawDescOnce sync.Once
+	file_parameters_v1alpha1_parameters_proto_rawDescData []byte
+)
+
+func file_parameters_v1alpha1_parameters_proto_rawDescGZIP() []byte {
+	file_parameters_v1alpha1_parameters_proto_rawDescOnce.Do(func() {
+		file_parameters_v1alpha1_parameters_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_parameters_v1alpha1_parameters_proto_rawDesc), len(file_parameters_v1alpha1_parameters_proto_rawDesc)))
+	})
+	return file_parameters_v1alpha1_parameters_proto_rawDescData
+}
+
+var file_parameters_v1alpha1_parameters_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_parameters_v1alpha1_parameters_proto_goTypes = []any{
+	(*Strategy)(nil),                   // 0: parameters.v1alpha1.Strategy
+	(*ParameterSetStatus)(nil),         // 1: parameters.v1alpha1.ParameterSetStatus
+	(*ParameterSet)(nil),               // 2: parameters.v1alpha1.ParameterSet
+	(*ListParameterSetsRequest)(nil),   // 3: parameters.v1alpha1.ListParameterSetsRequest
+	(*ListParameterSetsResponse)(nil),  // 4: parameters.v1alpha1.ListParameterSetsResponse
+	(*GetParameterSetRequest)(nil),     // 5: parameters.v1alpha1.GetParameterSetRequest
+	(*GetParameterSetResponse)(nil),    // 6: parameters.v1alpha1.GetParameterSetResponse
+	(*ApplyParameterSetRequest)(nil),   // 7: parameters.v1alpha1.ApplyParameterSetRequest
+	(*ApplyParameterSetResponse)(nil),  // 8: parameters.v1alpha1.ApplyParameterSetResponse
+	(*DeleteParameterSetRequest)(nil),  // 9: parameters.v1alpha1.DeleteParameterSetRequest
+	(*DeleteParameterSetResponse)(nil), // 10: parameters.v1alpha1.DeleteParameterSetResponse
+	nil,                                // 11: parameters.v1alpha1.Strategy.SourceEntry
+	nil,                                // 12: parameters.v1alpha1.ParameterSet.LabelsEntry
+	nil,                                // 13: parameters.v1alpha1.ListParameterSetsRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),      // 14: google.protobuf.Timestamp
+}
+var file_parameters_v1alpha1_parameters_proto_depIdxs = []int32{
+	11, // 0: parameters.v1alpha1.Strategy.source:type_name -> parameters.v1alpha1.Strategy.SourceEntry
+	14, // 1: parameters.v1alpha1.ParameterSetStatus.created:type_name -> google.protobuf.Timestamp
+	14, // 2: parameters.v1alpha1.ParameterSetStatus.modified:type_name -> google.protobuf.Timestamp
+	12, // 3: parameters.v1alpha1.ParameterSet.labels:type_name -> parameters.v1alpha1.ParameterSet.LabelsEntry
+	0,  // 4: parameters.v1alpha1.ParameterSet.parameters:type_name -> parameters.v1alpha1.Strategy
+	1,  // 5: parameters.v1alpha1.ParameterSet.status:type_name -> parameters.v1alpha1.ParameterSetStatus
+	13, // 6: parameters.v1alpha1.ListParameterSetsRequest.labels:type_name -> parameters.v1alpha1.ListParameterSetsRequest.LabelsEntry
+	2,  // 7: parameters.v1alpha1.ListParameterSetsResponse.parameterSets:type_name -> parameters.v1alpha1.ParameterSet
+	2,  // 8: parameters.v1alpha1.GetParameterSetResponse.parameterSet:type_name -> parameters.v1alpha1.ParameterSet
+	2,  // 9: parameters.v1alpha1.ApplyParameterSetRequest.parameterSet:type_name -> parameters.v1alpha1.ParameterSet
+	2,  // 10: parameters.v1alpha1.ApplyParameterSetResponse.parameterSet:type_name -> parameters.v1alpha1.ParameterSet
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_parameters_v1alpha1_parameters_proto_init() }
+func file_parameters_v1alpha1_parameters_proto_init() {
+	if File_parameters_v1alpha1_parameters_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_parameters_v1alpha1_parameters_proto_rawDesc), len(file_parameters_v1alpha1_parameters_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_parameters_v1alpha1_parameters_proto_goTypes,
+		DependencyIndexes: file_parameters_v1alpha1_parameters_proto_depIdxs,
+		MessageInfos:      file_parameters_v1alpha1_parameters_proto_msgTypes,
+	}.Build()
+	File_parameters_v1alpha1_parameters_proto = out.File
+	file_parameters_v1alpha1_parameters_proto_goTypes = nil
+	file_parameters_v1alpha1_parameters_proto_depIdxs = nil
+}
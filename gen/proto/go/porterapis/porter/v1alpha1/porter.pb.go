@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: porter/v1alpha1/porter.proto
 
@@ -9,10 +9,14 @@
 package porterv1alpha1
 
 import (
+	v1alpha11 "get.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1"
 	v1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	v1alpha12 "get.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
+	unsafe "unsafe"
 )
 
 const (
@@ -24,63 +28,106 @@ const (
 
 var File_porter_v1alpha1_porter_proto protoreflect.FileDescriptor
 
-var file_porter_v1alpha1_porter_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2f, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f,
-	0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a,
-	0x28, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x9f, 0x02, 0x0a, 0x06, 0x50, 0x6f,
-	0x72, 0x74, 0x65, 0x72, 0x12, 0x78, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x2e, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x9a,
-	0x01, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x12, 0x3a, 0x2e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73,
-	0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x69,
-	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0xcc, 0x01, 0x0a, 0x13,
-	0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x42, 0x0b, 0x50, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x50, 0x01, 0x5a, 0x4b, 0x67, 0x65, 0x74, 0x2e, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x73,
-	0x68, 0x2f, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x61, 0x70, 0x69, 0x73,
-	0x2f, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x3b, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2,
-	0x02, 0x03, 0x50, 0x58, 0x58, 0xaa, 0x02, 0x0f, 0x50, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x56,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x0f, 0x50, 0x6f, 0x72, 0x74, 0x65, 0x72,
-	0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x1b, 0x50, 0x6f, 0x72, 0x74,
-	0x65, 0x72, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x10, 0x50, 0x6f, 0x72, 0x74, 0x65, 0x72,
-	0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
-}
+const file_porter_v1alpha1_porter_proto_rawDesc = "" +
+	"\n" +
+	"\x1cporter/v1alpha1/porter.proto\x12\x0fporter.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a(installation/v1alpha1/installation.proto\x1a%installation/v1alpha1/lifecycle.proto\x1a&credentials/v1alpha1/credentials.proto\x1a$parameters/v1alpha1/parameters.proto2\xae\x17\n" +
+	"\x06Porter\x12\xbd\x01\n" +
+	"\x11ListInstallations\x12/.installation.v1alpha1.ListInstallationsRequest\x1a0.installation.v1alpha1.ListInstallationsResponse\"E\x82\xd3\xe4\x93\x02?Z\x13\x12\x11/v1/installations\x12(/v1/namespaces/{namespace}/installations\x12\xfd\x01\n" +
+	"\x1dListInstallationLatestOutputs\x12:.installation.v1alpha1.ListInstallationLatestOutputRequest\x1a;.installation.v1alpha1.ListInstallationLatestOutputResponse\"c\x82\xd3\xe4\x93\x02]Z\"\x12 /v1/installations/{name}/outputs\x127/v1/namespaces/{namespace}/installations/{name}/outputs\x12\xc4\x01\n" +
+	"\aInstall\x12%.installation.v1alpha1.InstallRequest\x1a%.installation.v1alpha1.ActionResponse\"i\x82\xd3\xe4\x93\x02c:\x01*Z%:\x01*\" /v1/installations/{name}/install\"7/v1/namespaces/{namespace}/installations/{name}/install0\x01\x12\xc4\x01\n" +
+	"\aUpgrade\x12%.installation.v1alpha1.UpgradeRequest\x1a%.installation.v1alpha1.ActionResponse\"i\x82\xd3\xe4\x93\x02c:\x01*Z%:\x01*\" /v1/installations/{name}/upgrade\"7/v1/namespaces/{namespace}/installations/{name}/upgrade0\x01\x12\xc0\x01\n" +
+	"\x06Invoke\x12$.installation.v1alpha1.InvokeRequest\x1a%.installation.v1alpha1.ActionResponse\"g\x82\xd3\xe4\x93\x02a:\x01*Z$:\x01*\"\x1f/v1/installations/{name}/invoke\"6/v1/namespaces/{namespace}/installations/{name}/invoke0\x01\x12\xcc\x01\n" +
+	"\tUninstall\x12'.installation.v1alpha1.UninstallRequest\x1a%.installation.v1alpha1.ActionResponse\"m\x82\xd3\xe4\x93\x02g:\x01*Z':\x01*\"\"/v1/installations/{name}/uninstall\"9/v1/namespaces/{namespace}/installations/{name}/uninstall0\x01\x12\xc6\x01\n" +
+	"\x11ApplyInstallation\x12/.installation.v1alpha1.ApplyInstallationRequest\x1a%.installation.v1alpha1.ActionResponse\"W\x82\xd3\xe4\x93\x02Q:\x01*Z\x1c:\x01*\"\x17/v1/installations:apply\"./v1/namespaces/{namespace}/installations:apply0\x01\x12\xc5\x01\n" +
+	"\x0fGetInstallation\x12-.installation.v1alpha1.GetInstallationRequest\x1a..installation.v1alpha1.GetInstallationResponse\"S\x82\xd3\xe4\x93\x02MZ\x1a\x12\x18/v1/installations/{name}\x12//v1/namespaces/{namespace}/installations/{name}\x12\xba\x01\n" +
+	"\bListRuns\x12&.installation.v1alpha1.ListRunsRequest\x1a'.installation.v1alpha1.ListRunsResponse\"]\x82\xd3\xe4\x93\x02WZ\x1f\x12\x1d/v1/installations/{name}/runs\x124/v1/namespaces/{namespace}/installations/{name}/runs\x12\xb7\x01\n" +
+	"\aGetLogs\x12%.installation.v1alpha1.GetLogsRequest\x1a&.installation.v1alpha1.GetLogsResponse\"]\x82\xd3\xe4\x93\x02WZ\x1f\x12\x1d/v1/installations/{name}/logs\x124/v1/namespaces/{namespace}/installations/{name}/logs\x12y\n" +
+	"\x12ListCredentialSets\x12/.credentials.v1alpha1.ListCredentialSetsRequest\x1a0.credentials.v1alpha1.ListCredentialSetsResponse\"\x00\x12s\n" +
+	"\x10GetCredentialSet\x12-.credentials.v1alpha1.GetCredentialSetRequest\x1a..credentials.v1alpha1.GetCredentialSetResponse\"\x00\x12y\n" +
+	"\x12ApplyCredentialSet\x12/.credentials.v1alpha1.ApplyCredentialSetRequest\x1a0.credentials.v1alpha1.ApplyCredentialSetResponse\"\x00\x12|\n" +
+	"\x13DeleteCredentialSet\x120.credentials.v1alpha1.DeleteCredentialSetRequest\x1a1.credentials.v1alpha1.DeleteCredentialSetResponse\"\x00\x12t\n" +
+	"\x11ListParameterSets\x12-.parameters.v1alpha1.ListParameterSetsRequest\x1a..parameters.v1alpha1.ListParameterSetsResponse\"\x00\x12n\n" +
+	"\x0fGetParameterSet\x12+.parameters.v1alpha1.GetParameterSetRequest\x1a,.parameters.v1alpha1.GetParameterSetResponse\"\x00\x12t\n" +
+	"\x11ApplyParameterSet\x12-.parameters.v1alpha1.ApplyParameterSetRequest\x1a..parameters.v1alpha1.ApplyParameterSetResponse\"\x00\x12w\n" +
+	"\x12DeleteParameterSet\x12..parameters.v1alpha1.DeleteParameterSetRequest\x1a/.parameters.v1alpha1.DeleteParameterSetResponse\"\x00B\xcc\x01\n" +
+	"\x13com.porter.v1alpha1B\vPorterProtoP\x01ZKget.porter.sh/porter/gen/proto/go/porterapis/porter/v1alpha1;porterv1alpha1\xa2\x02\x03PXX\xaa\x02\x0fPorter.V1alpha1\xca\x02\x0fPorter\\V1alpha1\xe2\x02\x1bPorter\\V1alpha1\\GPBMetadata\xea\x02\x10Porter::V1alpha1b\x06proto3"
 
-var file_porter_v1alpha1_porter_proto_goTypes = []interface{}{
+var file_porter_v1alpha1_porter_proto_goTypes = []any{
 	(*v1alpha1.ListInstallationsRequest)(nil),             // 0: installation.v1alpha1.ListInstallationsRequest
 	(*v1alpha1.ListInstallationLatestOutputRequest)(nil),  // 1: installation.v1alpha1.ListInstallationLatestOutputRequest
-	(*v1alpha1.ListInstallationsResponse)(nil),            // 2: installation.v1alpha1.ListInstallationsResponse
-	(*v1alpha1.ListInstallationLatestOutputResponse)(nil), // 3: installation.v1alpha1.ListInstallationLatestOutputResponse
+	(*v1alpha1.InstallRequest)(nil),                       // 2: installation.v1alpha1.InstallRequest
+	(*v1alpha1.UpgradeRequest)(nil),                       // 3: installation.v1alpha1.UpgradeRequest
+	(*v1alpha1.InvokeRequest)(nil),                        // 4: installation.v1alpha1.InvokeRequest
+	(*v1alpha1.UninstallRequest)(nil),                     // 5: installation.v1alpha1.UninstallRequest
+	(*v1alpha1.ApplyInstallationRequest)(nil),             // 6: installation.v1alpha1.ApplyInstallationRequest
+	(*v1alpha1.GetInstallationRequest)(nil),               // 7: installation.v1alpha1.GetInstallationRequest
+	(*v1alpha1.ListRunsRequest)(nil),                      // 8: installation.v1alpha1.ListRunsRequest
+	(*v1alpha1.GetLogsRequest)(nil),                       // 9: installation.v1alpha1.GetLogsRequest
+	(*v1alpha11.ListCredentialSetsRequest)(nil),           // 10: credentials.v1alpha1.ListCredentialSetsRequest
+	(*v1alpha11.GetCredentialSetRequest)(nil),             // 11: credentials.v1alpha1.GetCredentialSetRequest
+	(*v1alpha11.ApplyCredentialSetRequest)(nil),           // 12: credentials.v1alpha1.ApplyCredentialSetRequest
+	(*v1alpha11.DeleteCredentialSetRequest)(nil),          // 13: credentials.v1alpha1.DeleteCredentialSetRequest
+	(*v1alpha12.ListParameterSetsRequest)(nil),            // 14: parameters.v1alpha1.ListParameterSetsRequest
+	(*v1alpha12.GetParameterSetRequest)(nil),              // 15: parameters.v1alpha1.GetParameterSetRequest
+	(*v1alpha12.ApplyParameterSetRequest)(nil),            // 16: parameters.v1alpha1.ApplyParameterSetRequest
+	(*v1alpha12.DeleteParameterSetRequest)(nil),           // 17: parameters.v1alpha1.DeleteParameterSetRequest
+	(*v1alpha1.ListInstallationsResponse)(nil),            // 18: installation.v1alpha1.ListInstallationsResponse
+	(*v1alpha1.ListInstallationLatestOutputResponse)(nil), // 19: installation.v1alpha1.ListInstallationLatestOutputResponse
+	(*v1alpha1.ActionResponse)(nil),                       // 20: installation.v1alpha1.ActionResponse
+	(*v1alpha1.GetInstallationResponse)(nil),              // 21: installation.v1alpha1.GetInstallationResponse
+	(*v1alpha1.ListRunsResponse)(nil),                     // 22: installation.v1alpha1.ListRunsResponse
+	(*v1alpha1.GetLogsResponse)(nil),                      // 23: installation.v1alpha1.GetLogsResponse
+	(*v1alpha11.ListCredentialSetsResponse)(nil),          // 24: credentials.v1alpha1.ListCredentialSetsResponse
+	(*v1alpha11.GetCredentialSetResponse)(nil),            // 25: credentials.v1alpha1.GetCredentialSetResponse
+	(*v1alpha11.ApplyCredentialSetResponse)(nil),          // 26: credentials.v1alpha1.ApplyCredentialSetResponse
+	(*v1alpha11.DeleteCredentialSetResponse)(nil),         // 27: credentials.v1alpha1.DeleteCredentialSetResponse
+	(*v1alpha12.ListParameterSetsResponse)(nil),           // 28: parameters.v1alpha1.ListParameterSetsResponse
+	(*v1alpha12.GetParameterSetResponse)(nil),             // 29: parameters.v1alpha1.GetParameterSetResponse
+	(*v1alpha12.ApplyParameterSetResponse)(nil),           // 30: parameters.v1alpha1.ApplyParameterSetResponse
+	(*v1alpha12.DeleteParameterSetResponse)(nil),          // 31: parameters.v1alpha1.DeleteParameterSetResponse
 }
 var file_porter_v1alpha1_porter_proto_depIdxs = []int32{
-	0, // 0: porter.v1alpha1.Porter.ListInstallations:input_type -> installation.v1alpha1.ListInstallationsRequest
-	1, // 1: porter.v1alpha1.Porter.ListInstallationLatestOutputs:input_type -> installation.v1alpha1.ListInstallationLatestOutputRequest
-	2, // 2: porter.v1alpha1.Porter.ListInstallations:output_type -> installation.v1alpha1.ListInstallationsResponse
-	3, // 3: porter.v1alpha1.Porter.ListInstallationLatestOutputs:output_type -> installation.v1alpha1.ListInstallationLatestOutputResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: porter.v1alpha1.Porter.ListInstallations:input_type -> installation.v1alpha1.ListInstallationsRequest
+	1,  // 1: porter.v1alpha1.Porter.ListInstallationLatestOutputs:input_type -> installation.v1alpha1.ListInstallationLatestOutputRequest
+	2,  // 2: porter.v1alpha1.Porter.Install:input_type -> installation.v1alpha1.InstallRequest
+	3,  // 3: porter.v1alpha1.Porter.Upgrade:input_type -> installation.v1alpha1.UpgradeRequest
+	4,  // 4: porter.v1alpha1.Porter.Invoke:input_type -> installation.v1alpha1.InvokeRequest
+	5,  // 5: porter.v1alpha1.Porter.Uninstall:input_type -> installation.v1alpha1.UninstallRequest
+	6,  // 6: porter.v1alpha1.Porter.ApplyInstallation:input_type -> installation.v1alpha1.ApplyInstallationRequest
+	7,  // 7: porter.v1alpha1.Porter.GetInstallation:input_type -> installation.v1alpha1.GetInstallationRequest
+	8,  // 8: porter.v1alpha1.Porter.ListRuns:input_type -> installation.v1alpha1.ListRunsRequest
+	9,  // 9: porter.v1alpha1.Porter.GetLogs:input_type -> installation.v1alpha1.GetLogsRequest
+	10, // 10: porter.v1alpha1.Porter.ListCredentialSets:input_type -> credentials.v1alpha1.ListCredentialSetsRequest
+	11, // 11: porter.v1alpha1.Porter.GetCredentialSet:input_type -> credentials.v1alpha1.GetCredentialSetRequest
+	12, // 12: porter.v1alpha1.Porter.ApplyCredentialSet:input_type -> credentials.v1alpha1.ApplyCredentialSetRequest
+	13, // 13: porter.v1alpha1.Porter.DeleteCredentialSet:input_type -> credentials.v1alpha1.DeleteCredentialSetRequest
+	14, // 14: porter.v1alpha1.Porter.ListParameterSets:input_type -> parameters.v1alpha1.ListParameterSetsRequest
+	15, // 15: porter.v1alpha1.Porter.GetParameterSet:input_type -> parameters.v1alpha1.GetParameterSetRequest
+	16, // 16: porter.v1alpha1.Porter.ApplyParameterSet:input_type -> parameters.v1alpha1.ApplyParameterSetRequest
+	17, // 17: porter.v1alpha1.Porter.DeleteParameterSet:input_type -> parameters.v1alpha1.DeleteParameterSetRequest
+	18, // 18: porter.v1alpha1.Porter.ListInstallations:output_type -> installation.v1alpha1.ListInstallationsResponse
+	19, // 19: porter.v1alpha1.Porter.ListInstallationLatestOutputs:output_type -> installation.v1alpha1.ListInstallationLatestOutputResponse
+	20, // 20: porter.v1alpha1.Porter.Install:output_type -> installation.v1alpha1.ActionResponse
+	20, // 21: porter.v1alpha1.Porter.Upgrade:output_type -> installation.v1alpha1.ActionResponse
+	20, // 22: porter.v1alpha1.Porter.Invoke:output_type -> installation.v1alpha1.ActionResponse
+	20, // 23: porter.v1alpha1.Porter.Uninstall:output_type -> installation.v1alpha1.ActionResponse
+	20, // 24: porter.v1alpha1.Porter.ApplyInstallation:output_type -> installation.v1alpha1.ActionResponse
+	21, // 25: porter.v1alpha1.Porter.GetInstallation:output_type -> installation.v1alpha1.GetInstallationResponse
+	22, // 26: porter.v1alpha1.Porter.ListRuns:output_type -> installation.v1alpha1.ListRunsResponse
+	23, // 27: porter.v1alpha1.Porter.GetLogs:output_type -> installation.v1alpha1.GetLogsResponse
+	24, // 28: porter.v1alpha1.Porter.ListCredentialSets:output_type -> credentials.v1alpha1.ListCredentialSetsResponse
+	25, // 29: porter.v1alpha1.Porter.GetCredentialSet:output_type -> credentials.v1alpha1.GetCredentialSetResponse
+	26, // 30: porter.v1alpha1.Porter.ApplyCredentialSet:output_type -> credentials.v1alpha1.ApplyCredentialSetResponse
+	27, // 31: porter.v1alpha1.Porter.DeleteCredentialSet:output_type -> credentials.v1alpha1.DeleteCredentialSetResponse
+	28, // 32: porter.v1alpha1.Porter.ListParameterSets:output_type -> parameters.v1alpha1.ListParameterSetsResponse
+	29, // 33: porter.v1alpha1.Porter.GetParameterSet:output_type -> parameters.v1alpha1.GetParameterSetResponse
+	30, // 34: porter.v1alpha1.Porter.ApplyParameterSet:output_type -> parameters.v1alpha1.ApplyParameterSetResponse
+	31, // 35: porter.v1alpha1.Porter.DeleteParameterSet:output_type -> parameters.v1alpha1.DeleteParameterSetResponse
+	18, // [18:36] is the sub-list for method output_type
+	0,  // [0:18] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_porter_v1alpha1_porter_proto_init() }
@@ -92,7 +139,7 @@ func file_porter_v1alpha1_porter_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_porter_v1alpha1_porter_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_porter_v1alpha1_porter_proto_rawDesc), len(file_porter_v1alpha1_porter_proto_rawDesc)),
 			NumEnums:      0,
 			NumMessages:   0,
 			NumExtensions: 0,
@@ -102,7 +149,6 @@ func file_porter_v1alpha1_porter_proto_init() {
 		DependencyIndexes: file_porter_v1alpha1_porter_proto_depIdxs,
 	}.Build()
 	File_porter_v1alpha1_porter_proto = out.File
-	file_porter_v1alpha1_porter_proto_rawDesc = nil
 	file_porter_v1alpha1_porter_proto_goTypes = nil
 	file_porter_v1alpha1_porter_proto_depIdxs = nil
 }
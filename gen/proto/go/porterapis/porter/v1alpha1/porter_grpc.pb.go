@@ -8,7 +8,9 @@ package porterv1alpha1
 
 import (
 	context "context"
+	v1alpha11 "get.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1"
 	v1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	v1alpha12 "get.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -27,6 +29,38 @@ type PorterClient interface {
 	ListInstallations(ctx context.Context, in *v1alpha1.ListInstallationsRequest, opts ...grpc.CallOption) (*v1alpha1.ListInstallationsResponse, error)
 	// Returns a list of all outputs for the latest installation run
 	ListInstallationLatestOutputs(ctx context.Context, in *v1alpha1.ListInstallationLatestOutputRequest, opts ...grpc.CallOption) (*v1alpha1.ListInstallationLatestOutputResponse, error)
+	// Installs a bundle, streaming its output until the action completes
+	Install(ctx context.Context, in *v1alpha1.InstallRequest, opts ...grpc.CallOption) (Porter_InstallClient, error)
+	// Upgrades an installation, streaming its output until the action completes
+	Upgrade(ctx context.Context, in *v1alpha1.UpgradeRequest, opts ...grpc.CallOption) (Porter_UpgradeClient, error)
+	// Invokes a custom action on an installation, streaming its output until the action completes
+	Invoke(ctx context.Context, in *v1alpha1.InvokeRequest, opts ...grpc.CallOption) (Porter_InvokeClient, error)
+	// Uninstalls an installation, streaming its output until the action completes
+	Uninstall(ctx context.Context, in *v1alpha1.UninstallRequest, opts ...grpc.CallOption) (Porter_UninstallClient, error)
+	// Creates or updates an installation from a document and reconciles it, streaming its output until the action completes
+	ApplyInstallation(ctx context.Context, in *v1alpha1.ApplyInstallationRequest, opts ...grpc.CallOption) (Porter_ApplyInstallationClient, error)
+	// Returns an installation
+	GetInstallation(ctx context.Context, in *v1alpha1.GetInstallationRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstallationResponse, error)
+	// Returns the runs of an installation
+	ListRuns(ctx context.Context, in *v1alpha1.ListRunsRequest, opts ...grpc.CallOption) (*v1alpha1.ListRunsResponse, error)
+	// Returns the logs of an installation run
+	GetLogs(ctx context.Context, in *v1alpha1.GetLogsRequest, opts ...grpc.CallOption) (*v1alpha1.GetLogsResponse, error)
+	// Returns a list of credential sets
+	ListCredentialSets(ctx context.Context, in *v1alpha11.ListCredentialSetsRequest, opts ...grpc.CallOption) (*v1alpha11.ListCredentialSetsResponse, error)
+	// Returns a credential set
+	GetCredentialSet(ctx context.Context, in *v1alpha11.GetCredentialSetRequest, opts ...grpc.CallOption) (*v1alpha11.GetCredentialSetResponse, error)
+	// Creates or updates a credential set
+	ApplyCredentialSet(ctx context.Context, in *v1alpha11.ApplyCredentialSetRequest, opts ...grpc.CallOption) (*v1alpha11.ApplyCredentialSetResponse, error)
+	// Deletes a credential set
+	DeleteCredentialSet(ctx context.Context, in *v1alpha11.DeleteCredentialSetRequest, opts ...grpc.CallOption) (*v1alpha11.DeleteCredentialSetResponse, error)
+	// Returns a list of parameter sets
+	ListParameterSets(ctx context.Context, in *v1alpha12.ListParameterSetsRequest, opts ...grpc.CallOption) (*v1alpha12.ListParameterSetsResponse, error)
+	// Returns a parameter set
+	GetParameterSet(ctx context.Context, in *v1alpha12.GetParameterSetRequest, opts ...grpc.CallOption) (*v1alpha12.GetParameterSetResponse, error)
+	// Creates or updates a parameter set
+	ApplyParameterSet(ctx context.Context, in *v1alpha12.ApplyParameterSetRequest, opts ...grpc.CallOption) (*v1alpha12.ApplyParameterSetResponse, error)
+	// Deletes a parameter set
+	DeleteParameterSet(ctx context.Context, in *v1alpha12.DeleteParameterSetRequest, opts ...grpc.CallOption) (*v1alpha12.DeleteParameterSetResponse, error)
 }
 
 type porterClient struct {
@@ -55,6 +89,265 @@ func (c *porterClient) ListInstallationLatestOutputs(ctx context.Context, in *v1
 	return out, nil
 }
 
+func (c *porterClient) Install(ctx context.Context, in *v1alpha1.InstallRequest, opts ...grpc.CallOption) (Porter_InstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Porter_ServiceDesc.Streams[0], "/porter.v1alpha1.Porter/Install", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &porterInstallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Porter_InstallClient interface {
+	Recv() (*v1alpha1.ActionResponse, error)
+	grpc.ClientStream
+}
+
+type porterInstallClient struct {
+	grpc.ClientStream
+}
+
+func (x *porterInstallClient) Recv() (*v1alpha1.ActionResponse, error) {
+	m := new(v1alpha1.ActionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *porterClient) Upgrade(ctx context.Context, in *v1alpha1.UpgradeRequest, opts ...grpc.CallOption) (Porter_UpgradeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Porter_ServiceDesc.Streams[1], "/porter.v1alpha1.Porter/Upgrade", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &porterUpgradeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Porter_UpgradeClient interface {
+	Recv() (*v1alpha1.ActionResponse, error)
+	grpc.ClientStream
+}
+
+type porterUpgradeClient struct {
+	grpc.ClientStream
+}
+
+func (x *porterUpgradeClient) Recv() (*v1alpha1.ActionResponse, error) {
+	m := new(v1alpha1.ActionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *porterClient) Invoke(ctx context.Context, in *v1alpha1.InvokeRequest, opts ...grpc.CallOption) (Porter_InvokeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Porter_ServiceDesc.Streams[2], "/porter.v1alpha1.Porter/Invoke", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &porterInvokeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Porter_InvokeClient interface {
+	Recv() (*v1alpha1.ActionResponse, error)
+	grpc.ClientStream
+}
+
+type porterInvokeClient struct {
+	grpc.ClientStream
+}
+
+func (x *porterInvokeClient) Recv() (*v1alpha1.ActionResponse, error) {
+	m := new(v1alpha1.ActionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *porterClient) Uninstall(ctx context.Context, in *v1alpha1.UninstallRequest, opts ...grpc.CallOption) (Porter_UninstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Porter_ServiceDesc.Streams[3], "/porter.v1alpha1.Porter/Uninstall", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &porterUninstallClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Porter_UninstallClient interface {
+	Recv() (*v1alpha1.ActionResponse, error)
+	grpc.ClientStream
+}
+
+type porterUninstallClient struct {
+	grpc.ClientStream
+}
+
+func (x *porterUninstallClient) Recv() (*v1alpha1.ActionResponse, error) {
+	m := new(v1alpha1.ActionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *porterClient) ApplyInstallation(ctx context.Context, in *v1alpha1.ApplyInstallationRequest, opts ...grpc.CallOption) (Porter_ApplyInstallationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Porter_ServiceDesc.Streams[4], "/porter.v1alpha1.Porter/ApplyInstallation", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &porterApplyInstallationClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Porter_ApplyInstallationClient interface {
+	Recv() (*v1alpha1.ActionResponse, error)
+	grpc.ClientStream
+}
+
+type porterApplyInstallationClient struct {
+	grpc.ClientStream
+}
+
+func (x *porterApplyInstallationClient) Recv() (*v1alpha1.ActionResponse, error) {
+	m := new(v1alpha1.ActionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *porterClient) GetInstallation(ctx context.Context, in *v1alpha1.GetInstallationRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstallationResponse, error) {
+	out := new(v1alpha1.GetInstallationResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/GetInstallation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) ListRuns(ctx context.Context, in *v1alpha1.ListRunsRequest, opts ...grpc.CallOption) (*v1alpha1.ListRunsResponse, error) {
+	out := new(v1alpha1.ListRunsResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/ListRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) GetLogs(ctx context.Context, in *v1alpha1.GetLogsRequest, opts ...grpc.CallOption) (*v1alpha1.GetLogsResponse, error) {
+	out := new(v1alpha1.GetLogsResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/GetLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) ListCredentialSets(ctx context.Context, in *v1alpha11.ListCredentialSetsRequest, opts ...grpc.CallOption) (*v1alpha11.ListCredentialSetsResponse, error) {
+	out := new(v1alpha11.ListCredentialSetsResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/ListCredentialSets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) GetCredentialSet(ctx context.Context, in *v1alpha11.GetCredentialSetRequest, opts ...grpc.CallOption) (*v1alpha11.GetCredentialSetResponse, error) {
+	out := new(v1alpha11.GetCredentialSetResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/GetCredentialSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) ApplyCredentialSet(ctx context.Context, in *v1alpha11.ApplyCredentialSetRequest, opts ...grpc.CallOption) (*v1alpha11.ApplyCredentialSetResponse, error) {
+	out := new(v1alpha11.ApplyCredentialSetResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/ApplyCredentialSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) DeleteCredentialSet(ctx context.Context, in *v1alpha11.DeleteCredentialSetRequest, opts ...grpc.CallOption) (*v1alpha11.DeleteCredentialSetResponse, error) {
+	out := new(v1alpha11.DeleteCredentialSetResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/DeleteCredentialSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) ListParameterSets(ctx context.Context, in *v1alpha12.ListParameterSetsRequest, opts ...grpc.CallOption) (*v1alpha12.ListParameterSetsResponse, error) {
+	out := new(v1alpha12.ListParameterSetsResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/ListParameterSets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) GetParameterSet(ctx context.Context, in *v1alpha12.GetParameterSetRequest, opts ...grpc.CallOption) (*v1alpha12.GetParameterSetResponse, error) {
+	out := new(v1alpha12.GetParameterSetResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/GetParameterSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) ApplyParameterSet(ctx context.Context, in *v1alpha12.ApplyParameterSetRequest, opts ...grpc.CallOption) (*v1alpha12.ApplyParameterSetResponse, error) {
+	out := new(v1alpha12.ApplyParameterSetResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/ApplyParameterSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *porterClient) DeleteParameterSet(ctx context.Context, in *v1alpha12.DeleteParameterSetRequest, opts ...grpc.CallOption) (*v1alpha12.DeleteParameterSetResponse, error) {
+	out := new(v1alpha12.DeleteParameterSetResponse)
+	err := c.cc.Invoke(ctx, "/porter.v1alpha1.Porter/DeleteParameterSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PorterServer is the server API for Porter service.
 // All implementations must embed UnimplementedPorterServer
 // for forward compatibility
@@ -63,6 +356,38 @@ type PorterServer interface {
 	ListInstallations(context.Context, *v1alpha1.ListInstallationsRequest) (*v1alpha1.ListInstallationsResponse, error)
 	// Returns a list of all outputs for the latest installation run
 	ListInstallationLatestOutputs(context.Context, *v1alpha1.ListInstallationLatestOutputRequest) (*v1alpha1.ListInstallationLatestOutputResponse, error)
+	// Installs a bundle, streaming its output until the action completes
+	Install(*v1alpha1.InstallRequest, Porter_InstallServer) error
+	// Upgrades an installation, streaming its output until the action completes
+	Upgrade(*v1alpha1.UpgradeRequest, Porter_UpgradeServer) error
+	// Invokes a custom action on an installation, streaming its output until the action completes
+	Invoke(*v1alpha1.InvokeRequest, Porter_InvokeServer) error
+	// Uninstalls an installation, streaming its output until the action completes
+	Uninstall(*v1alpha1.UninstallRequest, Porter_UninstallServer) error
+	// Creates or updates an installation from a document and reconciles it, streaming its output until the action completes
+	ApplyInstallation(*v1alpha1.ApplyInstallationRequest, Porter_ApplyInstallationServer) error
+	// Returns an installation
+	GetInstallation(context.Context, *v1alpha1.GetInstallationRequest) (*v1alpha1.GetInstallationResponse, error)
+	// Returns the runs of an installation
+	ListRuns(context.Context, *v1alpha1.ListRunsRequest) (*v1alpha1.ListRunsResponse, error)
+	// Returns the logs of an installation run
+	GetLogs(context.Context, *v1alpha1.GetLogsRequest) (*v1alpha1.GetLogsResponse, error)
+	// Returns a list of credential sets
+	ListCredentialSets(context.Context, *v1alpha11.ListCredentialSetsRequest) (*v1alpha11.ListCredentialSetsResponse, error)
+	// Returns a credential set
+	GetCredentialSet(context.Context, *v1alpha11.GetCredentialSetRequest) (*v1alpha11.GetCredentialSetResponse, error)
+	// Creates or updates a credential set
+	ApplyCredentialSet(context.Context, *v1alpha11.ApplyCredentialSetRequest) (*v1alpha11.ApplyCredentialSetResponse, error)
+	// Deletes a credential set
+	DeleteCredentialSet(context.Context, *v1alpha11.DeleteCredentialSetRequest) (*v1alpha11.DeleteCredentialSetResponse, error)
+	// Returns a list of parameter sets
+	ListParameterSets(context.Context, *v1alpha12.ListParameterSetsRequest) (*v1alpha12.ListParameterSetsResponse, error)
+	// Returns a parameter set
+	GetParameterSet(context.Context, *v1alpha12.GetParameterSetRequest) (*v1alpha12.GetParameterSetResponse, error)
+	// Creates or updates a parameter set
+	ApplyParameterSet(context.Context, *v1alpha12.ApplyParameterSetRequest) (*v1alpha12.ApplyParameterSetResponse, error)
+	// Deletes a parameter set
+	DeleteParameterSet(context.Context, *v1alpha12.DeleteParameterSetRequest) (*v1alpha12.DeleteParameterSetResponse, error)
 	mustEmbedUnimplementedPorterServer()
 }
 
@@ -76,6 +401,54 @@ func (UnimplementedPorterServer) ListInstallations(context.Context, *v1alpha1.Li
 func (UnimplementedPorterServer) ListInstallationLatestOutputs(context.Context, *v1alpha1.ListInstallationLatestOutputRequest) (*v1alpha1.ListInstallationLatestOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListInstallationLatestOutputs not implemented")
 }
+func (UnimplementedPorterServer) Install(*v1alpha1.InstallRequest, Porter_InstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method Install not implemented")
+}
+func (UnimplementedPorterServer) Upgrade(*v1alpha1.UpgradeRequest, Porter_UpgradeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Upgrade not implemented")
+}
+func (UnimplementedPorterServer) Invoke(*v1alpha1.InvokeRequest, Porter_InvokeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Invoke not implemented")
+}
+func (UnimplementedPorterServer) Uninstall(*v1alpha1.UninstallRequest, Porter_UninstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method Uninstall not implemented")
+}
+func (UnimplementedPorterServer) ApplyInstallation(*v1alpha1.ApplyInstallationRequest, Porter_ApplyInstallationServer) error {
+	return status.Errorf(codes.Unimplemented, "method ApplyInstallation not implemented")
+}
+func (UnimplementedPorterServer) GetInstallation(context.Context, *v1alpha1.GetInstallationRequest) (*v1alpha1.GetInstallationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstallation not implemented")
+}
+func (UnimplementedPorterServer) ListRuns(context.Context, *v1alpha1.ListRunsRequest) (*v1alpha1.ListRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+}
+func (UnimplementedPorterServer) GetLogs(context.Context, *v1alpha1.GetLogsRequest) (*v1alpha1.GetLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (UnimplementedPorterServer) ListCredentialSets(context.Context, *v1alpha11.ListCredentialSetsRequest) (*v1alpha11.ListCredentialSetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCredentialSets not implemented")
+}
+func (UnimplementedPorterServer) GetCredentialSet(context.Context, *v1alpha11.GetCredentialSetRequest) (*v1alpha11.GetCredentialSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCredentialSet not implemented")
+}
+func (UnimplementedPorterServer) ApplyCredentialSet(context.Context, *v1alpha11.ApplyCredentialSetRequest) (*v1alpha11.ApplyCredentialSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyCredentialSet not implemented")
+}
+func (UnimplementedPorterServer) DeleteCredentialSet(context.Context, *v1alpha11.DeleteCredentialSetRequest) (*v1alpha11.DeleteCredentialSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredentialSet not implemented")
+}
+func (UnimplementedPorterServer) ListParameterSets(context.Context, *v1alpha12.ListParameterSetsRequest) (*v1alpha12.ListParameterSetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParameterSets not implemented")
+}
+func (UnimplementedPorterServer) GetParameterSet(context.Context, *v1alpha12.GetParameterSetRequest) (*v1alpha12.GetParameterSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetParameterSet not implemented")
+}
+func (UnimplementedPorterServer) ApplyParameterSet(context.Context, *v1alpha12.ApplyParameterSetRequest) (*v1alpha12.ApplyParameterSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyParameterSet not implemented")
+}
+func (UnimplementedPorterServer) DeleteParameterSet(context.Context, *v1alpha12.DeleteParameterSetRequest) (*v1alpha12.DeleteParameterSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteParameterSet not implemented")
+}
 func (UnimplementedPorterServer) mustEmbedUnimplementedPorterServer() {}
 
 // UnsafePorterServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +498,309 @@ func _Porter_ListInstallationLatestOutputs_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Porter_Install_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1alpha1.InstallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PorterServer).Install(m, &porterInstallServer{stream})
+}
+
+type Porter_InstallServer interface {
+	Send(*v1alpha1.ActionResponse) error
+	grpc.ServerStream
+}
+
+type porterInstallServer struct {
+	grpc.ServerStream
+}
+
+func (x *porterInstallServer) Send(m *v1alpha1.ActionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Porter_Upgrade_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1alpha1.UpgradeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PorterServer).Upgrade(m, &porterUpgradeServer{stream})
+}
+
+type Porter_UpgradeServer interface {
+	Send(*v1alpha1.ActionResponse) error
+	grpc.ServerStream
+}
+
+type porterUpgradeServer struct {
+	grpc.ServerStream
+}
+
+func (x *porterUpgradeServer) Send(m *v1alpha1.ActionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Porter_Invoke_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1alpha1.InvokeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PorterServer).Invoke(m, &porterInvokeServer{stream})
+}
+
+type Porter_InvokeServer interface {
+	Send(*v1alpha1.ActionResponse) error
+	grpc.ServerStream
+}
+
+type porterInvokeServer struct {
+	grpc.ServerStream
+}
+
+func (x *porterInvokeServer) Send(m *v1alpha1.ActionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Porter_Uninstall_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1alpha1.UninstallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PorterServer).Uninstall(m, &porterUninstallServer{stream})
+}
+
+type Porter_UninstallServer interface {
+	Send(*v1alpha1.ActionResponse) error
+	grpc.ServerStream
+}
+
+type porterUninstallServer struct {
+	grpc.ServerStream
+}
+
+func (x *porterUninstallServer) Send(m *v1alpha1.ActionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Porter_ApplyInstallation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(v1alpha1.ApplyInstallationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PorterServer).ApplyInstallation(m, &porterApplyInstallationServer{stream})
+}
+
+type Porter_ApplyInstallationServer interface {
+	Send(*v1alpha1.ActionResponse) error
+	grpc.ServerStream
+}
+
+type porterApplyInstallationServer struct {
+	grpc.ServerStream
+}
+
+func (x *porterApplyInstallationServer) Send(m *v1alpha1.ActionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Porter_GetInstallation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstallationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).GetInstallation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/GetInstallation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).GetInstallation(ctx, req.(*v1alpha1.GetInstallationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/ListRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).ListRuns(ctx, req.(*v1alpha1.ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/GetLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).GetLogs(ctx, req.(*v1alpha1.GetLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_ListCredentialSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.ListCredentialSetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).ListCredentialSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/ListCredentialSets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).ListCredentialSets(ctx, req.(*v1alpha11.ListCredentialSetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_GetCredentialSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.GetCredentialSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).GetCredentialSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/GetCredentialSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).GetCredentialSet(ctx, req.(*v1alpha11.GetCredentialSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_ApplyCredentialSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.ApplyCredentialSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).ApplyCredentialSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/ApplyCredentialSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).ApplyCredentialSet(ctx, req.(*v1alpha11.ApplyCredentialSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_DeleteCredentialSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.DeleteCredentialSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).DeleteCredentialSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/DeleteCredentialSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).DeleteCredentialSet(ctx, req.(*v1alpha11.DeleteCredentialSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_ListParameterSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha12.ListParameterSetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).ListParameterSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/ListParameterSets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).ListParameterSets(ctx, req.(*v1alpha12.ListParameterSetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_GetParameterSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha12.GetParameterSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).GetParameterSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/GetParameterSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).GetParameterSet(ctx, req.(*v1alpha12.GetParameterSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_ApplyParameterSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha12.ApplyParameterSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).ApplyParameterSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/ApplyParameterSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).ApplyParameterSet(ctx, req.(*v1alpha12.ApplyParameterSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Porter_DeleteParameterSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha12.DeleteParameterSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PorterServer).DeleteParameterSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/porter.v1alpha1.Porter/DeleteParameterSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PorterServer).DeleteParameterSet(ctx, req.(*v1alpha12.DeleteParameterSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Porter_ServiceDesc is the grpc.ServiceDesc for Porter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,7 +816,77 @@ var Porter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListInstallationLatestOutputs",
 			Handler:    _Porter_ListInstallationLatestOutputs_Handler,
 		},
+		{
+			MethodName: "GetInstallation",
+			Handler:    _Porter_GetInstallation_Handler,
+		},
+		{
+			MethodName: "ListRuns",
+			Handler:    _Porter_ListRuns_Handler,
+		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _Porter_GetLogs_Handler,
+		},
+		{
+			MethodName: "ListCredentialSets",
+			Handler:    _Porter_ListCredentialSets_Handler,
+		},
+		{
+			MethodName: "GetCredentialSet",
+			Handler:    _Porter_GetCredentialSet_Handler,
+		},
+		{
+			MethodName: "ApplyCredentialSet",
+			Handler:    _Porter_ApplyCredentialSet_Handler,
+		},
+		{
+			MethodName: "DeleteCredentialSet",
+			Handler:    _Porter_DeleteCredentialSet_Handler,
+		},
+		{
+			MethodName: "ListParameterSets",
+			Handler:    _Porter_ListParameterSets_Handler,
+		},
+		{
+			MethodName: "GetParameterSet",
+			Handler:    _Porter_GetParameterSet_Handler,
+		},
+		{
+			MethodName: "ApplyParameterSet",
+			Handler:    _Porter_ApplyParameterSet_Handler,
+		},
+		{
+			MethodName: "DeleteParameterSet",
+			Handler:    _Porter_DeleteParameterSet_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Install",
+			Handler:       _Porter_Install_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Upgrade",
+			Handler:       _Porter_Upgrade_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Invoke",
+			Handler:       _Porter_Invoke_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Uninstall",
+			Handler:       _Porter_Uninstall_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ApplyInstallation",
+			Handler:       _Porter_ApplyInstallation_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "porter/v1alpha1/porter.proto",
 }
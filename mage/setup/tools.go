@@ -28,6 +28,11 @@ func EnsureProtobufTools() {
 		DefaultVersion: "v1.2",
 		VersionCommand: "--version",
 	}))
+	mgx.Must(pkg.EnsurePackageWith(pkg.EnsurePackageOptions{
+		Name:           "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway",
+		DefaultVersion: "v2.29.0",
+		VersionCommand: "--version",
+	}))
 }
 
 // IsCommandInPath determines if a command can be called based on the current PATH.
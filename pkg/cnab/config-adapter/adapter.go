@@ -374,6 +374,26 @@ func (c *ManifestConverter) buildDefaultPorterOutputs() []manifest.OutputDefinit
 				Comment:         cnab.PorterInternal,
 			},
 		},
+		{
+			Name:      config.StepProgressOutput,
+			Sensitive: true,
+			ApplyTo:   []string{cnab.ActionInstall, cnab.ActionUpgrade},
+			Schema: definition.Schema{
+				ID:          "https://porter.sh/generated-bundle/#porter-step-progress",
+				Description: "Records the steps completed by a run so that a failed run can be resumed. Porter internal output that should not be used by the bundle.",
+				Type:        "string",
+				Comment:     cnab.PorterInternal,
+			},
+		},
+		{
+			Name: config.StepReportOutput,
+			Schema: definition.Schema{
+				ID:          "https://porter.sh/generated-bundle/#porter-step-report",
+				Description: "Records the result of each step run by the action. Porter internal output that should not be used by the bundle.",
+				Type:        "string",
+				Comment:     cnab.PorterInternal,
+			},
+		},
 	}
 }
 
@@ -524,7 +524,7 @@ func TestManifestConverter_generateBundleOutputs(t *testing.T) {
 
 	defs := make(definition.Definitions, len(a.Manifest.Outputs))
 	outputs := a.generateBundleOutputs(ctx, &defs)
-	require.Len(t, defs, 6)
+	require.Len(t, defs, 8)
 
 	wantOutputDefinitions := map[string]bundle.Output{
 		"output1": {
@@ -559,6 +559,17 @@ func TestManifestConverter_generateBundleOutputs(t *testing.T) {
 			Definition:  "porter-state",
 			Path:        "/cnab/app/outputs/porter-state",
 		},
+		"porter-step-progress": {
+			Description: "Records the steps completed by a run so that a failed run can be resumed. Porter internal output that should not be used by the bundle.",
+			Definition:  "porter-step-progress-output",
+			ApplyTo:     []string{"install", "upgrade"},
+			Path:        "/cnab/app/outputs/porter-step-progress",
+		},
+		"porter-step-report": {
+			Description: "Records the result of each step run by the action. Porter internal output that should not be used by the bundle.",
+			Definition:  "porter-step-report-output",
+			Path:        "/cnab/app/outputs/porter-step-report",
+		},
 	}
 
 	require.Equal(t, wantOutputDefinitions, outputs)
@@ -592,6 +603,19 @@ func TestManifestConverter_generateBundleOutputs(t *testing.T) {
 			Type:            "string",
 			ContentEncoding: "base64",
 		},
+		"porter-step-progress-output": &definition.Schema{
+			ID:          "https://porter.sh/generated-bundle/#porter-step-progress",
+			Comment:     "porter-internal",
+			Description: "Records the steps completed by a run so that a failed run can be resumed. Porter internal output that should not be used by the bundle.",
+			Type:        "string",
+			WriteOnly:   toBool(true),
+		},
+		"porter-step-report-output": &definition.Schema{
+			ID:          "https://porter.sh/generated-bundle/#porter-step-report",
+			Comment:     "porter-internal",
+			Description: "Records the result of each step run by the action. Porter internal output that should not be used by the bundle.",
+			Type:        "string",
+		},
 	}
 
 	require.Equal(t, wantDefinitions, defs)
@@ -203,7 +203,25 @@ func (r *Runtime) Execute(ctx context.Context, args ActionArguments) error {
 		log.SetSensitiveAttributes(
 			tracing.ObjectAttribute("cnab-claim", cnabClaim),
 			tracing.ObjectAttribute("cnab-credentials", cnabCreds))
-		opResult, result, err := a.Run(ctx, cnabClaim, cnabCreds, r.ApplyConfig(ctx, args)...)
+		opConfigs := r.ApplyConfig(ctx, args)
+
+		// Persist the logs while the bundle is running so that they can be followed
+		var runLogs *runLogWriter
+		if currentRun.ShouldRecord() && args.PersistLogs {
+			runLogs = newRunLogWriter(r.installations, currentRun, DefaultLogChunkInterval)
+			opConfigs = append(opConfigs, runLogs.SetOutput())
+			runLogs.Start(ctx)
+		}
+
+		opResult, result, err := a.Run(ctx, cnabClaim, cnabCreds, opConfigs...)
+
+		// Persist the remaining logs before the result is saved, so that the
+		// logs are complete once the run has finished
+		if runLogs != nil {
+			if logsErr := runLogs.Close(context.WithoutCancel(ctx)); logsErr != nil {
+				log.Warnf("could not persist the logs for run %s: %s", currentRun.ID, logsErr)
+			}
+		}
 
 		// if the error was due to context cancellation, make a best-effort attempt
 		// to save any outputs the bundle wrote before being stopped, then return.
@@ -328,8 +346,9 @@ func (r *Runtime) SaveOperationResult(ctx context.Context, opResult driver.Opera
 	for outputName, outputValue := range opResult.Outputs {
 		// porter-state tracks bundle-managed resource state. Skip persisting it
 		// for modifies:false actions so that a read-only invoke cannot overwrite
-		// the installation's state record.
-		if !run.ActionModifies() && extBun.IsInternalOutput(outputName) {
+		// the installation's state record. The step report only describes the
+		// run, so it is always kept.
+		if !run.ActionModifies() && extBun.IsInternalOutput(outputName) && outputName != config.StepReportOutput {
 			continue
 		}
 
@@ -341,6 +360,16 @@ func (r *Runtime) SaveOperationResult(ctx context.Context, opResult driver.Opera
 		err = r.installations.InsertOutput(ctx, output)
 		if err != nil {
 			bigerr = multierror.Append(bigerr, fmt.Errorf("error adding %s output for %s run of installation %s\n%#v: %w", output.Name, run.Action, installation, output, err))
+			continue
+		}
+
+		// The log chunks persisted while the run was in progress are no longer
+		// needed once the complete logs are saved
+		if outputName == cnab.OutputInvocationImageLogs {
+			err = r.installations.RemoveLogChunks(ctx, run.ID)
+			if err != nil {
+				bigerr = multierror.Append(bigerr, fmt.Errorf("error removing the log chunks for %s run of installation %s: %w", run.Action, installation, err))
+			}
 		}
 	}
 
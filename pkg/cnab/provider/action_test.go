@@ -167,6 +167,35 @@ func TestSaveOperationResult_ModifiesTrue_SavesPorterState(t *testing.T) {
 	assert.True(t, hasPorterState, "porter-state should be saved for modifies:true actions")
 }
 
+func TestSaveOperationResult_RemovesLogChunks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := NewTestRuntime(t)
+	defer d.Close()
+
+	i := d.TestInstallations.CreateInstallation(storage.NewInstallation("", "mybuns"), d.TestInstallations.SetMutableInstallationValues)
+	run := d.TestInstallations.CreateRun(i.NewRun(cnab.ActionInstall, cnab.ExtendedBundle{}), d.TestInstallations.SetMutableRunValues)
+	require.NoError(t, d.TestInstallations.InsertLogChunk(ctx, run.NewLogChunk(1, []byte("installing"))))
+
+	opResult := driver.OperationResult{
+		Outputs: map[string]string{
+			cnab.OutputInvocationImageLogs: "installing",
+		},
+	}
+	err := d.SaveOperationResult(ctx, opResult, i, run, run.NewResult(cnab.StatusSucceeded))
+	require.NoError(t, err)
+
+	logs, ok, err := d.TestInstallations.GetLogs(ctx, run.ID)
+	require.NoError(t, err)
+	require.True(t, ok, "the logs should be saved as an output")
+	assert.Equal(t, "installing", logs)
+
+	chunks, err := d.TestInstallations.ListLogChunks(ctx, run.ID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, chunks, "the log chunks should be removed once the logs are saved")
+}
+
 func TestCheckForActiveRun(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,118 @@
+package cnabprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	cnabaction "github.com/cnabio/cnab-go/action"
+	"github.com/cnabio/cnab-go/driver"
+)
+
+// DefaultLogChunkInterval is how often the logs of an in-progress bundle run are persisted.
+const DefaultLogChunkInterval = 2 * time.Second
+
+// runLogWriter captures the logs written by a bundle run and periodically
+// persists them as log chunks, so that the logs can be followed from another
+// machine before the run completes.
+type runLogWriter struct {
+	installations storage.InstallationProvider
+	run           storage.Run
+	interval      time.Duration
+
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	sequence int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newRunLogWriter(installations storage.InstallationProvider, run storage.Run, interval time.Duration) *runLogWriter {
+	return &runLogWriter{
+		installations: installations,
+		run:           run,
+		interval:      interval,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Write buffers the logs until the next chunk is persisted.
+func (w *runLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+// SetOutput copies the output of the bundle to the log writer, in addition
+// to the output that was already configured.
+func (w *runLogWriter) SetOutput() cnabaction.OperationConfigFunc {
+	return func(op *driver.Operation) error {
+		op.Out = teeWriter(op.Out, w)
+		op.Err = teeWriter(op.Err, w)
+		return nil
+	}
+}
+
+// Start persisting the buffered logs in the background until Close is called.
+func (w *runLogWriter) Start(ctx context.Context) {
+	log := tracing.LoggerFromContext(ctx)
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				// Failing to persist a chunk should not fail the run, the
+				// complete logs are saved as an output when the run completes
+				if err := w.flush(ctx); err != nil {
+					log.Warnf("could not persist the logs for run %s: %s", w.run.ID, err)
+				}
+			}
+		}
+	}()
+}
+
+// Close stops persisting logs in the background and persists any remaining buffered logs.
+func (w *runLogWriter) Close(ctx context.Context) error {
+	close(w.stop)
+	<-w.done
+	return w.flush(ctx)
+}
+
+func (w *runLogWriter) flush(ctx context.Context) error {
+	w.mu.Lock()
+	if w.buf.Len() == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	data := make([]byte, w.buf.Len())
+	copy(data, w.buf.Bytes())
+	w.buf.Reset()
+	w.sequence++
+	chunk := w.run.NewLogChunk(w.sequence, data)
+	w.mu.Unlock()
+
+	if err := w.installations.InsertLogChunk(ctx, chunk); err != nil {
+		return fmt.Errorf("error saving log chunk %d: %w", chunk.Sequence, err)
+	}
+	return nil
+}
+
+func teeWriter(out io.Writer, w io.Writer) io.Writer {
+	if out == nil {
+		return w
+	}
+	return io.MultiWriter(out, w)
+}
@@ -0,0 +1,47 @@
+package cnabprovider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/cnabio/cnab-go/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLogWriter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewTestRuntime(t)
+	defer r.Close()
+
+	inst := r.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+	run := r.TestInstallations.CreateRun(inst.NewRun(cnab.ActionInstall, cnab.ExtendedBundle{}))
+
+	logs := newRunLogWriter(r.TestInstallations, run, 10*time.Millisecond)
+	op := &driver.Operation{}
+	require.NoError(t, logs.SetOutput()(op))
+	logs.Start(ctx)
+
+	fmt.Fprint(op.Out, "installing...\n")
+	require.Eventually(t, func() bool {
+		chunks, err := r.TestInstallations.ListLogChunks(ctx, run.ID, 0)
+		return err == nil && len(chunks) == 1
+	}, time.Second, 10*time.Millisecond, "the logs should be persisted while the run is in progress")
+
+	fmt.Fprint(op.Err, "done\n")
+	require.NoError(t, logs.Close(ctx))
+
+	chunks, err := r.TestInstallations.ListLogChunks(ctx, run.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, chunks, 2, "the remaining logs should be persisted when the writer is closed")
+	assert.Equal(t, "installing...\n", string(chunks[0].Data))
+	assert.Equal(t, 1, chunks[0].Sequence)
+	assert.Equal(t, "done\n", string(chunks[1].Data))
+	assert.Equal(t, 2, chunks[1].Sequence)
+}
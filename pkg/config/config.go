@@ -47,6 +47,18 @@ const (
 	// ClaimFilepath is the filepath to the claim.json inside of an bundle image
 	ClaimFilepath = "/cnab/claim.json"
 
+	// ResumeFilepath is the filepath inside of a bundle image where Porter places
+	// the step progress of the failed run that is being resumed.
+	ResumeFilepath = "/cnab/app/porter/resume.json"
+
+	// StepProgressOutput is the name of the internal output that records the
+	// steps completed by a run, so that a failed run can be resumed.
+	StepProgressOutput = "porter-step-progress"
+
+	// StepReportOutput is the name of the internal output that records the
+	// result of each step run by an action.
+	StepReportOutput = "porter-step-report"
+
 	// EnvPorterInstallationNamespace is the name of the environment variable which is injected into the
 	// bundle image, containing the namespace of the installation.
 	EnvPorterInstallationNamespace = "PORTER_INSTALLATION_NAMESPACE"
@@ -59,6 +71,14 @@ const (
 	// bundle image, containing the unique ID of the installation.
 	EnvPorterInstallationID = "PORTER_INSTALLATION_ID"
 
+	// EnvPorterFailedStep is the name of the environment variable which is set when running the
+	// onFailure and finally steps of an action, containing the description of the step that failed.
+	EnvPorterFailedStep = "PORTER_FAILED_STEP"
+
+	// EnvPorterFailedStepError is the name of the environment variable which is set when running the
+	// onFailure and finally steps of an action, containing the error from the step that failed.
+	EnvPorterFailedStepError = "PORTER_FAILED_STEP_ERROR"
+
 	// DefaultVerbosity is the default value for the --verbosity flag.
 	DefaultVerbosity = "info"
 )
@@ -193,7 +213,7 @@ func (c *Config) GetSecretsPlugin(name string) (SecretsPlugin, error) {
 		}
 	}
 
-	return SecretsPlugin{}, errors.New("secrets %q not defined")
+	return SecretsPlugin{}, fmt.Errorf("secrets %q not defined", name)
 }
 
 func (c *Config) GetSigningPlugin(name string) (SigningPlugin, error) {
@@ -38,6 +38,22 @@ type DependenciesConfig struct {
 	VersionStrategy string `mapstructure:"version-strategy"`
 }
 
+// RetentionConfig is the policy used by porter storage prune to decide which
+// runs, and their results and outputs, are kept for each installation.
+type RetentionConfig struct {
+	// KeepRuns is the number of most recent runs kept for each installation.
+	// All runs are kept when it is zero.
+	KeepRuns int `mapstructure:"keep-runs"`
+
+	// MaxAge is the maximum age of a run before it is removed, for example 720h.
+	// Runs are kept regardless of their age when it is empty.
+	MaxAge string `mapstructure:"max-age"`
+
+	// KeepFailed keeps failed runs regardless of KeepRuns and MaxAge so that
+	// they are available when troubleshooting.
+	KeepFailed bool `mapstructure:"keep-failed"`
+}
+
 // Data is the data stored in PORTER_HOME/porter.toml|yaml|json.
 // Use the accessor functions to ensure default values are handled properly.
 type Data struct {
@@ -118,6 +134,9 @@ type Data struct {
 	// Do not use directly, use Config.GetDependenciesVersionStrategy.
 	Dependencies DependenciesConfig `mapstructure:"dependencies"`
 
+	// Retention is the policy used to remove old runs with porter storage prune.
+	Retention RetentionConfig `mapstructure:"retention"`
+
 	// SchemaCheck specifies how strict Porter should be when comparing the
 	// schemaVersion field on a resource with the supported schemaVersion.
 	// Supported values are: exact, minor, major, none.
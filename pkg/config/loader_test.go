@@ -306,6 +306,27 @@ func TestLoad_DependenciesVersionStrategy_FromConfigFile(t *testing.T) {
 	assert.Equal(t, DependencyVersionStrategyMaxMinor, c.GetDependenciesVersionStrategy())
 }
 
+func TestLoad_Retention_FromConfigFile(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestConfig(t)
+	c.SetHomeDir("/home/myuser/.porter")
+
+	cfg := `retention:
+  keep-runs: 10
+  max-age: 720h
+  keep-failed: true
+`
+	require.NoError(t, c.TestContext.FileSystem.WriteFile(
+		"/home/myuser/.porter/config.yaml", []byte(cfg), 0600))
+
+	c.DataLoader = LoadFromFilesystem()
+	_, err := c.Load(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, RetentionConfig{KeepRuns: 10, MaxAge: "720h", KeepFailed: true}, c.Data.Retention)
+}
+
 func TestLoad_DependenciesVersionStrategy_FromEnvVar(t *testing.T) {
 	// Do not run in parallel — sets environment variables
 	os.Setenv("PORTER_DEPENDENCIES_VERSION_STRATEGY", DependencyVersionStrategyMin)
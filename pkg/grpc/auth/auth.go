@@ -0,0 +1,159 @@
+// Package auth authenticates and authorizes the clients of the porter api-server.
+//
+// Clients are identified by an Authenticator, either from the certificate that they
+// presented during the TLS handshake, or from a bearer token in the request metadata.
+// The Authorizer then decides if the identity may call the RPC in the namespace of the
+// request.
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"get.porter.sh/porter/pkg/tracing"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Identity is an authenticated client of the api-server.
+type Identity struct {
+	// Name of the client, used to match the client to authorization rules.
+	Name string
+
+	// Method used to authenticate the client, for example certificate or token.
+	Method string
+}
+
+type ctxKey int
+
+const identityCtxKey ctxKey = 0
+
+// IdentityFromContext returns the identity of the client that made the request.
+// Use AddIdentityToContext to add one.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityCtxKey).(Identity)
+	return id, ok
+}
+
+// AddIdentityToContext adds the identity of the client to the given context
+// use IdentityFromContext to read it back out
+func AddIdentityToContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityCtxKey, id)
+}
+
+// ErrNoCredentials is returned by an Authenticator when the request does not
+// have the credentials that it authenticates, so that the next Authenticator
+// can be tried.
+var ErrNoCredentials = errors.New("the request does not have credentials")
+
+// Authenticator identifies the client that made a request.
+type Authenticator interface {
+	// Authenticate returns the identity of the client that made the request.
+	// ErrNoCredentials is returned when the request does not have credentials for the authenticator.
+	Authenticate(ctx context.Context) (Identity, error)
+}
+
+// Authenticators tries each Authenticator in order, and identifies the client
+// with the first authenticator that finds credentials on the request.
+type Authenticators []Authenticator
+
+func (a Authenticators) Authenticate(ctx context.Context) (Identity, error) {
+	for _, authn := range a {
+		id, err := authn.Authenticate(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return id, err
+	}
+	return Identity{}, ErrNoCredentials
+}
+
+// Interceptor authenticates and authorizes each request made to the server.
+type Interceptor struct {
+	// Authenticator identifies the client making the request.
+	Authenticator Authenticator
+
+	// Authorizer decides if the client may make the request.
+	// When nil, every authenticated client is allowed.
+	Authorizer *Authorizer
+}
+
+// healthService is the prefix of the full method names of the gRPC health service.
+const healthService = "/grpc.health.v1.Health/"
+
+// authenticate identifies the client, returning a context with the identity of the client.
+// Health checks do not require authentication, so that they can be used by probes.
+func (i Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, healthService) {
+		return ctx, nil
+	}
+
+	spanCtx, log := tracing.StartSpan(ctx, attribute.String("method", method))
+	defer log.EndSpan()
+
+	id, err := i.Authenticator.Authenticate(spanCtx)
+	if err != nil {
+		if errors.Is(err, ErrNoCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "the request does not have a client certificate or bearer token")
+		}
+		log.Debugf("rejected the client: %s", err.Error())
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	log.SetAttributes(attribute.String("identity", id.Name), attribute.String("authentication", id.Method))
+	return AddIdentityToContext(ctx, id), nil
+}
+
+// authorize checks that the client may make the request.
+func (i Interceptor) authorize(ctx context.Context, method string, req interface{}) error {
+	if i.Authorizer == nil {
+		return nil
+	}
+	id, _ := IdentityFromContext(ctx)
+	if err := i.Authorizer.Authorize(id, method, req); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// UnaryServerInterceptor authenticates and authorizes unary RPCs.
+func (i Interceptor) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor authenticates streaming RPCs, and authorizes each
+// message that the client sends on the stream.
+func (i Interceptor) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, &authorizedStream{WrappedServerStream: wrapped, interceptor: i, method: info.FullMethod})
+}
+
+// authorizedStream authorizes the request messages as they are received, because
+// the namespace of a streaming RPC is only known once its request is read.
+type authorizedStream struct {
+	*grpc_middleware.WrappedServerStream
+	interceptor Interceptor
+	method      string
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.interceptor.authorize(s.Context(), s.method, m)
+}
@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	iGRPC "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	pGRPC "get.porter.sh/porter/gen/proto/go/porterapis/porter/v1alpha1"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testPorterServer records the identity of the client for the requests that were
+// allowed, and otherwise behaves like an unimplemented server.
+type testPorterServer struct {
+	pGRPC.UnimplementedPorterServer
+	identities chan Identity
+}
+
+func (s *testPorterServer) ListInstallations(ctx context.Context, req *iGRPC.ListInstallationsRequest) (*iGRPC.ListInstallationsResponse, error) {
+	id, _ := IdentityFromContext(ctx)
+	s.identities <- id
+	return &iGRPC.ListInstallationsResponse{}, nil
+}
+
+func (s *testPorterServer) Install(req *iGRPC.InstallRequest, stream pGRPC.Porter_InstallServer) error {
+	id, _ := IdentityFromContext(stream.Context())
+	s.identities <- id
+	return stream.Send(&iGRPC.ActionResponse{Log: "installing " + req.GetName()})
+}
+
+// startTestServer runs the Porter service in-process with the auth interceptor,
+// returning a function that connects a client to the server.
+func startTestServer(t *testing.T, interceptor Interceptor, tlsConfig *tls.Config) (*testPorterServer, func(creds credentials.TransportCredentials) pGRPC.PorterClient) {
+	listener := bufconn.Listen(1024 * 1024)
+	srvOpts := []grpc.ServerOption{
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(interceptor.StreamServerInterceptor)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(interceptor.UnaryServerInterceptor)),
+	}
+	if tlsConfig != nil {
+		srvOpts = append(srvOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	srv := grpc.NewServer(srvOpts...)
+	psrv := &testPorterServer{identities: make(chan Identity, 10)}
+	pGRPC.RegisterPorterServer(srv, psrv)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	connect := func(creds credentials.TransportCredentials) pGRPC.PorterClient {
+		dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}
+		conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return pGRPC.NewPorterClient(conn)
+	}
+	return psrv, connect
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// install calls the Install RPC and reads the stream until it completes.
+func install(ctx context.Context, client pGRPC.PorterClient, namespace string) error {
+	stream, err := client.Install(ctx, &iGRPC.InstallRequest{Name: "mybuns", Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func TestInterceptor_BearerToken(t *testing.T) {
+	tokens, err := NewTokenAuthenticator(TokenFile{Tokens: []TokenKey{{Identity: "ci", SHA256: hashToken("ci-token")}}})
+	require.NoError(t, err)
+	authz, err := NewAuthorizer(AuthorizationFile{Rules: []Rule{
+		{Identities: []string{"ci"}, Namespaces: []string{"dev"}, Permissions: []Permission{PermissionRead, PermissionRun}},
+		{Identities: []string{"ci"}, Namespaces: []string{"prod"}, Permissions: []Permission{PermissionRead}},
+	}})
+	require.NoError(t, err)
+
+	psrv, connect := startTestServer(t, Interceptor{Authenticator: Authenticators{tokens}, Authorizer: authz}, nil)
+	client := connect(insecure.NewCredentials())
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := client.ListInstallations(context.Background(), &iGRPC.ListInstallationsRequest{Namespace: ptr("dev")})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := client.ListInstallations(withToken("oops"), &iGRPC.ListInstallationsRequest{Namespace: ptr("dev")})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		_, err := client.ListInstallations(withToken("ci-token"), &iGRPC.ListInstallationsRequest{Namespace: ptr("prod")})
+		require.NoError(t, err)
+		assert.Equal(t, Identity{Name: "ci", Method: MethodToken}, <-psrv.identities)
+	})
+
+	t.Run("namespace denied", func(t *testing.T) {
+		_, err := client.ListInstallations(withToken("ci-token"), &iGRPC.ListInstallationsRequest{Namespace: ptr("test")})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("all namespaces denied", func(t *testing.T) {
+		_, err := client.ListInstallations(withToken("ci-token"), &iGRPC.ListInstallationsRequest{AllNamespaces: ptr(true)})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("stream allowed", func(t *testing.T) {
+		require.NoError(t, install(withToken("ci-token"), client, "dev"))
+		assert.Equal(t, "ci", (<-psrv.identities).Name)
+	})
+
+	t.Run("stream permission denied", func(t *testing.T) {
+		err := install(withToken("ci-token"), client, "prod")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("stream unauthenticated", func(t *testing.T) {
+		err := install(context.Background(), client, "dev")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+	assert.Empty(t, psrv.identities, "denied requests should not reach the server")
+}
+
+func TestInterceptor_ClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir)
+	serverCert := ca.issue(t, dir, "localhost")
+	deployerCert := ca.issue(t, dir, "deployer")
+	otherCA := newTestCA(t, t.TempDir())
+	untrustedCert := otherCA.issue(t, t.TempDir(), "deployer")
+
+	tokens, err := NewTokenAuthenticator(TokenFile{Tokens: []TokenKey{{Identity: "ci", SHA256: hashToken("ci-token")}}})
+	require.NoError(t, err)
+	authz, err := NewAuthorizer(AuthorizationFile{Rules: []Rule{
+		{Identities: []string{"deployer"}, Namespaces: []string{Wildcard}, Permissions: []Permission{Wildcard}},
+		{Identities: []string{Wildcard}, Namespaces: []string{""}, Permissions: []Permission{PermissionRead}},
+	}})
+	require.NoError(t, err)
+
+	tlsConfig, err := NewServerTLSConfig(serverCert.certFile, serverCert.keyFile, ca.certFile, false)
+	require.NoError(t, err)
+	interceptor := Interceptor{Authenticator: Authenticators{CertificateAuthenticator{}, tokens}, Authorizer: authz}
+	psrv, connect := startTestServer(t, interceptor, tlsConfig)
+
+	clientTLS := func(certs ...tls.Certificate) credentials.TransportCredentials {
+		return credentials.NewTLS(&tls.Config{ServerName: "localhost", RootCAs: ca.pool, Certificates: certs})
+	}
+
+	t.Run("client certificate", func(t *testing.T) {
+		client := connect(clientTLS(deployerCert.cert))
+		_, err := client.ListInstallations(context.Background(), &iGRPC.ListInstallationsRequest{AllNamespaces: ptr(true)})
+		require.NoError(t, err)
+		assert.Equal(t, Identity{Name: "deployer", Method: MethodCertificate}, <-psrv.identities)
+
+		require.NoError(t, install(context.Background(), client, "prod"))
+		assert.Equal(t, "deployer", (<-psrv.identities).Name)
+	})
+
+	t.Run("bearer token without a client certificate", func(t *testing.T) {
+		client := connect(clientTLS())
+		_, err := client.ListInstallations(withToken("ci-token"), &iGRPC.ListInstallationsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, Identity{Name: "ci", Method: MethodToken}, <-psrv.identities)
+
+		err = install(withToken("ci-token"), client, "")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("untrusted client certificate", func(t *testing.T) {
+		client := connect(clientTLS(untrustedCert.cert))
+		_, err := client.ListInstallations(context.Background(), &iGRPC.ListInstallationsRequest{})
+		assert.Equal(t, codes.Unavailable, status.Code(err), "the TLS handshake should fail")
+	})
+	assert.Empty(t, psrv.identities, "denied requests should not reach the server")
+}
+
+func TestNewServerTLSConfig_RequireClientCert(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir)
+	serverCert := ca.issue(t, dir, "localhost")
+
+	tlsConfig, err := NewServerTLSConfig(serverCert.certFile, serverCert.keyFile, ca.certFile, true)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+
+	tlsConfig, err = NewServerTLSConfig(serverCert.certFile, serverCert.keyFile, "", true)
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth, "client certificates are not requested without a client CA")
+
+	_, err = NewServerTLSConfig(serverCert.certFile, serverCert.keyFile, serverCert.keyFile, true)
+	assert.ErrorContains(t, err, "no certificates were found in the client CA file")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+type testCA struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	pool     *x509.CertPool
+	certFile string
+}
+
+type testCert struct {
+	cert     tls.Certificate
+	certFile string
+	keyFile  string
+}
+
+func newTestCA(t *testing.T, dir string) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "porter test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := testCA{cert: cert, key: key, pool: x509.NewCertPool(), certFile: filepath.Join(dir, "ca.crt")}
+	ca.pool.AddCert(cert)
+	writePEM(t, ca.certFile, "CERTIFICATE", der)
+	return ca
+}
+
+// issue creates a certificate signed by the CA, that is valid for both clients and localhost.
+func (ca testCA) issue(t *testing.T, dir string, commonName string) testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	c := testCert{certFile: filepath.Join(dir, commonName+".crt"), keyFile: filepath.Join(dir, commonName+".key")}
+	writePEM(t, c.certFile, "CERTIFICATE", der)
+	writePEM(t, c.keyFile, "EC PRIVATE KEY", keyDer)
+	c.cert, err = tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	require.NoError(t, err)
+	return c
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func TestInterceptor_HealthChecksAreNotAuthenticated(t *testing.T) {
+	interceptor := Interceptor{Authenticator: Authenticators{}}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, ok := IdentityFromContext(ctx)
+		assert.False(t, ok, "health checks should not have an identity")
+		return "SERVING", nil
+	}
+
+	resp, err := interceptor.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "SERVING", resp)
+
+	_, err = interceptor.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: porterService + "ListInstallations"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	credsGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1"
+	iGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	paramsGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1"
+	"get.porter.sh/porter/pkg/encoding"
+)
+
+// Permission is an operation that a rule allows on the resources in a namespace.
+type Permission string
+
+const (
+	// PermissionRead allows reading installations, runs, logs, outputs, credential sets and parameter sets.
+	PermissionRead Permission = "read"
+
+	// PermissionRun allows running bundle actions on installations.
+	PermissionRun Permission = "run"
+
+	// PermissionWrite allows creating, updating and deleting credential sets and parameter sets.
+	PermissionWrite Permission = "write"
+
+	// Wildcard matches any identity, namespace or permission in a rule.
+	Wildcard = "*"
+)
+
+// porterService is the prefix of the full method names of the Porter service.
+const porterService = "/porter.v1alpha1.Porter/"
+
+// methodPermissions is the permission required by each RPC of the Porter service.
+var methodPermissions = map[string]Permission{
+	porterService + "ListInstallations":             PermissionRead,
+	porterService + "ListInstallationLatestOutputs": PermissionRead,
+	porterService + "GetInstallation":               PermissionRead,
+	porterService + "ListRuns":                      PermissionRead,
+	porterService + "GetLogs":                       PermissionRead,
+	porterService + "ListCredentialSets":            PermissionRead,
+	porterService + "GetCredentialSet":              PermissionRead,
+	porterService + "ListParameterSets":             PermissionRead,
+	porterService + "GetParameterSet":               PermissionRead,
+	porterService + "Install":                       PermissionRun,
+	porterService + "Upgrade":                       PermissionRun,
+	porterService + "Invoke":                        PermissionRun,
+	porterService + "Uninstall":                     PermissionRun,
+	porterService + "ApplyInstallation":             PermissionRun,
+	porterService + "ApplyCredentialSet":            PermissionWrite,
+	porterService + "DeleteCredentialSet":           PermissionWrite,
+	porterService + "ApplyParameterSet":             PermissionWrite,
+	porterService + "DeleteParameterSet":            PermissionWrite,
+}
+
+// Rule grants permissions in a set of namespaces to a set of identities.
+type Rule struct {
+	// Identities that the rule applies to. Use * to match every authenticated client.
+	Identities []string `yaml:"identities" json:"identities"`
+
+	// Namespaces that the rule applies to. Use "" for the global namespace,
+	// and * to match every namespace, which is required for requests across all namespaces.
+	Namespaces []string `yaml:"namespaces" json:"namespaces"`
+
+	// Permissions granted by the rule. Use * to grant every permission.
+	Permissions []Permission `yaml:"permissions" json:"permissions"`
+}
+
+// AuthorizationFile is the file of authorization rules enforced by the server.
+type AuthorizationFile struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Authorizer decides if a client may call an RPC, based on the namespace of the request.
+// A request is allowed when any rule grants the permission required by the RPC.
+type Authorizer struct {
+	rules []Rule
+}
+
+// NewAuthorizer creates an authorizer that enforces the rules in the authorization file.
+func NewAuthorizer(file AuthorizationFile) (*Authorizer, error) {
+	for i, rule := range file.Rules {
+		if len(rule.Identities) == 0 {
+			return nil, fmt.Errorf("rule %d does not have any identities", i)
+		}
+		if len(rule.Namespaces) == 0 {
+			return nil, fmt.Errorf("rule %d does not have any namespaces", i)
+		}
+		for _, perm := range rule.Permissions {
+			switch perm {
+			case PermissionRead, PermissionRun, PermissionWrite, Wildcard:
+			default:
+				return nil, fmt.Errorf("rule %d has an invalid permission %q, allowed values are: %s, %s, %s, %s",
+					i, perm, PermissionRead, PermissionRun, PermissionWrite, Wildcard)
+			}
+		}
+	}
+	return &Authorizer{rules: file.Rules}, nil
+}
+
+// LoadAuthorizer creates an authorizer from an authorization file.
+func LoadAuthorizer(path string) (*Authorizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the authorization file %s: %w", path, err)
+	}
+	var file AuthorizationFile
+	if err := encoding.UnmarshalYaml(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse the authorization file %s: %w", path, err)
+	}
+	authz, err := NewAuthorizer(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization file %s: %w", path, err)
+	}
+	return authz, nil
+}
+
+// Authorize returns an error when the identity may not call the method with the request.
+// Methods of other services, such as health checks and reflection, are allowed for any
+// authenticated client.
+func (a *Authorizer) Authorize(id Identity, method string, req interface{}) error {
+	if !strings.HasPrefix(method, porterService) {
+		return nil
+	}
+	perm, ok := methodPermissions[method]
+	if !ok {
+		return fmt.Errorf("%s is not allowed to call %s", id.Name, method)
+	}
+
+	namespace, err := requestNamespace(req)
+	if err != nil {
+		return err
+	}
+	if a.allowed(id, perm, namespace) {
+		return nil
+	}
+	if namespace == Wildcard {
+		return fmt.Errorf("%s does not have %s permission in all namespaces", id.Name, perm)
+	}
+	return fmt.Errorf("%s does not have %s permission in the namespace %q", id.Name, perm, namespace)
+}
+
+func (a *Authorizer) allowed(id Identity, perm Permission, namespace string) bool {
+	for _, rule := range a.rules {
+		if matches(rule.Identities, id.Name) && matches(rule.Namespaces, namespace) && matchesPermission(rule.Permissions, perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns if the value is in the list, or the list contains the wildcard.
+// A wildcard value, used for requests across all namespaces, is only matched by the wildcard.
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == Wildcard || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesPermission(perms []Permission, perm Permission) bool {
+	for _, p := range perms {
+		if p == Wildcard || p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// requestNamespace returns the namespace of the resources affected by the request,
+// or the wildcard when the request is not limited to a single namespace.
+func requestNamespace(req interface{}) (string, error) {
+	switch r := req.(type) {
+	case *iGRPCv1alpha1.ListInstallationsRequest:
+		if r.GetAllNamespaces() {
+			return Wildcard, nil
+		}
+		return r.GetNamespace(), nil
+	case *credsGRPCv1alpha1.ListCredentialSetsRequest:
+		if r.GetAllNamespaces() {
+			return Wildcard, nil
+		}
+		return r.GetNamespace(), nil
+	case *paramsGRPCv1alpha1.ListParameterSetsRequest:
+		if r.GetAllNamespaces() {
+			return Wildcard, nil
+		}
+		return r.GetNamespace(), nil
+	case *iGRPCv1alpha1.GetLogsRequest:
+		// Runs are looked up by their id in every namespace, regardless of the
+		// installation name and namespace in the request
+		if r.GetRunId() != "" {
+			return Wildcard, nil
+		}
+		return r.GetNamespace(), nil
+	case *iGRPCv1alpha1.ApplyInstallationRequest:
+		// The namespace in the document takes precedence over the namespace of the request
+		var doc struct {
+			Namespace string `yaml:"namespace"`
+		}
+		if err := encoding.UnmarshalYaml([]byte(r.GetDocument()), &doc); err != nil {
+			return "", fmt.Errorf("unable to parse the installation document: %w", err)
+		}
+		if doc.Namespace != "" {
+			return doc.Namespace, nil
+		}
+		return r.GetNamespace(), nil
+	case *credsGRPCv1alpha1.ApplyCredentialSetRequest:
+		return r.GetCredentialSet().GetNamespace(), nil
+	case *paramsGRPCv1alpha1.ApplyParameterSetRequest:
+		return r.GetParameterSet().GetNamespace(), nil
+	case interface{ GetNamespace() string }:
+		return r.GetNamespace(), nil
+	default:
+		return "", fmt.Errorf("unable to determine the namespace of the request %T", req)
+	}
+}
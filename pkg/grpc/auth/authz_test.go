@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	credsGRPC "get.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1"
+	iGRPC "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	paramsGRPC "get.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	authz, err := NewAuthorizer(AuthorizationFile{Rules: []Rule{
+		{Identities: []string{"ci"}, Namespaces: []string{"dev"}, Permissions: []Permission{PermissionRead, PermissionRun}},
+		{Identities: []string{"admin"}, Namespaces: []string{Wildcard}, Permissions: []Permission{Wildcard}},
+		{Identities: []string{Wildcard}, Namespaces: []string{""}, Permissions: []Permission{PermissionRead}},
+	}})
+	require.NoError(t, err)
+
+	ci := Identity{Name: "ci"}
+	admin := Identity{Name: "admin"}
+	anyone := Identity{Name: "anyone"}
+
+	testcases := []struct {
+		name    string
+		id      Identity
+		method  string
+		req     interface{}
+		wantErr string
+	}{
+		{name: "read in namespace", id: ci, method: "ListRuns", req: &iGRPC.ListRunsRequest{Namespace: "dev"}},
+		{name: "run in namespace", id: ci, method: "Upgrade", req: &iGRPC.UpgradeRequest{Namespace: "dev"}},
+		{name: "write not granted", id: ci, method: "DeleteCredentialSet", req: &credsGRPC.DeleteCredentialSetRequest{Namespace: "dev"},
+			wantErr: `ci does not have write permission in the namespace "dev"`},
+		{name: "other namespace", id: ci, method: "GetInstallation", req: &iGRPC.GetInstallationRequest{Namespace: "prod"},
+			wantErr: `ci does not have read permission in the namespace "prod"`},
+		{name: "global namespace", id: anyone, method: "GetInstallation", req: &iGRPC.GetInstallationRequest{}},
+		{name: "all namespaces", id: ci, method: "ListCredentialSets", req: &credsGRPC.ListCredentialSetsRequest{Namespace: "dev", AllNamespaces: true},
+			wantErr: "ci does not have read permission in all namespaces"},
+		{name: "all namespaces wildcard", id: admin, method: "ListParameterSets", req: &paramsGRPC.ListParameterSetsRequest{AllNamespaces: true}},
+		{name: "logs by run id", id: ci, method: "GetLogs", req: &iGRPC.GetLogsRequest{RunId: "01ABC"},
+			wantErr: "ci does not have read permission in all namespaces"},
+		{name: "logs by run id in another namespace", id: ci, method: "GetLogs", req: &iGRPC.GetLogsRequest{Name: "mybuns", Namespace: "dev", RunId: "01ABC"},
+			wantErr: "ci does not have read permission in all namespaces"},
+		{name: "logs by installation", id: ci, method: "GetLogs", req: &iGRPC.GetLogsRequest{Name: "mybuns", Namespace: "dev"}},
+		{name: "apply credential set", id: admin, method: "ApplyCredentialSet",
+			req: &credsGRPC.ApplyCredentialSetRequest{CredentialSet: &credsGRPC.CredentialSet{Namespace: "prod"}}},
+		{name: "apply parameter set", id: ci, method: "ApplyParameterSet",
+			req:     &paramsGRPC.ApplyParameterSetRequest{ParameterSet: &paramsGRPC.ParameterSet{Namespace: "dev"}},
+			wantErr: `ci does not have write permission in the namespace "dev"`},
+		{name: "apply installation document namespace", id: ci, method: "ApplyInstallation",
+			req:     &iGRPC.ApplyInstallationRequest{Namespace: "dev", Document: "name: mybuns\nnamespace: prod\n"},
+			wantErr: `ci does not have run permission in the namespace "prod"`},
+		{name: "apply installation request namespace", id: ci, method: "ApplyInstallation",
+			req: &iGRPC.ApplyInstallationRequest{Namespace: "dev", Document: "name: mybuns\n"}},
+		{name: "unknown method", id: admin, method: "DeleteEverything", req: &iGRPC.GetInstallationRequest{},
+			wantErr: "admin is not allowed to call /porter.v1alpha1.Porter/DeleteEverything"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authz.Authorize(tc.id, porterService+tc.method, tc.req)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("other services", func(t *testing.T) {
+		err := authz.Authorize(Identity{Name: "nobody"}, "/grpc.health.v1.Health/Check", nil)
+		require.NoError(t, err, "services other than porter should be allowed for authenticated clients")
+	})
+}
+
+func TestNewAuthorizer_Validate(t *testing.T) {
+	_, err := NewAuthorizer(AuthorizationFile{Rules: []Rule{{Namespaces: []string{"dev"}}}})
+	require.EqualError(t, err, "rule 0 does not have any identities")
+
+	_, err = NewAuthorizer(AuthorizationFile{Rules: []Rule{{Identities: []string{"ci"}}}})
+	require.EqualError(t, err, "rule 0 does not have any namespaces")
+
+	_, err = NewAuthorizer(AuthorizationFile{Rules: []Rule{{Identities: []string{"ci"}, Namespaces: []string{"dev"}, Permissions: []Permission{"delete"}}}})
+	require.EqualError(t, err, `rule 0 has an invalid permission "delete", allowed values are: read, run, write, *`)
+}
+
+func TestLoadAuthorizer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "authz.yaml")
+	const rules = `rules:
+  - identities: [ci]
+    namespaces: [dev, ""]
+    permissions: [read, run]
+`
+	require.NoError(t, os.WriteFile(path, []byte(rules), 0600))
+
+	authz, err := LoadAuthorizer(path)
+	require.NoError(t, err)
+	require.NoError(t, authz.Authorize(Identity{Name: "ci"}, porterService+"Install", &iGRPC.InstallRequest{}))
+	require.Error(t, authz.Authorize(Identity{Name: "ci"}, porterService+"Install", &iGRPC.InstallRequest{Namespace: "prod"}))
+
+	_, err = LoadAuthorizer(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "could not read the authorization file")
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// MethodCertificate identifies clients that authenticated with a client certificate.
+const MethodCertificate = "certificate"
+
+// CertificateAuthenticator identifies clients by the common name of the client
+// certificate that they presented during the TLS handshake. The certificate
+// must have been verified against the client CA of the server.
+type CertificateAuthenticator struct{}
+
+func (a CertificateAuthenticator) Authenticate(ctx context.Context) (Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return Identity{}, ErrNoCredentials
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	if cert.Subject.CommonName == "" {
+		return Identity{}, errors.New("the client certificate does not have a common name")
+	}
+	return Identity{Name: cert.Subject.CommonName, Method: MethodCertificate}, nil
+}
+
+// NewServerTLSConfig creates the TLS configuration of the server from its certificate
+// and key. When clientCAFile is set, client certificates must be signed by one of the
+// certificate authorities in the file. Clients must present a certificate when
+// requireClientCert is set, otherwise they may authenticate another way instead.
+func NewServerTLSConfig(certFile string, keyFile string, clientCAFile string, requireClientCert bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the server certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		caData, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the client CA file %s: %w", clientCAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("no certificates were found in the client CA file %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if requireClientCert {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return cfg, nil
+}
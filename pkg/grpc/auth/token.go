@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"get.porter.sh/porter/pkg/encoding"
+	"google.golang.org/grpc/metadata"
+)
+
+// MethodToken identifies clients that authenticated with a bearer token.
+const MethodToken = "token"
+
+// TokenKey is an entry in the token file that grants a bearer token to an identity.
+type TokenKey struct {
+	// Identity of the clients that present the token.
+	Identity string `yaml:"identity" json:"identity"`
+
+	// SHA256 is the hex encoded SHA-256 hash of the token, so that the token
+	// itself is not stored on the server.
+	SHA256 string `yaml:"sha256" json:"sha256"`
+}
+
+// TokenFile is the file of bearer tokens that are accepted by the server.
+type TokenFile struct {
+	Tokens []TokenKey `yaml:"tokens" json:"tokens"`
+}
+
+// TokenAuthenticator identifies clients by the bearer token in the authorization
+// metadata of the request.
+type TokenAuthenticator struct {
+	// identities of the tokens, keyed by the hash of the token
+	identities map[string]string
+}
+
+// NewTokenAuthenticator creates an authenticator that accepts the tokens in the token file.
+func NewTokenAuthenticator(file TokenFile) (*TokenAuthenticator, error) {
+	a := &TokenAuthenticator{identities: make(map[string]string, len(file.Tokens))}
+	for i, key := range file.Tokens {
+		if key.Identity == "" {
+			return nil, fmt.Errorf("token %d does not have an identity", i)
+		}
+		hash, err := hex.DecodeString(key.SHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("the sha256 of the token for %s is not a hex encoded SHA-256 hash", key.Identity)
+		}
+		a.identities[strings.ToLower(key.SHA256)] = key.Identity
+	}
+	return a, nil
+}
+
+// LoadTokenAuthenticator creates an authenticator from a token file.
+func LoadTokenAuthenticator(path string) (*TokenAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the token file %s: %w", path, err)
+	}
+	var file TokenFile
+	if err := encoding.UnmarshalYaml(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse the token file %s: %w", path, err)
+	}
+	authn, err := NewTokenAuthenticator(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %w", path, err)
+	}
+	return authn, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(ctx context.Context) (Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return Identity{}, ErrNoCredentials
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return Identity{}, ErrNoCredentials
+	}
+	hash := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	identity, ok := a.identities[hex.EncodeToString(hash[:])]
+	if !ok {
+		return Identity{}, errors.New("invalid bearer token")
+	}
+	return Identity{Name: identity, Method: MethodToken}, nil
+}
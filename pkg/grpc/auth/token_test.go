@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTokenAuthenticator_Authenticate(t *testing.T) {
+	authn, err := NewTokenAuthenticator(TokenFile{Tokens: []TokenKey{
+		{Identity: "ci", SHA256: hashToken("ci-token")},
+		{Identity: "ops", SHA256: strings.ToUpper(hashToken("ops-token"))},
+	}})
+	require.NoError(t, err)
+
+	incoming := func(auth string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", auth))
+	}
+
+	testcases := []struct {
+		name    string
+		ctx     context.Context
+		wantID  Identity
+		wantErr error
+	}{
+		{name: "valid token", ctx: incoming("Bearer ci-token"), wantID: Identity{Name: "ci", Method: MethodToken}},
+		{name: "case insensitive scheme and hash", ctx: incoming("bearer ops-token"), wantID: Identity{Name: "ops", Method: MethodToken}},
+		{name: "no metadata", ctx: context.Background(), wantErr: ErrNoCredentials},
+		{name: "other scheme", ctx: incoming("Basic Y2k6dG9rZW4="), wantErr: ErrNoCredentials},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := authn.Authenticate(tc.ctx)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, id)
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := authn.Authenticate(incoming("Bearer ci-tokn"))
+		require.EqualError(t, err, "invalid bearer token")
+	})
+}
+
+func TestNewTokenAuthenticator_Validate(t *testing.T) {
+	_, err := NewTokenAuthenticator(TokenFile{Tokens: []TokenKey{{SHA256: hashToken("token")}}})
+	require.EqualError(t, err, "token 0 does not have an identity")
+
+	_, err = NewTokenAuthenticator(TokenFile{Tokens: []TokenKey{{Identity: "ci", SHA256: "token"}}})
+	require.EqualError(t, err, "the sha256 of the token for ci is not a hex encoded SHA-256 hash")
+}
+
+func TestLoadTokenAuthenticator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("tokens:\n  - identity: ci\n    sha256: "+hashToken("ci-token")+"\n"), 0600))
+
+	authn, err := LoadTokenAuthenticator(path)
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer ci-token"))
+	id, err := authn.Authenticate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", id.Name)
+
+	require.NoError(t, os.WriteFile(path, []byte("tokens:\n  - identity: ci\n"), 0600))
+	_, err = LoadTokenAuthenticator(path)
+	require.ErrorContains(t, err, "invalid token file")
+}
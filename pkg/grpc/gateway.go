@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	pGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/porter/v1alpha1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// gatewayBufferSize is the size of the buffer of the in-process connection between
+// the gateway and the gRPC services.
+const gatewayBufferSize = 1024 * 1024
+
+// gateway translates HTTP/JSON requests into calls to the gRPC services.
+//
+// The gateway calls the services over an in-process connection, served by a gRPC
+// server with the same services and interceptors as the gRPC server that listens
+// for clients. REST requests are authenticated, authorized and recorded in the
+// metrics the same way as gRPC requests.
+type gateway struct {
+	http.Handler
+
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+}
+
+// newGateway creates a gateway for the services registered by registerServices.
+// The installation RPCs are served under /v1, and the health of the server under /healthz.
+func newGateway(ctx context.Context, srvOpts []grpc.ServerOption, registerServices func(srv *grpc.Server)) (*gateway, error) {
+	listener := bufconn.Listen(gatewayBufferSize)
+	g := &gateway{grpcServer: grpc.NewServer(srvOpts...)}
+	registerServices(g.grpcServer)
+	go func() { _ = g.grpcServer.Serve(listener) }()
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	conn, err := grpc.NewClient("passthrough:///porter-gateway",
+		grpc.WithContextDialer(dialer),
+		// The connection does not leave the process
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		g.grpcServer.Stop()
+		return nil, fmt.Errorf("could not connect the gateway to the gRPC services: %w", err)
+	}
+	g.conn = conn
+
+	mux := runtime.NewServeMux(runtime.WithHealthzEndpoint(grpc_health_v1.NewHealthClient(conn)))
+	if err := pGRPCv1alpha1.RegisterPorterHandler(ctx, mux, conn); err != nil {
+		g.Close()
+		return nil, fmt.Errorf("could not register the gateway routes: %w", err)
+	}
+	g.Handler = mux
+	return g, nil
+}
+
+// Close stops the in-process gRPC server of the gateway.
+func (g *gateway) Close() {
+	if g.conn != nil {
+		_ = g.conn.Close()
+	}
+	g.grpcServer.Stop()
+}
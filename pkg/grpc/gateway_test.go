@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	iGRPC "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	pGRPC "get.porter.sh/porter/gen/proto/go/porterapis/porter/v1alpha1"
+	"get.porter.sh/porter/pkg/grpc/auth"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testPorterServer records the requests that it receives.
+type testPorterServer struct {
+	pGRPC.UnimplementedPorterServer
+	listRequests chan *iGRPC.ListInstallationsRequest
+}
+
+func (s *testPorterServer) ListInstallations(ctx context.Context, req *iGRPC.ListInstallationsRequest) (*iGRPC.ListInstallationsResponse, error) {
+	s.listRequests <- req
+	return &iGRPC.ListInstallationsResponse{Installation: []*iGRPC.Installation{{Name: req.GetName(), Namespace: req.GetNamespace()}}}, nil
+}
+
+func (s *testPorterServer) Install(req *iGRPC.InstallRequest, stream pGRPC.Porter_InstallServer) error {
+	if err := stream.Send(&iGRPC.ActionResponse{Log: "installing " + req.GetOptions().GetReference()}); err != nil {
+		return err
+	}
+	result := &iGRPC.ActionResult{RunId: "01RUN", Action: "install", Status: "succeeded"}
+	return stream.Send(&iGRPC.ActionResponse{Result: result})
+}
+
+func newTestGateway(t *testing.T) (*testPorterServer, *httptest.Server) {
+	hash := sha256.Sum256([]byte("ci-token"))
+	tokens, err := auth.NewTokenAuthenticator(auth.TokenFile{Tokens: []auth.TokenKey{{Identity: "ci", SHA256: hex.EncodeToString(hash[:])}}})
+	require.NoError(t, err)
+	authz, err := auth.NewAuthorizer(auth.AuthorizationFile{Rules: []auth.Rule{
+		{Identities: []string{"ci"}, Namespaces: []string{"", "dev"}, Permissions: []auth.Permission{auth.PermissionRead, auth.PermissionRun}},
+	}})
+	require.NoError(t, err)
+	interceptor := auth.Interceptor{Authenticator: auth.Authenticators{tokens}, Authorizer: authz}
+	srvOpts := []grpc.ServerOption{
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(interceptor.StreamServerInterceptor)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(interceptor.UnaryServerInterceptor)),
+	}
+
+	psrv := &testPorterServer{listRequests: make(chan *iGRPC.ListInstallationsRequest, 10)}
+	gw, err := newGateway(context.Background(), srvOpts, func(srv *grpc.Server) {
+		pGRPC.RegisterPorterServer(srv, psrv)
+		grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	})
+	require.NoError(t, err)
+	t.Cleanup(gw.Close)
+
+	httpServer := httptest.NewServer(gw)
+	t.Cleanup(httpServer.Close)
+	return psrv, httpServer
+}
+
+func doRequest(t *testing.T, method string, url string, token string, body string) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestGateway_ListInstallations(t *testing.T) {
+	psrv, srv := newTestGateway(t)
+
+	t.Run("namespace", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/v1/namespaces/dev/installations?name=mybuns&limit=5", "ci-token", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		req := <-psrv.listRequests
+		assert.Equal(t, "dev", req.GetNamespace())
+		assert.Equal(t, "mybuns", req.GetName())
+		assert.Equal(t, int64(5), req.GetLimit())
+
+		var body struct {
+			Installation []struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"installation"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Len(t, body.Installation, 1)
+		assert.Equal(t, "dev", body.Installation[0].Namespace)
+	})
+
+	t.Run("global namespace", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/v1/installations", "ci-token", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "", (<-psrv.listRequests).GetNamespace())
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/v1/namespaces/dev/installations", "", "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/v1/namespaces/prod/installations", "ci-token", "")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+	assert.Empty(t, psrv.listRequests, "denied requests should not reach the server")
+}
+
+func TestGateway_InstallStreamsTheResponses(t *testing.T) {
+	_, srv := newTestGateway(t)
+
+	resp := doRequest(t, http.MethodPost, srv.URL+"/v1/namespaces/dev/installations/mybuns/install", "ci-token",
+		`{"options": {"reference": "example.com/mybuns:v0.1.0"}}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Each message on the stream is written as a line of JSON
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"log":"installing example.com/mybuns:v0.1.0"`)
+	assert.Contains(t, lines[1], `"runId":"01RUN"`)
+}
+
+func TestGateway_Healthz(t *testing.T) {
+	_, srv := newTestGateway(t)
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/healthz", "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode, "health checks should not require authentication")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "SERVING")
+}
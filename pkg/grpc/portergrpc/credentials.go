@@ -0,0 +1,142 @@
+package portergrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	credsGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// populateGRPCCredentialSet populates a GRPC CredentialSet (generated from protobuf)
+// from a native porter DisplayCredentialSet
+func populateGRPCCredentialSet(ctx context.Context, cs porter.DisplayCredentialSet, gCS *credsGRPCv1alpha1.CredentialSet) error {
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	bCS, err := json.Marshal(cs)
+	if err != nil {
+		return log.Errorf("porter.DisplayCredentialSet marshal error: %w", err)
+	}
+	pjum := protojson.UnmarshalOptions{}
+	err = pjum.Unmarshal(bCS, gCS)
+	if err != nil {
+		return log.Errorf("credentials GRPC CredentialSet unmarshal error: %w", err)
+	}
+	return nil
+}
+
+// ListCredentialSets takes a GRPC ListCredentialSetsRequest and returns a filtered list of
+// credential sets as a GRPC ListCredentialSetsResponse
+func (s *PorterServer) ListCredentialSets(ctx context.Context, req *credsGRPCv1alpha1.ListCredentialSetsRequest) (*credsGRPCv1alpha1.ListCredentialSetsResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := porter.ListOptions{
+		Name:          req.GetName(),
+		Namespace:     req.GetNamespace(),
+		Labels:        newInstallationOptsFromLabels(req.GetLabels()),
+		AllNamespaces: req.GetAllNamespaces(),
+		Skip:          req.GetSkip(),
+		Limit:         req.GetLimit(),
+	}
+	credSets, err := p.ListCredentials(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	gCredSets := []*credsGRPCv1alpha1.CredentialSet{}
+	for _, cs := range credSets {
+		gCS := &credsGRPCv1alpha1.CredentialSet{}
+		if err := populateGRPCCredentialSet(ctx, cs, gCS); err != nil {
+			return nil, err
+		}
+		gCredSets = append(gCredSets, gCS)
+	}
+	return &credsGRPCv1alpha1.ListCredentialSetsResponse{CredentialSets: gCredSets}, nil
+}
+
+// GetCredentialSet takes a GRPC GetCredentialSetRequest and returns the credential set
+// as a GRPC GetCredentialSetResponse
+func (s *PorterServer) GetCredentialSet(ctx context.Context, req *credsGRPCv1alpha1.GetCredentialSetRequest) (*credsGRPCv1alpha1.GetCredentialSetResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gCS, err := getGRPCCredentialSet(ctx, p, req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &credsGRPCv1alpha1.GetCredentialSetResponse{CredentialSet: gCS}, nil
+}
+
+func getGRPCCredentialSet(ctx context.Context, p *porter.Porter, namespace string, name string) (*credsGRPCv1alpha1.CredentialSet, error) {
+	cs, err := p.Credentials.GetCredentialSet(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	gCS := &credsGRPCv1alpha1.CredentialSet{}
+	if err := populateGRPCCredentialSet(ctx, porter.NewDisplayCredentialSet(cs), gCS); err != nil {
+		return nil, err
+	}
+	return gCS, nil
+}
+
+// ApplyCredentialSet takes a GRPC ApplyCredentialSetRequest and creates or updates the
+// credential set, returning the saved credential set as a GRPC ApplyCredentialSetResponse
+func (s *PorterServer) ApplyCredentialSet(ctx context.Context, req *credsGRPCv1alpha1.ApplyCredentialSetRequest) (*credsGRPCv1alpha1.ApplyCredentialSetResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gCS := req.GetCredentialSet()
+	if gCS.GetSchemaVersion() == "" {
+		gCS.SchemaVersion = string(storage.DefaultCredentialSetSchemaVersion)
+	}
+	bCS, err := protojson.Marshal(gCS)
+	if err != nil {
+		return nil, log.Errorf("credentials GRPC CredentialSet marshal error: %w", err)
+	}
+	file, cleanup, err := writeTempDocument(p, "porter-credentials-*.json", bCS)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if err = p.CredentialsApply(ctx, porter.ApplyOptions{File: file}); err != nil {
+		return nil, err
+	}
+	applied, err := getGRPCCredentialSet(ctx, p, gCS.GetNamespace(), gCS.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &credsGRPCv1alpha1.ApplyCredentialSetResponse{CredentialSet: applied}, nil
+}
+
+// DeleteCredentialSet takes a GRPC DeleteCredentialSetRequest and deletes the credential set
+func (s *PorterServer) DeleteCredentialSet(ctx context.Context, req *credsGRPCv1alpha1.DeleteCredentialSetRequest) (*credsGRPCv1alpha1.DeleteCredentialSetResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := porter.CredentialDeleteOptions{
+		Name:      req.GetName(),
+		Namespace: req.GetNamespace(),
+		Force:     req.GetForce(),
+	}
+	if err := p.DeleteCredential(ctx, opts); err != nil {
+		return nil, err
+	}
+	return &credsGRPCv1alpha1.DeleteCredentialSetResponse{}, nil
+}
@@ -0,0 +1,97 @@
+package portergrpc
+
+import (
+	"context"
+	"testing"
+
+	credsGRPC "get.porter.sh/porter/gen/proto/go/porterapis/credentials/v1alpha1"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestPorterWithCredentialSets(t *testing.T) (*porter.TestPorter, context.Context) {
+	p := porter.NewTestPorter(t)
+	ctx := context.Background()
+	for _, cs := range []storage.CredentialSet{
+		storage.NewCredentialSet("dev", "azure", secrets.SourceMap{Name: "token", Source: secrets.Source{Strategy: "env", Hint: "AZURE_TOKEN"}}),
+		storage.NewCredentialSet("prod", "azure"),
+		storage.NewCredentialSet("dev", "aws"),
+	} {
+		require.NoError(t, p.TestCredentials.InsertCredentialSet(ctx, cs))
+	}
+	return p, AddPorterConnectionToContext(p.Porter, ctx)
+}
+
+func TestListCredentialSetsReturnsTheFilteredCredentialSets(t *testing.T) {
+	p, ctx := setupTestPorterWithCredentialSets(t)
+	defer p.Close()
+
+	credSvc := PorterServer{}
+	resp, err := credSvc.ListCredentialSets(ctx, &credsGRPC.ListCredentialSetsRequest{Namespace: "dev"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetCredentialSets(), 2)
+	for _, cs := range resp.GetCredentialSets() {
+		assert.Equal(t, "dev", cs.GetNamespace())
+	}
+
+	resp, err = credSvc.ListCredentialSets(ctx, &credsGRPC.ListCredentialSetsRequest{Name: "azure", AllNamespaces: true})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetCredentialSets(), 2)
+}
+
+func TestGetCredentialSetReturnsTheCredentialSet(t *testing.T) {
+	p, ctx := setupTestPorterWithCredentialSets(t)
+	defer p.Close()
+
+	credSvc := PorterServer{}
+	resp, err := credSvc.GetCredentialSet(ctx, &credsGRPC.GetCredentialSetRequest{Name: "azure", Namespace: "dev"})
+	require.NoError(t, err)
+	cs := resp.GetCredentialSet()
+	assert.Equal(t, "azure", cs.GetName())
+	require.Len(t, cs.GetCredentials(), 1)
+	assert.Equal(t, "token", cs.GetCredentials()[0].GetName())
+	assert.Equal(t, map[string]string{"env": "AZURE_TOKEN"}, cs.GetCredentials()[0].GetSource())
+
+	_, err = credSvc.GetCredentialSet(ctx, &credsGRPC.GetCredentialSetRequest{Name: "missing", Namespace: "dev"})
+	require.ErrorIs(t, err, storage.ErrNotFound{})
+}
+
+func TestApplyCredentialSetSavesTheCredentialSet(t *testing.T) {
+	p, ctx := setupTestPorterWithCredentialSets(t)
+	defer p.Close()
+
+	credSvc := PorterServer{}
+	req := &credsGRPC.ApplyCredentialSetRequest{
+		CredentialSet: &credsGRPC.CredentialSet{
+			Name:      "gcp",
+			Namespace: "dev",
+			Credentials: []*credsGRPC.Strategy{
+				{Name: "key", Source: map[string]string{"secret": "gcp-key"}},
+			},
+		},
+	}
+	resp, err := credSvc.ApplyCredentialSet(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "gcp", resp.GetCredentialSet().GetName())
+	assert.Equal(t, string(storage.DefaultCredentialSetSchemaVersion), resp.GetCredentialSet().GetSchemaVersion())
+
+	cs, err := p.Credentials.GetCredentialSet(ctx, "dev", "gcp")
+	require.NoError(t, err)
+	require.Len(t, cs.Credentials, 1)
+	assert.Equal(t, secrets.Source{Strategy: "secret", Hint: "gcp-key"}, cs.Credentials[0].Source)
+}
+
+func TestDeleteCredentialSetRemovesTheCredentialSet(t *testing.T) {
+	p, ctx := setupTestPorterWithCredentialSets(t)
+	defer p.Close()
+
+	credSvc := PorterServer{}
+	_, err := credSvc.DeleteCredentialSet(ctx, &credsGRPC.DeleteCredentialSetRequest{Name: "aws", Namespace: "dev"})
+	require.NoError(t, err)
+
+	_, err = p.Credentials.GetCredentialSet(ctx, "dev", "aws")
+	require.ErrorIs(t, err, storage.ErrNotFound{})
+}
@@ -123,3 +123,28 @@ func (s *PorterServer) ListInstallationLatestOutputs(ctx context.Context, req *i
 	}
 	return res, nil
 }
+
+// GetInstallation takes a GRPC GetInstallationRequest and returns the porter installation
+// as a GRPC GetInstallationResponse
+func (s *PorterServer) GetInstallation(ctx context.Context, req *iGRPCv1alpha1.GetInstallationRequest) (*iGRPCv1alpha1.GetInstallationResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := porter.ShowOptions{}
+	opts.Name = req.GetName()
+	opts.Namespace = req.GetNamespace()
+	installation, _, err := p.GetInstallation(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	gInst := &iGRPCv1alpha1.Installation{}
+	err = populateGRPCInstallation(ctx, porter.NewDisplayInstallation(installation), gInst)
+	if err != nil {
+		return nil, err
+	}
+	return &iGRPCv1alpha1.GetInstallationResponse{Installation: gInst}, nil
+}
@@ -137,3 +137,14 @@ func verifyInstallations(t *testing.T, installations []*iGRPC.Installation, allI
 		assert.JSONEq(t, string(grpcExpInst), string(bActInst))
 	}
 }
+
+func TestGetInstallationReturnsThePorterInstallation(t *testing.T) {
+	ctx, insts := setupTestPorterWithInstallations(t, []instInfo{{namespace: "test", name: "foo"}})
+	instSvc := PorterServer{}
+	resp, err := instSvc.GetInstallation(ctx, &iGRPC.GetInstallationRequest{Name: "foo", Namespace: "test"})
+	assert.NoError(t, err)
+	verifyInstallations(t, []*iGRPC.Installation{resp.GetInstallation()}, insts)
+
+	_, err = instSvc.GetInstallation(ctx, &iGRPC.GetInstallationRequest{Name: "missing", Namespace: "test"})
+	assert.ErrorIs(t, err, storage.ErrNotFound{})
+}
@@ -0,0 +1,223 @@
+package portergrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	iGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	pGRPC "get.porter.sh/porter/gen/proto/go/porterapis/porter/v1alpha1"
+	"get.porter.sh/porter/pkg/encoding"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// actionStream is implemented by the server streams of the RPCs that run a bundle.
+type actionStream interface {
+	Send(*iGRPCv1alpha1.ActionResponse) error
+	Context() context.Context
+}
+
+// actionLogWriter sends the output of a bundle action to the client as it is written.
+type actionLogWriter struct {
+	// Sending on a stream is not safe from multiple goroutines
+	mu     sync.Mutex
+	stream actionStream
+}
+
+func (w *actionLogWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.stream.Send(&iGRPCv1alpha1.ActionResponse{Log: string(b)}); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// newParamsFromMap converts GRPC parameter values into NAME=VALUE parameters.
+func newParamsFromMap(params map[string]string) []string {
+	retParams := make([]string, 0, len(params))
+	for k, v := range params {
+		retParams = append(retParams, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(retParams)
+	return retParams
+}
+
+// newBundleExecutionOptions creates the options for running a bundle from a GRPC BundleActionOptions
+func newBundleExecutionOptions(name string, namespace string, gOpts *iGRPCv1alpha1.BundleActionOptions) *porter.BundleExecutionOptions {
+	opts := porter.NewBundleExecutionOptions()
+	opts.Name = name
+	opts.Namespace = namespace
+	opts.Reference = gOpts.GetReference()
+	opts.InsecureRegistry = gOpts.GetInsecureRegistry()
+	opts.Params = newParamsFromMap(gOpts.GetParameters())
+	opts.ParameterSets = gOpts.GetParameterSets()
+	opts.CredentialIdentifiers = gOpts.GetCredentialSets()
+	opts.Driver = gOpts.GetDriver()
+	opts.AllowDockerHostAccess = gOpts.GetAllowDockerHostAccess()
+	opts.ForceRun = gOpts.GetForceRun()
+	return opts
+}
+
+// runAction runs a bundle action on an installation, streaming the output of the action
+// to the client. When the action creates a run, the result of the run is sent once the
+// action completes.
+func runAction(stream actionStream, namespace string, name string, action func(ctx context.Context, p *porter.Porter) error) error {
+	ctx, log := tracing.StartSpan(stream.Context(),
+		attribute.String("installation", name), attribute.String("namespace", namespace))
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return log.Error(errors.New("the installation name is required"))
+	}
+
+	// The connection is only used by this stream, so its output can be sent to the client
+	out := &actionLogWriter{stream: stream}
+	p.Out = out
+	p.Err = out
+
+	var lastRunID string
+	if inst, err := p.Installations.GetInstallation(ctx, namespace, name); err == nil {
+		lastRunID = inst.Status.RunID
+	}
+
+	actionErr := action(ctx, p)
+
+	// The installation is not found when it was deleted by the action
+	inst, err := p.Installations.GetInstallation(ctx, namespace, name)
+	if err == nil && inst.Status.RunID != "" && inst.Status.RunID != lastRunID {
+		result := &iGRPCv1alpha1.ActionResult{
+			RunId:  inst.Status.RunID,
+			Action: inst.Status.Action,
+			Status: inst.Status.ResultStatus,
+		}
+		if err := stream.Send(&iGRPCv1alpha1.ActionResponse{Result: result}); err != nil {
+			return log.Errorf("could not send the result of the action: %w", err)
+		}
+	}
+
+	if actionErr != nil {
+		return log.Error(actionErr)
+	}
+	return nil
+}
+
+// Install takes a GRPC InstallRequest and installs the bundle, streaming the output
+// of the install action as a series of GRPC ActionResponses
+func (s *PorterServer) Install(req *iGRPCv1alpha1.InstallRequest, stream pGRPC.Porter_InstallServer) error {
+	return runAction(stream, req.GetNamespace(), req.GetName(), func(ctx context.Context, p *porter.Porter) error {
+		opts := porter.InstallOptions{
+			BundleExecutionOptions: newBundleExecutionOptions(req.GetName(), req.GetNamespace(), req.GetOptions()),
+			Labels:                 newInstallationOptsFromLabels(req.GetLabels()),
+		}
+		if err := opts.Validate(ctx, nil, p); err != nil {
+			return err
+		}
+		return p.InstallBundle(ctx, opts)
+	})
+}
+
+// Upgrade takes a GRPC UpgradeRequest and upgrades the installation, streaming the output
+// of the upgrade action as a series of GRPC ActionResponses
+func (s *PorterServer) Upgrade(req *iGRPCv1alpha1.UpgradeRequest, stream pGRPC.Porter_UpgradeServer) error {
+	return runAction(stream, req.GetNamespace(), req.GetName(), func(ctx context.Context, p *porter.Porter) error {
+		opts := &porter.UpgradeOptions{
+			BundleExecutionOptions: newBundleExecutionOptions(req.GetName(), req.GetNamespace(), req.GetOptions()),
+			Version:                req.GetVersion(),
+			ForceUpgrade:           req.GetForceUpgrade(),
+		}
+		if err := opts.Validate(ctx, nil, p); err != nil {
+			return err
+		}
+		return p.UpgradeBundle(ctx, opts)
+	})
+}
+
+// Invoke takes a GRPC InvokeRequest and runs the custom action on the installation, streaming
+// the output of the action as a series of GRPC ActionResponses
+func (s *PorterServer) Invoke(req *iGRPCv1alpha1.InvokeRequest, stream pGRPC.Porter_InvokeServer) error {
+	return runAction(stream, req.GetNamespace(), req.GetName(), func(ctx context.Context, p *porter.Porter) error {
+		opts := porter.InvokeOptions{
+			BundleExecutionOptions: newBundleExecutionOptions(req.GetName(), req.GetNamespace(), req.GetOptions()),
+			Action:                 req.GetAction(),
+		}
+		if err := opts.Validate(ctx, nil, p); err != nil {
+			return err
+		}
+		return p.InvokeBundle(ctx, opts)
+	})
+}
+
+// Uninstall takes a GRPC UninstallRequest and uninstalls the installation, streaming the output
+// of the uninstall action as a series of GRPC ActionResponses
+func (s *PorterServer) Uninstall(req *iGRPCv1alpha1.UninstallRequest, stream pGRPC.Porter_UninstallServer) error {
+	return runAction(stream, req.GetNamespace(), req.GetName(), func(ctx context.Context, p *porter.Porter) error {
+		opts := porter.UninstallOptions{
+			BundleExecutionOptions: newBundleExecutionOptions(req.GetName(), req.GetNamespace(), req.GetOptions()),
+			UninstallDeleteOptions: porter.UninstallDeleteOptions{
+				Delete:      req.GetDelete(),
+				ForceDelete: req.GetForceDelete(),
+			},
+		}
+		if err := opts.Validate(ctx, nil, p); err != nil {
+			return err
+		}
+		return p.UninstallBundle(ctx, opts)
+	})
+}
+
+// ApplyInstallation takes a GRPC ApplyInstallationRequest with an installation document, and reconciles
+// the installation with the document, streaming the output of the action as a series of GRPC ActionResponses
+func (s *PorterServer) ApplyInstallation(req *iGRPCv1alpha1.ApplyInstallationRequest, stream pGRPC.Porter_ApplyInstallationServer) error {
+	var doc porter.DisplayInstallation
+	if err := encoding.UnmarshalYaml([]byte(req.GetDocument()), &doc); err != nil {
+		return fmt.Errorf("unable to parse the installation document: %w", err)
+	}
+	namespace := doc.Namespace
+	if namespace == "" {
+		namespace = req.GetNamespace()
+	}
+
+	return runAction(stream, namespace, doc.Name, func(ctx context.Context, p *porter.Porter) error {
+		file, cleanup, err := writeTempDocument(p, "porter-installation-*.yaml", []byte(req.GetDocument()))
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		opts := porter.ApplyOptions{
+			Namespace: req.GetNamespace(),
+			Force:     req.GetForce(),
+		}
+		if err := opts.Validate(p.Context, []string{file}); err != nil {
+			return err
+		}
+		return p.InstallationApply(ctx, opts)
+	})
+}
+
+// writeTempDocument writes a document received from the client to a temporary file, so that
+// it can be passed to commands that read documents from a file. Call the returned function to
+// remove the file.
+func writeTempDocument(p *porter.Porter, pattern string, data []byte) (string, func(), error) {
+	f, err := p.FileSystem.TempFile("", pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not create a temporary file for the document: %w", err)
+	}
+	defer f.Close()
+
+	cleanup := func() { _ = p.FileSystem.Remove(f.Name()) }
+	if _, err := f.Write(data); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("could not write the document to %s: %w", f.Name(), err)
+	}
+	return f.Name(), cleanup, nil
+}
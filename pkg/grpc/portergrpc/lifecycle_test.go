@@ -0,0 +1,167 @@
+package portergrpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	iGRPC "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	"get.porter.sh/porter/pkg/cnab"
+	cnabtooci "get.porter.sh/porter/pkg/cnab/cnab-to-oci"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/portercontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// testActionStream captures the messages sent by an RPC that runs a bundle.
+type testActionStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*iGRPC.ActionResponse
+}
+
+func (s *testActionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testActionStream) Send(resp *iGRPC.ActionResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+// logs returns the output of the action that was streamed to the client.
+func (s *testActionStream) logs() string {
+	var b strings.Builder
+	for _, resp := range s.responses {
+		b.WriteString(resp.GetLog())
+	}
+	return b.String()
+}
+
+// result returns the result of the action, which is always the last message.
+func (s *testActionStream) result() *iGRPC.ActionResult {
+	if len(s.responses) == 0 {
+		return nil
+	}
+	return s.responses[len(s.responses)-1].GetResult()
+}
+
+func setupTestPorterWithBundle(t *testing.T) (*porter.TestPorter, context.Context) {
+	p := porter.NewTestPorter(t)
+	bun, err := cnab.LoadBundle(portercontext.New(), "../../cnab/provider/testdata/bundle.json")
+	require.NoError(t, err)
+	p.TestRegistry.MockPullBundle = func(ctx context.Context, ref cnab.OCIReference, opts cnabtooci.RegistryOptions) (cnab.BundleReference, error) {
+		return cnab.BundleReference{Reference: ref, Definition: bun}, nil
+	}
+	// Run the bundles with the debug driver, which prints the operation instead of running it
+	p.Data.RuntimeDriver = "debug"
+	return p, AddPorterConnectionToContext(p.Porter, context.Background())
+}
+
+func TestInstallStreamsTheOutputAndResultOfTheAction(t *testing.T) {
+	p, ctx := setupTestPorterWithBundle(t)
+	defer p.Close()
+
+	req := &iGRPC.InstallRequest{
+		Name:      "mybuns",
+		Namespace: "dev",
+		Options: &iGRPC.BundleActionOptions{
+			Reference: "example.com/mybuns:v0.1.0",
+		},
+		Labels: map[string]string{"team": "platform"},
+	}
+	stream := &testActionStream{ctx: ctx}
+	instSvc := PorterServer{}
+	require.NoError(t, instSvc.Install(req, stream))
+
+	assert.Contains(t, stream.logs(), `"action": "install"`, "the output of the debug driver should be streamed")
+
+	inst, err := p.Installations.GetInstallation(ctx, "dev", "mybuns")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform"}, inst.Labels)
+
+	result := stream.result()
+	require.NotNil(t, result, "the result should be sent after the action completes")
+	assert.Equal(t, inst.Status.RunID, result.GetRunId())
+	assert.Equal(t, cnab.ActionInstall, result.GetAction())
+	assert.Equal(t, cnab.StatusSucceeded, result.GetStatus())
+
+	t.Run("invoke", func(t *testing.T) {
+		req := &iGRPC.InvokeRequest{
+			Name:      "mybuns",
+			Namespace: "dev",
+			Action:    "zombies",
+		}
+		stream := &testActionStream{ctx: ctx}
+		require.NoError(t, instSvc.Invoke(req, stream))
+		require.NotNil(t, stream.result())
+		assert.Equal(t, "zombies", stream.result().GetAction())
+		assert.NotEqual(t, result.GetRunId(), stream.result().GetRunId(), "the result should be for the new run")
+	})
+
+	t.Run("uninstall and delete", func(t *testing.T) {
+		req := &iGRPC.UninstallRequest{
+			Name:      "mybuns",
+			Namespace: "dev",
+			Delete:    true,
+		}
+		stream := &testActionStream{ctx: ctx}
+		require.NoError(t, instSvc.Uninstall(req, stream))
+		assert.Contains(t, stream.logs(), `"action": "uninstall"`)
+		assert.Nil(t, stream.result(), "no result is sent when the installation is deleted")
+	})
+}
+
+func TestInstallReturnsErrorWhenTheActionFails(t *testing.T) {
+	p, ctx := setupTestPorterWithBundle(t)
+	defer p.Close()
+
+	req := &iGRPC.InstallRequest{
+		Name:    "mybuns",
+		Options: &iGRPC.BundleActionOptions{Reference: "example.com/mybuns:v0.1.0", Driver: "missing-driver"},
+	}
+	stream := &testActionStream{ctx: ctx}
+	instSvc := PorterServer{}
+	err := instSvc.Install(req, stream)
+	require.Error(t, err)
+	assert.Nil(t, stream.result(), "no result is sent when the action did not run the bundle")
+}
+
+func TestActionsRequireAnInstallationName(t *testing.T) {
+	p, ctx := setupTestPorterWithBundle(t)
+	defer p.Close()
+
+	instSvc := PorterServer{}
+	err := instSvc.Upgrade(&iGRPC.UpgradeRequest{}, &testActionStream{ctx: ctx})
+	require.EqualError(t, err, "the installation name is required")
+}
+
+func TestApplyInstallationReconcilesTheInstallationDocument(t *testing.T) {
+	p, ctx := setupTestPorterWithBundle(t)
+	defer p.Close()
+
+	const installationFile = `schemaType: Installation
+schemaVersion: 1.0.2
+name: mybuns
+bundle:
+  repository: example.com/mybuns
+  version: 0.1.0
+`
+	req := &iGRPC.ApplyInstallationRequest{Document: installationFile, Namespace: "dev"}
+	stream := &testActionStream{ctx: ctx}
+	instSvc := PorterServer{}
+	require.NoError(t, instSvc.ApplyInstallation(req, stream))
+
+	inst, err := p.Installations.GetInstallation(ctx, "dev", "mybuns")
+	require.NoError(t, err)
+	require.NotNil(t, stream.result())
+	assert.Equal(t, inst.Status.RunID, stream.result().GetRunId())
+	assert.Equal(t, cnab.ActionInstall, stream.result().GetAction())
+}
+
+func TestNewParamsFromMapIsSorted(t *testing.T) {
+	params := newParamsFromMap(map[string]string{"b": "2", "a": "1=1"})
+	assert.Equal(t, []string{"a=1=1", "b=2"}, params)
+}
@@ -0,0 +1,142 @@
+package portergrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	paramsGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// populateGRPCParameterSet populates a GRPC ParameterSet (generated from protobuf)
+// from a native porter DisplayParameterSet
+func populateGRPCParameterSet(ctx context.Context, ps porter.DisplayParameterSet, gPS *paramsGRPCv1alpha1.ParameterSet) error {
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	bPS, err := json.Marshal(ps)
+	if err != nil {
+		return log.Errorf("porter.DisplayParameterSet marshal error: %w", err)
+	}
+	pjum := protojson.UnmarshalOptions{}
+	err = pjum.Unmarshal(bPS, gPS)
+	if err != nil {
+		return log.Errorf("parameters GRPC ParameterSet unmarshal error: %w", err)
+	}
+	return nil
+}
+
+// ListParameterSets takes a GRPC ListParameterSetsRequest and returns a filtered list of
+// parameter sets as a GRPC ListParameterSetsResponse
+func (s *PorterServer) ListParameterSets(ctx context.Context, req *paramsGRPCv1alpha1.ListParameterSetsRequest) (*paramsGRPCv1alpha1.ListParameterSetsResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := porter.ListOptions{
+		Name:          req.GetName(),
+		Namespace:     req.GetNamespace(),
+		Labels:        newInstallationOptsFromLabels(req.GetLabels()),
+		AllNamespaces: req.GetAllNamespaces(),
+		Skip:          req.GetSkip(),
+		Limit:         req.GetLimit(),
+	}
+	paramSets, err := p.ListParameters(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	gParamSets := []*paramsGRPCv1alpha1.ParameterSet{}
+	for _, ps := range paramSets {
+		gPS := &paramsGRPCv1alpha1.ParameterSet{}
+		if err := populateGRPCParameterSet(ctx, ps, gPS); err != nil {
+			return nil, err
+		}
+		gParamSets = append(gParamSets, gPS)
+	}
+	return &paramsGRPCv1alpha1.ListParameterSetsResponse{ParameterSets: gParamSets}, nil
+}
+
+// GetParameterSet takes a GRPC GetParameterSetRequest and returns the parameter set
+// as a GRPC GetParameterSetResponse
+func (s *PorterServer) GetParameterSet(ctx context.Context, req *paramsGRPCv1alpha1.GetParameterSetRequest) (*paramsGRPCv1alpha1.GetParameterSetResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gPS, err := getGRPCParameterSet(ctx, p, req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &paramsGRPCv1alpha1.GetParameterSetResponse{ParameterSet: gPS}, nil
+}
+
+func getGRPCParameterSet(ctx context.Context, p *porter.Porter, namespace string, name string) (*paramsGRPCv1alpha1.ParameterSet, error) {
+	ps, err := p.Parameters.GetParameterSet(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	gPS := &paramsGRPCv1alpha1.ParameterSet{}
+	if err := populateGRPCParameterSet(ctx, porter.NewDisplayParameterSet(ps), gPS); err != nil {
+		return nil, err
+	}
+	return gPS, nil
+}
+
+// ApplyParameterSet takes a GRPC ApplyParameterSetRequest and creates or updates the
+// parameter set, returning the saved parameter set as a GRPC ApplyParameterSetResponse
+func (s *PorterServer) ApplyParameterSet(ctx context.Context, req *paramsGRPCv1alpha1.ApplyParameterSetRequest) (*paramsGRPCv1alpha1.ApplyParameterSetResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gPS := req.GetParameterSet()
+	if gPS.GetSchemaVersion() == "" {
+		gPS.SchemaVersion = string(storage.DefaultParameterSetSchemaVersion)
+	}
+	bPS, err := protojson.Marshal(gPS)
+	if err != nil {
+		return nil, log.Errorf("parameters GRPC ParameterSet marshal error: %w", err)
+	}
+	file, cleanup, err := writeTempDocument(p, "porter-parameters-*.json", bPS)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if err = p.ParametersApply(ctx, porter.ApplyOptions{File: file}); err != nil {
+		return nil, err
+	}
+	applied, err := getGRPCParameterSet(ctx, p, gPS.GetNamespace(), gPS.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &paramsGRPCv1alpha1.ApplyParameterSetResponse{ParameterSet: applied}, nil
+}
+
+// DeleteParameterSet takes a GRPC DeleteParameterSetRequest and deletes the parameter set
+func (s *PorterServer) DeleteParameterSet(ctx context.Context, req *paramsGRPCv1alpha1.DeleteParameterSetRequest) (*paramsGRPCv1alpha1.DeleteParameterSetResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := porter.ParameterDeleteOptions{
+		Name:      req.GetName(),
+		Namespace: req.GetNamespace(),
+		Force:     req.GetForce(),
+	}
+	if err := p.DeleteParameter(ctx, opts); err != nil {
+		return nil, err
+	}
+	return &paramsGRPCv1alpha1.DeleteParameterSetResponse{}, nil
+}
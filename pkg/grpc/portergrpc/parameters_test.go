@@ -0,0 +1,97 @@
+package portergrpc
+
+import (
+	"context"
+	"testing"
+
+	paramsGRPC "get.porter.sh/porter/gen/proto/go/porterapis/parameters/v1alpha1"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestPorterWithParameterSets(t *testing.T) (*porter.TestPorter, context.Context) {
+	p := porter.NewTestPorter(t)
+	ctx := context.Background()
+	for _, ps := range []storage.ParameterSet{
+		storage.NewParameterSet("dev", "mysql", secrets.SourceMap{Name: "password", Source: secrets.Source{Strategy: "env", Hint: "MYSQL_PASSWORD"}}),
+		storage.NewParameterSet("prod", "mysql"),
+		storage.NewParameterSet("dev", "redis"),
+	} {
+		require.NoError(t, p.TestParameters.InsertParameterSet(ctx, ps))
+	}
+	return p, AddPorterConnectionToContext(p.Porter, ctx)
+}
+
+func TestListParameterSetsReturnsTheFilteredParameterSets(t *testing.T) {
+	p, ctx := setupTestPorterWithParameterSets(t)
+	defer p.Close()
+
+	paramSvc := PorterServer{}
+	resp, err := paramSvc.ListParameterSets(ctx, &paramsGRPC.ListParameterSetsRequest{Namespace: "dev"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetParameterSets(), 2)
+	for _, ps := range resp.GetParameterSets() {
+		assert.Equal(t, "dev", ps.GetNamespace())
+	}
+
+	resp, err = paramSvc.ListParameterSets(ctx, &paramsGRPC.ListParameterSetsRequest{Name: "mysql", AllNamespaces: true})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetParameterSets(), 2)
+}
+
+func TestGetParameterSetReturnsTheParameterSet(t *testing.T) {
+	p, ctx := setupTestPorterWithParameterSets(t)
+	defer p.Close()
+
+	paramSvc := PorterServer{}
+	resp, err := paramSvc.GetParameterSet(ctx, &paramsGRPC.GetParameterSetRequest{Name: "mysql", Namespace: "dev"})
+	require.NoError(t, err)
+	ps := resp.GetParameterSet()
+	assert.Equal(t, "mysql", ps.GetName())
+	require.Len(t, ps.GetParameters(), 1)
+	assert.Equal(t, "password", ps.GetParameters()[0].GetName())
+	assert.Equal(t, map[string]string{"env": "MYSQL_PASSWORD"}, ps.GetParameters()[0].GetSource())
+
+	_, err = paramSvc.GetParameterSet(ctx, &paramsGRPC.GetParameterSetRequest{Name: "missing", Namespace: "dev"})
+	require.ErrorIs(t, err, storage.ErrNotFound{})
+}
+
+func TestApplyParameterSetSavesTheParameterSet(t *testing.T) {
+	p, ctx := setupTestPorterWithParameterSets(t)
+	defer p.Close()
+
+	paramSvc := PorterServer{}
+	req := &paramsGRPC.ApplyParameterSetRequest{
+		ParameterSet: &paramsGRPC.ParameterSet{
+			Name:      "postgres",
+			Namespace: "dev",
+			Parameters: []*paramsGRPC.Strategy{
+				{Name: "password", Source: map[string]string{"secret": "postgres-password"}},
+			},
+		},
+	}
+	resp, err := paramSvc.ApplyParameterSet(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", resp.GetParameterSet().GetName())
+	assert.Equal(t, string(storage.DefaultParameterSetSchemaVersion), resp.GetParameterSet().GetSchemaVersion())
+
+	ps, err := p.Parameters.GetParameterSet(ctx, "dev", "postgres")
+	require.NoError(t, err)
+	require.Len(t, ps.Parameters, 1)
+	assert.Equal(t, secrets.Source{Strategy: "secret", Hint: "postgres-password"}, ps.Parameters[0].Source)
+}
+
+func TestDeleteParameterSetRemovesTheParameterSet(t *testing.T) {
+	p, ctx := setupTestPorterWithParameterSets(t)
+	defer p.Close()
+
+	paramSvc := PorterServer{}
+	_, err := paramSvc.DeleteParameterSet(ctx, &paramsGRPC.DeleteParameterSetRequest{Name: "redis", Namespace: "dev"})
+	require.NoError(t, err)
+
+	_, err = p.Parameters.GetParameterSet(ctx, "dev", "redis")
+	require.ErrorIs(t, err, storage.ErrNotFound{})
+}
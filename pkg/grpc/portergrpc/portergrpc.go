@@ -12,6 +12,7 @@ import (
 	signingplugin "get.porter.sh/porter/pkg/signing/pluginstore"
 	"get.porter.sh/porter/pkg/storage"
 	storageplugin "get.porter.sh/porter/pkg/storage/pluginstore"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 )
 
@@ -42,3 +43,31 @@ func (s *PorterServer) NewConnectionInterceptor(ctx context.Context, req interfa
 	ctx = AddPorterConnectionToContext(p, ctx)
 	return handler(ctx, req)
 }
+
+// NewStreamConnectionInterceptor creates a middleware interceptor for the GRPC server that manages creating a porter connection for each streaming RPC.
+// Each connection uses its own copy of the porter context, so that the output of a streaming RPC can be sent to the client without
+// affecting other RPCs. If the connection is unable to be created then the RPC fails, otherwise the connection is added to the
+// stream context and the next handler in the chain is called
+func (s *PorterServer) NewStreamConnectionInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	cfg := s.copyConfig()
+	storage := storage.NewPluginAdapter(storageplugin.NewStore(cfg))
+	secretStorage := secrets.NewPluginAdapter(secretsplugin.NewStore(cfg))
+	signer := signing.NewPluginAdapter(signingplugin.NewSigner(cfg))
+	p := porter.NewFor(cfg, storage, secretStorage, signer)
+	if _, err := p.Connect(ss.Context()); err != nil {
+		return err
+	}
+	defer p.Close()
+
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = AddPorterConnectionToContext(p, ss.Context())
+	return handler(srv, wrapped)
+}
+
+// copyConfig returns a copy of the server's porter configuration with its own porter context.
+func (s *PorterServer) copyConfig() *config.Config {
+	cfg := *s.PorterConfig
+	pCtx := *s.PorterConfig.Context
+	cfg.Context = &pCtx
+	return &cfg
+}
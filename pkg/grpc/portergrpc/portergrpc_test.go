@@ -27,3 +27,21 @@ func TestNewConnectionInterceptorCallsNextHandlerInTheChainWithThePorterConnecti
 	assert.NotNil(t, newP)
 	assert.IsType(t, &porter.Porter{}, newP)
 }
+
+func TestNewStreamConnectionInterceptorCallsNextHandlerWithAConnectionThatHasItsOwnOutput(t *testing.T) {
+	cfg := config.NewTestConfig(t)
+	srv := PorterServer{PorterConfig: cfg.Config}
+	parentStreamInfo := &grpc.StreamServerInfo{FullMethod: "SomeService.StreamMethod", IsServerStream: true}
+	var newP *porter.Porter
+	testHandler := func(srv interface{}, stream grpc.ServerStream) error {
+		var err error
+		newP, err = GetPorterConnectionFromContext(stream.Context())
+		return err
+	}
+	stream := &testActionStream{ctx: context.Background()}
+	chain := grpc_middleware.ChainStreamServer(srv.NewStreamConnectionInterceptor)
+	err := chain(nil, stream, parentStreamInfo, testHandler)
+	assert.Nil(t, err)
+	assert.NotNil(t, newP)
+	assert.NotSame(t, cfg.Context, newP.Context, "each stream should have its own porter context so that its output can be redirected")
+}
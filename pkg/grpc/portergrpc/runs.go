@@ -0,0 +1,84 @@
+package portergrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	iGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/tracing"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// populateGRPCRun populates a GRPC Run (generated from protobuf)
+// from a native porter DisplayRun
+func populateGRPCRun(ctx context.Context, run porter.DisplayRun, gRun *iGRPCv1alpha1.Run) error {
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	bRun, err := json.Marshal(run)
+	if err != nil {
+		return log.Errorf("porter.DisplayRun marshal error: %w", err)
+	}
+	pjum := protojson.UnmarshalOptions{}
+	err = pjum.Unmarshal(bRun, gRun)
+	if err != nil {
+		return log.Errorf("installation GRPC Run unmarshal error: %w", err)
+	}
+	return nil
+}
+
+// ListRuns takes a GRPC ListRunsRequest and returns the runs of the porter installation,
+// oldest first, as a GRPC ListRunsResponse
+func (s *PorterServer) ListRuns(ctx context.Context, req *iGRPCv1alpha1.ListRunsRequest) (*iGRPCv1alpha1.ListRunsResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := porter.RunListOptions{}
+	opts.Name = req.GetName()
+	opts.Namespace = req.GetNamespace()
+	runs, err := p.ListInstallationRuns(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	gRuns := []*iGRPCv1alpha1.Run{}
+	for _, run := range runs {
+		gRun := &iGRPCv1alpha1.Run{}
+		if err := populateGRPCRun(ctx, run, gRun); err != nil {
+			return nil, err
+		}
+		gRuns = append(gRuns, gRun)
+	}
+	return &iGRPCv1alpha1.ListRunsResponse{Runs: gRuns}, nil
+}
+
+// GetLogs takes a GRPC GetLogsRequest and returns the logs of an installation run as a
+// GRPC GetLogsResponse. When no run is specified, the logs of the installation's last run
+// are returned.
+func (s *PorterServer) GetLogs(ctx context.Context, req *iGRPCv1alpha1.GetLogsRequest) (*iGRPCv1alpha1.GetLogsResponse, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+	p, err := GetPorterConnectionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &porter.LogsShowOptions{RunID: req.GetRunId()}
+	opts.Name = req.GetName()
+	opts.Namespace = req.GetNamespace()
+	if opts.Name == "" && opts.RunID == "" {
+		return nil, errors.New("either the installation name or the run id is required")
+	}
+	logs, ok, err := p.GetInstallationLogs(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("no logs found")
+	}
+	return &iGRPCv1alpha1.GetLogsResponse{Logs: logs}, nil
+}
@@ -0,0 +1,67 @@
+package portergrpc
+
+import (
+	"context"
+	"testing"
+
+	iGRPC "get.porter.sh/porter/gen/proto/go/porterapis/installation/v1alpha1"
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestPorterWithRuns(t *testing.T) (*porter.TestPorter, context.Context, []storage.Run) {
+	p := porter.NewTestPorter(t)
+	inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"), p.TestInstallations.SetMutableInstallationValues)
+	var runs []storage.Run
+	for _, action := range []string{cnab.ActionInstall, cnab.ActionUpgrade} {
+		run := p.TestInstallations.CreateRun(inst.NewRun(action, cnab.ExtendedBundle{}))
+		p.TestInstallations.CreateResult(run.NewResult(cnab.StatusRunning))
+		result := p.TestInstallations.CreateResult(run.NewResult(cnab.StatusSucceeded))
+		p.TestInstallations.CreateOutput(result.NewOutput(cnab.OutputInvocationImageLogs, []byte(action+" logs")))
+		runs = append(runs, run)
+	}
+	ctx := AddPorterConnectionToContext(p.Porter, context.Background())
+	return p, ctx, runs
+}
+
+func TestListRunsReturnsTheRunsOfTheInstallation(t *testing.T) {
+	p, ctx, runs := setupTestPorterWithRuns(t)
+	defer p.Close()
+
+	instSvc := PorterServer{}
+	resp, err := instSvc.ListRuns(ctx, &iGRPC.ListRunsRequest{Name: "mybuns", Namespace: "dev"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRuns(), 2)
+	for i, run := range resp.GetRuns() {
+		assert.Equal(t, runs[i].ID, run.GetId())
+		assert.Equal(t, runs[i].Action, run.GetAction())
+		assert.Equal(t, cnab.StatusSucceeded, run.GetStatus())
+		assert.True(t, run.GetStopped().IsValid(), "completed runs should have a stopped timestamp")
+	}
+}
+
+func TestGetLogsReturnsTheLogsOfARun(t *testing.T) {
+	p, ctx, runs := setupTestPorterWithRuns(t)
+	defer p.Close()
+
+	instSvc := PorterServer{}
+	t.Run("last run", func(t *testing.T) {
+		resp, err := instSvc.GetLogs(ctx, &iGRPC.GetLogsRequest{Name: "mybuns", Namespace: "dev"})
+		require.NoError(t, err)
+		assert.Equal(t, "upgrade logs", resp.GetLogs())
+	})
+
+	t.Run("specific run", func(t *testing.T) {
+		resp, err := instSvc.GetLogs(ctx, &iGRPC.GetLogsRequest{RunId: runs[0].ID})
+		require.NoError(t, err)
+		assert.Equal(t, "install logs", resp.GetLogs())
+	})
+
+	t.Run("no installation or run", func(t *testing.T) {
+		_, err := instSvc.GetLogs(ctx, &iGRPC.GetLogsRequest{})
+		require.EqualError(t, err, "either the installation name or the run id is required")
+	})
+}
@@ -2,13 +2,16 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	pGRPCv1alpha1 "get.porter.sh/porter/gen/proto/go/porterapis/porter/v1alpha1"
 	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/grpc/auth"
 	pserver "get.porter.sh/porter/pkg/grpc/portergrpc"
 	"get.porter.sh/porter/pkg/porter"
 	"get.porter.sh/porter/pkg/tracing"
@@ -18,6 +21,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -44,6 +48,10 @@ type PorterGRPCService struct {
 	PorterConfig *config.Config
 	opts         *porter.ServiceOptions
 	ctx          context.Context
+
+	// gateway and gatewayServer serve the HTTP/JSON gateway, when it is enabled.
+	gateway       *gateway
+	gatewayServer *http.Server
 }
 
 func NewServer(ctx context.Context, opts *porter.ServiceOptions) (*PorterGRPCService, error) {
@@ -71,18 +79,23 @@ func (s *PorterGRPCService) ListenAndServe() (*grpc.Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	srv := grpc.NewServer(
-		grpc.StreamInterceptor(grpcMetrics.StreamServerInterceptor()),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpcMetrics.UnaryServerInterceptor(),
-			psrv.NewConnectionInterceptor),
-		),
-	)
-	reflection.Register(srv)
-
-	pGRPCv1alpha1.RegisterPorterServer(srv, psrv)
+	credsOpts, err := newTransportCredentials(s.ctx, s.opts)
+	if err != nil {
+		return nil, err
+	}
+	interceptorOpts, err := newInterceptors(s.ctx, psrv, s.opts)
+	if err != nil {
+		return nil, err
+	}
+	registerServices := func(srv *grpc.Server) {
+		reflection.Register(srv)
+		pGRPCv1alpha1.RegisterPorterServer(srv, psrv)
+		grpc_health_v1.RegisterHealthServer(srv, healthServer)
+		grpc_prometheus.Register(srv)
+	}
+	srv := grpc.NewServer(append(credsOpts, interceptorOpts...)...)
+	registerServices(srv)
 	healthServer.SetServingStatus(s.opts.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
-	grpc_prometheus.Register(srv)
 
 	srvErrCh := make(chan error, 1)
 	go func() {
@@ -119,6 +132,129 @@ func (s *PorterGRPCService) ListenAndServe() (*grpc.Server, error) {
 		// Continue if no error is received yet
 	}
 
+	if s.opts.GatewayPort != 0 {
+		log.Infof("Starting the HTTP/JSON gateway on %v", s.opts.GatewayPort)
+		if !s.opts.AuthenticationEnabled() {
+			log.Warn("the HTTP/JSON gateway does not authenticate clients, specify --token-file to require a bearer token")
+		}
+		gw, err := newGateway(s.ctx, interceptorOpts, registerServices)
+		if err != nil {
+			return nil, err
+		}
+		gatewayServer := &http.Server{
+			Handler:           gw,
+			Addr:              fmt.Sprintf(":%d", s.opts.GatewayPort),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		s.gateway = gw
+		s.gatewayServer = gatewayServer
+		go func() {
+			var err error
+			if s.opts.TLSCertFile != "" {
+				// Gateway clients authenticate with bearer tokens, so client certificates are not requested
+				gatewayServer.TLSConfig, err = auth.NewServerTLSConfig(s.opts.TLSCertFile, s.opts.TLSKeyFile, "", false)
+				if err == nil {
+					err = gatewayServer.ListenAndServeTLS("", "")
+				}
+			} else {
+				err = gatewayServer.ListenAndServe()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				srvErrCh <- err
+			}
+		}()
+		select {
+		case err := <-srvErrCh:
+			if err != nil {
+				_ = log.Errorf("Unable to start the HTTP/JSON gateway. %w", err)
+				os.Exit(1)
+			}
+		default:
+			// Continue if no error is received yet
+		}
+		grpcMetrics.InitializeMetrics(gw.grpcServer)
+	}
+
 	grpcMetrics.InitializeMetrics(srv)
 	return srv, nil
 }
+
+// Close stops the HTTP/JSON gateway, when it was started, waiting until ctx is
+// done for the requests in progress to complete. Call Close after the gRPC
+// server is stopped.
+func (s *PorterGRPCService) Close(ctx context.Context) error {
+	if s.gatewayServer == nil {
+		return nil
+	}
+
+	err := s.gatewayServer.Shutdown(ctx)
+	s.gateway.Close()
+	s.gatewayServer = nil
+	s.gateway = nil
+	return err
+}
+
+// newTransportCredentials configures TLS for the server, when a server certificate is specified.
+func newTransportCredentials(ctx context.Context, opts *porter.ServiceOptions) ([]grpc.ServerOption, error) {
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if opts.TLSCertFile == "" {
+		return nil, nil
+	}
+	// Clients that authenticate with a bearer token do not have a certificate
+	requireClientCert := opts.TokenFile == ""
+	tlsConfig, err := auth.NewServerTLSConfig(opts.TLSCertFile, opts.TLSKeyFile, opts.ClientCAFile, requireClientCert)
+	if err != nil {
+		return nil, log.Error(err)
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// newInterceptors configures the interceptors of the server. When authentication is
+// enabled, requests are authenticated and authorized before a porter connection is
+// created for them.
+func newInterceptors(ctx context.Context, psrv *pserver.PorterServer, opts *porter.ServiceOptions) ([]grpc.ServerOption, error) {
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	streamInterceptors := []grpc.StreamServerInterceptor{grpcMetrics.StreamServerInterceptor()}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpcMetrics.UnaryServerInterceptor()}
+
+	if opts.AuthenticationEnabled() {
+		var authns auth.Authenticators
+		if opts.ClientCAFile != "" {
+			authns = append(authns, auth.CertificateAuthenticator{})
+		}
+		if opts.TokenFile != "" {
+			if opts.TLSCertFile == "" {
+				log.Warn("bearer tokens are sent unencrypted, specify --tls-cert and --tls-key to protect them")
+			}
+			tokenAuthn, err := auth.LoadTokenAuthenticator(opts.TokenFile)
+			if err != nil {
+				return nil, log.Error(err)
+			}
+			authns = append(authns, tokenAuthn)
+		}
+
+		interceptor := auth.Interceptor{Authenticator: authns}
+		if opts.AuthorizationFile != "" {
+			authz, err := auth.LoadAuthorizer(opts.AuthorizationFile)
+			if err != nil {
+				return nil, log.Error(err)
+			}
+			interceptor.Authorizer = authz
+		} else {
+			log.Warn("no authorization file was specified, every authenticated client has access to all namespaces")
+		}
+		streamInterceptors = append(streamInterceptors, interceptor.StreamServerInterceptor)
+		unaryInterceptors = append(unaryInterceptors, interceptor.UnaryServerInterceptor)
+	}
+
+	streamInterceptors = append(streamInterceptors, psrv.NewStreamConnectionInterceptor)
+	unaryInterceptors = append(unaryInterceptors, psrv.NewConnectionInterceptor)
+	return []grpc.ServerOption{
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+	}, nil
+}
@@ -215,6 +215,25 @@ func (l *Linter) Lint(ctx context.Context, m *manifest.Manifest, config *config.
 			return nil, span.Error(fmt.Errorf("error validating action: %s", action.name))
 		}
 		results = append(results, res...)
+
+		// The onFailure and finally steps run after the action's steps, and
+		// may use the outputs of the steps that ran before them
+		stepOutputs := map[string]struct{}{}
+		stepGroups := []struct {
+			location string
+			steps    manifest.Steps
+		}{
+			{action.name, action.steps},
+			{action.name + " onFailure", m.OnFailure[action.name]},
+			{action.name + " finally", m.Finally[action.name]},
+		}
+		for _, group := range stepGroups {
+			res, err = validateStepConditions(m, group.steps, action.name, group.location, stepOutputs)
+			if err != nil {
+				return nil, span.Error(fmt.Errorf("error validating step conditions for %s: %w", group.location, err))
+			}
+			results = append(results, res...)
+		}
 	}
 
 	deps := make(map[string]interface{}, len(m.Dependencies.Requires))
@@ -544,49 +563,179 @@ func validateSemverConstraint(name string, installedVersion string, versionConst
 func validateParamsAppliesToAction(m *manifest.Manifest, steps manifest.Steps, tmplParams manifest.ParameterDefinitions, actionName string, config *config.Config) (Results, error) {
 	var results Results
 	for stepNumber, step := range steps {
-		data, err := yaml.Marshal(step.Data)
-		if err != nil {
-			return nil, fmt.Errorf("error during marshalling: %w", err)
+		if step.IsParallel() {
+			for _, groupStep := range step.Parallel {
+				res, err := validateStepParamsAppliesToAction(m, groupStep, stepNumber, tmplParams, actionName, config)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, res...)
+			}
+			continue
 		}
 
-		tmplResult, err := m.ScanManifestTemplating(data, config)
+		res, err := validateStepParamsAppliesToAction(m, step, stepNumber, tmplParams, actionName, config)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing templating: %w", err)
+			return nil, err
 		}
+		results = append(results, res...)
+	}
 
-		for _, variable := range tmplResult.Variables {
-			paramName, ok := m.GetTemplateParameterName(variable)
-			if !ok {
+	return results, nil
+}
+
+// validateStepParamsAppliesToAction checks that the parameters used by a step apply to the action.
+func validateStepParamsAppliesToAction(m *manifest.Manifest, step *manifest.Step, stepNumber int, tmplParams manifest.ParameterDefinitions, actionName string, config *config.Config) (Results, error) {
+	var results Results
+	data, err := yaml.Marshal(step.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error during marshalling: %w", err)
+	}
+
+	tmplResult, err := m.ScanManifestTemplating(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing templating: %w", err)
+	}
+
+	for _, variable := range tmplResult.Variables {
+		paramName, ok := m.GetTemplateParameterName(variable)
+		if !ok {
+			continue
+		}
+
+		for _, tmplParam := range tmplParams {
+			if tmplParam.Name != paramName {
 				continue
 			}
-
-			for _, tmplParam := range tmplParams {
-				if tmplParam.Name != paramName {
-					continue
+			if !tmplParam.AppliesTo(actionName) {
+				description, err := step.GetDescription()
+				if err != nil {
+					return nil, fmt.Errorf("error getting step description: %w", err)
 				}
-				if !tmplParam.AppliesTo(actionName) {
-					description, err := step.GetDescription()
-					if err != nil {
-						return nil, fmt.Errorf("error getting step description: %w", err)
-					}
-					res := Result{
-						Level: LevelError,
-						Location: Location{
-							Action:          actionName,
-							Mixin:           step.GetMixinName(),
-							StepNumber:      stepNumber + 1,
-							StepDescription: description,
-						},
-						Code:    "porter-101",
-						Title:   "Parameter does not apply to action",
-						Message: fmt.Sprintf("Parameter %s does not apply to %s action", paramName, actionName),
-						URL:     "https://porter.sh/docs/references/linter/#porter-101",
-					}
-					results = append(results, res)
+				res := Result{
+					Level: LevelError,
+					Location: Location{
+						Action:          actionName,
+						Mixin:           step.GetMixinName(),
+						StepNumber:      stepNumber + 1,
+						StepDescription: description,
+					},
+					Code:    "porter-101",
+					Title:   "Parameter does not apply to action",
+					Message: fmt.Sprintf("Parameter %s does not apply to %s action", paramName, actionName),
+					URL:     "https://porter.sh/docs/references/linter/#porter-101",
 				}
+				results = append(results, res)
 			}
 		}
 	}
 
 	return results, nil
 }
+
+// validateStepConditions checks that the template variables used in the when
+// condition of each step are defined and available to the action. Step outputs
+// are only available after the step that generates them has run, while bundle
+// outputs may also be available from a previous run. The steps in a parallel
+// group cannot use the outputs of the other steps in the group.
+//
+// stepOutputs holds the outputs generated by the steps that ran before, and is
+// updated with the outputs generated by the steps. The results are reported at
+// the location, such as the name of the action.
+func validateStepConditions(m *manifest.Manifest, steps manifest.Steps, actionName string, location string, stepOutputs map[string]struct{}) (Results, error) {
+	var results Results
+	for stepNumber, step := range steps {
+		if step == nil {
+			continue
+		}
+
+		groupSteps := manifest.Steps{step}
+		if step.IsParallel() {
+			groupSteps = step.Parallel
+		}
+		for _, groupStep := range groupSteps {
+			if groupStep == nil {
+				continue
+			}
+			res, err := validateStepCondition(m, groupStep, stepNumber, actionName, location, stepOutputs)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, res...)
+		}
+
+		for _, groupStep := range groupSteps {
+			if groupStep == nil {
+				continue
+			}
+			for _, name := range groupStep.GetOutputNames() {
+				stepOutputs[name] = struct{}{}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// validateStepCondition checks the when condition of a single step, given the
+// outputs generated by the previous steps in the action.
+func validateStepCondition(m *manifest.Manifest, step *manifest.Step, stepNumber int, actionName string, location string, stepOutputs map[string]struct{}) (Results, error) {
+	if step.When == "" {
+		return nil, nil
+	}
+
+	vars, err := m.GetTemplateVariables(step.When)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the templating used in the when condition of the %s step: %w", humanize.Ordinal(stepNumber+1), err)
+	}
+
+	varNames := make([]string, 0, len(vars))
+	for v := range vars {
+		varNames = append(varNames, v)
+	}
+	sort.Strings(varNames)
+
+	var results Results
+	for _, v := range varNames {
+		var problem string
+		if paramName, ok := m.GetTemplateParameterName(v); ok {
+			if param, defined := m.Parameters[paramName]; !defined {
+				problem = "which is not defined as a parameter on the bundle"
+			} else if !param.AppliesTo(actionName) {
+				problem = fmt.Sprintf("which does not apply to the %s action", actionName)
+			}
+		} else if credName, ok := m.GetTemplateCredentialName(v); ok {
+			if _, defined := m.Credentials[credName]; !defined {
+				problem = "which is not defined as a credential on the bundle"
+			}
+		} else if outputName, ok := m.GetTemplateOutputName(v); ok {
+			_, isStepOutput := stepOutputs[outputName]
+			_, isBundleOutput := m.Outputs[outputName]
+			if !isStepOutput && !isBundleOutput {
+				problem = "which is not a bundle output or generated by a previous step in the action"
+			}
+		} else if !strings.HasPrefix(v, "bundle.") && !strings.HasPrefix(v, "installation.") && !strings.HasPrefix(v, "env.") {
+			problem = "which is not a supported template variable (supported: bundle.*, installation.* and env.*)"
+		}
+		if problem == "" {
+			continue
+		}
+
+		description, _ := step.GetDescription()
+		results = append(results, Result{
+			Level: LevelError,
+			Location: Location{
+				Action:          location,
+				Mixin:           step.GetMixinName(),
+				StepNumber:      stepNumber + 1,
+				StepDescription: description,
+			},
+			Code:    "porter-112",
+			Title:   "Step condition error",
+			Message: fmt.Sprintf("The when condition references %s, %s", v, problem),
+			URL:     "https://porter.sh/reference/linter/#porter-112",
+		})
+	}
+
+	return results, nil
+}
@@ -1009,3 +1009,168 @@ func TestLinter_Lint_MixinVersions(t *testing.T) {
 	}
 
 }
+
+func TestLinter_Lint_StepConditions(t *testing.T) {
+	ctx := context.Background()
+	testConfig := config.NewTestConfig(t).Config
+
+	newStep := func(description string, when string, outputs ...string) *manifest.Step {
+		execStep := map[string]interface{}{
+			"description": description,
+		}
+		if len(outputs) > 0 {
+			var stepOutputs []interface{}
+			for _, o := range outputs {
+				stepOutputs = append(stepOutputs, map[string]interface{}{"name": o})
+			}
+			execStep["outputs"] = stepOutputs
+		}
+		return &manifest.Step{
+			When: when,
+			Data: map[string]interface{}{"exec": execStep},
+		}
+	}
+	newResult := func(stepNumber int, description string, message string) Result {
+		return Result{
+			Level: LevelError,
+			Location: Location{
+				Action:          "install",
+				Mixin:           "exec",
+				StepNumber:      stepNumber,
+				StepDescription: description,
+			},
+			Code:    "porter-112",
+			Title:   "Step condition error",
+			Message: message,
+			URL:     "https://porter.sh/reference/linter/#porter-112",
+		}
+	}
+	inHook := func(hook string, r Result) Result {
+		r.Location.Action = "install " + hook
+		return r
+	}
+
+	testcases := []struct {
+		name      string
+		steps     manifest.Steps
+		onFailure manifest.Steps
+		finally   manifest.Steps
+		want      Results
+	}{
+		{
+			name: "valid conditions",
+			steps: manifest.Steps{
+				newStep("first", "${ bundle.parameters.env } == prod", "address"),
+				newStep("second", "${ bundle.outputs.address }"),
+				newStep("third", "!${ bundle.credentials.token }"),
+				newStep("fourth", "${ bundle.outputs.port } != 80"),
+				newStep("fifth", "${ installation.name } == mybuns"),
+			},
+		},
+		{
+			name:  "undefined parameter",
+			steps: manifest.Steps{newStep("first", "${ bundle.parameters.missing }")},
+			want: Results{newResult(1, "first",
+				"The when condition references bundle.parameters.missing, which is not defined as a parameter on the bundle")},
+		},
+		{
+			name:  "parameter does not apply to action",
+			steps: manifest.Steps{newStep("first", "${ bundle.parameters.upgradeOnly } == true")},
+			want: Results{newResult(1, "first",
+				"The when condition references bundle.parameters.upgradeOnly, which does not apply to the install action")},
+		},
+		{
+			name:  "undefined credential",
+			steps: manifest.Steps{newStep("first", "${ bundle.credentials.missing }")},
+			want: Results{newResult(1, "first",
+				"The when condition references bundle.credentials.missing, which is not defined as a credential on the bundle")},
+		},
+		{
+			name: "output from a later step",
+			steps: manifest.Steps{
+				newStep("first", "${ bundle.outputs.address }"),
+				newStep("second", "", "address"),
+			},
+			want: Results{newResult(1, "first",
+				"The when condition references bundle.outputs.address, which is not a bundle output or generated by a previous step in the action")},
+		},
+		{
+			name: "output from a step in the same parallel group",
+			steps: manifest.Steps{
+				{Parallel: manifest.Steps{
+					newStep("first", "", "address"),
+					newStep("second", "${ bundle.outputs.address }"),
+				}},
+				newStep("third", "${ bundle.outputs.address }"),
+			},
+			want: Results{newResult(1, "second",
+				"The when condition references bundle.outputs.address, which is not a bundle output or generated by a previous step in the action")},
+		},
+		{
+			name:  "dependency output",
+			steps: manifest.Steps{newStep("first", "${ bundle.dependencies.mysql.outputs.password }")},
+		},
+		{
+			name:  "unsupported namespace",
+			steps: manifest.Steps{newStep("first", "${ runtime.name }")},
+			want: Results{newResult(1, "first",
+				"The when condition references runtime.name, which is not a supported template variable (supported: bundle.*, installation.* and env.*)")},
+		},
+		{
+			name:      "hook steps use outputs of the action steps",
+			steps:     manifest.Steps{newStep("first", "", "address")},
+			onFailure: manifest.Steps{newStep("cleanup", "${ bundle.outputs.address }", "logs")},
+			finally:   manifest.Steps{newStep("notify", "${ bundle.outputs.logs } && ${ bundle.parameters.env } == prod")},
+		},
+		{
+			name:    "bad condition in a finally step",
+			steps:   manifest.Steps{newStep("first", "")},
+			finally: manifest.Steps{newStep("notify", "${ bundle.parameters.upgradeOnly } == true")},
+			want: Results{inHook("finally", newResult(1, "notify",
+				"The when condition references bundle.parameters.upgradeOnly, which does not apply to the install action"))},
+		},
+		{
+			name:  "bad condition in an onFailure step",
+			steps: manifest.Steps{newStep("first", "")},
+			onFailure: manifest.Steps{
+				newStep("cleanup", ""),
+				newStep("collect", "${ bundle.credentials.missing }"),
+			},
+			want: Results{inHook("onFailure", newResult(2, "collect",
+				"The when condition references bundle.credentials.missing, which is not defined as a credential on the bundle"))},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cxt := portercontext.NewTestContext(t)
+			mixins := mixin.NewTestMixinProvider()
+			l := New(cxt.Context, mixins)
+
+			m := &manifest.Manifest{
+				SchemaVersion: "1.0.1",
+				Parameters: manifest.ParameterDefinitions{
+					"env":         {Name: "env"},
+					"upgradeOnly": {Name: "upgradeOnly", ApplyTo: []string{"upgrade"}},
+				},
+				Credentials: manifest.CredentialDefinitions{
+					"token": {Name: "token"},
+				},
+				Outputs: manifest.OutputDefinitions{
+					"port": {Name: "port"},
+				},
+				Install: tc.steps,
+			}
+			if tc.onFailure != nil {
+				m.OnFailure = map[string]manifest.Steps{"install": tc.onFailure}
+			}
+			if tc.finally != nil {
+				m.Finally = map[string]manifest.Steps{"install": tc.finally}
+			}
+
+			results, err := l.Lint(ctx, m, testConfig, nil)
+			require.NoError(t, err, "Lint failed")
+			require.Equal(t, tc.want, results, "unexpected lint results")
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"get.porter.sh/porter/pkg/cnab"
 	"get.porter.sh/porter/pkg/config"
@@ -101,6 +102,13 @@ type Manifest struct {
 	Uninstall Steps `yaml:"uninstall"`
 	Upgrade   Steps `yaml:"upgrade"`
 
+	// OnFailure defines steps, by action name, that are run after a step in the action fails.
+	OnFailure map[string]Steps `yaml:"onFailure,omitempty"`
+
+	// Finally defines steps, by action name, that are run after the action's
+	// steps, whether the action succeeded or failed.
+	Finally map[string]Steps `yaml:"finally,omitempty"`
+
 	Custom                  CustomDefinitions                 `yaml:"custom,omitempty"`
 	CustomActions           map[string]Steps                  `yaml:"-"`
 	CustomActionDefinitions map[string]CustomActionDefinition `yaml:"customActions,omitempty"`
@@ -121,6 +129,55 @@ type Manifest struct {
 	Files []FileSource `yaml:"files,omitempty"`
 }
 
+// HasAction determines if the specified action is defined by the bundle.
+func (m *Manifest) HasAction(action string) bool {
+	switch action {
+	case cnab.ActionInstall, cnab.ActionUninstall:
+		return true
+	case cnab.ActionUpgrade:
+		return m.Upgrade != nil
+	default:
+		_, ok := m.CustomActions[action]
+		return ok
+	}
+}
+
+// validateActionHooks validates the onFailure and finally steps defined for each action.
+func (m *Manifest) validateActionHooks() error {
+	var result error
+	hooks := []struct {
+		name  string
+		steps map[string]Steps
+	}{
+		{"onFailure", m.OnFailure},
+		{"finally", m.Finally},
+	}
+	for _, hook := range hooks {
+		actionNames := make([]string, 0, len(hook.steps))
+		for actionName := range hook.steps {
+			actionNames = append(actionNames, actionName)
+		}
+		sort.Strings(actionNames)
+
+		for _, actionName := range actionNames {
+			steps := hook.steps[actionName]
+			if !m.HasAction(actionName) {
+				result = multierror.Append(result, fmt.Errorf("%s steps are defined for the %s action, which is not defined by the bundle", hook.name, actionName))
+				continue
+			}
+			for _, step := range steps {
+				if step != nil && step.IsParallel() {
+					result = multierror.Append(result, fmt.Errorf("validation of %s steps for action %q failed: parallel groups are not supported", hook.name, actionName))
+				}
+			}
+			if err := steps.Validate(m); err != nil {
+				result = multierror.Append(result, fmt.Errorf("validation of %s steps for action %q failed: %w", hook.name, actionName, err))
+			}
+		}
+	}
+	return result
+}
+
 // FileSource declares a file to be downloaded during porter build.
 type FileSource struct {
 	// URL is the http/https URL to download the file from.
@@ -188,6 +245,10 @@ func (m *Manifest) Validate(ctx context.Context, cfg *config.Config) error {
 		}
 	}
 
+	if err = m.validateActionHooks(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	for _, dep := range m.Dependencies.Requires {
 		err = dep.Validate(cfg.Context)
 		if err != nil {
@@ -358,6 +419,12 @@ func (m *Manifest) GetTemplateDependencyOutputName(value string) (string, string
 	return m.getTemplateDependencyOutputName(value)
 }
 
+// GetTemplateOutputName returns the output name from a bundle output
+// template variable, e.g. bundle.outputs.NAME.
+func (m *Manifest) GetTemplateOutputName(value string) (string, bool) {
+	return m.getTemplateOutputName(value)
+}
+
 // GetTemplatedOutputs returns the output definitions for any bundle level outputs
 // that have been templated, keyed by the output name.
 func (m *Manifest) GetTemplatedOutputs() OutputDefinitions {
@@ -1253,14 +1320,143 @@ func (s Steps) Validate(m *Manifest) error {
 	return nil
 }
 
+// Flatten returns the steps with each parallel group replaced by the steps in the group.
+func (s Steps) Flatten() Steps {
+	flattened := make(Steps, 0, len(s))
+	for _, step := range s {
+		if step != nil && step.IsParallel() {
+			flattened = append(flattened, step.Parallel...)
+			continue
+		}
+		flattened = append(flattened, step)
+	}
+	return flattened
+}
+
+// Step is a single step in an action. The step is defined by the mixin that
+// executes it, with additional Porter-level fields that control when and how
+// the step is run. The Porter-level fields are not passed to the mixin.
 type Step struct {
+	// When is an optional condition that determines if the step is run.
+	// The condition is templated using the same data as the step, and the step
+	// is skipped when it evaluates to false.
+	When string `yaml:"when,omitempty"`
+
+	// Retry is an optional policy for running the step again when it fails.
+	Retry *StepRetry `yaml:"retry,omitempty"`
+
+	// Timeout is an optional duration, such as 10m, that each attempt to run
+	// the step may take before it is stopped.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Parallel is a group of steps that are run at the same time, and is
+	// defined instead of a mixin step. The group fails when any of its steps fail.
+	Parallel Steps `yaml:"parallel,omitempty"`
+
+	// Data is the mixin name and the mixin's step definition.
 	Data map[string]interface{} `yaml:",inline"`
 }
 
+// StepRetry defines when and how often a failed step is run again.
+type StepRetry struct {
+	// Attempts is the maximum number of times the step is run, including the first attempt.
+	Attempts int `yaml:"attempts"`
+
+	// Backoff is an optional duration, such as 10s, to wait before the first
+	// retry. The wait is doubled before each subsequent retry.
+	Backoff string `yaml:"backoff,omitempty"`
+
+	// OnExitCodes limits retries to failures with one of the specified exit
+	// codes. When empty, the step is retried for any failure.
+	OnExitCodes []int `yaml:"onExitCodes,omitempty"`
+}
+
+// GetBackoff returns the duration to wait before the first retry.
+func (r *StepRetry) GetBackoff() (time.Duration, error) {
+	if r == nil || r.Backoff == "" {
+		return 0, nil
+	}
+	backoff, err := time.ParseDuration(r.Backoff)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retry backoff %q: %w", r.Backoff, err)
+	}
+	if backoff < 0 {
+		return 0, fmt.Errorf("invalid retry backoff %q: the backoff cannot be negative", r.Backoff)
+	}
+	return backoff, nil
+}
+
+// ShouldRetry determines if a failure with the specified exit code should be retried.
+// Use an exit code of -1 when the exit code is not known, such as when the step timed out.
+func (r *StepRetry) ShouldRetry(exitCode int) bool {
+	if r == nil {
+		return false
+	}
+	if len(r.OnExitCodes) == 0 {
+		return true
+	}
+	for _, code := range r.OnExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate the retry policy.
+func (r *StepRetry) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.Attempts < 1 {
+		return fmt.Errorf("invalid retry attempts %d: at least 1 attempt is required", r.Attempts)
+	}
+	_, err := r.GetBackoff()
+	return err
+}
+
+// GetMixinStep returns the step data defined by the mixin, without the
+// Porter-level fields, so that it can be passed to the mixin.
+func (s *Step) GetMixinStep() *Step {
+	return &Step{Data: s.Data}
+}
+
+// GetAttempts returns the maximum number of times that the step is run.
+func (s *Step) GetAttempts() int {
+	if s.Retry == nil || s.Retry.Attempts < 1 {
+		return 1
+	}
+	return s.Retry.Attempts
+}
+
+// GetTimeout returns how long each attempt to run the step may take.
+// A timeout of 0 means that the step does not time out.
+func (s *Step) GetTimeout() (time.Duration, error) {
+	if s.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(s.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", s.Timeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q: the timeout must be greater than 0", s.Timeout)
+	}
+	return timeout, nil
+}
+
+// IsParallel determines if the step is a group of steps that are run at the same time.
+func (s *Step) IsParallel() bool {
+	return len(s.Parallel) > 0
+}
+
 func (s *Step) Validate(m *Manifest) error {
 	if s == nil {
 		return errors.New("found an empty step")
 	}
+	if s.IsParallel() {
+		return s.validateParallel(m)
+	}
 	if len(s.Data) == 0 {
 		return errors.New("no mixin specified")
 	}
@@ -1284,12 +1480,61 @@ func (s *Step) Validate(m *Manifest) error {
 		return err
 	}
 
+	if err := s.Retry.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.GetTimeout(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateParallel validates a group of steps that are run at the same time.
+func (s *Step) validateParallel(m *Manifest) error {
+	if len(s.Data) > 0 {
+		return errors.New("a parallel group cannot also define a mixin step")
+	}
+	if s.When != "" || s.Retry != nil || s.Timeout != "" {
+		return errors.New("when, retry and timeout must be defined on the steps in a parallel group and not on the group")
+	}
+
+	// Outputs from the steps in the group are merged, so each output may only be generated by one step
+	outputs := make(map[string]struct{})
+	for i, step := range s.Parallel {
+		if step != nil && step.IsParallel() {
+			return errors.New("parallel groups cannot be nested")
+		}
+		if err := step.Validate(m); err != nil {
+			return fmt.Errorf("failed to validate %s step in the parallel group: %w", humanize.Ordinal(i+1), err)
+		}
+		for _, name := range step.GetOutputNames() {
+			if _, ok := outputs[name]; ok {
+				return fmt.Errorf("the %s output is generated by more than one step in the parallel group", name)
+			}
+			outputs[name] = struct{}{}
+		}
+	}
 	return nil
 }
 
 // GetDescription returns a description of the step.
-// Every step must have this property.
+// Every step must have this property. The description of a parallel group is
+// the description of each of its steps.
 func (s *Step) GetDescription() (string, error) {
+	if s.IsParallel() {
+		descriptions := make([]string, 0, len(s.Parallel))
+		for _, step := range s.Parallel {
+			description, err := step.GetDescription()
+			if err != nil {
+				return "", err
+			}
+			descriptions = append(descriptions, description)
+		}
+		return strings.Join(descriptions, ", "), nil
+	}
+
 	if s.Data == nil {
 		return "", errors.New("empty step data")
 	}
@@ -1320,6 +1565,28 @@ func (s *Step) GetMixinName() string {
 	return mixinName
 }
 
+// GetOutputNames returns the names of the outputs declared by the mixin step.
+func (s *Step) GetOutputNames() []string {
+	mixinStep, ok := s.Data[s.GetMixinName()].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	outputs, ok := mixinStep["outputs"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, output := range outputs {
+		if o, ok := output.(map[string]interface{}); ok {
+			if name, ok := o["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
 func UnmarshalManifest(cxt *portercontext.Context, manifestData []byte) (*Manifest, error) {
 	// Unmarshal the manifest into the normal struct
 	manifest := &Manifest{}
@@ -226,6 +226,203 @@ func TestManifest_Empty_Steps(t *testing.T) {
 	assert.EqualError(t, err, "3 errors occurred:\n\t* validation of action \"install\" failed: failed to validate 2nd step: found an empty step\n\t* validation of action \"uninstall\" failed: failed to validate 2nd step: found an empty step\n\t* validation of action \"status\" failed: failed to validate 1st step: found an empty step\n\n")
 }
 
+func TestManifest_StepConditions(t *testing.T) {
+	c := config.NewTestConfig(t)
+
+	c.TestContext.AddTestFile("testdata/step-conditions.yaml", config.Name)
+
+	m, err := LoadManifestFrom(context.Background(), c.Config, config.Name)
+	require.NoError(t, err, "a step with a when condition should be valid")
+	require.Len(t, m.Install, 2)
+
+	assert.Empty(t, m.Install[0].When, "the first step should not have a condition")
+
+	conditionalStep := m.Install[1]
+	assert.Equal(t, "${ bundle.parameters.env } == prod", conditionalStep.When)
+	assert.Equal(t, "exec", conditionalStep.GetMixinName(), "the when condition should not be treated as the mixin")
+	assert.NotContains(t, conditionalStep.Data, "when", "the when condition should not be included in the mixin step data")
+
+	mixinStep := conditionalStep.GetMixinStep()
+	assert.Empty(t, mixinStep.When, "the mixin step should not include Porter-level fields")
+	assert.Equal(t, conditionalStep.Data, mixinStep.Data)
+}
+
+func TestStep_Validate_RetryAndTimeout(t *testing.T) {
+	m := &Manifest{Mixins: []MixinDeclaration{{Name: "exec"}}}
+	newStep := func(retry *StepRetry, timeout string) *Step {
+		return &Step{
+			Retry:   retry,
+			Timeout: timeout,
+			Data: map[string]interface{}{
+				"exec": map[string]interface{}{"description": "Deploy"},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name    string
+		step    *Step
+		wantErr string
+	}{
+		{name: "no policy", step: newStep(nil, "")},
+		{name: "valid policy", step: newStep(&StepRetry{Attempts: 3, Backoff: "10s", OnExitCodes: []int{1}}, "10m")},
+		{name: "no attempts", step: newStep(&StepRetry{}, ""), wantErr: "invalid retry attempts 0"},
+		{name: "invalid backoff", step: newStep(&StepRetry{Attempts: 2, Backoff: "soon"}, ""), wantErr: `invalid retry backoff "soon"`},
+		{name: "negative backoff", step: newStep(&StepRetry{Attempts: 2, Backoff: "-1s"}, ""), wantErr: "the backoff cannot be negative"},
+		{name: "invalid timeout", step: newStep(nil, "forever"), wantErr: `invalid timeout "forever"`},
+		{name: "zero timeout", step: newStep(nil, "0s"), wantErr: "the timeout must be greater than 0"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.step.Validate(m)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestManifest_ParallelSteps(t *testing.T) {
+	c := config.NewTestConfig(t)
+
+	c.TestContext.AddTestFile("testdata/parallel-steps.yaml", config.Name)
+
+	m, err := LoadManifestFrom(context.Background(), c.Config, config.Name)
+	require.NoError(t, err, "a parallel group should be valid")
+	require.Len(t, m.Install, 2)
+
+	group := m.Install[0]
+	require.True(t, group.IsParallel())
+	assert.Empty(t, group.Data, "the parallel group should not be treated as a mixin step")
+	require.Len(t, group.Parallel, 2)
+	assert.Equal(t, "exec", group.Parallel[0].GetMixinName())
+	assert.Equal(t, "5m", group.Parallel[1].Timeout)
+
+	description, err := group.GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "Create the database, Create the cache", description)
+
+	flattened := m.Install.Flatten()
+	require.Len(t, flattened, 3, "the steps in the parallel group should be flattened into the action")
+	assert.Equal(t, []string{"connstr"}, flattened[0].GetOutputNames())
+}
+
+func TestStep_Validate_Parallel(t *testing.T) {
+	m := &Manifest{Mixins: []MixinDeclaration{{Name: "exec"}}}
+	newStep := func(description string, outputs ...string) *Step {
+		var outputList []interface{}
+		for _, output := range outputs {
+			outputList = append(outputList, map[string]interface{}{"name": output})
+		}
+		return &Step{
+			Data: map[string]interface{}{
+				"exec": map[string]interface{}{"description": description, "outputs": outputList},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name    string
+		step    *Step
+		wantErr string
+	}{
+		{name: "valid group", step: &Step{Parallel: Steps{newStep("Create the database", "connstr"), newStep("Create the cache", "host")}}},
+		{name: "mixin step on the group", step: &Step{Parallel: Steps{newStep("Create the database")}, Data: newStep("Deploy").Data},
+			wantErr: "a parallel group cannot also define a mixin step"},
+		{name: "retry on the group", step: &Step{Parallel: Steps{newStep("Create the database")}, Retry: &StepRetry{Attempts: 2}},
+			wantErr: "must be defined on the steps in a parallel group and not on the group"},
+		{name: "nested group", step: &Step{Parallel: Steps{&Step{Parallel: Steps{newStep("Create the database")}}}},
+			wantErr: "parallel groups cannot be nested"},
+		{name: "invalid step", step: &Step{Parallel: Steps{newStep("Create the database"), {Data: map[string]interface{}{"helm3": map[string]interface{}{}}}}},
+			wantErr: "failed to validate 2nd step in the parallel group: mixin (helm3) was not declared"},
+		{name: "duplicate output", step: &Step{Parallel: Steps{newStep("Create the database", "host"), newStep("Create the cache", "host")}},
+			wantErr: "the host output is generated by more than one step in the parallel group"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.step.Validate(m)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestManifest_ActionHooks(t *testing.T) {
+	c := config.NewTestConfig(t)
+
+	c.TestContext.AddTestFile("testdata/action-hooks.yaml", config.Name)
+
+	m, err := LoadManifestFrom(context.Background(), c.Config, config.Name)
+	require.NoError(t, err, "onFailure and finally steps should be valid")
+
+	assert.Empty(t, m.CustomActions, "onFailure and finally should not be treated as custom actions")
+	require.Len(t, m.OnFailure["install"], 1)
+	assert.Equal(t, "exec", m.OnFailure["install"][0].GetMixinName())
+	require.Len(t, m.Finally["install"], 1)
+	require.Len(t, m.Finally["uninstall"], 1)
+}
+
+func TestManifest_Validate_ActionHooks(t *testing.T) {
+	execStep := &Step{Data: map[string]interface{}{"exec": map[string]interface{}{"description": "Collect pod logs"}}}
+
+	testcases := []struct {
+		name      string
+		onFailure map[string]Steps
+		finally   map[string]Steps
+		wantErr   string
+	}{
+		{name: "valid hooks", onFailure: map[string]Steps{"install": {execStep}}, finally: map[string]Steps{"install": {execStep}}},
+		{name: "undefined action", finally: map[string]Steps{"upgrade": {execStep}},
+			wantErr: "finally steps are defined for the upgrade action, which is not defined by the bundle"},
+		{name: "parallel group", onFailure: map[string]Steps{"install": {{Parallel: Steps{execStep}}}},
+			wantErr: `validation of onFailure steps for action "install" failed: parallel groups are not supported`},
+		{name: "invalid step", onFailure: map[string]Steps{"install": {{Data: map[string]interface{}{"helm3": map[string]interface{}{}}}}},
+			wantErr: "mixin (helm3) was not declared"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Manifest{
+				Mixins:    []MixinDeclaration{{Name: "exec"}},
+				OnFailure: tc.onFailure,
+				Finally:   tc.finally,
+			}
+			err := m.validateActionHooks()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestStepRetry_ShouldRetry(t *testing.T) {
+	var noRetry *StepRetry
+	assert.False(t, noRetry.ShouldRetry(1), "a step without a retry policy should not be retried")
+
+	anyFailure := &StepRetry{Attempts: 2}
+	assert.True(t, anyFailure.ShouldRetry(1))
+	assert.True(t, anyFailure.ShouldRetry(-1), "timeouts should be retried when no exit codes are specified")
+
+	someFailures := &StepRetry{Attempts: 2, OnExitCodes: []int{2, 75}}
+	assert.True(t, someFailures.ShouldRetry(75))
+	assert.False(t, someFailures.ShouldRetry(1))
+	assert.False(t, someFailures.ShouldRetry(-1))
+}
+
+func TestStep_GetAttempts(t *testing.T) {
+	assert.Equal(t, 1, (&Step{}).GetAttempts())
+	assert.Equal(t, 3, (&Step{Retry: &StepRetry{Attempts: 3}}).GetAttempts())
+}
+
 func TestManifest_Validate_Name(t *testing.T) {
 	c := config.NewTestConfig(t)
 
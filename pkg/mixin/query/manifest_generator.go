@@ -51,12 +51,16 @@ func (g ManifestGenerator) buildInputForMixin(mixinName string) BuildInput {
 	}
 
 	filterSteps := func(action string, steps manifest.Steps) {
+		// Include the onFailure and finally steps of the action, which are run by the same mixins
+		steps = append(steps.Flatten(), g.Manifest.OnFailure[action]...)
+		steps = append(steps, g.Manifest.Finally[action]...)
+
 		mixinSteps := manifest.Steps{}
 		for _, step := range steps {
 			if step.GetMixinName() != mixinName {
 				continue
 			}
-			mixinSteps = append(mixinSteps, step)
+			mixinSteps = append(mixinSteps, step.GetMixinStep())
 		}
 		input.Actions[action] = mixinSteps
 	}
@@ -43,3 +43,20 @@ func TestManifestGenerator_BuildInput(t *testing.T) {
 		assert.Equal(t, map[string]interface{}{"extensions": []interface{}{"iot"}}, input.Config, "az mixin should have config")
 	})
 }
+
+func TestManifestGenerator_BuildInput_NestedSteps(t *testing.T) {
+	c := config.NewTestConfig(t)
+	c.TestContext.AddTestFile("testdata/porter-parallel.yaml", config.Name)
+
+	m, err := manifest.LoadManifestFrom(context.Background(), c.Config, config.Name)
+	require.NoError(t, err, "could not load manifest")
+
+	g := ManifestGenerator{Manifest: m}
+	input := g.buildInputForMixin("az")
+
+	require.Contains(t, input.Actions, "upgrade")
+	assert.Len(t, input.Actions["upgrade"], 1, "expected the az upgrade step from the parallel group")
+
+	require.Contains(t, input.Actions, "install")
+	assert.Len(t, input.Actions["install"], 2, "expected the az install step and the az finally step for install")
+}
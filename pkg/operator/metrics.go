@@ -0,0 +1,67 @@
+package operator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// ResultInSync indicates that the installation was already up-to-date.
+	ResultInSync = "in_sync"
+
+	// ResultReconciled indicates that the installation had drifted and was reconciled.
+	ResultReconciled = "reconciled"
+
+	// ResultFailed indicates that the installation could not be reconciled.
+	ResultFailed = "failed"
+
+	// ResultBackoff indicates that the installation was skipped because a
+	// previous attempt to reconcile it failed, and its backoff has not expired.
+	ResultBackoff = "backoff"
+)
+
+// Metrics collected by the operator, exposed in the Prometheus format.
+type Metrics struct {
+	// Registry that the metrics are registered with.
+	Registry *prometheus.Registry
+
+	cycles          prometheus.Counter
+	reconciliations *prometheus.CounterVec
+	duration        prometheus.Histogram
+	backoff         prometheus.Gauge
+	lastCycle       prometheus.Gauge
+}
+
+// NewMetrics creates the operator metrics and registers them with a new registry.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		cycles: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "porter_operator_reconcile_cycles_total",
+			Help: "Total number of times the installations were listed and checked for drift.",
+		}),
+		reconciliations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "porter_operator_reconciliations_total",
+			Help: "Total number of installations checked by the operator, by result.",
+		}, []string{"result"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "porter_operator_reconcile_duration_seconds",
+			Help:    "Time taken to check and reconcile an installation.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 4, 8),
+		}),
+		backoff: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "porter_operator_installations_in_backoff",
+			Help: "Number of installations that are waiting to be retried after they failed to reconcile.",
+		}),
+		lastCycle: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "porter_operator_last_cycle_timestamp_seconds",
+			Help: "Unix time when the operator last completed checking the installations.",
+		}),
+	}
+	m.Registry.MustRegister(m.cycles, m.reconciliations, m.duration, m.backoff, m.lastCycle)
+
+	// Report every result, even before it first occurs
+	for _, result := range []string{ResultInSync, ResultReconciled, ResultFailed, ResultBackoff} {
+		m.reconciliations.WithLabelValues(result)
+	}
+	return m
+}
@@ -0,0 +1,335 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// DefaultInterval is the default time between checking the installations for drift.
+	DefaultInterval = 5 * time.Minute
+
+	// DefaultJitter is the default fraction of the interval that is randomly
+	// added to it, so that multiple operators do not check at the same time.
+	DefaultJitter = 0.1
+
+	// DefaultConcurrency is the default number of installations reconciled at the same time.
+	DefaultConcurrency = 4
+
+	// DefaultBackoff is the default time to wait before retrying an
+	// installation that failed to reconcile. It doubles after each failure.
+	DefaultBackoff = time.Minute
+
+	// DefaultMaxBackoff is the default maximum time to wait before retrying an
+	// installation that failed to reconcile.
+	DefaultMaxBackoff = time.Hour
+
+	// DefaultMetricsPort is the default port that the metrics are served on.
+	DefaultMetricsPort = 9093
+)
+
+// Options configures the reconciliation daemon.
+type Options struct {
+	// Namespace of the installations to reconcile.
+	Namespace string
+
+	// AllNamespaces reconciles the installations in every namespace.
+	AllNamespaces bool
+
+	// Interval between checking the installations for drift.
+	Interval time.Duration
+
+	// Jitter is the fraction of the interval, between 0 and 1, that is
+	// randomly added to each interval.
+	Jitter float64
+
+	// Concurrency is the maximum number of installations reconciled at the same time.
+	Concurrency int
+
+	// Backoff is the time to wait before retrying an installation that failed
+	// to reconcile. It doubles after each consecutive failure.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum time to wait before retrying an installation
+	// that failed to reconcile.
+	MaxBackoff time.Duration
+
+	// MetricsPort is the port that the Prometheus metrics are served on. The
+	// metrics are not served when it is zero.
+	MetricsPort int
+}
+
+// Validate the options and apply defaults.
+func (o *Options) Validate() error {
+	if o.Interval <= 0 {
+		return fmt.Errorf("invalid --interval %s, must be greater than zero", o.Interval)
+	}
+	if o.Jitter < 0 || o.Jitter > 1 {
+		return fmt.Errorf("invalid --jitter %v, must be between 0 and 1", o.Jitter)
+	}
+	if o.Concurrency <= 0 {
+		return fmt.Errorf("invalid --concurrency %d, must be greater than zero", o.Concurrency)
+	}
+	if o.Backoff <= 0 {
+		return fmt.Errorf("invalid --backoff %s, must be greater than zero", o.Backoff)
+	}
+	if o.MaxBackoff < o.Backoff {
+		return fmt.Errorf("invalid --max-backoff %s, must be at least --backoff %s", o.MaxBackoff, o.Backoff)
+	}
+	if o.MetricsPort < 0 {
+		return fmt.Errorf("invalid --metrics-port %d", o.MetricsPort)
+	}
+	return nil
+}
+
+// GetNamespace returns the namespace filter used to list the installations.
+func (o Options) GetNamespace() string {
+	if o.AllNamespaces {
+		return "*"
+	}
+	return o.Namespace
+}
+
+// backoffState tracks an installation that failed to reconcile.
+type backoffState struct {
+	// failures is the number of consecutive times the installation failed to reconcile.
+	failures int
+
+	// retryAt is when the installation may be reconciled again.
+	retryAt time.Time
+}
+
+// Operator periodically reconciles the installations in the store with their
+// desired state.
+type Operator struct {
+	porter  *porter.Porter
+	opts    Options
+	Metrics *Metrics
+
+	// backoff tracks the installations that failed to reconcile, by installation key.
+	mu      sync.Mutex // protects backoff
+	backoff map[string]backoffState
+
+	// now returns the current time, so that backoff can be tested.
+	now func() time.Time
+
+	// reconcile runs the action that brings an installation back in sync,
+	// when it is out of sync, and returns the plan that was determined.
+	// It is called for multiple installations at the same time.
+	reconcile func(ctx context.Context, opts porter.ReconcileOptions) (porter.InstallationPlan, error)
+}
+
+// NewOperator creates an Operator. p must already be connected.
+func NewOperator(p *porter.Porter, opts Options) *Operator {
+	return &Operator{
+		porter:    p,
+		opts:      opts,
+		Metrics:   NewMetrics(),
+		backoff:   make(map[string]backoffState),
+		now:       time.Now,
+		reconcile: p.PlanAndReconcileInstallation,
+	}
+}
+
+// Run reconciles the installations every interval until ctx is cancelled.
+func (o *Operator) Run(ctx context.Context) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if o.opts.MetricsPort > 0 {
+		srv := &http.Server{
+			Addr:              fmt.Sprintf(":%d", o.opts.MetricsPort),
+			Handler:           promhttp.HandlerFor(o.Metrics.Registry, promhttp.HandlerOpts{}),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				_ = log.Errorf("could not serve the operator metrics: %w", err)
+			}
+		}()
+		defer srv.Close()
+		log.Infof("Serving metrics on %s", srv.Addr)
+	}
+
+	log.Infof("Reconciling installations every %s", o.opts.Interval)
+	for {
+		if err := o.ReconcileAll(ctx); err != nil {
+			// Keep running, the store may be temporarily unavailable
+			_ = log.Error(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Stopping the operator")
+			return nil
+		case <-time.After(o.nextInterval()):
+		}
+	}
+}
+
+// nextInterval returns the time to wait before the next check, including jitter.
+func (o *Operator) nextInterval() time.Duration {
+	interval := o.opts.Interval
+	if maxJitter := int64(float64(interval) * o.opts.Jitter); maxJitter > 0 {
+		interval += time.Duration(rand.Int63n(maxJitter))
+	}
+	return interval
+}
+
+// ReconcileAll lists the installations and reconciles those that are out of
+// sync with their desired state, up to the configured concurrency at a time.
+func (o *Operator) ReconcileAll(ctx context.Context) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	installations, err := o.porter.Installations.ListInstallations(ctx, storage.ListOptions{
+		Namespace: o.opts.GetNamespace(),
+	})
+	if err != nil {
+		return log.Errorf("could not list installations: %w", err)
+	}
+
+	reconcilable := make(map[string]bool, len(installations))
+	queue := make(chan storage.Installation, len(installations))
+	for _, inst := range installations {
+		if !shouldReconcile(inst) {
+			continue
+		}
+		reconcilable[inst.String()] = true
+		queue <- inst
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for inst := range queue {
+				result := o.reconcileInstallation(ctx, inst)
+				o.Metrics.reconciliations.WithLabelValues(result).Inc()
+			}
+		}()
+	}
+	wg.Wait()
+	o.pruneBackoff(reconcilable)
+
+	o.Metrics.cycles.Inc()
+	o.Metrics.lastCycle.Set(float64(o.now().Unix()))
+	return nil
+}
+
+// shouldReconcile returns false for installations that can never be
+// reconciled: those that are uninstalled, or that do not reference a bundle
+// that can be pulled.
+func shouldReconcile(inst storage.Installation) bool {
+	if inst.IsUninstalled() {
+		return false
+	}
+	_, ok, err := inst.Bundle.GetBundleReference()
+	return ok && err == nil
+}
+
+// reconcileInstallation reconciles an installation when it is out of sync,
+// and returns the result.
+func (o *Operator) reconcileInstallation(ctx context.Context, inst storage.Installation) string {
+	ctx, log := tracing.StartSpan(ctx,
+		attribute.String("installation", inst.Name), attribute.String("namespace", inst.Namespace))
+	defer log.EndSpan()
+
+	key := inst.String()
+	if retryAt, ok := o.getRetryTime(key); ok && o.now().Before(retryAt) {
+		log.Debugf("Skipping installation %s until %s because it failed to reconcile", key, retryAt.Format(time.RFC3339))
+		return ResultBackoff
+	}
+
+	start := time.Now()
+	defer func() { o.Metrics.duration.Observe(time.Since(start).Seconds()) }()
+
+	opts := porter.ReconcileOptions{
+		Namespace:    inst.Namespace,
+		Name:         inst.Name,
+		Installation: inst,
+	}
+	plan, err := o.reconcile(ctx, opts)
+	if err != nil {
+		o.recordFailure(ctx, key, fmt.Errorf("could not reconcile installation %s: %w", key, err))
+		return ResultFailed
+	}
+
+	o.recordSuccess(key)
+	if plan.Action == porter.PlanActionNone {
+		return ResultInSync
+	}
+
+	log.Infof("Reconciled installation %s with the %s action because %s", key, plan.Action, plan.Reason)
+	return ResultReconciled
+}
+
+// getRetryTime returns when an installation that failed to reconcile may be retried.
+func (o *Operator) getRetryTime(key string) (time.Time, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	state, ok := o.backoff[key]
+	return state.retryAt, ok
+}
+
+// recordFailure backs off from reconciling the installation, doubling the
+// time to wait after each consecutive failure.
+func (o *Operator) recordFailure(ctx context.Context, key string, err error) {
+	log := tracing.LoggerFromContext(ctx)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	state := o.backoff[key]
+	state.failures++
+
+	wait := o.opts.Backoff
+	for i := 1; i < state.failures && wait < o.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > o.opts.MaxBackoff {
+		wait = o.opts.MaxBackoff
+	}
+	state.retryAt = o.now().Add(wait)
+	o.backoff[key] = state
+	o.Metrics.backoff.Set(float64(len(o.backoff)))
+
+	_ = log.Errorf("%w, retrying in %s", err, wait)
+}
+
+// recordSuccess clears the backoff of the installation.
+func (o *Operator) recordSuccess(key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.backoff, key)
+	o.Metrics.backoff.Set(float64(len(o.backoff)))
+}
+
+// pruneBackoff clears the backoff of installations that were deleted, or that
+// can no longer be reconciled, so that they are not tracked forever.
+func (o *Operator) pruneBackoff(reconcilable map[string]bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for key := range o.backoff {
+		if !reconcilable[key] {
+			delete(o.backoff, key)
+		}
+	}
+	o.Metrics.backoff.Set(float64(len(o.backoff)))
+}
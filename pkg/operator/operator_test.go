@@ -0,0 +1,298 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/porter"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricValue returns the value of a counter or gauge.
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	var metric dto.Metric
+	require.NoError(t, m.Write(&metric))
+	if metric.Counter != nil {
+		return metric.Counter.GetValue()
+	}
+	return metric.Gauge.GetValue()
+}
+
+func validOptions() Options {
+	return Options{
+		Interval:    DefaultInterval,
+		Jitter:      DefaultJitter,
+		Concurrency: DefaultConcurrency,
+		Backoff:     DefaultBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		modify  func(o *Options)
+		wantErr string
+	}{
+		{name: "valid", modify: func(o *Options) {}},
+		{name: "no interval", modify: func(o *Options) { o.Interval = 0 }, wantErr: "invalid --interval"},
+		{name: "negative jitter", modify: func(o *Options) { o.Jitter = -0.1 }, wantErr: "invalid --jitter"},
+		{name: "jitter too large", modify: func(o *Options) { o.Jitter = 1.5 }, wantErr: "invalid --jitter"},
+		{name: "no concurrency", modify: func(o *Options) { o.Concurrency = 0 }, wantErr: "invalid --concurrency"},
+		{name: "no backoff", modify: func(o *Options) { o.Backoff = 0 }, wantErr: "invalid --backoff"},
+		{name: "max backoff too small", modify: func(o *Options) { o.MaxBackoff = time.Second }, wantErr: "invalid --max-backoff"},
+		{name: "negative metrics port", modify: func(o *Options) { o.MetricsPort = -1 }, wantErr: "invalid --metrics-port"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := validOptions()
+			tc.modify(&opts)
+			err := opts.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// testOperator is an operator with a stubbed reconcile function.
+type testOperator struct {
+	*Operator
+	mu         sync.Mutex
+	plans      map[string]porter.InstallationPlan
+	failing    map[string]bool
+	reconciled []string
+}
+
+func newTestOperator(t *testing.T, p *porter.TestPorter) *testOperator {
+	opts := validOptions()
+	opts.AllNamespaces = true
+	require.NoError(t, opts.Validate())
+
+	o := &testOperator{
+		Operator: NewOperator(p.Porter, opts),
+		plans:    map[string]porter.InstallationPlan{},
+		failing:  map[string]bool{},
+	}
+	o.reconcile = func(_ context.Context, opts porter.ReconcileOptions) (porter.InstallationPlan, error) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		plan, ok := o.plans[opts.Name]
+		if !ok {
+			return porter.InstallationPlan{Action: porter.PlanActionNone}, nil
+		}
+		if o.failing[opts.Name] {
+			return plan, errors.New("bundle failed")
+		}
+		o.reconciled = append(o.reconciled, opts.Name)
+		return plan, nil
+	}
+	return o
+}
+
+func createInstallation(p *porter.TestPorter, namespace string, name string, modify ...func(i *storage.Installation)) {
+	inst := storage.NewInstallation(namespace, name)
+	inst.Bundle = storage.OCIReferenceParts{Repository: "example.com/mybuns", Version: "v1.0.0"}
+	p.TestInstallations.CreateInstallation(inst, modify...)
+}
+
+func TestOperator_ReconcileAll(t *testing.T) {
+	ctx := context.Background()
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	createInstallation(p, "", "in-sync")
+	createInstallation(p, "dev", "drifted")
+	createInstallation(p, "", "no-bundle", func(i *storage.Installation) { i.Bundle = storage.OCIReferenceParts{} })
+	createInstallation(p, "", "uninstalled", func(i *storage.Installation) {
+		installed := time.Now()
+		uninstalled := installed.Add(time.Minute)
+		i.Status.Installed = &installed
+		i.Status.Uninstalled = &uninstalled
+	})
+
+	o := newTestOperator(t, p)
+	o.plans["drifted"] = porter.InstallationPlan{Action: cnab.ActionUpgrade, Reason: "the bundle version changed"}
+	o.plans["no-bundle"] = porter.InstallationPlan{Action: cnab.ActionInstall}
+	o.plans["uninstalled"] = porter.InstallationPlan{Action: cnab.ActionUninstall}
+
+	require.NoError(t, o.ReconcileAll(ctx))
+
+	assert.Equal(t, []string{"drifted"}, o.reconciled, "only the drifted installation should be reconciled")
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.reconciliations.WithLabelValues(ResultInSync)))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.reconciliations.WithLabelValues(ResultReconciled)))
+	assert.Equal(t, 0.0, metricValue(t, o.Metrics.reconciliations.WithLabelValues(ResultFailed)))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.cycles))
+	assert.NotZero(t, metricValue(t, o.Metrics.lastCycle))
+}
+
+func TestOperator_ReconcileAll_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	for _, name := range []string{"api", "db", "web", "worker"} {
+		createInstallation(p, "", name)
+	}
+
+	o := newTestOperator(t, p)
+	o.opts.Concurrency = 2
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	started := make(chan struct{})
+	var startedOnce sync.Once
+	o.reconcile = func(_ context.Context, _ porter.ReconcileOptions) (porter.InstallationPlan, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		if inFlight == o.opts.Concurrency {
+			startedOnce.Do(func() { close(started) })
+		}
+		mu.Unlock()
+
+		// Wait until the configured number of installations are reconciled at the same time
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+		}
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return porter.InstallationPlan{Action: porter.PlanActionNone}, nil
+	}
+
+	require.NoError(t, o.ReconcileAll(ctx))
+	assert.Equal(t, 2, maxInFlight, "installations should be reconciled up to the configured concurrency at a time")
+	assert.Equal(t, 4.0, metricValue(t, o.Metrics.reconciliations.WithLabelValues(ResultInSync)))
+}
+
+func TestOperator_ReconcileAll_Backoff(t *testing.T) {
+	ctx := context.Background()
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	createInstallation(p, "", "broken")
+
+	o := newTestOperator(t, p)
+	o.plans["broken"] = porter.InstallationPlan{Action: cnab.ActionInstall}
+	o.failing["broken"] = true
+
+	now := time.Date(2020, time.April, 18, 1, 2, 3, 0, time.UTC)
+	o.now = func() time.Time { return now }
+
+	// The first failure backs off for the initial backoff
+	require.NoError(t, o.ReconcileAll(ctx))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.reconciliations.WithLabelValues(ResultFailed)))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.backoff))
+	retryAt, ok := o.getRetryTime("/broken")
+	require.True(t, ok, "the installation should be in backoff")
+	assert.Equal(t, now.Add(DefaultBackoff), retryAt)
+
+	// The installation is skipped until the backoff expires
+	require.NoError(t, o.ReconcileAll(ctx))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.reconciliations.WithLabelValues(ResultBackoff)))
+
+	// Each consecutive failure doubles the backoff
+	now = retryAt
+	require.NoError(t, o.ReconcileAll(ctx))
+	retryAt, _ = o.getRetryTime("/broken")
+	assert.Equal(t, now.Add(2*DefaultBackoff), retryAt)
+
+	// The backoff is cleared once the installation is reconciled
+	now = retryAt
+	o.failing["broken"] = false
+	require.NoError(t, o.ReconcileAll(ctx))
+	assert.Equal(t, []string{"broken"}, o.reconciled)
+	assert.Equal(t, 0.0, metricValue(t, o.Metrics.backoff))
+	_, ok = o.getRetryTime("/broken")
+	assert.False(t, ok, "the backoff should be cleared")
+}
+
+func TestOperator_ReconcileAll_PruneBackoff(t *testing.T) {
+	ctx := context.Background()
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	createInstallation(p, "", "broken")
+	createInstallation(p, "", "deleted")
+
+	o := newTestOperator(t, p)
+	o.plans["broken"] = porter.InstallationPlan{Action: cnab.ActionInstall}
+	o.plans["deleted"] = porter.InstallationPlan{Action: cnab.ActionInstall}
+	o.failing["broken"] = true
+	o.failing["deleted"] = true
+
+	require.NoError(t, o.ReconcileAll(ctx))
+	assert.Equal(t, 2.0, metricValue(t, o.Metrics.backoff))
+
+	require.NoError(t, p.Installations.RemoveInstallation(ctx, "", "deleted"))
+	require.NoError(t, o.ReconcileAll(ctx))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.backoff))
+	_, ok := o.getRetryTime("/deleted")
+	assert.False(t, ok, "the backoff of a deleted installation should be cleared")
+	_, ok = o.getRetryTime("/broken")
+	assert.True(t, ok, "the backoff of an existing installation should be kept")
+}
+
+func TestOperator_RecordFailure_MaxBackoff(t *testing.T) {
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	o := newTestOperator(t, p)
+	now := time.Now()
+	o.now = func() time.Time { return now }
+
+	for i := 0; i < 20; i++ {
+		o.recordFailure(context.Background(), "/broken", errors.New("bundle failed"))
+	}
+	retryAt, _ := o.getRetryTime("/broken")
+	assert.Equal(t, now.Add(DefaultMaxBackoff), retryAt, "the backoff should not exceed the maximum")
+}
+
+func TestOperator_NextInterval(t *testing.T) {
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	o := newTestOperator(t, p)
+	for i := 0; i < 100; i++ {
+		interval := o.nextInterval()
+		assert.GreaterOrEqual(t, interval, DefaultInterval)
+		assert.Less(t, interval, DefaultInterval+time.Duration(float64(DefaultInterval)*DefaultJitter))
+	}
+
+	o.opts.Jitter = 0
+	assert.Equal(t, DefaultInterval, o.nextInterval(), "the interval should not change without jitter")
+}
+
+func TestOperator_Run_StopsWhenCancelled(t *testing.T) {
+	p := porter.NewTestPorter(t)
+	defer p.Close()
+
+	createInstallation(p, "", "in-sync")
+
+	o := newTestOperator(t, p)
+	ctx, cancel := context.WithCancel(context.Background())
+	o.reconcile = func(_ context.Context, _ porter.ReconcileOptions) (porter.InstallationPlan, error) {
+		// Stop the operator while it is checking the installations
+		cancel()
+		return porter.InstallationPlan{Action: porter.PlanActionNone}, nil
+	}
+
+	require.NoError(t, o.Run(ctx))
+	assert.Equal(t, 1.0, metricValue(t, o.Metrics.cycles), "the operator should stop after the current check completes")
+}
@@ -15,6 +15,28 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// CommandError is returned when a package command fails. The underlying error
+// is available with errors.As, for example to get the exit code of the command
+// from an *exec.ExitError.
+type CommandError struct {
+	// Command that was executed.
+	Command string
+
+	// Stderr captured from the command.
+	Stderr string
+
+	// Err is the error returned when running the command.
+	Err error
+}
+
+func (e CommandError) Error() string {
+	return fmt.Sprintf("package command failed %s\n%s", e.Command, e.Stderr)
+}
+
+func (e CommandError) Unwrap() error {
+	return e.Err
+}
+
 type Runner struct {
 	*portercontext.Context
 	// pkgDir is the absolute path to where the package is installed
@@ -102,7 +124,7 @@ func (r *Runner) Run(ctx context.Context, commandOpts pkgmgmt.CommandOptions) er
 	err = cmd.Wait()
 	if err != nil {
 		// Include stderr in the error, otherwise it just includes the exit code
-		err = fmt.Errorf("package command failed %s\n%s", prettyCmd, cmdStderr)
+		err = CommandError{Command: prettyCmd, Stderr: cmdStderr.String(), Err: err}
 		// Do not flag this as an error in the logs because we often call mixins to see if they support a command
 		// and if they don't it's not an error, e.g. not all mixins support lint or schema
 		span.Debug(err.Error())
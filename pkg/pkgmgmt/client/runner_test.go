@@ -1,6 +1,7 @@
 package client
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,3 +35,11 @@ func TestRunner_Validate_MissingExecutable(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "package not found")
 }
+
+func TestCommandError(t *testing.T) {
+	exitErr := errors.New("exit status 2")
+	err := CommandError{Command: "/mixins/exec/runtimes/exec-runtime install", Stderr: "oops", Err: exitErr}
+
+	assert.Equal(t, "package command failed /mixins/exec/runtimes/exec-runtime install\noops", err.Error())
+	assert.ErrorIs(t, err, exitErr, "the error from the command should be available so that callers can get the exit code")
+}
@@ -15,13 +15,16 @@ import (
 )
 
 type ApplyOptions struct {
+	printer.PrintOptions
+
 	Namespace string
 	File      string
 
 	// Force the installation to be re-applied regardless of anything being changed or not
 	Force bool
 
-	// DryRun only checks if the changes would trigger a bundle run
+	// DryRun only checks if the changes would trigger a bundle run, and prints
+	// the plan in the requested format.
 	DryRun bool
 }
 
@@ -47,18 +50,39 @@ func (o *ApplyOptions) Validate(cxt *portercontext.Context, args []string) error
 		return fmt.Errorf("invalid file argument %s, must be a file not a directory", o.File)
 	}
 
-	return nil
+	return o.PrintOptions.Validate(ApplyDefaultFormat, ApplyAllowedFormats)
 }
 
 func (p *Porter) InstallationApply(ctx context.Context, opts ApplyOptions) error {
 	ctx, log := tracing.StartSpan(ctx)
 	defer log.EndSpan()
 
+	reconcileOpts, err := p.loadInstallationFile(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		plan, err := p.PlanInstallation(ctx, reconcileOpts)
+		if err != nil {
+			return err
+		}
+		return p.printInstallationPlan(plan, opts.Format)
+	}
+	return p.ReconcileInstallation(ctx, reconcileOpts)
+}
+
+// loadInstallationFile reads an installation document and merges it with the
+// existing installation, if present, so that it is ready to be reconciled.
+func (p *Porter) loadInstallationFile(ctx context.Context, opts ApplyOptions) (ReconcileOptions, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
 	log.Debugf("Reading input file %s", opts.File)
 
 	namespace, err := p.getNamespaceFromFile(opts)
 	if err != nil {
-		return log.Error(err)
+		return ReconcileOptions{}, log.Error(err)
 	}
 
 	if log.ShouldLog(zapcore.DebugLevel) {
@@ -69,39 +93,46 @@ func (p *Porter) InstallationApply(ctx context.Context, opts ApplyOptions) error
 
 	var input DisplayInstallation
 	if err := encoding.UnmarshalFile(p.FileSystem, opts.File, &input); err != nil {
-		return log.Errorf("unable to parse %s as an installation document: %w", opts.File, err)
+		return ReconcileOptions{}, log.Errorf("unable to parse %s as an installation document: %w", opts.File, err)
 	}
 	input.Namespace = namespace
 	inputInstallation, err := input.ConvertToInstallation()
 	if err != nil {
-		return log.Error(err)
+		return ReconcileOptions{}, log.Error(err)
 	}
 
 	installation, err := p.Installations.GetInstallation(ctx, inputInstallation.Namespace, inputInstallation.Name)
 	if err != nil {
 		if !errors.Is(err, storage.ErrNotFound{}) {
-			return log.Errorf("could not query for an existing installation document for %s: %w", inputInstallation, err)
+			return ReconcileOptions{}, log.Errorf("could not query for an existing installation document for %s: %w", inputInstallation, err)
 		}
 
 		// Create a new installation
 		installation = storage.NewInstallation(input.Namespace, input.Name)
-		log.Info("Creating a new installation", attribute.String("installation", installation.String()))
+		if opts.DryRun {
+			log.Debug("Planning a new installation", attribute.String("installation", installation.String()))
+		} else {
+			log.Info("Creating a new installation", attribute.String("installation", installation.String()))
+		}
 	} else {
-		log.Infof("Updating %s installation\n", installation)
+		if opts.DryRun {
+			log.Debugf("Planning changes to %s installation\n", installation)
+		} else {
+			log.Infof("Updating %s installation\n", installation)
+		}
 	}
 
 	installation.Apply(inputInstallation.InstallationSpec)
 	checkStrategy := p.GetSchemaCheckStrategy(ctx)
 	if err := installation.Validate(ctx, checkStrategy); err != nil {
-		return log.Errorf("invalid installation: %w", err)
+		return ReconcileOptions{}, log.Errorf("invalid installation: %w", err)
 	}
 
-	reconcileOpts := ReconcileOptions{
+	return ReconcileOptions{
 		Namespace:    input.Namespace,
 		Name:         input.Name,
 		Installation: installation,
 		Force:        opts.Force,
 		DryRun:       opts.DryRun,
-	}
-	return p.ReconcileInstallation(ctx, reconcileOpts)
+	}, nil
 }
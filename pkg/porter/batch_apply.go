@@ -0,0 +1,489 @@
+package porter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"get.porter.sh/porter/pkg/encoding"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// BatchApplyDefaultParallelism is the default number of installations
+	// that are reconciled at the same time by porter apply.
+	BatchApplyDefaultParallelism = 4
+
+	BatchApplyStatusApplied   = "applied"
+	BatchApplyStatusPlanned   = "planned"
+	BatchApplyStatusUpToDate  = "up-to-date"
+	BatchApplyStatusSucceeded = "succeeded"
+	BatchApplyStatusFailed    = "failed"
+	BatchApplyStatusSkipped   = "skipped"
+)
+
+// BatchApplyOptions are the options for applying a set of documents with porter apply.
+type BatchApplyOptions struct {
+	printer.PrintOptions
+
+	// Files and directories containing the documents to apply.
+	Files []string
+
+	// Namespace used for documents that do not define a namespace.
+	Namespace string
+
+	// Force installations to be re-applied regardless of anything being changed or not.
+	Force bool
+
+	// DryRun prints what would be applied without saving any changes or executing any bundles.
+	DryRun bool
+
+	// Parallelism is the maximum number of installations reconciled at the same time.
+	Parallelism int
+}
+
+func (o *BatchApplyOptions) Validate(cxt *portercontext.Context) error {
+	if len(o.Files) == 0 {
+		return errors.New("at least one file or directory must be specified with --file")
+	}
+
+	for _, file := range o.Files {
+		if _, err := cxt.FileSystem.Stat(file); err != nil {
+			return fmt.Errorf("invalid --file %s: %w", file, err)
+		}
+	}
+
+	if o.Parallelism == 0 {
+		o.Parallelism = BatchApplyDefaultParallelism
+	} else if o.Parallelism < 0 {
+		return fmt.Errorf("invalid --parallelism %d, must be greater than zero", o.Parallelism)
+	}
+
+	return o.PrintOptions.Validate(ApplyDefaultFormat, ApplyAllowedFormats)
+}
+
+// BatchApplyResult is the outcome of applying a single document.
+type BatchApplyResult struct {
+	// Kind is the schemaType of the document.
+	Kind string `json:"kind" yaml:"kind"`
+
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Name      string `json:"name" yaml:"name"`
+
+	// File that defined the document.
+	File string `json:"file" yaml:"file"`
+
+	// Action executed for an installation: install, upgrade, uninstall or none.
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+
+	// Status of the document: applied, planned, up-to-date, succeeded, failed or skipped.
+	Status string `json:"status" yaml:"status"`
+
+	// Message explains the status, such as why an installation was skipped or failed.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// batchDocument is a document loaded by porter apply.
+type batchDocument struct {
+	file       string
+	schemaType string
+}
+
+// batchInstallation is an installation document and the installations in the
+// batch that must be reconciled before it.
+type batchInstallation struct {
+	opts      ReconcileOptions
+	file      string
+	dependsOn []*batchInstallation
+	result    BatchApplyResult
+
+	// output of the worker that reconciled the installation.
+	output bytes.Buffer
+
+	// done is closed once the installation is reconciled.
+	done chan struct{}
+}
+
+func (i *batchInstallation) String() string {
+	return displayInstallationName(i.opts.Namespace, i.opts.Name)
+}
+
+// BatchApply applies every credential set, parameter set and installation
+// document found in the specified files and directories. Credential and
+// parameter sets are applied first, then installations are reconciled in
+// dependency order, and a summary of the results is printed.
+func (p *Porter) BatchApply(ctx context.Context, opts BatchApplyOptions) error {
+	ctx, log := tracing.StartSpan(ctx, attribute.Bool("dryRun", opts.DryRun))
+	defer log.EndSpan()
+
+	docs, err := p.loadBatchDocuments(ctx, opts.Files)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	var results []BatchApplyResult
+	var installDocs []batchDocument
+	for _, doc := range docs {
+		applyOpts := ApplyOptions{Namespace: opts.Namespace, File: doc.file, DryRun: opts.DryRun}
+		switch doc.schemaType {
+		case storage.SchemaTypeCredentialSet:
+			results = append(results, p.applyBatchCredentialSet(ctx, applyOpts))
+		case storage.SchemaTypeParameterSet:
+			results = append(results, p.applyBatchParameterSet(ctx, applyOpts))
+		case storage.SchemaTypeInstallation:
+			installDocs = append(installDocs, doc)
+		}
+	}
+
+	for _, r := range results {
+		if r.Status == BatchApplyStatusFailed {
+			// Do not reconcile installations when the sets that they may use could not be applied
+			for _, doc := range installDocs {
+				results = append(results, BatchApplyResult{
+					Kind:    storage.SchemaTypeInstallation,
+					File:    doc.file,
+					Status:  BatchApplyStatusSkipped,
+					Message: "a credential or parameter set could not be applied",
+				})
+			}
+			installDocs = nil
+			break
+		}
+	}
+
+	installs := make([]*batchInstallation, 0, len(installDocs))
+	for _, doc := range installDocs {
+		reconcileOpts, err := p.loadInstallationFile(ctx, ApplyOptions{Namespace: opts.Namespace, File: doc.file, Force: opts.Force, DryRun: opts.DryRun})
+		if err != nil {
+			return err
+		}
+		installs = append(installs, &batchInstallation{
+			opts: reconcileOpts,
+			file: doc.file,
+		})
+	}
+
+	ordered, err := p.resolveBatchDependencies(ctx, installs)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	p.reconcileBatchInstallations(ctx, ordered, opts.Parallelism)
+	for _, inst := range installs {
+		results = append(results, inst.result)
+	}
+
+	if err := p.printBatchApplyResults(results, opts.Format); err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		if r.Status == BatchApplyStatusFailed || r.Status == BatchApplyStatusSkipped {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return log.Errorf("%d of %d documents could not be applied", failed, len(results))
+	}
+	return nil
+}
+
+// loadBatchDocuments finds the yaml and json documents in the specified files
+// and directories, and identifies the type of each document.
+func (p *Porter) loadBatchDocuments(ctx context.Context, paths []string) ([]batchDocument, error) {
+	log := tracing.LoggerFromContext(ctx)
+
+	var files []string
+	for _, path := range paths {
+		info, err := p.FileSystem.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not access %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := p.FileSystem.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not list the files in %s: %w", path, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+
+	docs := make([]batchDocument, 0, len(files))
+	for _, file := range files {
+		var doc struct {
+			SchemaType string `json:"schemaType" yaml:"schemaType"`
+		}
+		if err := encoding.UnmarshalFile(p.FileSystem, file, &doc); err != nil {
+			return nil, fmt.Errorf("invalid file %s: %w", file, err)
+		}
+
+		switch {
+		case doc.SchemaType == "":
+			log.Warnf("Skipping %s because it does not define a schemaType", file)
+			continue
+		case strings.EqualFold(doc.SchemaType, storage.SchemaTypeCredentialSet):
+			doc.SchemaType = storage.SchemaTypeCredentialSet
+		case strings.EqualFold(doc.SchemaType, storage.SchemaTypeParameterSet):
+			doc.SchemaType = storage.SchemaTypeParameterSet
+		case strings.EqualFold(doc.SchemaType, storage.SchemaTypeInstallation):
+			doc.SchemaType = storage.SchemaTypeInstallation
+		default:
+			return nil, fmt.Errorf("unsupported schemaType %s in %s, must be one of %s, %s or %s",
+				doc.SchemaType, file, storage.SchemaTypeCredentialSet, storage.SchemaTypeParameterSet, storage.SchemaTypeInstallation)
+		}
+		docs = append(docs, batchDocument{file: file, schemaType: doc.SchemaType})
+	}
+
+	return docs, nil
+}
+
+func (p *Porter) applyBatchCredentialSet(ctx context.Context, opts ApplyOptions) BatchApplyResult {
+	result := BatchApplyResult{Kind: storage.SchemaTypeCredentialSet, File: opts.File}
+
+	creds, err := p.loadCredentialSetFile(ctx, opts)
+	if err == nil {
+		result.Namespace = creds.Namespace
+		result.Name = creds.Name
+		if opts.DryRun {
+			result.Status = BatchApplyStatusPlanned
+			return result
+		}
+		err = p.Credentials.UpsertCredentialSet(ctx, creds.CredentialSet)
+	}
+	if err != nil {
+		result.Status = BatchApplyStatusFailed
+		result.Message = err.Error()
+		return result
+	}
+	result.Status = BatchApplyStatusApplied
+	return result
+}
+
+func (p *Porter) applyBatchParameterSet(ctx context.Context, opts ApplyOptions) BatchApplyResult {
+	result := BatchApplyResult{Kind: storage.SchemaTypeParameterSet, File: opts.File}
+
+	params, err := p.loadParameterSetFile(ctx, opts)
+	if err == nil {
+		result.Namespace = params.Namespace
+		result.Name = params.Name
+		if opts.DryRun {
+			result.Status = BatchApplyStatusPlanned
+			return result
+		}
+		err = p.Parameters.UpsertParameterSet(ctx, params.ParameterSet)
+	}
+	if err != nil {
+		result.Status = BatchApplyStatusFailed
+		result.Message = err.Error()
+		return result
+	}
+	result.Status = BatchApplyStatusApplied
+	return result
+}
+
+// resolveBatchDependencies links each installation to the installations in
+// the batch listed in its dependsOn field, and returns the installations in the
+// order in which they should be reconciled. Dependencies that are not in the
+// batch must already exist. An error is returned when the dependencies form a cycle.
+func (p *Porter) resolveBatchDependencies(ctx context.Context, installs []*batchInstallation) ([]*batchInstallation, error) {
+	byName := make(map[string]*batchInstallation, len(installs))
+	for _, inst := range installs {
+		key := inst.String()
+		if _, ok := byName[key]; ok {
+			return nil, fmt.Errorf("the %s installation is defined more than once", key)
+		}
+		byName[key] = inst
+	}
+
+	for _, inst := range installs {
+		for _, dep := range inst.opts.Installation.DependsOn {
+			if key, value, ok := strings.Cut(dep, "="); ok {
+				// A label selector depends on every installation in the batch with the label
+				var matched bool
+				for _, other := range installs {
+					if other != inst && other.opts.Installation.Labels[key] == value {
+						inst.dependsOn = append(inst.dependsOn, other)
+						matched = true
+					}
+				}
+				if !matched {
+					return nil, fmt.Errorf("the %s installation depends on %s but no installations in the batch have that label", inst, dep)
+				}
+				continue
+			}
+
+			namespace, name := inst.opts.Namespace, dep
+			if ns, n, ok := strings.Cut(dep, "/"); ok {
+				namespace, name = ns, n
+			}
+
+			if other, ok := byName[displayInstallationName(namespace, name)]; ok {
+				if other == inst {
+					return nil, fmt.Errorf("the %s installation cannot depend on itself", inst)
+				}
+				inst.dependsOn = append(inst.dependsOn, other)
+				continue
+			}
+
+			if _, err := p.Installations.GetInstallation(ctx, namespace, name); err != nil {
+				if errors.Is(err, storage.ErrNotFound{}) {
+					return nil, fmt.Errorf("the %s installation depends on %s which does not exist", inst, displayInstallationName(namespace, name))
+				}
+				return nil, fmt.Errorf("could not retrieve the %s dependency of the %s installation: %w", displayInstallationName(namespace, name), inst, err)
+			}
+		}
+	}
+
+	// Order the installations after their dependencies and detect cycles with a depth-first search
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*batchInstallation]int, len(installs))
+	ordered := make([]*batchInstallation, 0, len(installs))
+	var visit func(inst *batchInstallation, path []string) error
+	visit = func(inst *batchInstallation, path []string) error {
+		path = append(path, inst.String())
+		switch state[inst] {
+		case visiting:
+			return fmt.Errorf("the installations have a circular dependency: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		state[inst] = visiting
+		for _, dep := range inst.dependsOn {
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+		state[inst] = visited
+		ordered = append(ordered, inst)
+		return nil
+	}
+	for _, inst := range installs {
+		if err := visit(inst, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// reconcileBatchInstallations reconciles the installations in the order
+// returned by resolveBatchDependencies, with up to parallelism workers at the
+// same time. An installation is only reconciled after its dependencies have
+// completed successfully. Each worker writes to its own output, which is
+// printed in order once the installation is reconciled.
+func (p *Porter) reconcileBatchInstallations(ctx context.Context, ordered []*batchInstallation, parallelism int) {
+	queue := make(chan *batchInstallation, len(ordered))
+	for _, inst := range ordered {
+		inst.done = make(chan struct{})
+		queue <- inst
+	}
+	close(queue)
+
+	// The installations are queued after their dependencies, so a worker only
+	// waits for installations that another worker has already started
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism && i < len(ordered); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for inst := range queue {
+				for _, dep := range inst.dependsOn {
+					<-dep.done
+				}
+				p.NewWorker(&inst.output).reconcileBatchInstallation(ctx, inst)
+				close(inst.done)
+			}
+		}()
+	}
+
+	for _, inst := range ordered {
+		<-inst.done
+		p.writeWorkerOutput(inst.output.Bytes())
+	}
+	wg.Wait()
+}
+
+func (p *Porter) reconcileBatchInstallation(ctx context.Context, inst *batchInstallation) {
+	inst.result = BatchApplyResult{
+		Kind:      storage.SchemaTypeInstallation,
+		Namespace: inst.opts.Namespace,
+		Name:      inst.opts.Name,
+		File:      inst.file,
+	}
+
+	for _, dep := range inst.dependsOn {
+		if dep.result.Status == BatchApplyStatusFailed || dep.result.Status == BatchApplyStatusSkipped {
+			inst.result.Status = BatchApplyStatusSkipped
+			inst.result.Message = fmt.Sprintf("the %s dependency was not applied", dep)
+			return
+		}
+	}
+
+	var plan InstallationPlan
+	var err error
+	if inst.opts.DryRun {
+		plan, err = p.PlanInstallation(ctx, inst.opts)
+	} else {
+		plan, err = p.reconcileInstallation(ctx, inst.opts)
+	}
+	inst.result.Action = plan.Action
+	switch {
+	case err != nil:
+		inst.result.Status = BatchApplyStatusFailed
+		inst.result.Message = err.Error()
+	case plan.Action == PlanActionNone:
+		inst.result.Status = BatchApplyStatusUpToDate
+	case inst.opts.DryRun:
+		inst.result.Status = BatchApplyStatusPlanned
+		inst.result.Message = plan.Reason
+	default:
+		inst.result.Status = BatchApplyStatusSucceeded
+		inst.result.Message = plan.Reason
+	}
+}
+
+func (p *Porter) printBatchApplyResults(results []BatchApplyResult, format printer.Format) error {
+	switch format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, results)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, results)
+	case printer.FormatPlaintext, "":
+		row := func(v interface{}) []string {
+			r, ok := v.(BatchApplyResult)
+			if !ok {
+				return nil
+			}
+			return []string{r.Kind, r.Namespace, r.Name, r.Action, r.Status, r.Message}
+		}
+		return printer.PrintTable(p.Out, results, row, "KIND", "NAMESPACE", "NAME", "ACTION", "STATUS", "MESSAGE")
+	default:
+		return fmt.Errorf("invalid format: %s", format)
+	}
+}
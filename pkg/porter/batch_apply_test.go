@@ -0,0 +1,246 @@
+package porter
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/cnab"
+	cnabtooci "get.porter.sh/porter/pkg/cnab/cnab-to-oci"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchApplyOptions_Validate(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+	require.NoError(t, p.FileSystem.MkdirAll("env", pkg.FileModeDirectory))
+
+	t.Run("defaults", func(t *testing.T) {
+		opts := BatchApplyOptions{Files: []string{"env"}}
+		require.NoError(t, opts.Validate(p.Context))
+		assert.Equal(t, BatchApplyDefaultParallelism, opts.Parallelism)
+		assert.Equal(t, printer.FormatPlaintext, opts.Format)
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		opts := BatchApplyOptions{}
+		require.EqualError(t, opts.Validate(p.Context), "at least one file or directory must be specified with --file")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		opts := BatchApplyOptions{Files: []string{"missing.yaml"}}
+		require.ErrorContains(t, opts.Validate(p.Context), "invalid --file missing.yaml")
+	})
+
+	t.Run("invalid parallelism", func(t *testing.T) {
+		opts := BatchApplyOptions{Files: []string{"env"}, Parallelism: -1}
+		require.EqualError(t, opts.Validate(p.Context), "invalid --parallelism -1, must be greater than zero")
+	})
+}
+
+func TestPorter_BatchApply(t *testing.T) {
+	bun, err := cnab.LoadBundle(portercontext.New(), filepath.Join("testdata/bundle.json"))
+	require.NoError(t, err)
+
+	const (
+		credsFile = `schemaType: CredentialSet
+schemaVersion: 1.0.1
+name: mycreds
+credentials:
+  - name: my-first-cred
+    source:
+      value: cred-1
+`
+		paramsFile = `schemaType: ParameterSet
+schemaVersion: 1.0.1
+name: myparams
+parameters:
+  - name: my-second-param
+    source:
+      value: spring-music-demo
+`
+		dbFile = `schemaType: Installation
+schemaVersion: 1.0.2
+name: db
+labels:
+  tier: data
+bundle:
+  repository: example.com/mybuns
+  version: 0.1.0
+`
+		appFile = `schemaType: Installation
+schemaVersion: 1.0.2
+name: app
+dependsOn:
+  - tier=data
+bundle:
+  repository: example.com/mybuns
+  version: 0.1.0
+`
+	)
+
+	setup := func(t *testing.T, files map[string]string) *TestPorter {
+		p := NewTestPorter(t)
+		p.TestRegistry.MockPullBundle = func(ctx context.Context, ref cnab.OCIReference, opts cnabtooci.RegistryOptions) (cnab.BundleReference, error) {
+			return cnab.BundleReference{Reference: ref, Definition: bun}, nil
+		}
+		require.NoError(t, p.FileSystem.MkdirAll("env", pkg.FileModeDirectory))
+		for name, contents := range files {
+			require.NoError(t, p.FileSystem.WriteFile(filepath.Join("env", name), []byte(contents), pkg.FileModeWritable))
+		}
+		return p
+	}
+
+	batchApply := func(t *testing.T, p *TestPorter, opts BatchApplyOptions) ([]BatchApplyResult, error) {
+		opts.Files = []string{"env"}
+		opts.RawFormat = "json"
+		require.NoError(t, opts.Validate(p.Context))
+		applyErr := p.BatchApply(p.RootContext, opts)
+
+		// Logs are printed in tests, skip ahead to the summary
+		output := p.TestConfig.TestContext.GetOutput()
+		start := strings.Index(output, "\n[\n")
+		require.GreaterOrEqual(t, start, 0, "the summary was not printed")
+
+		var results []BatchApplyResult
+		require.NoError(t, json.Unmarshal([]byte(output[start:]), &results))
+		return results, applyErr
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		// app sorts before db, but must be ordered after it
+		p := setup(t, map[string]string{
+			"app.yaml":    appFile,
+			"creds.yaml":  credsFile,
+			"db.yaml":     dbFile,
+			"notes.txt":   "ignored",
+			"params.yaml": paramsFile,
+		})
+		defer p.Close()
+
+		results, err := batchApply(t, p, BatchApplyOptions{Namespace: "dev", DryRun: true})
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+
+		assert.Equal(t, storage.SchemaTypeCredentialSet, results[0].Kind, "sets should be applied before installations")
+		assert.Equal(t, "mycreds", results[0].Name)
+		assert.Equal(t, BatchApplyStatusPlanned, results[0].Status)
+		assert.Equal(t, storage.SchemaTypeParameterSet, results[1].Kind)
+		assert.Equal(t, BatchApplyStatusPlanned, results[1].Status)
+
+		for _, r := range results[2:] {
+			assert.Equal(t, storage.SchemaTypeInstallation, r.Kind)
+			assert.Equal(t, "dev", r.Namespace)
+			assert.Equal(t, cnab.ActionInstall, r.Action)
+			assert.Equal(t, BatchApplyStatusPlanned, r.Status)
+		}
+
+		_, err = p.Credentials.GetCredentialSet(p.RootContext, "dev", "mycreds")
+		require.ErrorIs(t, err, storage.ErrNotFound{}, "the credential set should not be saved")
+		_, err = p.Installations.GetInstallation(p.RootContext, "dev", "db")
+		require.ErrorIs(t, err, storage.ErrNotFound{}, "the installation should not be saved")
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		files := map[string]string{"db.yaml": dbFile, "app.yaml": appFile}
+		for _, name := range []string{"api", "web", "worker"} {
+			files[name+".yaml"] = strings.Replace(appFile, "name: app", "name: "+name, 1)
+		}
+		p := setup(t, files)
+		defer p.Close()
+
+		results, err := batchApply(t, p, BatchApplyOptions{Namespace: "dev", DryRun: true, Parallelism: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+		for _, r := range results {
+			assert.Equal(t, BatchApplyStatusPlanned, r.Status, r.Name)
+		}
+	})
+
+	t.Run("applies sets", func(t *testing.T) {
+		p := setup(t, map[string]string{
+			"creds.yaml":  credsFile,
+			"params.yaml": paramsFile,
+		})
+		defer p.Close()
+
+		results, err := batchApply(t, p, BatchApplyOptions{Namespace: "dev"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, r := range results {
+			assert.Equal(t, BatchApplyStatusApplied, r.Status)
+		}
+
+		_, err = p.Credentials.GetCredentialSet(p.RootContext, "dev", "mycreds")
+		require.NoError(t, err, "the credential set should be saved")
+		_, err = p.Parameters.GetParameterSet(p.RootContext, "dev", "myparams")
+		require.NoError(t, err, "the parameter set should be saved")
+	})
+
+	t.Run("circular dependency", func(t *testing.T) {
+		p := setup(t, map[string]string{
+			"app.yaml": appFile,
+			"db.yaml":  strings.Replace(dbFile, "labels:", "dependsOn:\n  - app\nlabels:", 1),
+		})
+		defer p.Close()
+
+		opts := BatchApplyOptions{Files: []string{"env"}, Namespace: "dev"}
+		require.NoError(t, opts.Validate(p.Context))
+		err := p.BatchApply(p.RootContext, opts)
+		require.ErrorContains(t, err, "circular dependency")
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		p := setup(t, map[string]string{
+			"app.yaml": strings.Replace(appFile, "tier=data", "test/db", 1),
+		})
+		defer p.Close()
+
+		opts := BatchApplyOptions{Files: []string{"env"}, Namespace: "dev"}
+		require.NoError(t, opts.Validate(p.Context))
+		err := p.BatchApply(p.RootContext, opts)
+		require.EqualError(t, err, "the dev/app installation depends on test/db which does not exist")
+	})
+
+	t.Run("unknown schemaType", func(t *testing.T) {
+		p := setup(t, map[string]string{
+			"other.yaml": "schemaType: Bundle\n",
+		})
+		defer p.Close()
+
+		opts := BatchApplyOptions{Files: []string{"env"}}
+		require.NoError(t, opts.Validate(p.Context))
+		err := p.BatchApply(p.RootContext, opts)
+		require.ErrorContains(t, err, "unsupported schemaType Bundle in env/other.yaml")
+	})
+}
+
+func TestPorter_ReconcileBatchInstallations_SkipsFailedDependencies(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+
+	// The db installation fails to reconcile because its bundle cannot be pulled
+	db := &batchInstallation{
+		opts: ReconcileOptions{Namespace: "dev", Name: "db", DryRun: true, Installation: storage.NewInstallation("dev", "db")},
+	}
+	app := &batchInstallation{
+		opts: ReconcileOptions{Namespace: "dev", Name: "app", DryRun: true, Installation: storage.NewInstallation("dev", "app")},
+	}
+	app.opts.Installation.DependsOn = []string{"db"}
+
+	ordered, err := p.resolveBatchDependencies(p.RootContext, []*batchInstallation{app, db})
+	require.NoError(t, err)
+	assert.Equal(t, []*batchInstallation{db, app}, ordered, "dependencies should be reconciled first")
+
+	p.reconcileBatchInstallations(p.RootContext, ordered, 2)
+	assert.Equal(t, BatchApplyStatusFailed, db.result.Status)
+	assert.Equal(t, BatchApplyStatusSkipped, app.result.Status)
+	assert.Equal(t, "the dev/db dependency was not applied", app.result.Message)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"get.porter.sh/porter/pkg/cnab"
 	"get.porter.sh/porter/pkg/editor"
 	"get.porter.sh/porter/pkg/encoding"
 	"get.porter.sh/porter/pkg/generator"
@@ -16,6 +19,7 @@ import (
 	"get.porter.sh/porter/pkg/storage"
 	"get.porter.sh/porter/pkg/tracing"
 	dtprinter "github.com/carolynvs/datetime-printer"
+	"github.com/hashicorp/go-multierror"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -417,20 +421,39 @@ func (p *Porter) CredentialsApply(ctx context.Context, o ApplyOptions) error {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.EndSpan()
 
+	creds, err := p.loadCredentialSetFile(ctx, o)
+	if err != nil {
+		return err
+	}
+
+	err = p.Credentials.UpsertCredentialSet(ctx, creds.CredentialSet)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.Out, "Applied %s credential set\n", creds)
+	return nil
+}
+
+// loadCredentialSetFile reads and validates a credential set document so that it is ready to be saved.
+func (p *Porter) loadCredentialSetFile(ctx context.Context, o ApplyOptions) (DisplayCredentialSet, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+
 	span.Debugf("Reading input file %s...\n", o.File)
 	namespace, err := p.getNamespaceFromFile(o)
 	if err != nil {
-		return span.Error(err)
+		return DisplayCredentialSet{}, span.Error(err)
 	}
 
 	var creds DisplayCredentialSet
 	err = encoding.UnmarshalFile(p.FileSystem, o.File, &creds)
 	if err != nil {
-		return span.Error(fmt.Errorf("could not load %s as a credential set: %w", o.File, err))
+		return DisplayCredentialSet{}, span.Error(fmt.Errorf("could not load %s as a credential set: %w", o.File, err))
 	}
 
 	if err = creds.Validate(ctx, p.GetSchemaCheckStrategy(ctx)); err != nil {
-		return span.Error(fmt.Errorf("invalid credential set: %w", err))
+		return DisplayCredentialSet{}, span.Error(fmt.Errorf("invalid credential set: %w", err))
 	}
 
 	creds.Namespace = namespace
@@ -438,16 +461,10 @@ func (p *Porter) CredentialsApply(ctx context.Context, o ApplyOptions) error {
 
 	err = p.Credentials.Validate(ctx, creds.CredentialSet)
 	if err != nil {
-		return span.Error(fmt.Errorf("credential set is invalid: %w", err))
+		return DisplayCredentialSet{}, span.Error(fmt.Errorf("credential set is invalid: %w", err))
 	}
 
-	err = p.Credentials.UpsertCredentialSet(ctx, creds.CredentialSet)
-	if err != nil {
-		return err
-	}
-
-	fmt.Fprintf(p.Out, "Applied %s credential set\n", creds)
-	return nil
+	return creds, nil
 }
 
 func (p *Porter) getNamespaceFromFile(o ApplyOptions) (string, error) {
@@ -469,6 +486,105 @@ func (p *Porter) getNamespaceFromFile(o ApplyOptions) (string, error) {
 	return o.Namespace, nil
 }
 
+// CredentialValidateOptions are the options for Porter's credentials validate command.
+type CredentialValidateOptions struct {
+	BundleReferenceOptions
+
+	// Action that the credential set is validated for. When empty, the
+	// credentials used by every action are validated.
+	Action string
+}
+
+// Validate the args and flags provided to Porter's credentials validate command.
+func (o *CredentialValidateOptions) Validate(ctx context.Context, args []string, p *Porter) error {
+	if err := validateCredentialName(args); err != nil {
+		return err
+	}
+
+	if err := o.BundleReferenceOptions.Validate(ctx, args, p); err != nil {
+		return err
+	}
+
+	if o.File == "" && o.CNABFile == "" && o.Reference == "" {
+		return errors.New("no bundle specified. Either --reference, --file or --cnab-file must be specified or the current directory must contain a porter.yaml file")
+	}
+	return nil
+}
+
+// ValidateCredentialSet checks that a credential set can be used with a bundle,
+// without running the bundle. Every credential in the set is resolved, and
+// the credentials that the bundle requires must be defined in the set.
+// All the problems that are found are returned together.
+func (p *Porter) ValidateCredentialSet(ctx context.Context, opts CredentialValidateOptions) error {
+	ctx, span := tracing.StartSpan(ctx,
+		attribute.String("namespace", opts.Namespace),
+		attribute.String("name", opts.Name),
+		attribute.String("action", opts.Action),
+	)
+	defer span.EndSpan()
+
+	cs, err := p.Credentials.GetCredentialSet(ctx, opts.Namespace, opts.Name)
+	if err != nil {
+		return span.Error(fmt.Errorf("unable to get credential set %s: %w", opts.Name, err))
+	}
+
+	bundleRef, err := opts.GetBundleReference(ctx, p)
+	if err != nil {
+		return span.Error(err)
+	}
+	bun := bundleRef.Definition
+	if err := validateBundleAction(bun, opts.Action); err != nil {
+		return span.Error(err)
+	}
+
+	var problems *multierror.Error
+	if err := p.Credentials.Validate(ctx, cs); err != nil {
+		problems = multierror.Append(problems, err)
+	} else if _, err := p.Credentials.ResolveAll(ctx, cs, cs.Keys()); err != nil {
+		problems = multierror.Append(problems, err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(bun.Credentials)) {
+		cred := bun.Credentials[name]
+		if !cred.Required || (opts.Action != "" && !cred.AppliesTo(opts.Action)) {
+			continue
+		}
+		if !cs.HasCredential(name) {
+			problems = multierror.Append(problems, fmt.Errorf("credential %s is required by the bundle%s but is not defined in the credential set", name, describeAction(opts.Action)))
+		}
+	}
+
+	if err := problems.ErrorOrNil(); err != nil {
+		return span.Error(fmt.Errorf("credential set %s is not valid for bundle %s: %w", cs.Name, bun.Name, err))
+	}
+
+	// Note that we are not using span.Info because the command's output must go to standard out
+	fmt.Fprintf(p.Out, "Credential set %s is valid for bundle %s\n", cs.Name, bun.Name)
+	return nil
+}
+
+// validateBundleAction checks that the action is defined by the bundle.
+// An empty action represents every action and is always valid.
+func validateBundleAction(bun cnab.ExtendedBundle, action string) error {
+	switch action {
+	case "", cnab.ActionInstall, cnab.ActionUpgrade, cnab.ActionUninstall:
+		return nil
+	}
+	if _, ok := bun.Actions[action]; !ok {
+		return fmt.Errorf("action %s is not defined by bundle %s", action, bun.Name)
+	}
+	return nil
+}
+
+// describeAction returns a description of the action used in validation
+// messages, which is empty when every action is validated.
+func describeAction(action string) string {
+	if action == "" {
+		return ""
+	}
+	return fmt.Sprintf(" for the %s action", action)
+}
+
 // CredentialCreateOptions represent options for Porter's credential create command
 type CredentialCreateOptions struct {
 	FileName   string
@@ -11,10 +11,12 @@ import (
 
 	"get.porter.sh/porter/pkg/portercontext"
 	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
 	"get.porter.sh/porter/pkg/storage"
 	"get.porter.sh/porter/pkg/test"
 	"get.porter.sh/porter/pkg/yaml"
 	"get.porter.sh/porter/tests"
+	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -654,3 +656,60 @@ func TestPorter_CredentialsApply(t *testing.T) {
 		assert.Equal(t, "/path/to/kool-config", cs.Credentials[0].Source.Hint, "unexpected credential mapping value")
 	})
 }
+
+func TestPorter_ValidateCredentialSet(t *testing.T) {
+	ctx := context.Background()
+
+	validate := func(t *testing.T, p *TestPorter, cs storage.CredentialSet, action string) error {
+		p.TestConfig.TestContext.AddTestFile("testdata/bundle.json", "/bundle.json")
+		require.NoError(t, p.TestCredentials.InsertCredentialSet(ctx, cs))
+
+		opts := CredentialValidateOptions{Action: action}
+		opts.CNABFile = "/bundle.json"
+		require.NoError(t, opts.Validate(ctx, []string{cs.Name}, p.Porter))
+		return p.ValidateCredentialSet(ctx, opts)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		cs := storage.NewCredentialSet("", "mycreds",
+			secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: host.SourceValue, Hint: "abc"}},
+			secrets.SourceMap{Name: "my-second-cred", Source: secrets.Source{Strategy: host.SourceValue, Hint: "def"}},
+			secrets.SourceMap{Name: "unused", Source: secrets.Source{Strategy: host.SourceValue, Hint: "ghi"}},
+		)
+		require.NoError(t, validate(t, p, cs, ""))
+		assert.Equal(t, "Credential set mycreds is valid for bundle porter-hello\n", p.TestConfig.TestContext.GetOutput())
+	})
+
+	t.Run("reports every problem", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		cs := storage.NewCredentialSet("", "mycreds",
+			secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: host.SourceEnv, Hint: "PORTER_TEST_UNDEFINED_CRED"}},
+		)
+		err := validate(t, p, cs, "")
+		require.ErrorContains(t, err, "credential set mycreds is not valid for bundle porter-hello")
+		assert.ErrorContains(t, err, "unable to resolve credential mycreds.my-first-cred")
+		assert.ErrorContains(t, err, "credential my-second-cred is required by the bundle but is not defined in the credential set")
+	})
+
+	t.Run("undefined action", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		err := validate(t, p, storage.NewCredentialSet("", "mycreds"), "missing")
+		require.EqualError(t, err, "action missing is not defined by bundle porter-hello")
+	})
+
+	t.Run("no bundle", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		opts := CredentialValidateOptions{}
+		err := opts.Validate(ctx, []string{"mycreds"}, p.Porter)
+		require.ErrorContains(t, err, "no bundle specified")
+	})
+}
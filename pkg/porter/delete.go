@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"get.porter.sh/porter/pkg/cnab"
 	"get.porter.sh/porter/pkg/portercontext"
+	secretsplugins "get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
 )
 
 const installationDeleteTmpl = "deleting installation records for %s...\n"
@@ -53,5 +57,73 @@ func (p *Porter) DeleteInstallation(ctx context.Context, opts DeleteOptions) err
 	}
 
 	fmt.Fprintf(p.Out, installationDeleteTmpl, opts.Name)
-	return p.Installations.RemoveInstallation(ctx, opts.Namespace, opts.Name)
+	return p.removeInstallation(ctx, opts.Namespace, opts.Name)
+}
+
+// removeInstallation removes the installation with its runs, results and
+// outputs, and then deletes the secrets that Porter created for their
+// sensitive parameters and outputs.
+func (p *Porter) removeInstallation(ctx context.Context, namespace string, name string) error {
+	installation, err := p.Installations.GetInstallation(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	keys, err := p.listInstallationSecrets(ctx, installation)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Installations.RemoveInstallation(ctx, namespace, name); err != nil {
+		return err
+	}
+
+	p.removeSecrets(ctx, keys)
+	return nil
+}
+
+// listInstallationSecrets returns the keys of the secrets created by Porter
+// for the sensitive parameters of an installation, and the sensitive
+// parameters and outputs of its runs.
+func (p *Porter) listInstallationSecrets(ctx context.Context, installation storage.Installation) ([]string, error) {
+	keys := storage.SanitizedParameterSecrets(installation.Parameters.Parameters, installation.ID)
+
+	runs, results, err := p.Installations.ListRuns(ctx, installation.Namespace, installation.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not list runs for installation %s: %w", installation, err)
+	}
+	for _, run := range runs {
+		keys = append(keys, storage.SanitizedParameterSecrets(run.Parameters.Parameters, run.ID)...)
+		for _, result := range results[run.ID] {
+			outputs, err := p.Installations.ListOutputs(ctx, result.ID)
+			if err != nil {
+				return nil, fmt.Errorf("could not list outputs for result %s: %w", result.ID, err)
+			}
+			for _, output := range outputs {
+				if output.Key != "" {
+					keys = append(keys, output.Key)
+				}
+			}
+		}
+	}
+	return keys, nil
+}
+
+// removeSecrets deletes secrets that Porter created for sensitive parameters
+// and outputs. The records that referenced the secrets have already been
+// removed, so a warning is logged when the secrets cannot be deleted instead
+// of failing the command.
+func (p *Porter) removeSecrets(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	log := tracing.LoggerFromContext(ctx)
+	err := p.Sanitizer.RemoveSecrets(ctx, keys)
+	if errors.Is(err, secretsplugins.ErrNotImplemented) {
+		log.Warnf("%d secrets created for sensitive parameters and outputs were not removed because the secrets plugin does not support deleting secrets: %s",
+			len(keys), strings.Join(keys, ", "))
+	} else if err != nil {
+		log.Warnf("Some secrets created for sensitive parameters and outputs were not removed: %s", err)
+	}
 }
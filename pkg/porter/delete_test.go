@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/secrets"
 	"get.porter.sh/porter/pkg/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -84,3 +85,43 @@ func TestDeleteInstallation(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteInstallation_RemovesSecrets(t *testing.T) {
+	ctx := context.Background()
+	p := NewTestPorter(t)
+	defer p.Close()
+
+	inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "test"), func(i *storage.Installation) {
+		i.Parameters.Parameters = []secrets.SourceMap{
+			{Name: "password", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: i.ID + "-password"}},
+			{Name: "token", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: "my-token"}},
+		}
+	})
+	run := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionUninstall, cnab.ExtendedBundle{}), func(r *storage.Run) {
+		r.Parameters.Parameters = []secrets.SourceMap{
+			{Name: "password", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: r.ID + "-password"}},
+		}
+	})
+	result := p.TestInstallations.CreateResult(run.NewResult(cnab.StatusSucceeded))
+	p.TestInstallations.CreateOutput(result.NewOutput("kubeconfig", nil), func(o *storage.Output) {
+		o.Key = run.ID + "-kubeconfig"
+	})
+
+	createdSecrets := []string{inst.ID + "-password", run.ID + "-password", run.ID + "-kubeconfig"}
+	for _, key := range append(createdSecrets, "my-token") {
+		require.NoError(t, p.TestSecrets.Create(ctx, secrets.SourceSecret, key, "supersecret"))
+	}
+
+	opts := DeleteOptions{}
+	opts.Namespace = "dev"
+	opts.Name = "test"
+	opts.Force = true
+	require.NoError(t, p.DeleteInstallation(ctx, opts))
+
+	for _, key := range createdSecrets {
+		_, err := p.TestSecrets.Resolve(ctx, secrets.SourceSecret, key)
+		require.Errorf(t, err, "secret %s should have been deleted with the installation", key)
+	}
+	_, err := p.TestSecrets.Resolve(ctx, secrets.SourceSecret, "my-token")
+	require.NoError(t, err, "secrets that were not created by Porter should be kept")
+}
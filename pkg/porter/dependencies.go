@@ -496,7 +496,7 @@ func (e *dependencyExecutioner) finalizeExecute(ctx context.Context, dep *queued
 	// will resolve to false and thus be a no-op
 	if uninstallOpts.shouldDelete() {
 		span.Infof(installationDeleteTmpl, e.depArgs.Installation)
-		return e.Installations.RemoveInstallation(ctx, e.depArgs.Installation.Namespace, e.depArgs.Installation.Name)
+		return e.porter.removeInstallation(ctx, e.depArgs.Installation.Namespace, e.depArgs.Installation.Name)
 	}
 	return nil
 }
@@ -0,0 +1,456 @@
+package porter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"github.com/cnabio/cnab-go/secrets/host"
+)
+
+const (
+	// DriftChangeAdded indicates that a value is defined now but wasn't used by the last run.
+	DriftChangeAdded = "added"
+
+	// DriftChangeRemoved indicates that a value was used by the last run but is no longer defined.
+	DriftChangeRemoved = "removed"
+
+	// DriftChangeModified indicates that a value is different from the value used by the last run.
+	DriftChangeModified = "changed"
+)
+
+// DriftOptions are the options for reporting how installations have drifted
+// from their last run.
+type DriftOptions struct {
+	printer.PrintOptions
+
+	// Name of the installation to check. When empty, all installations in the namespace are checked.
+	Name string
+
+	// Namespace of the installations to check.
+	Namespace string
+
+	// AllNamespaces checks installations in every namespace.
+	AllNamespaces bool
+}
+
+// Validate the drift options and arguments.
+func (o *DriftOptions) Validate(args []string) error {
+	switch len(args) {
+	case 0:
+	case 1:
+		o.Name = strings.TrimSpace(args[0])
+	default:
+		return fmt.Errorf("only one positional argument may be specified, the installation name, but multiple were received: %s", args)
+	}
+
+	if o.Name != "" && o.AllNamespaces {
+		return errors.New("--all-namespaces cannot be used when an installation name is specified")
+	}
+
+	return o.PrintOptions.Validate(ShowDefaultFormat, ShowAllowedFormats)
+}
+
+// GetNamespace returns the namespace filter for the installations to check.
+func (o DriftOptions) GetNamespace() string {
+	if o.AllNamespaces {
+		return "*"
+	}
+	return o.Namespace
+}
+
+// InstallationDrift reports how the desired state of an installation differs
+// from the state used by its last run.
+type InstallationDrift struct {
+	// Namespace of the installation.
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	// Name of the installation.
+	Name string `json:"name" yaml:"name"`
+
+	// InSync is true when applying the installation would not trigger a bundle run.
+	InSync bool `json:"inSync" yaml:"inSync"`
+
+	// Reason explains why the installation could not be compared against a previous run.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// LastRunID is the id of the run that the installation was compared against.
+	LastRunID string `json:"lastRunId,omitempty" yaml:"lastRunId,omitempty"`
+
+	// Bundle is set when the bundle definition has changed.
+	Bundle *BundleDrift `json:"bundle,omitempty" yaml:"bundle,omitempty"`
+
+	// Parameters that have changed, sorted by name.
+	Parameters []ValueDrift `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// ParameterSets is set when parameter sets were added or removed from the installation.
+	// Changing the sets only triggers a bundle run when the resolved parameters change.
+	ParameterSets *SetDrift `json:"parameterSets,omitempty" yaml:"parameterSets,omitempty"`
+
+	// CredentialSets is set when credential sets were added or removed from the installation.
+	// Changing the sets only triggers a bundle run when the resolved credentials change.
+	CredentialSets *SetDrift `json:"credentialSets,omitempty" yaml:"credentialSets,omitempty"`
+
+	// Credentials whose mappings have changed, sorted by name.
+	Credentials []ValueDrift `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+
+	// CredentialValuesChanged is true when the credential mappings are the same
+	// but one or more of the resolved credential values are different, for
+	// example when a secret was rotated.
+	CredentialValuesChanged bool `json:"credentialValuesChanged,omitempty" yaml:"credentialValuesChanged,omitempty"`
+}
+
+// BundleDrift describes a change to the bundle used by an installation.
+type BundleDrift struct {
+	OldReference string `json:"oldReference" yaml:"oldReference"`
+	NewReference string `json:"newReference" yaml:"newReference"`
+	OldVersion   string `json:"oldVersion,omitempty" yaml:"oldVersion,omitempty"`
+	NewVersion   string `json:"newVersion,omitempty" yaml:"newVersion,omitempty"`
+	OldDigest    string `json:"oldDigest,omitempty" yaml:"oldDigest,omitempty"`
+	NewDigest    string `json:"newDigest,omitempty" yaml:"newDigest,omitempty"`
+}
+
+// ValueDrift describes a change to a named parameter or credential.
+// The old and new values are not included for sensitive values.
+type ValueDrift struct {
+	Name      string `json:"name" yaml:"name"`
+	Change    string `json:"change" yaml:"change"`
+	Sensitive bool   `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+	OldValue  string `json:"oldValue,omitempty" yaml:"oldValue,omitempty"`
+	NewValue  string `json:"newValue,omitempty" yaml:"newValue,omitempty"`
+}
+
+// SetDrift describes the named sets that were added or removed from an installation.
+type SetDrift struct {
+	Added   []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// PrintInstallationsDrift reports how the installations have changed since their last run.
+func (p *Porter) PrintInstallationsDrift(ctx context.Context, opts DriftOptions) error {
+	results, err := p.GetInstallationsDrift(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, results)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, results)
+	case printer.FormatPlaintext:
+		if len(results) == 0 {
+			fmt.Fprintln(p.Out, "No installations found")
+			return nil
+		}
+		for i, drift := range results {
+			if i > 0 {
+				fmt.Fprintln(p.Out)
+			}
+			printInstallationDrift(p.Out, drift)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
+
+// GetInstallationsDrift compares the installations against their last run.
+func (p *Porter) GetInstallationsDrift(ctx context.Context, opts DriftOptions) ([]InstallationDrift, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+
+	var installations []storage.Installation
+	if opts.Name != "" {
+		inst, err := p.Installations.GetInstallation(ctx, opts.Namespace, opts.Name)
+		if err != nil {
+			return nil, span.Error(err)
+		}
+		installations = []storage.Installation{inst}
+	} else {
+		var err error
+		installations, err = p.Installations.ListInstallations(ctx, storage.ListOptions{Namespace: opts.GetNamespace()})
+		if err != nil {
+			return nil, span.Error(err)
+		}
+	}
+
+	results := make([]InstallationDrift, 0, len(installations))
+	for _, inst := range installations {
+		drift, err := p.GetInstallationDrift(ctx, inst)
+		if err != nil {
+			return nil, span.Errorf("error checking installation %s/%s for drift: %w", inst.Namespace, inst.Name, err)
+		}
+		results = append(results, drift)
+	}
+	return results, nil
+}
+
+// GetInstallationDrift compares the desired state of an installation against
+// its last run, using the same logic as IsInstallationInSync, and reports
+// exactly what has changed.
+func (p *Porter) GetInstallationDrift(ctx context.Context, inst storage.Installation) (InstallationDrift, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+
+	drift := InstallationDrift{Namespace: inst.Namespace, Name: inst.Name}
+
+	// Compare against the same run that reconcile uses
+	var lastRun *storage.Run
+	r, err := p.Installations.GetLastRun(ctx, inst.Namespace, inst.Name)
+	if err == nil {
+		lastRun = &r
+	} else if !errors.Is(err, storage.ErrNotFound{}) {
+		return drift, err
+	}
+
+	if status, decided := checkInstallationState(inst, lastRun); decided {
+		drift.InSync = status.inSync
+		drift.Reason = status.reason
+		return drift, nil
+	}
+	drift.LastRunID = lastRun.ID
+
+	action, err := p.prepareReconcileAction(ctx, &inst)
+	if err != nil {
+		return drift, err
+	}
+
+	// Whether the installation is in sync is decided by reconcile, the
+	// differences below explain what has changed. Both use the same resolved
+	// parameters and credentials.
+	in := newSyncInputs(inst, lastRun, action)
+	status, err := p.checkInstallationInSync(ctx, in)
+	if err != nil {
+		return drift, err
+	}
+	drift.InSync = status.inSync
+
+	newRef, err := action.GetOptions().GetBundleReference(ctx, p)
+	if err != nil {
+		return drift, err
+	}
+	b := newRef.Definition
+
+	// Has the bundle definition changed?
+	if lastRun.BundleDigest != newRef.Digest.String() {
+		drift.Bundle = &BundleDrift{
+			OldReference: lastRun.BundleReference,
+			NewReference: newRef.Reference.String(),
+			OldVersion:   lastRun.Bundle.Version,
+			NewVersion:   b.Version,
+			OldDigest:    lastRun.BundleDigest,
+			NewDigest:    newRef.Digest.String(),
+		}
+	}
+
+	// Which parameters have changed?
+	oldParams, newParams, err := in.parameters(ctx, p)
+	if err != nil {
+		return drift, err
+	}
+	drift.Parameters = diffParameters(b, oldParams, newParams)
+
+	// Which parameter and credential sets were added or removed?
+	drift.ParameterSets = diffSets(lastRun.ParameterSets, inst.ParameterSets)
+	drift.CredentialSets = diffSets(lastRun.CredentialSets, inst.CredentialSets)
+
+	// Have the credential mappings or their values changed?
+	newCreds, newCredsDigest, err := in.credentials(ctx, p)
+	if err != nil {
+		return drift, err
+	}
+	drift.Credentials = diffCredentials(lastRun.Credentials.Credentials, newCreds.Credentials)
+	if len(drift.Credentials) == 0 && lastRun.CredentialsDigest != newCredsDigest {
+		drift.CredentialValuesChanged = true
+	}
+
+	return drift, nil
+}
+
+func diffParameters(b cnab.ExtendedBundle, oldParams map[string]string, newParams map[string]string) []ValueDrift {
+	var changes []ValueDrift
+	addChange := func(name string, change string, oldValue string, newValue string) {
+		c := ValueDrift{Name: name, Change: change}
+		if b.IsSensitiveParameter(name) {
+			c.Sensitive = true
+		} else {
+			c.OldValue = oldValue
+			c.NewValue = newValue
+		}
+		changes = append(changes, c)
+	}
+
+	for name, oldValue := range oldParams {
+		newValue, ok := newParams[name]
+		if !ok {
+			addChange(name, DriftChangeRemoved, oldValue, "")
+		} else if oldValue != newValue {
+			addChange(name, DriftChangeModified, oldValue, newValue)
+		}
+	}
+	for name, newValue := range newParams {
+		if _, ok := oldParams[name]; !ok {
+			addChange(name, DriftChangeAdded, "", newValue)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func diffCredentials(oldCreds []secrets.SourceMap, newCreds []secrets.SourceMap) []ValueDrift {
+	oldSources := make(map[string]secrets.Source, len(oldCreds))
+	for _, cred := range oldCreds {
+		oldSources[cred.Name] = cred.Source
+	}
+	newSources := make(map[string]secrets.Source, len(newCreds))
+	for _, cred := range newCreds {
+		newSources[cred.Name] = cred.Source
+	}
+
+	// Credential sources usually only reference where the value is stored, so
+	// they are safe to include in the report. The value strategy is the
+	// exception, its hint is the credential value.
+	var changes []ValueDrift
+	addChange := func(name string, change string, oldSource *secrets.Source, newSource *secrets.Source) {
+		c := ValueDrift{Name: name, Change: change}
+		if (oldSource != nil && oldSource.Strategy == host.SourceValue) || (newSource != nil && newSource.Strategy == host.SourceValue) {
+			c.Sensitive = true
+		} else {
+			if oldSource != nil {
+				c.OldValue = oldSource.Strategy + ":" + oldSource.Hint
+			}
+			if newSource != nil {
+				c.NewValue = newSource.Strategy + ":" + newSource.Hint
+			}
+		}
+		changes = append(changes, c)
+	}
+
+	for name, oldSource := range oldSources {
+		oldSource := oldSource
+		newSource, ok := newSources[name]
+		if !ok {
+			addChange(name, DriftChangeRemoved, &oldSource, nil)
+		} else if oldSource != newSource {
+			addChange(name, DriftChangeModified, &oldSource, &newSource)
+		}
+	}
+	for name, newSource := range newSources {
+		newSource := newSource
+		if _, ok := oldSources[name]; !ok {
+			addChange(name, DriftChangeAdded, nil, &newSource)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func diffSets(oldSets []string, newSets []string) *SetDrift {
+	var result SetDrift
+	for _, name := range newSets {
+		if !containsString(oldSets, name) {
+			result.Added = append(result.Added, name)
+		}
+	}
+	for _, name := range oldSets {
+		if !containsString(newSets, name) {
+			result.Removed = append(result.Removed, name)
+		}
+	}
+
+	if len(result.Added) == 0 && len(result.Removed) == 0 {
+		return nil
+	}
+	return &result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func printInstallationDrift(out io.Writer, drift InstallationDrift) {
+	status := "in sync"
+	if !drift.InSync {
+		status = "out of sync"
+	}
+	fmt.Fprintf(out, "%s: %s\n", displayInstallationName(drift.Namespace, drift.Name), status)
+
+	if drift.Reason != "" {
+		fmt.Fprintf(out, "  Reason: %s\n", drift.Reason)
+		return
+	}
+	fmt.Fprintf(out, "  Last Run: %s\n", drift.LastRunID)
+
+	if drift.Bundle != nil {
+		fmt.Fprintf(out, "  Bundle: %s (%s) -> %s (%s)\n",
+			drift.Bundle.OldReference, drift.Bundle.OldVersion, drift.Bundle.NewReference, drift.Bundle.NewVersion)
+	}
+	printValueDrift(out, "Parameters", drift.Parameters)
+	printSetDrift(out, "Parameter Sets", drift.ParameterSets)
+	printSetDrift(out, "Credential Sets", drift.CredentialSets)
+	printValueDrift(out, "Credentials", drift.Credentials)
+	if drift.CredentialValuesChanged {
+		fmt.Fprintln(out, "  Credentials: one or more resolved credential values have changed")
+	}
+}
+
+func printValueDrift(out io.Writer, title string, changes []ValueDrift) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "  %s:\n", title)
+	for _, c := range changes {
+		switch {
+		case c.Sensitive:
+			fmt.Fprintf(out, "    %s: %s (sensitive)\n", c.Name, c.Change)
+		case c.Change == DriftChangeAdded:
+			fmt.Fprintf(out, "    %s: %s %s\n", c.Name, c.Change, c.NewValue)
+		case c.Change == DriftChangeRemoved:
+			fmt.Fprintf(out, "    %s: %s %s\n", c.Name, c.Change, c.OldValue)
+		default:
+			fmt.Fprintf(out, "    %s: %s %s -> %s\n", c.Name, c.Change, c.OldValue, c.NewValue)
+		}
+	}
+}
+
+func printSetDrift(out io.Writer, title string, changes *SetDrift) {
+	if changes == nil {
+		return
+	}
+
+	fmt.Fprintf(out, "  %s:\n", title)
+	if len(changes.Added) > 0 {
+		fmt.Fprintf(out, "    added: %s\n", strings.Join(changes.Added, ", "))
+	}
+	if len(changes.Removed) > 0 {
+		fmt.Fprintf(out, "    removed: %s\n", strings.Join(changes.Removed, ", "))
+	}
+}
+
+func displayInstallationName(namespace string, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,262 @@
+package porter
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	cnabtooci "get.porter.sh/porter/pkg/cnab/cnab-to-oci"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriftOptions_Validate(t *testing.T) {
+	t.Run("all installations", func(t *testing.T) {
+		opts := DriftOptions{AllNamespaces: true}
+		require.NoError(t, opts.Validate(nil))
+		assert.Equal(t, "*", opts.GetNamespace())
+		assert.Equal(t, printer.FormatPlaintext, opts.Format)
+	})
+
+	t.Run("installation name", func(t *testing.T) {
+		opts := DriftOptions{PrintOptions: printer.PrintOptions{RawFormat: "json"}}
+		require.NoError(t, opts.Validate([]string{"mybuns"}))
+		assert.Equal(t, "mybuns", opts.Name)
+	})
+
+	t.Run("name with all namespaces", func(t *testing.T) {
+		opts := DriftOptions{AllNamespaces: true}
+		require.EqualError(t, opts.Validate([]string{"mybuns"}), "--all-namespaces cannot be used when an installation name is specified")
+	})
+}
+
+func TestPorter_GetInstallationDrift(t *testing.T) {
+	const (
+		bundleRef = "example.com/mybuns:v0.1.0"
+		oldDigest = "sha256:a07af2a6d9f6d9e5e8c3b0d4b1e5bd8f0bcfb4f5d0f0c3a3e1b4a7ec2b3a9c11"
+		newDigest = "sha256:b07af2a6d9f6d9e5e8c3b0d4b1e5bd8f0bcfb4f5d0f0c3a3e1b4a7ec2b3a9c22"
+	)
+
+	bun, err := cnab.LoadBundle(portercontext.New(), filepath.Join("testdata/bundle.json"))
+	require.NoError(t, err)
+
+	// setup creates an installation with a successful upgrade run that used the
+	// default parameters and the azure credential set
+	setup := func(t *testing.T, currentDigest string) (*TestPorter, storage.Installation, storage.Run) {
+		p := NewTestPorter(t)
+		ref := cnab.MustParseOCIReference(bundleRef)
+		p.TestRegistry.MockPullBundle = func(ctx context.Context, ref cnab.OCIReference, opts cnabtooci.RegistryOptions) (cnab.BundleReference, error) {
+			return cnab.BundleReference{Reference: ref, Digest: digest.Digest(currentDigest), Definition: bun}, nil
+		}
+
+		creds := storage.NewCredentialSet("dev", "azure",
+			storage.ValueStrategy("my-first-cred", "value-1"),
+			storage.ValueStrategy("my-second-cred", "value-2"))
+		require.NoError(t, p.Credentials.InsertCredentialSet(p.RootContext, creds))
+
+		inst := storage.NewInstallation("dev", "mybuns")
+		inst.TrackBundle(ref)
+		inst.CredentialSets = []string{"azure"}
+		inst.Status.Installed = &now
+		p.TestInstallations.CreateInstallation(inst)
+
+		run := inst.NewRun(cnab.ActionUpgrade, bun)
+		run.BundleReference = bundleRef
+		run.BundleDigest = oldDigest
+		run.CredentialSets = []string{"azure"}
+		run.Credentials = storage.NewInternalCredentialSet(creds.Credentials...)
+		require.NoError(t, run.SetCredentialsDigest())
+		run.Parameters.Parameters = []secrets.SourceMap{storage.ValueStrategy("my-second-param", "spring-music-demo")}
+		p.TestInstallations.CreateRun(run)
+		p.TestInstallations.CreateResult(run.NewResult(cnab.StatusSucceeded))
+
+		return p, inst, run
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		p, inst, run := setup(t, oldDigest)
+		defer p.Close()
+
+		drift, err := p.GetInstallationDrift(p.RootContext, inst)
+		require.NoError(t, err)
+		assert.True(t, drift.InSync)
+		assert.Equal(t, run.ID, drift.LastRunID)
+		assert.Nil(t, drift.Bundle)
+		assert.Empty(t, drift.Parameters)
+		assert.Empty(t, drift.Credentials)
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		drift, err := p.GetInstallationDrift(p.RootContext, storage.NewInstallation("dev", "mybuns"))
+		require.NoError(t, err)
+		assert.False(t, drift.InSync)
+		assert.Equal(t, "the installation has not completed successfully yet", drift.Reason)
+	})
+
+	t.Run("compares against the last run like reconcile", func(t *testing.T) {
+		p, inst, _ := setup(t, oldDigest)
+		defer p.Close()
+
+		failedRun := inst.NewRun(cnab.ActionUpgrade, bun)
+		p.TestInstallations.CreateRun(failedRun)
+		p.TestInstallations.CreateResult(failedRun.NewResult(cnab.StatusFailed))
+
+		drift, err := p.GetInstallationDrift(p.RootContext, inst)
+		require.NoError(t, err)
+		assert.Equal(t, failedRun.ID, drift.LastRunID)
+
+		plan, err := p.PlanInstallation(p.RootContext, ReconcileOptions{Namespace: inst.Namespace, Name: inst.Name, Installation: inst})
+		require.NoError(t, err)
+		assert.Equal(t, plan.InSync, drift.InSync, "drift and reconcile should agree")
+		assert.False(t, drift.InSync, "the failed run used a different bundle")
+	})
+
+	t.Run("set changes that do not change values", func(t *testing.T) {
+		p, inst, _ := setup(t, oldDigest)
+		defer p.Close()
+
+		require.NoError(t, p.Credentials.InsertCredentialSet(p.RootContext, storage.NewCredentialSet("dev", "unused")))
+		inst.CredentialSets = []string{"azure", "unused"}
+
+		drift, err := p.GetInstallationDrift(p.RootContext, inst)
+		require.NoError(t, err)
+		assert.Equal(t, &SetDrift{Added: []string{"unused"}}, drift.CredentialSets, "the set change should be reported")
+
+		plan, err := p.PlanInstallation(p.RootContext, ReconcileOptions{Namespace: inst.Namespace, Name: inst.Name, Installation: inst})
+		require.NoError(t, err)
+		assert.Equal(t, plan.InSync, drift.InSync, "drift and reconcile should agree")
+		assert.True(t, drift.InSync, "the resolved credentials did not change")
+	})
+
+	t.Run("resolves the credentials once", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("the command is a shell script")
+		}
+
+		p, inst, _ := setup(t, oldDigest)
+		defer p.Close()
+
+		// The command records each time that it is run
+		tmp := t.TempDir()
+		runsFile := filepath.Join(tmp, "runs")
+		cmdFile := filepath.Join(tmp, "get-cred.sh")
+		require.NoError(t, os.WriteFile(cmdFile, []byte("#!/bin/sh\necho run >> "+runsFile+"\nprintf value-2\n"), 0700))
+		creds := storage.NewCredentialSet("dev", "azure",
+			storage.ValueStrategy("my-first-cred", "value-1"),
+			secrets.SourceMap{Name: "my-second-cred", Source: secrets.Source{Strategy: host.SourceCommand, Hint: cmdFile}})
+		require.NoError(t, p.Credentials.UpdateCredentialSet(p.RootContext, creds))
+
+		drift, err := p.GetInstallationDrift(p.RootContext, inst)
+		require.NoError(t, err)
+		assert.False(t, drift.InSync)
+		require.Len(t, drift.Credentials, 1)
+		assert.Equal(t, "my-second-cred", drift.Credentials[0].Name)
+
+		runs, err := os.ReadFile(runsFile)
+		require.NoError(t, err)
+		assert.Equal(t, "run\n", string(runs), "the command should only run once to decide if the installation is in sync and report what changed")
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		p, inst, _ := setup(t, newDigest)
+		defer p.Close()
+
+		ps := storage.NewParameterSet("dev", "myps", storage.ValueStrategy("my-first-param", "2"))
+		require.NoError(t, p.Parameters.InsertParameterSet(p.RootContext, ps))
+		creds := storage.NewCredentialSet("dev", "aws", secrets.SourceMap{Name: "my-second-cred", Source: secrets.Source{Strategy: "secret", Hint: "aws-token"}})
+		require.NoError(t, p.Credentials.InsertCredentialSet(p.RootContext, creds))
+		require.NoError(t, p.Secrets.Create(p.RootContext, "secret", "aws-token", "value-3"))
+
+		inst.ParameterSets = []string{"myps"}
+		inst.CredentialSets = []string{"azure", "aws"}
+		inst.Parameters = inst.NewInternalParameterSet(storage.ValueStrategy("my-second-param", "override"))
+
+		drift, err := p.GetInstallationDrift(p.RootContext, inst)
+		require.NoError(t, err)
+		assert.False(t, drift.InSync)
+
+		require.NotNil(t, drift.Bundle, "the bundle digest changed")
+		assert.Equal(t, oldDigest, drift.Bundle.OldDigest)
+		assert.Equal(t, newDigest, drift.Bundle.NewDigest)
+
+		assert.Equal(t, []ValueDrift{
+			{Name: "my-second-param", Change: DriftChangeModified, Sensitive: true},
+		}, drift.Parameters, "my-first-param only applies to install, and sensitive values should not be exposed")
+		assert.Equal(t, &SetDrift{Added: []string{"myps"}}, drift.ParameterSets)
+		assert.Equal(t, &SetDrift{Added: []string{"aws"}}, drift.CredentialSets)
+		assert.Equal(t, []ValueDrift{
+			{Name: "my-second-cred", Change: DriftChangeModified, Sensitive: true},
+		}, drift.Credentials, "the mapping for my-second-cred now comes from the aws credential set, and the old value should not be exposed")
+		assert.False(t, drift.CredentialValuesChanged)
+	})
+
+	t.Run("credential value changed", func(t *testing.T) {
+		p, inst, _ := setup(t, oldDigest)
+		defer p.Close()
+
+		// Rotate the secret without changing the credential mapping
+		require.NoError(t, p.Secrets.Create(p.RootContext, "secret", "azure-token", "old-token"))
+		creds, err := p.Credentials.GetCredentialSet(p.RootContext, "dev", "azure")
+		require.NoError(t, err)
+		creds.Credentials[0].Source = secrets.Source{Strategy: "secret", Hint: "azure-token"}
+		require.NoError(t, p.Credentials.UpdateCredentialSet(p.RootContext, creds))
+
+		run, err := p.Installations.GetLastRun(p.RootContext, "dev", "mybuns")
+		require.NoError(t, err)
+		run.Credentials = storage.NewInternalCredentialSet(creds.Credentials...)
+		run.Credentials.Credentials[0].ResolvedValue = "old-token"
+		run.Credentials.Credentials[1].ResolvedValue = "value-2"
+		require.NoError(t, run.SetCredentialsDigest())
+		require.NoError(t, p.Installations.UpsertRun(p.RootContext, run))
+
+		require.NoError(t, p.Secrets.Create(p.RootContext, "secret", "azure-token", "new-token"))
+
+		drift, err := p.GetInstallationDrift(p.RootContext, inst)
+		require.NoError(t, err)
+		assert.False(t, drift.InSync)
+		assert.Empty(t, drift.Credentials, "the credential mappings did not change")
+		assert.True(t, drift.CredentialValuesChanged)
+	})
+}
+
+func TestPorter_PrintInstallationsDrift(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+
+	p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+	p.TestInstallations.CreateInstallation(storage.NewInstallation("test", "mybuns"))
+
+	t.Run("json", func(t *testing.T) {
+		opts := DriftOptions{AllNamespaces: true, PrintOptions: printer.PrintOptions{RawFormat: "json"}}
+		require.NoError(t, opts.Validate(nil))
+		require.NoError(t, p.PrintInstallationsDrift(p.RootContext, opts))
+
+		var results []InstallationDrift
+		require.NoError(t, json.Unmarshal([]byte(p.TestConfig.TestContext.GetOutput()), &results))
+		require.Len(t, results, 2)
+		assert.Equal(t, "dev", results[0].Namespace)
+		assert.False(t, results[0].InSync)
+	})
+
+	t.Run("plaintext", func(t *testing.T) {
+		opts := DriftOptions{Namespace: "test"}
+		require.NoError(t, opts.Validate([]string{"mybuns"}))
+		require.NoError(t, p.PrintInstallationsDrift(p.RootContext, opts))
+
+		output := p.TestConfig.TestContext.GetOutput()
+		assert.Contains(t, output, "test/mybuns: out of sync\n  Reason: the installation has not completed successfully yet\n")
+	})
+}
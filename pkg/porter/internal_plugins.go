@@ -11,14 +11,18 @@ import (
 	"get.porter.sh/porter/pkg/plugins"
 	"get.porter.sh/porter/pkg/portercontext"
 	secretsplugins "get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/secrets/plugins/encrypted_filesystem"
 	"get.porter.sh/porter/pkg/secrets/plugins/filesystem"
 	"get.porter.sh/porter/pkg/secrets/plugins/host"
+	"get.porter.sh/porter/pkg/secrets/plugins/vault"
 	signingplugins "get.porter.sh/porter/pkg/signing/plugins"
 	"get.porter.sh/porter/pkg/signing/plugins/cosign"
 	"get.porter.sh/porter/pkg/signing/plugins/notation"
 	storageplugins "get.porter.sh/porter/pkg/storage/plugins"
+	storagefilesystem "get.porter.sh/porter/pkg/storage/plugins/filesystem"
 	"get.porter.sh/porter/pkg/storage/plugins/mongodb"
 	"get.porter.sh/porter/pkg/storage/plugins/mongodb_docker"
+	"get.porter.sh/porter/pkg/storage/plugins/sqlite"
 	"get.porter.sh/porter/pkg/tracing"
 	"github.com/hashicorp/go-plugin"
 )
@@ -132,6 +136,20 @@ func getInternalPlugins() map[string]InternalPlugin {
 				return filesystem.NewPlugin(c, pluginCfg), nil
 			},
 		},
+		encrypted_filesystem.PluginKey: {
+			Interface:       secretsplugins.PluginInterface,
+			ProtocolVersion: secretsplugins.PluginProtocolVersion,
+			Create: func(c *config.Config, pluginCfg interface{}) (plugin.Plugin, error) {
+				return encrypted_filesystem.NewPlugin(c, pluginCfg)
+			},
+		},
+		vault.PluginKey: {
+			Interface:       secretsplugins.PluginInterface,
+			ProtocolVersion: secretsplugins.PluginProtocolVersion,
+			Create: func(c *config.Config, pluginCfg interface{}) (plugin.Plugin, error) {
+				return vault.NewPlugin(c, pluginCfg)
+			},
+		},
 		mongodb.PluginKey: {
 			Interface:       storageplugins.PluginInterface,
 			ProtocolVersion: storageplugins.PluginProtocolVersion,
@@ -146,6 +164,20 @@ func getInternalPlugins() map[string]InternalPlugin {
 				return mongodb_docker.NewPlugin(c.Context, pluginCfg)
 			},
 		},
+		sqlite.PluginKey: {
+			Interface:       storageplugins.PluginInterface,
+			ProtocolVersion: storageplugins.PluginProtocolVersion,
+			Create: func(c *config.Config, pluginCfg interface{}) (plugin.Plugin, error) {
+				return sqlite.NewPlugin(c, pluginCfg)
+			},
+		},
+		storagefilesystem.PluginKey: {
+			Interface:       storageplugins.PluginInterface,
+			ProtocolVersion: storageplugins.PluginProtocolVersion,
+			Create: func(c *config.Config, pluginCfg interface{}) (plugin.Plugin, error) {
+				return storagefilesystem.NewPlugin(c, pluginCfg)
+			},
+		},
 		notation.PluginKey: {
 			Interface:       signingplugins.PluginInterface,
 			ProtocolVersion: signingplugins.PluginProtocolVersion,
@@ -96,6 +96,14 @@ type BundleExecutionOptions struct {
 	// ForceRun bypasses the check that prevents starting a new run for an
 	// installation that already has an incomplete run.
 	ForceRun bool
+
+	// RollbackTo is the ID of the previous run that is being restored by
+	// porter installations rollback. It is recorded on the new run.
+	RollbackTo string
+
+	// Resume the installation's last run, which failed, skipping the steps
+	// that it completed.
+	Resume bool
 }
 
 func NewBundleExecutionOptions() *BundleExecutionOptions {
@@ -372,6 +380,7 @@ func (p *Porter) BuildActionArgs(ctx context.Context, installation storage.Insta
 	if err != nil {
 		return cnabprovider.ActionArguments{}, err
 	}
+	run.RollbackTo = opts.RollbackTo
 
 	args := cnabprovider.ActionArguments{
 		Run:                   run,
@@ -383,6 +392,12 @@ func (p *Porter) BuildActionArgs(ctx context.Context, installation storage.Insta
 		PersistLogs:           !opts.NoLogs,
 		ForceRun:              opts.ForceRun,
 	}
+
+	if opts.Resume {
+		if err = p.prepareResume(ctx, &args); err != nil {
+			return cnabprovider.ActionArguments{}, err
+		}
+	}
 	return args, nil
 }
 
@@ -502,7 +517,9 @@ func (p *Porter) resolveCredentialSets(ctx context.Context, namespace string, cr
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.EndSpan()
 
-	finalCreds := make(map[string]secrets.SourceMap, len(bun.Credentials))
+	// The sources of each bundle credential, including the other credentials
+	// in its set that are used to build its value
+	finalCreds := make(map[string]secrets.StrategyList, len(bun.Credentials))
 	for _, csName := range credSetNames {
 		var cs storage.CredentialSet
 		// Try to get the creds in the local namespace first, fallback to the global creds
@@ -530,14 +547,22 @@ func (p *Porter) resolveCredentialSets(ctx context.Context, namespace string, cr
 				continue
 			}
 
-			// If a credential is mapped in multiple credential sets, the strategy associated with the last set specified "wins"
-			finalCreds[cred.Name] = cred
+			// If a credential is mapped in multiple credential sets, the strategy associated with the last set specified "wins".
+			// The credentials used to build the value of a template, json or base64 source are scoped to its set,
+			// so that they are resolved from the same set even when another set maps a credential with the same name.
+			finalCreds[cred.Name] = storage.ScopeSourceReferences(cs.Credentials, cred.Name, cs.Name)
 		}
 	}
 
 	composite := storage.NewInternalCredentialSet()
-	for _, cred := range finalCreds {
-		composite.Credentials = append(composite.Credentials, cred)
+	included := make(map[string]bool)
+	for _, sources := range finalCreds {
+		for _, cred := range sources {
+			if !included[cred.Name] {
+				included[cred.Name] = true
+				composite.Credentials = append(composite.Credentials, cred)
+			}
+		}
 	}
 	return composite, nil
 }
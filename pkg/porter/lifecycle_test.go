@@ -2,6 +2,7 @@ package porter
 
 import (
 	"context"
+	"encoding/base64"
 	"io"
 	"runtime"
 	"sort"
@@ -16,6 +17,7 @@ import (
 	"get.porter.sh/porter/pkg/secrets"
 	"get.porter.sh/porter/pkg/storage"
 	"get.porter.sh/porter/tests"
+	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/cnabio/cnab-to-oci/relocation"
 	"github.com/stretchr/testify/assert"
@@ -113,7 +115,8 @@ func TestPorter_BuildActionArgs(t *testing.T) {
 				CredentialIdentifiers: []string{
 					"mycreds",
 				},
-				Driver: "docker",
+				Driver:     "docker",
+				RollbackTo: "01G1VJGY43HT3KZN82DS6DDPWK",
 				BundleReferenceOptions: &BundleReferenceOptions{
 					installationOptions: installationOptions{
 						BundleDefinitionOptions: BundleDefinitionOptions{
@@ -142,6 +145,7 @@ func TestPorter_BuildActionArgs(t *testing.T) {
 		assert.Equal(t, opts.AllowDockerHostAccess, args.AllowDockerHostAccess, "AllowDockerHostAccess not populated correctly")
 		assert.Equal(t, opts.Driver, args.Driver, "Driver not populated correctly")
 		assert.NotEmpty(t, args.Installation, "Installation not populated")
+		assert.Equal(t, opts.RollbackTo, args.Run.RollbackTo, "RollbackTo not populated correctly")
 		wantReloMap := relocation.ImageRelocationMap{"gabrtv/microservice@sha256:cca460afa270d4c527981ef9ca4989346c56cf9b20217dcea37df1ece8120687": "my.registry/microservice@sha256:cca460afa270d4c527981ef9ca4989346c56cf9b20217dcea37df1ece8120687"}
 		assert.Equal(t, wantReloMap, args.BundleReference.RelocationMap, "RelocationMapping not populated correctly")
 	})
@@ -415,6 +419,120 @@ func TestPorter_applyActionOptionsToInstallation_FollowsParameterHierarchy(t *te
 	})
 }
 
+func TestPorter_applyActionOptionsToInstallation_CompositeParameterSources(t *testing.T) {
+	t.Parallel()
+
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := context.Background()
+
+	p.TestConfig.TestContext.AddTestFile("testdata/porter.yaml", config.Name)
+	m, err := manifest.LoadManifestFrom(context.Background(), p.Config, config.Name)
+	require.NoError(t, err)
+	bun, err := configadapter.ConvertToTestBundle(ctx, p.Config, m)
+	require.NoError(t, err)
+
+	// app-config is not a parameter of the bundle, it is only used to build my-second-param
+	err = p.TestParameters.InsertParameterSet(ctx, storage.NewParameterSet("", "myps",
+		storage.ValueStrategy("app-config", `{"app": {"name": "music"}}`),
+		secrets.SourceMap{Name: "app-name", Source: secrets.Source{Strategy: secrets.SourceJSON, Hint: "app-config#app.name"}},
+		secrets.SourceMap{Name: "my-second-param", Source: secrets.Source{Strategy: secrets.SourceTemplate, Hint: "{{app-name}}-demo"}}))
+	require.NoError(t, err, "Create parameter set failed")
+
+	i := storage.NewInstallation("", bun.Name)
+	opts := NewInstallOptions()
+	opts.bundleRef = &cnab.BundleReference{Reference: kahnlatest, Definition: bun}
+	opts.ParameterSets = []string{"myps"}
+	err = p.applyActionOptionsToInstallation(ctx, opts, &i)
+	require.NoError(t, err)
+
+	finalParams := opts.GetParameters()
+	assert.Equal(t, "music-demo", finalParams["my-second-param"])
+	assert.NotContains(t, finalParams, "app-config", "parameters that are only used by other sources should not be passed to the bundle")
+	assert.NotContains(t, finalParams, "app-name", "parameters that are only used by other sources should not be passed to the bundle")
+}
+
+func TestPorter_resolveCredentialSets_CompositeSources(t *testing.T) {
+	t.Parallel()
+
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := context.Background()
+
+	bun := bundle.Bundle{Credentials: map[string]bundle.Credential{
+		"my-first-cred":  {Location: bundle.Location{EnvironmentVariable: "MY_FIRST_CRED"}},
+		"my-second-cred": {Location: bundle.Location{Path: "/path/to/my-second-cred"}},
+	}}
+	err := p.TestCredentials.InsertCredentialSet(ctx, storage.NewCredentialSet("", "mycreds",
+		secrets.SourceMap{Name: "encoded-token", Source: secrets.Source{Strategy: host.SourceEnv, Hint: "TOKEN"}},
+		secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: secrets.SourceBase64, Hint: "encoded-token"}},
+		secrets.SourceMap{Name: "unused", Source: secrets.Source{Strategy: host.SourceValue, Hint: "unused"}}))
+	require.NoError(t, err, "Create credential set failed")
+
+	creds, err := p.resolveCredentialSets(ctx, "", []string{"mycreds"}, bun, "install")
+	require.NoError(t, err)
+	keys := creds.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"my-first-cred", "mycreds:encoded-token"}, keys,
+		"the credentials used to build the value of a bundle credential should be included")
+}
+
+func TestPorter_resolveCredentialSets_CompositeSourcesInMultipleSets(t *testing.T) {
+	t.Parallel()
+
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := context.Background()
+
+	bun := bundle.Bundle{Credentials: map[string]bundle.Credential{
+		"my-first-cred":  {Location: bundle.Location{EnvironmentVariable: "MY_FIRST_CRED"}},
+		"my-second-cred": {Location: bundle.Location{Path: "/path/to/my-second-cred"}},
+		"my-third-cred":  {Location: bundle.Location{Path: "/path/to/my-third-cred"}},
+	}}
+	err := p.TestCredentials.InsertCredentialSet(ctx, storage.NewCredentialSet("", "first",
+		secrets.SourceMap{Name: "encoded-token", Source: secrets.Source{Strategy: host.SourceValue, Hint: base64.StdEncoding.EncodeToString([]byte("first-token"))}},
+		secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: secrets.SourceBase64, Hint: "encoded-token"}},
+		secrets.SourceMap{Name: "my-second-cred", Source: secrets.Source{Strategy: host.SourceValue, Hint: "first"}},
+		secrets.SourceMap{Name: "my-third-cred", Source: secrets.Source{Strategy: secrets.SourceTemplate, Hint: "{{my-second-cred}}-user"}}))
+	require.NoError(t, err, "Create credential set failed")
+	err = p.TestCredentials.InsertCredentialSet(ctx, storage.NewCredentialSet("", "second",
+		secrets.SourceMap{Name: "encoded-token", Source: secrets.Source{Strategy: host.SourceValue, Hint: base64.StdEncoding.EncodeToString([]byte("second-token"))}},
+		secrets.SourceMap{Name: "my-second-cred", Source: secrets.Source{Strategy: host.SourceValue, Hint: "second"}}))
+	require.NoError(t, err, "Create credential set failed")
+
+	creds, err := p.resolveCredentialSets(ctx, "", []string{"first", "second"}, bun, "install")
+	require.NoError(t, err)
+
+	resolved, err := p.Credentials.ResolveAll(ctx, creds, creds.Keys())
+	require.NoError(t, err)
+	assert.Equal(t, "first-token", resolved["my-first-cred"], "composite sources should use the credentials from their own set")
+	assert.Equal(t, "second", resolved["my-second-cred"], "the last set that maps a credential should win")
+	assert.Equal(t, "first-user", resolved["my-third-cred"], "composite sources should use the bundle credentials from their own set")
+}
+
+func TestPorter_resolveCredentialSets_CompositeSourcesInDottedSet(t *testing.T) {
+	t.Parallel()
+
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := context.Background()
+
+	bun := bundle.Bundle{Credentials: map[string]bundle.Credential{
+		"my-first-cred": {Location: bundle.Location{EnvironmentVariable: "MY_FIRST_CRED"}},
+	}}
+	err := p.TestCredentials.InsertCredentialSet(ctx, storage.NewCredentialSet("", "prod.azure",
+		secrets.SourceMap{Name: "user", Source: secrets.Source{Strategy: host.SourceValue, Hint: "admin"}},
+		secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: secrets.SourceTemplate, Hint: "{{user}}-token"}}))
+	require.NoError(t, err, "Create credential set failed")
+
+	creds, err := p.resolveCredentialSets(ctx, "", []string{"prod.azure"}, bun, "install")
+	require.NoError(t, err)
+
+	resolved, err := p.Credentials.ResolveAll(ctx, creds, creds.Keys())
+	require.NoError(t, err)
+	assert.Equal(t, "admin-token", resolved["my-first-cred"], "the name of the set should not be treated as a nested lookup")
+}
+
 // Validate that when we resolve parameters on an installation that sensitive parameters are
 // not persisted on the installation record and instead are referenced by a secret
 func TestPorter_applyActionOptionsToInstallation_sanitizesParameters(t *testing.T) {
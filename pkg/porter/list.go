@@ -110,6 +110,9 @@ type DisplayInstallation struct {
 	// ParameterSets that should be included when the bundle is reconciled.
 	ParameterSets []string `json:"parameterSets,omitempty" yaml:"parameterSets,omitempty" toml:"parameterSets,omitempty"`
 
+	// DependsOn lists the installations that must be applied before this installation.
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty" toml:"dependsOn,omitempty"`
+
 	// Status of the installation.
 	Status                      storage.InstallationStatus `json:"status,omitempty" yaml:"status,omitempty" toml:"status,omitempty"`
 	DisplayInstallationMetadata `json:"_calculated" yaml:"_calculated"`
@@ -140,6 +143,7 @@ func NewDisplayInstallation(installation storage.Installation) DisplayInstallati
 		Labels:         installation.Labels,
 		CredentialSets: installation.CredentialSets,
 		ParameterSets:  installation.ParameterSets,
+		DependsOn:      installation.DependsOn,
 		Status:         installation.Status,
 		DisplayInstallationMetadata: DisplayInstallationMetadata{
 			DisplayInstallationState:  getDisplayInstallationState(installation),
@@ -165,6 +169,7 @@ func (d DisplayInstallation) ConvertToInstallation() (storage.Installation, erro
 			Labels:         d.Labels,
 			CredentialSets: d.CredentialSets,
 			ParameterSets:  d.ParameterSets,
+			DependsOn:      d.DependsOn,
 		},
 		Status: d.Status,
 	}
@@ -211,14 +216,16 @@ func (l DisplayInstallations) Less(i, j int) bool {
 }
 
 type DisplayRun struct {
-	ID         string                 `json:"id" yaml:"id"`
-	Bundle     string                 `json:"bundle,omitempty" yaml:"bundle,omitempty"`
-	Version    string                 `json:"version" yaml:"version"`
-	Action     string                 `json:"action" yaml:"action"`
-	Parameters map[string]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	Started    time.Time              `json:"started" yaml:"started"`
-	Stopped    *time.Time             `json:"stopped" yaml:"stopped"`
-	Status     string                 `json:"status" yaml:"status"`
+	ID          string                 `json:"id" yaml:"id"`
+	Bundle      string                 `json:"bundle,omitempty" yaml:"bundle,omitempty"`
+	Version     string                 `json:"version" yaml:"version"`
+	Action      string                 `json:"action" yaml:"action"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Started     time.Time              `json:"started" yaml:"started"`
+	Stopped     *time.Time             `json:"stopped" yaml:"stopped"`
+	Status      string                 `json:"status" yaml:"status"`
+	RollbackTo  string                 `json:"rollbackTo,omitempty" yaml:"rollbackTo,omitempty"`
+	ResumedFrom string                 `json:"resumedFrom,omitempty" yaml:"resumedFrom,omitempty"`
 }
 
 // NewDisplayRun converts a stored Run into its display form. Parameters is
@@ -227,11 +234,13 @@ type DisplayRun struct {
 // it here would just show misleading blank values.
 func NewDisplayRun(run storage.Run) DisplayRun {
 	return DisplayRun{
-		ID:      run.ID,
-		Action:  run.Action,
-		Started: run.Created,
-		Bundle:  run.BundleReference,
-		Version: run.Bundle.Version,
+		ID:          run.ID,
+		Action:      run.Action,
+		Started:     run.Created,
+		Bundle:      run.BundleReference,
+		Version:     run.Bundle.Version,
+		RollbackTo:  run.RollbackTo,
+		ResumedFrom: run.ResumedFrom,
 	}
 }
 
@@ -1,17 +1,29 @@
 package porter
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"get.porter.sh/porter/pkg/cnab"
 	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// LogsFollowPollInterval is how often porter logs show --follow checks for new logs.
+const LogsFollowPollInterval = time.Second
+
 // LogsShowOptions represent options for an installation logs show command
 type LogsShowOptions struct {
 	installationOptions
 	RunID string
+
+	// Follow prints the logs of a run as they are persisted until the run completes.
+	Follow bool
 }
 
 // Installation name passed to the command.
@@ -41,6 +53,10 @@ func (o *LogsShowOptions) Validate(cxt *portercontext.Context) error {
 
 // ShowInstallationLogs shows logs for an installation, according to the provided options.
 func (p *Porter) ShowInstallationLogs(ctx context.Context, opts *LogsShowOptions) error {
+	if opts.Follow {
+		return p.FollowInstallationLogs(ctx, opts)
+	}
+
 	logs, ok, err := p.GetInstallationLogs(ctx, opts)
 	if err != nil {
 		return err
@@ -62,9 +78,121 @@ func (p *Porter) GetInstallationLogs(ctx context.Context, opts *LogsShowOptions)
 	}
 	installation := opts.Name
 
-	if opts.RunID != "" {
-		return p.Installations.GetLogs(ctx, opts.RunID)
+	runID := opts.RunID
+	if runID == "" {
+		lastRun, err := p.Installations.GetLastRun(ctx, opts.Namespace, installation)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound{}) {
+				return "", false, nil
+			}
+			return "", false, err
+		}
+		runID = lastRun.ID
+	}
+
+	logs, ok, err := p.Installations.GetLogs(ctx, runID)
+	if ok || err != nil {
+		return logs, ok, err
 	}
 
+	// The run may still be in progress, use the logs persisted so far
+	chunks, err := p.Installations.ListLogChunks(ctx, runID, 0)
+	if err != nil {
+		return "", false, err
+	}
+	if len(chunks) > 0 {
+		var buf bytes.Buffer
+		for _, chunk := range chunks {
+			buf.Write(chunk.Data)
+		}
+		return buf.String(), true, nil
+	}
+
+	if opts.RunID != "" {
+		return "", false, nil
+	}
 	return p.Installations.GetLastLogs(ctx, opts.Namespace, installation)
 }
+
+// FollowInstallationLogs prints the logs of a run as they are persisted,
+// until the run completes. When an installation is specified, the logs of
+// its most recent run are followed.
+func (p *Porter) FollowInstallationLogs(ctx context.Context, opts *LogsShowOptions) error {
+	if err := p.applyDefaultOptions(ctx, &opts.installationOptions); err != nil {
+		return err
+	}
+
+	runID := opts.RunID
+	if runID == "" {
+		lastRun, err := p.Installations.GetLastRun(ctx, opts.Namespace, opts.Name)
+		if err != nil {
+			return fmt.Errorf("could not find the last run of installation %s: %w", displayInstallationName(opts.Namespace, opts.Name), err)
+		}
+		runID = lastRun.ID
+	}
+
+	ctx, log := tracing.StartSpan(ctx, attribute.String("run", runID))
+	defer log.EndSpan()
+
+	var sequence, written int
+	for {
+		// Check the status before reading the logs, so that the logs persisted
+		// before the run completed are always printed
+		status, err := p.getRunStatus(ctx, runID)
+		if err != nil {
+			return log.Error(err)
+		}
+
+		chunks, err := p.Installations.ListLogChunks(ctx, runID, sequence)
+		if err != nil {
+			return log.Errorf("could not list the logs for run %s: %w", runID, err)
+		}
+		for _, chunk := range chunks {
+			if _, err := p.Out.Write(chunk.Data); err != nil {
+				return err
+			}
+			sequence = chunk.Sequence
+			written += len(chunk.Data)
+		}
+
+		if isTerminalStatus(status) {
+			// The log chunks are removed once the logs are saved as an output,
+			// so print any logs that were saved after the last chunk was read
+			logs, ok, err := p.Installations.GetLogs(ctx, runID)
+			if err != nil {
+				return log.Error(err)
+			}
+			if !ok {
+				if sequence > 0 {
+					return nil
+				}
+				return errors.New("no logs found")
+			}
+
+			if sequence == 0 {
+				// The logs were not persisted while the run was in progress,
+				// for example when the run was executed by an older version of Porter
+				fmt.Fprintln(p.Out, logs)
+			} else if len(logs) > written {
+				fmt.Fprint(p.Out, logs[written:])
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(LogsFollowPollInterval):
+		}
+	}
+}
+
+// isTerminalStatus determines if a run with the specified status has completed.
+func isTerminalStatus(status string) bool {
+	switch status {
+	case cnab.StatusSucceeded, cnab.StatusFailed, cnab.StatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
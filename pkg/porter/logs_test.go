@@ -3,6 +3,7 @@ package porter
 import (
 	"context"
 	"testing"
+	"time"
 
 	"get.porter.sh/porter/pkg/cnab"
 	"get.porter.sh/porter/pkg/portercontext"
@@ -101,4 +102,79 @@ func TestPorter_ShowInstallationLogs(t *testing.T) {
 
 		assert.Contains(t, p.TestConfig.TestContext.GetOutput(), testLogs)
 	})
+
+	t.Run("in progress", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		i := p.TestInstallations.CreateInstallation(storage.NewInstallation("", "test"))
+		c := p.TestInstallations.CreateRun(i.NewRun(cnab.ActionInstall, bun))
+		p.TestInstallations.CreateResult(c.NewResult(cnab.StatusRunning))
+		require.NoError(t, p.Installations.InsertLogChunk(context.Background(), c.NewLogChunk(1, []byte("installing..."))))
+
+		var opts LogsShowOptions
+		opts.Name = "test"
+		logs, ok, err := p.GetInstallationLogs(context.Background(), &opts)
+		require.NoError(t, err, "GetInstallationLogs failed")
+		require.True(t, ok, "the logs persisted so far should be returned")
+		assert.Equal(t, "installing...", logs)
+	})
+}
+
+func TestPorter_FollowInstallationLogs(t *testing.T) {
+	bun := cnab.ExtendedBundle{}
+
+	t.Run("run in progress", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		i := p.TestInstallations.CreateInstallation(storage.NewInstallation("", "test"))
+		c := p.TestInstallations.CreateRun(i.NewRun(cnab.ActionInstall, bun))
+		p.TestInstallations.CreateResult(c.NewResult(cnab.StatusRunning))
+		require.NoError(t, p.Installations.InsertLogChunk(context.Background(), c.NewLogChunk(1, []byte("installing...\n"))))
+
+		// Complete the run while the logs are followed, the last chunk is
+		// only saved with the complete logs, and the chunks are removed
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			r := c.NewResult(cnab.StatusSucceeded)
+			_ = p.Installations.InsertResult(context.Background(), r)
+			_ = p.Installations.InsertOutput(context.Background(), r.NewOutput(cnab.OutputInvocationImageLogs, []byte("installing...\ndone\n")))
+			_ = p.Installations.RemoveLogChunks(context.Background(), c.ID)
+		}()
+
+		opts := LogsShowOptions{Follow: true}
+		opts.Name = "test"
+		require.NoError(t, p.ShowInstallationLogs(context.Background(), &opts))
+		assert.Equal(t, "installing...\ndone\n", p.TestConfig.TestContext.GetOutput(), "the logs should be printed once")
+	})
+
+	t.Run("completed run without log chunks", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		i := p.TestInstallations.CreateInstallation(storage.NewInstallation("", "test"))
+		c := p.TestInstallations.CreateRun(i.NewRun(cnab.ActionInstall, bun))
+		r := p.TestInstallations.CreateResult(c.NewResult(cnab.StatusSucceeded))
+		p.TestInstallations.CreateOutput(r.NewOutput(cnab.OutputInvocationImageLogs, []byte("saved logs")))
+
+		opts := LogsShowOptions{Follow: true, RunID: c.ID}
+		require.NoError(t, p.ShowInstallationLogs(context.Background(), &opts))
+		assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "saved logs")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		i := p.TestInstallations.CreateInstallation(storage.NewInstallation("", "test"))
+		c := p.TestInstallations.CreateRun(i.NewRun(cnab.ActionInstall, bun))
+		p.TestInstallations.CreateResult(c.NewResult(cnab.StatusRunning))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		opts := LogsShowOptions{Follow: true, RunID: c.ID}
+		err := p.ShowInstallationLogs(ctx, &opts)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -24,7 +25,9 @@ import (
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/bundle/definition"
 	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/hashicorp/go-multierror"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ParameterShowOptions represent options for Porter's parameter show command
@@ -406,6 +409,144 @@ func validateParameterName(args []string) error {
 	}
 }
 
+// ParameterValidateOptions are the options for Porter's parameters validate command.
+type ParameterValidateOptions struct {
+	BundleReferenceOptions
+
+	// Action that the parameter set is validated for. When empty, the
+	// parameters used by every action are validated.
+	Action string
+}
+
+// Validate the args and flags provided to Porter's parameters validate command.
+func (o *ParameterValidateOptions) Validate(ctx context.Context, args []string, p *Porter) error {
+	if err := validateParameterName(args); err != nil {
+		return err
+	}
+
+	if err := o.BundleReferenceOptions.Validate(ctx, args, p); err != nil {
+		return err
+	}
+
+	if o.File == "" && o.CNABFile == "" && o.Reference == "" {
+		return errors.New("no bundle specified. Either --reference, --file or --cnab-file must be specified or the current directory must contain a porter.yaml file")
+	}
+	return nil
+}
+
+// ValidateParameterSet checks that a parameter set can be used with a bundle,
+// without running the bundle. Every parameter in the set is resolved and
+// checked against its definition in the bundle, and the parameters that the
+// bundle requires must be defined in the set. All the problems that are found
+// are returned together.
+func (p *Porter) ValidateParameterSet(ctx context.Context, opts ParameterValidateOptions) error {
+	ctx, span := tracing.StartSpan(ctx,
+		attribute.String("namespace", opts.Namespace),
+		attribute.String("name", opts.Name),
+		attribute.String("action", opts.Action),
+	)
+	defer span.EndSpan()
+
+	ps, err := p.Parameters.GetParameterSet(ctx, opts.Namespace, opts.Name)
+	if err != nil {
+		return span.Error(fmt.Errorf("unable to get parameter set %s: %w", opts.Name, err))
+	}
+
+	bundleRef, err := opts.GetBundleReference(ctx, p)
+	if err != nil {
+		return span.Error(err)
+	}
+	bun := bundleRef.Definition
+	if err := validateBundleAction(bun, opts.Action); err != nil {
+		return span.Error(err)
+	}
+
+	var problems *multierror.Error
+	if err := p.Parameters.Validate(ctx, ps); err != nil {
+		problems = multierror.Append(problems, err)
+	} else {
+		for _, param := range ps.Parameters {
+			if err := p.validateParameterSetEntry(ctx, bun, ps, param); err != nil {
+				problems = multierror.Append(problems, err)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(bun.Parameters)) {
+		param := bun.Parameters[name]
+		if !param.Required || (opts.Action != "" && !param.AppliesTo(opts.Action)) {
+			continue
+		}
+		// Porter sets its internal parameters, and parameter sources are resolved from the outputs of previous runs
+		if bun.IsInternalParameter(name) || bun.ParameterHasSource(name) {
+			continue
+		}
+		if !ps.Parameters.Contains(name) {
+			problems = multierror.Append(problems, fmt.Errorf("parameter %s is required by the bundle%s but is not defined in the parameter set", name, describeAction(opts.Action)))
+		}
+	}
+
+	if err := problems.ErrorOrNil(); err != nil {
+		return span.Error(fmt.Errorf("parameter set %s is not valid for bundle %s: %w", ps.Name, bun.Name, err))
+	}
+
+	// Note that we are not using span.Info because the command's output must go to standard out
+	fmt.Fprintf(p.Out, "Parameter set %s is valid for bundle %s\n", ps.Name, bun.Name)
+	return nil
+}
+
+// validateParameterSetEntry resolves a parameter from a parameter set and
+// checks that the value matches the definition of the parameter in the bundle.
+func (p *Porter) validateParameterSetEntry(ctx context.Context, bun cnab.ExtendedBundle, ps storage.ParameterSet, param secrets.SourceMap) error {
+	paramDef, ok := bun.Parameters[param.Name]
+	if !ok {
+		// Extra parameters are allowed so that sets can be reused, and may be
+		// used to build the value of another parameter, so only resolve them
+		_, err := p.Parameters.ResolveAll(ctx, ps, []string{param.Name})
+		return err
+	}
+
+	def, ok := bun.Definitions[paramDef.Definition]
+	if !ok {
+		return fmt.Errorf("definition %s not defined in bundle", paramDef.Definition)
+	}
+
+	// File parameters with a path source are read from the path when the bundle is run
+	if bun.IsFileType(def) && param.Source.Strategy == host.SourcePath {
+		if _, err := p.FileSystem.Stat(param.Source.Hint); err != nil {
+			return fmt.Errorf("unable to read file parameter %s at %s: %w", param.Name, param.Source.Hint, err)
+		}
+		return nil
+	}
+
+	resolved, err := p.Parameters.ResolveAll(ctx, ps, []string{param.Name})
+	if err != nil {
+		return err
+	}
+
+	rawValue, err := p.getUnconvertedValueFromRaw(bun, def, param.Name, resolved[param.Name])
+	if err != nil {
+		return err
+	}
+	if def.Type == nil || bun.IsFileType(def) {
+		return nil
+	}
+
+	value, err := def.ConvertValue(rawValue)
+	if err != nil {
+		return fmt.Errorf("unable to convert the value of parameter %s to the destination parameter type %s: %w", param.Name, def.Type, err)
+	}
+	valErrs, err := def.Validate(value)
+	if err != nil {
+		return fmt.Errorf("encountered an error validating parameter %s: %w", param.Name, err)
+	}
+	var problems *multierror.Error
+	for _, valErr := range valErrs {
+		problems = multierror.Append(problems, fmt.Errorf("invalid value for parameter %s: %s", param.Name, valErr.Error))
+	}
+	return problems.ErrorOrNil()
+}
+
 // loadParameterSets loads parameter values per their parameter set strategies
 func (p *Porter) loadParameterSets(ctx context.Context, bun cnab.ExtendedBundle, namespace string, params []string, overridenParameters secrets.StrategyList) (secrets.Set, error) {
 	resolvedParameters := secrets.Set{}
@@ -458,11 +599,23 @@ func (p *Porter) loadParameterSets(ctx context.Context, bun cnab.ExtendedBundle,
 			}
 		}
 
+		// Extra parameters in the set that are only used to build the value of
+		// a template, json or base64 source are resolved with the parameters
+		// that use them, and are not passed to the bundle
+		var extraParams []string
+		for _, param := range pset.Parameters {
+			for _, ref := range storage.SourceReferences(pset.Parameters, param.Name) {
+				if _, ok := bun.Parameters[ref]; !ok {
+					extraParams = append(extraParams, ref)
+				}
+			}
+		}
+
 		keysToResolve := pset.Keys()
-		if len(skipParams) > 0 {
+		if len(skipParams) > 0 || len(extraParams) > 0 {
 			keysToResolve = []string{}
 			for _, param := range pset.Parameters {
-				if !slices.Contains(skipParams, param.Name) {
+				if !slices.Contains(skipParams, param.Name) && !slices.Contains(extraParams, param.Name) {
 					keysToResolve = append(keysToResolve, param.Name)
 				}
 			}
@@ -585,21 +738,40 @@ func (p *Porter) ParametersApply(ctx context.Context, o ApplyOptions) error {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.EndSpan()
 
+	params, err := p.loadParameterSetFile(ctx, o)
+	if err != nil {
+		return err
+	}
+
+	err = p.Parameters.UpsertParameterSet(ctx, params.ParameterSet)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.Out, "Applied %s parameter set\n", params)
+	return nil
+}
+
+// loadParameterSetFile reads and validates a parameter set document so that it is ready to be saved.
+func (p *Porter) loadParameterSetFile(ctx context.Context, o ApplyOptions) (DisplayParameterSet, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+
 	span.Debugf("Reading input file %s...", o.File)
 	namespace, err := p.getNamespaceFromFile(o)
 	if err != nil {
-		return span.Error(err)
+		return DisplayParameterSet{}, span.Error(err)
 	}
 
 	var params DisplayParameterSet
 	err = encoding.UnmarshalFile(p.FileSystem, o.File, &params)
 	if err != nil {
-		return span.Error(fmt.Errorf("could not load %s as a parameter set: %w", o.File, err))
+		return DisplayParameterSet{}, span.Error(fmt.Errorf("could not load %s as a parameter set: %w", o.File, err))
 	}
 
 	checkStrategy := p.GetSchemaCheckStrategy(ctx)
 	if err = params.Validate(ctx, checkStrategy); err != nil {
-		return span.Error(fmt.Errorf("invalid parameter set: %w", err))
+		return DisplayParameterSet{}, span.Error(fmt.Errorf("invalid parameter set: %w", err))
 	}
 
 	params.Namespace = namespace
@@ -622,16 +794,10 @@ func (p *Porter) ParametersApply(ctx context.Context, o ApplyOptions) error {
 
 	err = p.Parameters.Validate(ctx, params.ParameterSet)
 	if err != nil {
-		return span.Error(fmt.Errorf("parameter set is invalid: %w", err))
-	}
-
-	err = p.Parameters.UpsertParameterSet(ctx, params.ParameterSet)
-	if err != nil {
-		return err
+		return DisplayParameterSet{}, span.Error(fmt.Errorf("parameter set is invalid: %w", err))
 	}
 
-	fmt.Fprintf(p.Out, "Applied %s parameter set\n", params)
-	return nil
+	return params, nil
 }
 
 // finalizeParameters accepts a set of resolved parameters and combines them
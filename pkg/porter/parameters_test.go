@@ -17,6 +17,7 @@ import (
 	"get.porter.sh/porter/tests"
 	"github.com/cnabio/cnab-go/bundle"
 	"github.com/cnabio/cnab-go/bundle/definition"
+	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1397,3 +1398,77 @@ func Test_DependencyParameterOverride(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, opts.depParams["dep#first-param"], "1")
 }
+
+func TestPorter_ValidateParameterSet(t *testing.T) {
+	ctx := context.Background()
+
+	validate := func(t *testing.T, p *TestPorter, ps storage.ParameterSet, action string) error {
+		p.TestConfig.TestContext.AddTestFile("testdata/bundle.json", "/bundle.json")
+		require.NoError(t, p.TestParameters.InsertParameterSet(ctx, ps))
+
+		opts := ParameterValidateOptions{Action: action}
+		opts.CNABFile = "/bundle.json"
+		require.NoError(t, opts.Validate(ctx, []string{ps.Name}, p.Porter))
+		return p.ValidateParameterSet(ctx, opts)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		ps := storage.NewParameterSet("", "myparams",
+			secrets.SourceMap{Name: "my-first-param", Source: secrets.Source{Strategy: host.SourceValue, Hint: "10"}},
+			secrets.SourceMap{Name: "my-second-param", Source: secrets.Source{Strategy: secrets.SourceTemplate, Hint: "{{prefix}}-music"}},
+			secrets.SourceMap{Name: "prefix", Source: secrets.Source{Strategy: host.SourceValue, Hint: "spring"}},
+		)
+		require.NoError(t, validate(t, p, ps, cnab.ActionInstall))
+		assert.Equal(t, "Parameter set myparams is valid for bundle porter-hello\n", p.TestConfig.TestContext.GetOutput())
+	})
+
+	t.Run("extra parameters are ignored", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		ps := storage.NewParameterSet("", "myparams",
+			secrets.SourceMap{Name: "my-first-param", Source: secrets.Source{Strategy: host.SourceValue, Hint: "10"}},
+			secrets.SourceMap{Name: "extra", Source: secrets.Source{Strategy: host.SourceValue, Hint: "abc"}},
+		)
+		require.NoError(t, validate(t, p, ps, cnab.ActionInstall))
+		assert.Equal(t, "Parameter set myparams is valid for bundle porter-hello\n", p.TestConfig.TestContext.GetOutput())
+	})
+
+	t.Run("reports every problem", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		ps := storage.NewParameterSet("", "myparams",
+			secrets.SourceMap{Name: "my-first-param", Source: secrets.Source{Strategy: host.SourceValue, Hint: "ten"}},
+			secrets.SourceMap{Name: "porter-debug", Source: secrets.Source{Strategy: host.SourceEnv, Hint: "PORTER_TEST_UNDEFINED_PARAM"}},
+			secrets.SourceMap{Name: "extra", Source: secrets.Source{Strategy: host.SourceValue, Hint: "abc"}},
+		)
+		err := validate(t, p, ps, "")
+		require.ErrorContains(t, err, "parameter set myparams is not valid for bundle porter-hello")
+		assert.ErrorContains(t, err, "unable to convert the value of parameter my-first-param")
+		assert.ErrorContains(t, err, "unable to resolve parameter myparams.porter-debug")
+		assert.NotContains(t, err.Error(), "extra", "parameters that the bundle does not use should be ignored")
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		ps := storage.NewParameterSet("", "myparams",
+			secrets.SourceMap{Name: "my-second-param", Source: secrets.Source{Strategy: secrets.SourceJSON, Hint: "config#password"}},
+		)
+		err := validate(t, p, ps, "")
+		require.ErrorContains(t, err, "my-second-param uses config, which is not defined in the set")
+	})
+
+	t.Run("undefined action", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		err := validate(t, p, storage.NewParameterSet("", "myparams"), "missing")
+		require.EqualError(t, err, "action missing is not defined by bundle porter-hello")
+	})
+}
@@ -0,0 +1,111 @@
+package porter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/storage"
+)
+
+// PlanActionNone is the action of a plan for an installation that is already up-to-date.
+const PlanActionNone = "none"
+
+// InstallationPlan describes what applying an installation would do, without
+// executing the bundle.
+type InstallationPlan struct {
+	// Namespace of the installation.
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	// Name of the installation.
+	Name string `json:"name" yaml:"name"`
+
+	// Action that would be executed: install, upgrade, uninstall or none.
+	Action string `json:"action" yaml:"action"`
+
+	// InSync is true when the installation matches its last run.
+	InSync bool `json:"inSync" yaml:"inSync"`
+
+	// Reason explains why the action would be executed or skipped.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// Bundle is the reference of the bundle that would be executed.
+	Bundle string `json:"bundle" yaml:"bundle"`
+
+	// Version of the bundle that would be executed.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// ParameterSets used to resolve the parameters.
+	ParameterSets []string `json:"parameterSets,omitempty" yaml:"parameterSets,omitempty"`
+
+	// CredentialSets used to resolve the credentials.
+	CredentialSets []string `json:"credentialSets,omitempty" yaml:"credentialSets,omitempty"`
+
+	// Parameters resolved for the action. Sensitive values are masked.
+	Parameters DisplayValues `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// newInstallationPlan summarizes the bundle action that was prepared for the installation.
+func (p *Porter) newInstallationPlan(ctx context.Context, inst storage.Installation, action BundleAction) (InstallationPlan, error) {
+	opts := action.GetOptions()
+	bundleRef, err := opts.GetBundleReference(ctx, p)
+	if err != nil {
+		return InstallationPlan{}, err
+	}
+
+	plan := InstallationPlan{
+		Namespace:      inst.Namespace,
+		Name:           inst.Name,
+		Action:         action.GetAction(),
+		Bundle:         bundleRef.Reference.String(),
+		Version:        bundleRef.Definition.Version,
+		ParameterSets:  inst.ParameterSets,
+		CredentialSets: inst.CredentialSets,
+		Parameters:     NewDisplayValuesFromParameters(bundleRef.Definition, opts.GetParameters()),
+	}
+
+	// Never include sensitive values in the plan
+	for i, param := range plan.Parameters {
+		if param.Sensitive || bundleRef.Definition.IsSensitiveParameter(param.Name) {
+			plan.Parameters[i].Sensitive = true
+			plan.Parameters[i].Value = plan.Parameters[i].PrintValue()
+		}
+	}
+
+	return plan, nil
+}
+
+// printInstallationPlan prints the plan in the requested format.
+func (p *Porter) printInstallationPlan(plan InstallationPlan, format printer.Format) error {
+	switch format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, plan)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, plan)
+	case printer.FormatPlaintext, "":
+		fmt.Fprintf(p.Out, "Installation: %s\n", displayInstallationName(plan.Namespace, plan.Name))
+		fmt.Fprintf(p.Out, "Action: %s\n", plan.Action)
+		if plan.Reason != "" {
+			fmt.Fprintf(p.Out, "Reason: %s\n", plan.Reason)
+		}
+		fmt.Fprintf(p.Out, "Bundle: %s\n", plan.Bundle)
+		if plan.Version != "" {
+			fmt.Fprintf(p.Out, "Version: %s\n", plan.Version)
+		}
+		if len(plan.ParameterSets) > 0 {
+			fmt.Fprintf(p.Out, "Parameter Sets: %s\n", strings.Join(plan.ParameterSets, ", "))
+		}
+		if len(plan.CredentialSets) > 0 {
+			fmt.Fprintf(p.Out, "Credential Sets: %s\n", strings.Join(plan.CredentialSets, ", "))
+		}
+		if len(plan.Parameters) > 0 {
+			fmt.Fprintln(p.Out)
+			fmt.Fprintln(p.Out, "Parameters:")
+			return p.printDisplayValuesTable(plan.Parameters)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", format)
+	}
+}
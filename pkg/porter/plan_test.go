@@ -0,0 +1,106 @@
+package porter
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/cnab"
+	cnabtooci "get.porter.sh/porter/pkg/cnab/cnab-to-oci"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_InstallationApply_DryRun(t *testing.T) {
+	bun, err := cnab.LoadBundle(portercontext.New(), filepath.Join("testdata/bundle.json"))
+	require.NoError(t, err)
+
+	const installationFile = `schemaType: Installation
+schemaVersion: 1.0.2
+name: mybuns
+namespace: dev
+bundle:
+  repository: example.com/mybuns
+  version: 0.1.0
+parameters:
+  my-first-param: 2
+  my-second-param: supersecret
+`
+
+	setup := func(t *testing.T) *TestPorter {
+		p := NewTestPorter(t)
+		p.TestRegistry.MockPullBundle = func(ctx context.Context, ref cnab.OCIReference, opts cnabtooci.RegistryOptions) (cnab.BundleReference, error) {
+			return cnab.BundleReference{Reference: ref, Definition: bun}, nil
+		}
+		require.NoError(t, p.FileSystem.WriteFile("mybuns.yaml", []byte(installationFile), pkg.FileModeWritable))
+		return p
+	}
+
+	applyDryRun := func(t *testing.T, p *TestPorter) InstallationPlan {
+		opts := ApplyOptions{DryRun: true, PrintOptions: printer.PrintOptions{RawFormat: "json"}}
+		require.NoError(t, opts.Validate(p.Context, []string{"mybuns.yaml"}))
+		require.NoError(t, p.InstallationApply(p.RootContext, opts))
+
+		// Debug logs are printed in tests, skip ahead to the plan
+		output := p.TestConfig.TestContext.GetOutput()
+		start := strings.Index(output, "\n{\n")
+		require.GreaterOrEqual(t, start, 0, "the plan was not printed")
+
+		var plan InstallationPlan
+		require.NoError(t, json.Unmarshal([]byte(output[start:]), &plan))
+		return plan
+	}
+
+	t.Run("new installation", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		plan := applyDryRun(t, p)
+		assert.Equal(t, "dev", plan.Namespace)
+		assert.Equal(t, "mybuns", plan.Name)
+		assert.Equal(t, cnab.ActionInstall, plan.Action)
+		assert.Equal(t, "the installation has not completed successfully yet", plan.Reason)
+		assert.Equal(t, "example.com/mybuns:v0.1.0", plan.Bundle)
+
+		require.Len(t, plan.Parameters, 2)
+		assert.Equal(t, "my-first-param", plan.Parameters[0].Name)
+		assert.Equal(t, float64(2), plan.Parameters[0].Value)
+		assert.Equal(t, "my-second-param", plan.Parameters[1].Name)
+		assert.True(t, plan.Parameters[1].Sensitive)
+		assert.Equal(t, "******", plan.Parameters[1].Value, "sensitive values should be masked")
+
+		_, err := p.Installations.GetInstallation(p.RootContext, "dev", "mybuns")
+		require.ErrorIs(t, err, storage.ErrNotFound{}, "the installation should not be saved")
+		_, err = p.Installations.GetLastRun(p.RootContext, "dev", "mybuns")
+		require.ErrorIs(t, err, storage.ErrNotFound{}, "a run should not be recorded")
+	})
+
+	t.Run("up-to-date installation", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		inst := storage.NewInstallation("dev", "mybuns")
+		inst.TrackBundle(cnab.MustParseOCIReference("example.com/mybuns:v0.1.0"))
+		inst.Status.Installed = &now
+		p.TestInstallations.CreateInstallation(inst)
+		// my-first-param only applies to install, so it isn't compared
+		run := inst.NewRun(cnab.ActionInstall, bun)
+		run.Parameters.Parameters = []secrets.SourceMap{
+			storage.ValueStrategy("my-second-param", "supersecret"),
+		}
+		require.NoError(t, run.SetCredentialsDigest())
+		p.TestInstallations.CreateRun(run)
+
+		plan := applyDryRun(t, p)
+		assert.Equal(t, PlanActionNone, plan.Action)
+		assert.True(t, plan.InSync)
+		assert.Equal(t, "the installation is already up-to-date", plan.Reason)
+	})
+}
@@ -49,6 +49,9 @@ type Porter struct {
 	Secrets       secrets.Store
 	Storage       storage.Provider
 	Signer        signing.Signer
+
+	// workers holds the locks shared with the workers created by NewWorker.
+	workers *workerLocks
 }
 
 // New porter client, initialized with useful defaults.
@@ -86,6 +89,7 @@ func NewFor(c *config.Config, store storage.Store, secretStorage secrets.Store,
 		CNAB:          cnabprovider.NewRuntime(c, installationStorage, credStorage, paramStorage, secretStorage, sanitizerService),
 		Sanitizer:     sanitizerService,
 		Signer:        signer,
+		workers:       &workerLocks{},
 	}
 }
 
@@ -1,6 +1,7 @@
 package porter
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -32,34 +33,150 @@ type ReconcileOptions struct {
 // This is only used for install/upgrade actions triggered by applying a file
 // to an installation. For uninstall or invoke, you should call those directly.
 func (p *Porter) ReconcileInstallation(ctx context.Context, opts ReconcileOptions) error {
+	_, err := p.reconcileInstallation(ctx, opts)
+	return err
+}
+
+// PlanAndReconcileInstallation reconciles the installation in the same way as
+// ReconcileInstallation, and returns the plan that was used to decide which
+// action, if any, was executed. It is safe to call for multiple installations
+// at the same time: each call uses its own worker, and its output is printed
+// once the installation is reconciled.
+func (p *Porter) PlanAndReconcileInstallation(ctx context.Context, opts ReconcileOptions) (InstallationPlan, error) {
+	var out bytes.Buffer
+	defer func() { p.writeWorkerOutput(out.Bytes()) }()
+
+	return p.NewWorker(&out).reconcileInstallation(ctx, opts)
+}
+
+// reconcileInstallation implements ReconcileInstallation, and returns the plan
+// that was executed.
+func (p *Porter) reconcileInstallation(ctx context.Context, opts ReconcileOptions) (InstallationPlan, error) {
 	ctx, log := tracing.StartSpan(ctx)
 	defer log.EndSpan()
 	log.Debugf("Reconciling %s/%s installation", opts.Namespace, opts.Name)
 
+	plan, actionOpts, status, err := p.planReconcile(ctx, &opts)
+	if err != nil {
+		return InstallationPlan{}, err
+	}
+	status.log(log)
+
+	if plan.InSync {
+		if opts.Force {
+			log.Info("The installation is up-to-date but will be re-applied because --force was specified")
+		} else {
+			log.Info("The installation is already up-to-date.")
+			return plan, nil
+		}
+	}
+
+	log.Infof("The installation is out-of-sync, running the %s action...", actionOpts.GetAction())
+	if err := actionOpts.Validate(ctx, nil, p); err != nil {
+		return InstallationPlan{}, err
+	}
+
+	if opts.DryRun {
+		log.Info("Skipping bundle execution because --dry-run was specified")
+		return plan, nil
+	} else {
+		if err = p.Installations.UpsertInstallation(ctx, opts.Installation); err != nil {
+			return InstallationPlan{}, err
+		}
+	}
+
+	return plan, p.ExecuteAction(ctx, opts.Installation, actionOpts)
+}
+
+// PlanInstallation determines which action, if any, ReconcileInstallation
+// would execute for the installation, along with the resolved parameters for
+// that action. The bundle is not executed and nothing is saved to storage.
+func (p *Porter) PlanInstallation(ctx context.Context, opts ReconcileOptions) (InstallationPlan, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	plan, actionOpts, _, err := p.planReconcile(ctx, &opts)
+	if err != nil {
+		return InstallationPlan{}, err
+	}
+
+	if plan.Action != PlanActionNone {
+		if err := actionOpts.Validate(ctx, nil, p); err != nil {
+			return InstallationPlan{}, err
+		}
+	}
+
+	return plan, nil
+}
+
+// planReconcile prepares the bundle action for the installation and decides
+// if it should be executed.
+func (p *Porter) planReconcile(ctx context.Context, opts *ReconcileOptions) (InstallationPlan, BundleAction, syncStatus, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
 	// Get the last run of the installation, if available
 	var lastRun *storage.Run
 	r, err := p.Installations.GetLastRun(ctx, opts.Namespace, opts.Name)
 	neverRun := errors.Is(err, storage.ErrNotFound{})
 	if err != nil && !neverRun {
-		return err
+		return InstallationPlan{}, nil, syncStatus{}, err
 	}
 	if !neverRun {
 		lastRun = &r
 	}
 
-	ref, ok, err := opts.Installation.Bundle.GetBundleReference()
+	// Configure the bundle action that we should execute IF IT'S OUT OF SYNC
+	actionOpts, err := p.prepareReconcileAction(ctx, &opts.Installation)
+	if err != nil {
+		return InstallationPlan{}, nil, syncStatus{}, err
+	}
+
+	// Determine if the installation's desired state is out of sync with reality 🤯
+	status, err := p.checkInstallationInSync(ctx, newSyncInputs(opts.Installation, lastRun, actionOpts))
+	if err != nil {
+		return InstallationPlan{}, nil, syncStatus{}, err
+	}
+
+	plan, err := p.newInstallationPlan(ctx, opts.Installation, actionOpts)
 	if err != nil {
-		return log.Error(err)
+		return InstallationPlan{}, nil, syncStatus{}, err
+	}
+	plan.InSync = status.inSync
+	plan.Reason = status.reason
+	if status.inSync {
+		if opts.Force {
+			plan.Reason = "--force was specified"
+		} else {
+			plan.Action = PlanActionNone
+			if plan.Reason == "" {
+				plan.Reason = "the installation is already up-to-date"
+			}
+		}
+	}
+
+	return plan, actionOpts, status, nil
+}
+
+// prepareReconcileAction configures the bundle action that should be executed
+// to bring the installation in sync with its desired state, and applies the
+// action's options to the installation.
+func (p *Porter) prepareReconcileAction(ctx context.Context, inst *storage.Installation) (BundleAction, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	ref, ok, err := inst.Bundle.GetBundleReference()
+	if err != nil {
+		return nil, log.Error(err)
 	}
 	if !ok {
-		instYaml, _ := yaml.Marshal(opts.Installation)
-		return log.Error(fmt.Errorf("the installation does not define a valid bundle reference.\n%s", instYaml))
+		instYaml, _ := yaml.Marshal(inst)
+		return nil, log.Error(fmt.Errorf("the installation does not define a valid bundle reference.\n%s", instYaml))
 	}
 
-	// Configure the bundle action that we should execute IF IT'S OUT OF SYNC
 	var actionOpts BundleAction
-	if opts.Installation.IsInstalled() {
-		if opts.Installation.Uninstalled {
+	if inst.IsInstalled() {
+		if inst.Uninstalled {
 			actionOpts = NewUninstallOptions()
 		} else {
 			actionOpts = NewUpgradeOptions()
@@ -70,177 +187,280 @@ func (p *Porter) ReconcileInstallation(ctx context.Context, opts ReconcileOption
 
 	lifecycleOpts := actionOpts.GetOptions()
 	lifecycleOpts.Reference = ref.String()
-	lifecycleOpts.Name = opts.Name
-	lifecycleOpts.Namespace = opts.Namespace
-	lifecycleOpts.CredentialIdentifiers = opts.Installation.CredentialSets
-	lifecycleOpts.ParameterSets = opts.Installation.ParameterSets
+	lifecycleOpts.Name = inst.Name
+	lifecycleOpts.Namespace = inst.Namespace
+	lifecycleOpts.CredentialIdentifiers = inst.CredentialSets
+	lifecycleOpts.ParameterSets = inst.ParameterSets
 
 	// Parameters specified inline in the installation YAML are the user's current
 	// explicit desired values and must override param set resolution — just like
 	// --param flags do. Pass them as CurrentParamOverrides so that
 	// applyActionOptionsToInstallation treats them as current overrides rather
 	// than old persisted values, without any string round-trip.
-	lifecycleOpts.CurrentParamOverrides = opts.Installation.Parameters.Parameters
+	lifecycleOpts.CurrentParamOverrides = inst.Parameters.Parameters
 
-	if err = p.applyActionOptionsToInstallation(ctx, actionOpts, &opts.Installation); err != nil {
-		return err
+	if err = p.applyActionOptionsToInstallation(ctx, actionOpts, inst); err != nil {
+		return nil, err
 	}
 
-	// Determine if the installation's desired state is out of sync with reality 🤯
-	inSync, err := p.IsInstallationInSync(ctx, opts.Installation, lastRun, actionOpts)
+	return actionOpts, nil
+}
+
+// IsInstallationInSync determines if the desired state of the installation matches
+// the state of the installation the last time it was modified.
+func (p *Porter) IsInstallationInSync(ctx context.Context, i storage.Installation, lastRun *storage.Run, action BundleAction) (bool, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	status, err := p.checkInstallationInSync(ctx, newSyncInputs(i, lastRun, action))
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	if inSync {
-		if opts.Force {
-			log.Info("The installation is up-to-date but will be re-applied because --force was specified")
-		} else {
-			log.Info("The installation is already up-to-date.")
-			return nil
-		}
-	}
+	status.log(log)
+	return status.inSync, nil
+}
 
-	log.Infof("The installation is out-of-sync, running the %s action...", actionOpts.GetAction())
-	if err := actionOpts.Validate(ctx, nil, p); err != nil {
-		return err
+// syncStatus explains why a bundle run would be triggered or ignored for an installation.
+type syncStatus struct {
+	inSync bool
+	reason string
+	attrs  []attribute.KeyValue
+}
+
+func (s syncStatus) log(log tracing.TraceLogger) {
+	if s.reason == "" {
+		return
 	}
 
-	if opts.DryRun {
-		log.Info("Skipping bundle execution because --dry-run was specified")
-		return nil
+	if s.inSync {
+		log.Info("Ignoring because "+s.reason, s.attrs...)
 	} else {
-		if err = p.Installations.UpsertInstallation(ctx, opts.Installation); err != nil {
-			return err
-		}
+		log.Info("Triggering because "+s.reason, s.attrs...)
 	}
-
-	return p.ExecuteAction(ctx, opts.Installation, actionOpts)
 }
 
-// IsInstallationInSync determines if the desired state of the installation matches
-// the state of the installation the last time it was modified.
-func (p *Porter) IsInstallationInSync(ctx context.Context, i storage.Installation, lastRun *storage.Run, action BundleAction) (bool, error) {
+// checkInstallationInSync determines if the desired state of the installation
+// matches the state of the installation the last time it was modified, and
+// explains why a bundle run would be triggered or ignored.
+func (p *Porter) checkInstallationInSync(ctx context.Context, in *syncInputs) (syncStatus, error) {
 	ctx, log := tracing.StartSpan(ctx)
 	defer log.EndSpan()
 
-	// Only print out info messages if we are triggering a bundle run. Otherwise, keep the explanations in debug output.
-
-	// Has it been uninstalled? If so, we don't ever reconcile it again
-	if i.IsUninstalled() {
-		log.Info("Ignoring because the installation is uninstalled")
-		return true, nil
+	trigger := func(reason string, attrs ...attribute.KeyValue) (syncStatus, error) {
+		return syncStatus{inSync: false, reason: reason, attrs: attrs}, nil
 	}
 
-	// Should we uninstall it?
-	if i.Uninstalled {
-		// Only try to uninstall if it's been installed before
-		if i.IsInstalled() {
-			log.Info("Triggering because installation.uninstalled is true")
-			return false, nil
-		}
-
-		// Otherwise ignore this installation
-		log.Info("Ignoring because installation.uninstalled is true but the installation doesn't exist yet")
-		return true, nil
-	} else {
-		// Should we install it?
-		if !i.IsInstalled() {
-			log.Info("Triggering because the installation has not completed successfully yet")
-			return false, nil
-		}
-	}
+	// Only print out info messages if we are triggering a bundle run. Otherwise, keep the explanations in debug output.
 
-	// We want to upgrade, but we don't have values to compare against
-	// This shouldn't happen but check just in case
-	if lastRun == nil {
-		log.Info("Triggering because the last run for the installation wasn't recorded")
-		return false, nil
+	// Is the installation being installed, uninstalled or ignored?
+	lastRun := in.lastRun
+	if status, decided := checkInstallationState(in.inst, lastRun); decided {
+		return status, nil
 	}
 
 	// Figure out if we need to upgrade
-	opts := action.GetOptions()
-
-	newRef, err := opts.GetBundleReference(ctx, p)
+	newRef, err := in.action.GetOptions().GetBundleReference(ctx, p)
 	if err != nil {
-		return false, err
+		return syncStatus{}, err
 	}
 
 	// Has the bundle definition changed?
 	if lastRun.BundleDigest != newRef.Digest.String() {
-		log.Info("Triggering because the bundle definition has changed",
+		return trigger("the bundle definition has changed",
 			attribute.String("oldReference", lastRun.BundleReference),
 			attribute.String("oldDigest", lastRun.BundleDigest),
 			attribute.String("newReference", newRef.Reference.String()),
 			attribute.String("newDigest", newRef.Digest.String()))
-		return false, nil
 	}
 
-	// Convert parameters to a string to compare them. This avoids problems comparing
-	// values that may be equal but have different types due to how the parameter
-	// value was loaded.
-	b := newRef.Definition
-	prepParametersForComparison := func(params map[string]interface{}) (map[string]string, error) {
-		compParams := make(map[string]string, len(params))
-		for paramName, rawValue := range params {
-			if b.IsInternalParameter(paramName) {
-				continue
-			}
+	oldParams, newParams, err := in.parameters(ctx, p)
+	if err != nil {
+		return syncStatus{}, err
+	}
 
-			typedValue, err := b.ConvertParameterValue(paramName, rawValue)
-			if err != nil {
-				return nil, err
-			}
+	if !cmp.Equal(oldParams, newParams) {
+		diff := cmp.Diff(oldParams, newParams)
+		return trigger("the parameters have changed",
+			attribute.String("diff", diff))
+	}
 
-			stringValue, err := b.WriteParameterToString(paramName, typedValue)
-			if err != nil {
-				return nil, err
-			}
+	// Compare the digest of the resolved credentials against the last run's,
+	// so that we can detect a changed credential value (e.g. a rotated secret)
+	// even when the attached credential set names haven't changed.
+	_, newCredsDigest, err := in.credentials(ctx, p)
+	if err != nil {
+		return syncStatus{}, err
+	}
 
-			compParams[paramName] = stringValue
-		}
-		return compParams, nil
+	if lastRun.CredentialsDigest != newCredsDigest {
+		return trigger("the credentials have changed",
+			attribute.String("oldDigest", lastRun.CredentialsDigest),
+			attribute.String("newDigest", newCredsDigest))
 	}
+	return syncStatus{inSync: true}, nil
+}
 
-	lastRunParams, err := p.Sanitizer.RestoreParameterSet(ctx, lastRun.Parameters, cnab.NewBundle(lastRun.Bundle))
+// syncInputs are the values of an installation that are compared against its
+// last run. The parameters and credentials are resolved the first time that
+// they are used, and only once, so that sources such as commands and secrets
+// give the same values when the comparison is repeated, e.g. to report what
+// has changed.
+type syncInputs struct {
+	inst    storage.Installation
+	lastRun *storage.Run
+	action  BundleAction
+
+	paramsResolved bool
+	oldParams      map[string]string
+	newParams      map[string]string
+
+	credsResolved bool
+	creds         storage.CredentialSet
+	credsDigest   string
+}
+
+func newSyncInputs(inst storage.Installation, lastRun *storage.Run, action BundleAction) *syncInputs {
+	return &syncInputs{inst: inst, lastRun: lastRun, action: action}
+}
+
+// parameters returns the parameters used by the last run and the current
+// parameters, prepared for comparison.
+func (in *syncInputs) parameters(ctx context.Context, p *Porter) (map[string]string, map[string]string, error) {
+	if in.paramsResolved {
+		return in.oldParams, in.newParams, nil
+	}
+
+	opts := in.action.GetOptions()
+	newRef, err := opts.GetBundleReference(ctx, p)
 	if err != nil {
-		return false, err
+		return nil, nil, err
 	}
 
-	oldParams, err := prepParametersForComparison(lastRunParams)
+	b := newRef.Definition
+	lastRunParams, err := p.Sanitizer.RestoreParameterSet(ctx, in.lastRun.Parameters, cnab.NewBundle(in.lastRun.Bundle))
 	if err != nil {
-		return false, fmt.Errorf("error prepping old parameters for comparison: %w", err)
+		return nil, nil, err
 	}
 
-	newParams, err := prepParametersForComparison(opts.GetParameters())
+	in.oldParams, err = prepParametersForComparison(b, lastRunParams)
 	if err != nil {
-		return false, fmt.Errorf("error prepping current parameters for comparison: %w", err)
+		return nil, nil, fmt.Errorf("error prepping old parameters for comparison: %w", err)
 	}
 
-	if !cmp.Equal(oldParams, newParams) {
-		diff := cmp.Diff(oldParams, newParams)
-		log.Info("Triggering because the parameters have changed",
-			attribute.String("diff", diff))
-		return false, nil
-	}
-
-	// Resolve the credentials that would have been used for the last run, and
-	// compare their current digest against the last run's, so that we can
-	// detect a changed credential value (e.g. a rotated secret) even when the
-	// attached credential set names haven't changed. Compose using
-	// lastRun.Action rather than the action we're about to run - some
-	// credentials may only apply to specific actions (see Credential.AppliesTo),
-	// so comparing against a differently-scoped set (e.g. right after an
-	// install, when the next action under consideration is upgrade) would
-	// otherwise report a mismatch even though nothing has actually changed.
-	composedCreds, err := p.resolveCredentialSets(ctx, i.Namespace, i.CredentialSets, newRef.Definition.Bundle, lastRun.Action)
+	in.newParams, err = prepParametersForComparison(b, opts.GetParameters())
 	if err != nil {
-		return false, err
+		return nil, nil, fmt.Errorf("error prepping current parameters for comparison: %w", err)
+	}
+
+	in.paramsResolved = true
+	return in.oldParams, in.newParams, nil
+}
+
+// credentials returns the credentials that would have been used for the last
+// run, along with the digest of their current values. They are composed using
+// lastRun.Action rather than the action we're about to run - some credentials
+// may only apply to specific actions (see Credential.AppliesTo), so comparing
+// against a differently-scoped set (e.g. right after an install, when the
+// next action under consideration is upgrade) would otherwise report a
+// mismatch even though nothing has actually changed.
+func (in *syncInputs) credentials(ctx context.Context, p *Porter) (storage.CredentialSet, string, error) {
+	if in.credsResolved {
+		return in.creds, in.credsDigest, nil
+	}
+
+	newRef, err := in.action.GetOptions().GetBundleReference(ctx, p)
+	if err != nil {
+		return storage.CredentialSet{}, "", err
+	}
+
+	in.creds, in.credsDigest, err = p.getCredentialsDigest(ctx, in.inst, newRef.Definition, in.lastRun.Action)
+	if err != nil {
+		return storage.CredentialSet{}, "", err
+	}
+
+	in.credsResolved = true
+	return in.creds, in.credsDigest, nil
+}
+
+// checkInstallationState determines if a bundle run would be triggered or
+// ignored based on the state of the installation alone. Returns false when the
+// installation must be compared against its last run to decide.
+func checkInstallationState(i storage.Installation, lastRun *storage.Run) (syncStatus, bool) {
+	trigger := func(reason string) (syncStatus, bool) {
+		return syncStatus{inSync: false, reason: reason}, true
+	}
+	ignore := func(reason string) (syncStatus, bool) {
+		return syncStatus{inSync: true, reason: reason}, true
+	}
+
+	// Has it been uninstalled? If so, we don't ever reconcile it again
+	if i.IsUninstalled() {
+		return ignore("the installation is uninstalled")
+	}
+
+	// Should we uninstall it?
+	if i.Uninstalled {
+		// Only try to uninstall if it's been installed before
+		if i.IsInstalled() {
+			return trigger("installation.uninstalled is true")
+		}
+
+		// Otherwise ignore this installation
+		return ignore("installation.uninstalled is true but the installation doesn't exist yet")
+	} else {
+		// Should we install it?
+		if !i.IsInstalled() {
+			return trigger("the installation has not completed successfully yet")
+		}
+	}
+
+	// We want to upgrade, but we don't have values to compare against
+	// This shouldn't happen but check just in case
+	if lastRun == nil {
+		return trigger("the last run for the installation wasn't recorded")
+	}
+
+	return syncStatus{}, false
+}
+
+// prepParametersForComparison converts parameters to a string to compare them.
+// This avoids problems comparing values that may be equal but have different
+// types due to how the parameter value was loaded.
+func prepParametersForComparison(b cnab.ExtendedBundle, params map[string]interface{}) (map[string]string, error) {
+	compParams := make(map[string]string, len(params))
+	for paramName, rawValue := range params {
+		if b.IsInternalParameter(paramName) {
+			continue
+		}
+
+		typedValue, err := b.ConvertParameterValue(paramName, rawValue)
+		if err != nil {
+			return nil, err
+		}
+
+		stringValue, err := b.WriteParameterToString(paramName, typedValue)
+		if err != nil {
+			return nil, err
+		}
+
+		compParams[paramName] = stringValue
+	}
+	return compParams, nil
+}
+
+// getCredentialsDigest composes and resolves the credentials that the
+// installation would use for the specified action, returning the composed
+// credential set along with the digest of the resolved values.
+func (p *Porter) getCredentialsDigest(ctx context.Context, i storage.Installation, b cnab.ExtendedBundle, action string) (storage.CredentialSet, string, error) {
+	composedCreds, err := p.resolveCredentialSets(ctx, i.Namespace, i.CredentialSets, b.Bundle, action)
+	if err != nil {
+		return storage.CredentialSet{}, "", err
 	}
 
 	resolvedCreds, err := p.Credentials.ResolveAll(ctx, composedCreds, composedCreds.Keys())
 	if err != nil {
-		return false, err
+		return storage.CredentialSet{}, "", err
 	}
 	for idx, cred := range composedCreds.Credentials {
 		composedCreds.Credentials[idx].ResolvedValue = resolvedCreds[cred.Name]
@@ -248,14 +468,7 @@ func (p *Porter) IsInstallationInSync(ctx context.Context, i storage.Installatio
 
 	newCredsRun := storage.Run{Credentials: composedCreds}
 	if err := newCredsRun.SetCredentialsDigest(); err != nil {
-		return false, err
-	}
-
-	if lastRun.CredentialsDigest != newCredsRun.CredentialsDigest {
-		log.Info("Triggering because the credentials have changed",
-			attribute.String("oldDigest", lastRun.CredentialsDigest),
-			attribute.String("newDigest", newCredsRun.CredentialsDigest))
-		return false, nil
+		return storage.CredentialSet{}, "", err
 	}
-	return true, nil
+	return composedCreds, newCredsRun.CredentialsDigest, nil
 }
@@ -0,0 +1,111 @@
+package porter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"get.porter.sh/porter/pkg/cnab"
+	configadapter "get.porter.sh/porter/pkg/cnab/config-adapter"
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/runtime"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// prepareResume updates the action arguments to resume the installation's last
+// run. The last run must have failed while running the same action with the
+// same bundle, and the steps that it completed are skipped by the new run.
+func (p *Porter) prepareResume(ctx context.Context, args *cnabprovider.ActionArguments) error {
+	ctx, span := tracing.StartSpan(ctx, attribute.String("installation", args.Installation.String()))
+	defer span.EndSpan()
+
+	inst := args.Installation
+	lastRun, err := p.Installations.GetLastRun(ctx, inst.Namespace, inst.Name)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound{}) {
+			return span.Errorf("cannot resume installation %s because it does not have a run to resume", inst)
+		}
+		return span.Errorf("could not retrieve the last run of installation %s: %w", inst, err)
+	}
+
+	status, err := p.getRunStatus(ctx, lastRun.ID)
+	if err != nil {
+		return span.Error(err)
+	}
+	if status != cnab.StatusFailed {
+		return span.Errorf("cannot resume run %s of installation %s because its status is %s, only failed runs can be resumed", lastRun.ID, inst, status)
+	}
+	if lastRun.Action != args.Run.Action {
+		return span.Errorf("cannot resume run %s of installation %s with the %s action because it ran the %s action", lastRun.ID, inst, args.Run.Action, lastRun.Action)
+	}
+
+	if err = checkResumeBundle(lastRun, args.BundleReference.Definition); err != nil {
+		return span.Error(err)
+	}
+
+	progress, err := p.getRunStepProgress(ctx, lastRun)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	if args.Files == nil {
+		args.Files = make(map[string]string, 1)
+	}
+	args.Files[config.ResumeFilepath] = progress
+	args.Run.ResumedFrom = lastRun.ID
+
+	fmt.Fprintf(p.Out, "Resuming run %s of installation %s\n", lastRun.ID, inst)
+	return nil
+}
+
+// checkResumeBundle verifies that the bundle has not changed since the run
+// being resumed, by comparing the digest of the manifest used to build it.
+func checkResumeBundle(lastRun storage.Run, bun cnab.ExtendedBundle) error {
+	lastStamp, err := configadapter.LoadStamp(cnab.NewBundle(lastRun.Bundle))
+	if err != nil {
+		return fmt.Errorf("cannot resume run %s because its bundle was not built by Porter: %w", lastRun.ID, err)
+	}
+	stamp, err := configadapter.LoadStamp(bun)
+	if err != nil {
+		return fmt.Errorf("cannot resume run %s because the bundle was not built by Porter: %w", lastRun.ID, err)
+	}
+
+	if stamp.ManifestDigest == "" || stamp.ManifestDigest == "unknown" || stamp.ManifestDigest != lastStamp.ManifestDigest {
+		return fmt.Errorf("cannot resume run %s because the bundle has changed since it was run, the manifest digest %s does not match %s. Run the action again without --resume", lastRun.ID, stamp.ManifestDigest, lastStamp.ManifestDigest)
+	}
+	return nil
+}
+
+// getRunStepProgress returns the step progress recorded by a run, in the
+// format that is placed in the bundle to resume the run.
+func (p *Porter) getRunStepProgress(ctx context.Context, run storage.Run) (string, error) {
+	bun := cnab.NewBundle(run.Bundle)
+	if _, ok := bun.Outputs[config.StepProgressOutput]; !ok {
+		return "", fmt.Errorf("cannot resume run %s because its bundle does not record step progress, rebuild the bundle with a newer version of Porter to support resuming runs", run.ID)
+	}
+
+	outputs, err := p.Installations.GetOutputs(ctx, run.ID)
+	if err != nil {
+		return "", fmt.Errorf("could not list the outputs of run %s: %w", run.ID, err)
+	}
+
+	output, ok := outputs.GetByName(config.StepProgressOutput)
+	if !ok {
+		// The run failed before any steps were completed
+		progress, err := json.Marshal(runtime.StepProgress{Action: run.Action})
+		if err != nil {
+			return "", fmt.Errorf("could not marshal the step progress of run %s: %w", run.ID, err)
+		}
+		return string(progress), nil
+	}
+
+	output, err = p.Sanitizer.RestoreOutput(ctx, output)
+	if err != nil {
+		return "", fmt.Errorf("could not restore the step progress of run %s: %w", run.ID, err)
+	}
+	return string(output.Value), nil
+}
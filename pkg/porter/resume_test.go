@@ -0,0 +1,123 @@
+package porter
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_PrepareResume(t *testing.T) {
+	// buildBundle creates a bundle built by Porter from a manifest with the specified digest
+	buildBundle := func(manifestDigest string) cnab.ExtendedBundle {
+		return cnab.NewBundle(bundle.Bundle{
+			Name: "mybuns",
+			Custom: map[string]interface{}{
+				config.CustomPorterKey: map[string]interface{}{"manifestDigest": manifestDigest},
+			},
+			Outputs: map[string]bundle.Output{
+				config.StepProgressOutput: {Definition: config.StepProgressOutput + "-output"},
+			},
+		})
+	}
+
+	// setup creates an installation whose last run ran the install action with
+	// the specified status and recorded the step progress
+	setup := func(t *testing.T, status string) (*TestPorter, cnabprovider.ActionArguments) {
+		p := NewTestPorter(t)
+
+		bun := buildBundle("abc123")
+		inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		run := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionInstall, bun), func(r *storage.Run) {
+			r.ID = "run-1"
+			r.Bundle = bun.Bundle
+		})
+		result := p.TestInstallations.CreateResult(run.NewResult(status))
+		p.TestInstallations.CreateOutput(result.NewOutput(config.StepProgressOutput, nil), func(o *storage.Output) {
+			o.Key = "run-1-progress"
+		})
+		require.NoError(t, p.Secrets.Create(p.RootContext, secrets.SourceSecret, "run-1-progress",
+			`{"action":"install","completedSteps":[{"index":0,"description":"Create a database"}]}`))
+
+		args := cnabprovider.ActionArguments{
+			Installation:    inst,
+			Run:             inst.NewRun(cnab.ActionInstall, bun),
+			BundleReference: cnab.BundleReference{Definition: bun},
+		}
+		return p, args
+	}
+
+	t.Run("failed run", func(t *testing.T) {
+		p, args := setup(t, cnab.StatusFailed)
+		defer p.Close()
+
+		require.NoError(t, p.prepareResume(p.RootContext, &args))
+		assert.Equal(t, "run-1", args.Run.ResumedFrom)
+		assert.Equal(t, `{"action":"install","completedSteps":[{"index":0,"description":"Create a database"}]}`, args.Files[config.ResumeFilepath],
+			"the step progress of the failed run should be passed to the bundle")
+		assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Resuming run run-1 of installation dev/mybuns")
+	})
+
+	t.Run("failed before completing any steps", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		bun := buildBundle("abc123")
+		inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		run := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionInstall, bun), func(r *storage.Run) {
+			r.Bundle = bun.Bundle
+		})
+		p.TestInstallations.CreateResult(run.NewResult(cnab.StatusFailed))
+
+		args := cnabprovider.ActionArguments{
+			Installation:    inst,
+			Run:             inst.NewRun(cnab.ActionInstall, bun),
+			BundleReference: cnab.BundleReference{Definition: bun},
+		}
+		require.NoError(t, p.prepareResume(p.RootContext, &args))
+		assert.Equal(t, `{"action":"install"}`, args.Files[config.ResumeFilepath])
+	})
+
+	t.Run("succeeded run", func(t *testing.T) {
+		p, args := setup(t, cnab.StatusSucceeded)
+		defer p.Close()
+
+		err := p.prepareResume(p.RootContext, &args)
+		require.ErrorContains(t, err, "only failed runs can be resumed")
+	})
+
+	t.Run("different action", func(t *testing.T) {
+		p, args := setup(t, cnab.StatusFailed)
+		defer p.Close()
+
+		args.Run.Action = cnab.ActionUpgrade
+		err := p.prepareResume(p.RootContext, &args)
+		require.ErrorContains(t, err, "with the upgrade action because it ran the install action")
+	})
+
+	t.Run("bundle changed", func(t *testing.T) {
+		p, args := setup(t, cnab.StatusFailed)
+		defer p.Close()
+
+		args.BundleReference.Definition = buildBundle("def456")
+		err := p.prepareResume(p.RootContext, &args)
+		require.ErrorContains(t, err, "the bundle has changed since it was run")
+		assert.Empty(t, args.Run.ResumedFrom)
+	})
+
+	t.Run("no runs", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		args := cnabprovider.ActionArguments{Installation: inst, Run: inst.NewRun(cnab.ActionInstall, buildBundle("abc123"))}
+		err := p.prepareResume(p.RootContext, &args)
+		require.ErrorContains(t, err, "does not have a run to resume")
+	})
+}
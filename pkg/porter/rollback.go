@@ -0,0 +1,206 @@
+package porter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RollbackOptions are the options for rolling back an installation to a previous run.
+type RollbackOptions struct {
+	printer.PrintOptions
+
+	// Namespace of the installation.
+	Namespace string
+
+	// Name of the installation.
+	Name string
+
+	// RunID of the run to roll back to.
+	RunID string
+
+	// Previous rolls back to the successful run before the current one.
+	Previous bool
+
+	// DryRun prints the plan for the rollback without executing the bundle.
+	DryRun bool
+}
+
+func (o *RollbackOptions) Validate(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("the installation name is required")
+	case 1:
+		o.Name = args[0]
+	default:
+		return fmt.Errorf("only one positional argument may be specified, the installation name, but multiple were received: %s", args)
+	}
+
+	if o.RunID == "" && !o.Previous {
+		return errors.New("either --to-run or --previous must be specified")
+	}
+	if o.RunID != "" && o.Previous {
+		return errors.New("either --to-run or --previous may be set, but not both")
+	}
+
+	return o.PrintOptions.Validate(ApplyDefaultFormat, ApplyAllowedFormats)
+}
+
+// RollbackInstallation upgrades an installation back to the bundle, parameters
+// and credentials used by a previous successful run.
+func (p *Porter) RollbackInstallation(ctx context.Context, opts RollbackOptions) error {
+	ctx, log := tracing.StartSpan(ctx,
+		attribute.String("installation", displayInstallationName(opts.Namespace, opts.Name)),
+		attribute.Bool("dryRun", opts.DryRun))
+	defer log.EndSpan()
+
+	inst, err := p.Installations.GetInstallation(ctx, opts.Namespace, opts.Name)
+	if err != nil {
+		return log.Errorf("could not find installation %s: %w", displayInstallationName(opts.Namespace, opts.Name), err)
+	}
+	if !inst.IsInstalled() || inst.Uninstalled {
+		return log.Errorf("the installation %s cannot be rolled back because it is not installed", inst)
+	}
+
+	target, err := p.getRollbackRun(ctx, inst, opts)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	upgradeOpts, err := p.prepareRollback(ctx, &inst, target)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		plan, err := p.newInstallationPlan(ctx, inst, upgradeOpts)
+		if err != nil {
+			return err
+		}
+		plan.Reason = fmt.Sprintf("rolling back to run %s", target.ID)
+		return p.printInstallationPlan(plan, opts.Format)
+	}
+
+	if err := upgradeOpts.Validate(ctx, nil, p); err != nil {
+		return err
+	}
+
+	inst.Status.Modified = time.Now()
+	if err := inst.Validate(ctx, p.GetSchemaCheckStrategy(ctx)); err != nil {
+		return log.Errorf("invalid installation: %w", err)
+	}
+	if err := p.Installations.UpdateInstallation(ctx, inst); err != nil {
+		return err
+	}
+
+	log.Infof("Rolling back %s to run %s with bundle %s", inst, target.ID, target.BundleReference)
+	return p.ExecuteAction(ctx, inst, upgradeOpts)
+}
+
+// getRollbackRun finds the run that the installation should be rolled back to.
+func (p *Porter) getRollbackRun(ctx context.Context, inst storage.Installation, opts RollbackOptions) (storage.Run, error) {
+	runs, results, err := p.Installations.ListRuns(ctx, inst.Namespace, inst.Name)
+	if err != nil {
+		return storage.Run{}, fmt.Errorf("could not list runs for installation %s: %w", inst, err)
+	}
+
+	// Only successful install and upgrade runs determine the state of the installation
+	var candidates []storage.Run
+	for _, run := range runs {
+		if run.Action != cnab.ActionInstall && run.Action != cnab.ActionUpgrade {
+			continue
+		}
+		runResults := results[run.ID]
+		if len(runResults) == 0 || runResults[len(runResults)-1].Status != cnab.StatusSucceeded {
+			continue
+		}
+		candidates = append(candidates, run)
+	}
+
+	if opts.Previous {
+		// The most recent successful run is the current state of the installation
+		if len(candidates) < 2 {
+			return storage.Run{}, fmt.Errorf("the installation %s does not have a previous successful install or upgrade run to roll back to", inst)
+		}
+		return candidates[len(candidates)-2], nil
+	}
+
+	for _, run := range candidates {
+		if run.ID == opts.RunID {
+			return run, nil
+		}
+	}
+
+	run, err := p.Installations.GetRun(ctx, opts.RunID)
+	if err != nil {
+		return storage.Run{}, fmt.Errorf("could not find run %s: %w", opts.RunID, err)
+	}
+	if run.Namespace != inst.Namespace || run.Installation != inst.Name {
+		return storage.Run{}, fmt.Errorf("run %s belongs to installation %s, not %s", run.ID, displayInstallationName(run.Namespace, run.Installation), inst)
+	}
+	return storage.Run{}, fmt.Errorf("cannot roll back to run %s because only successful install and upgrade runs can be restored", run.ID)
+}
+
+// prepareRollback restores the bundle, parameters and credentials from the
+// target run on the installation, and configures the upgrade that applies them.
+func (p *Porter) prepareRollback(ctx context.Context, inst *storage.Installation, target storage.Run) (*UpgradeOptions, error) {
+	ctx, log := tracing.StartSpan(ctx, attribute.String("run", target.ID))
+	defer log.EndSpan()
+
+	if target.BundleReference == "" {
+		return nil, log.Errorf("cannot roll back to run %s because it did not record a bundle reference", target.ID)
+	}
+	ref, err := cnab.ParseOCIReference(target.BundleReference)
+	if err != nil {
+		return nil, log.Errorf("invalid bundle reference %s recorded on run %s: %w", target.BundleReference, target.ID, err)
+	}
+
+	// Restore the parameter overrides from the run, including the sensitive
+	// values that the Sanitizer saved in the secret store
+	resolved, err := p.Parameters.ResolveAll(ctx, target.ParameterOverrides, target.ParameterOverrides.Keys())
+	if err != nil {
+		return nil, log.Errorf("could not restore the parameters from run %s: %w", target.ID, err)
+	}
+	overrides := make(secrets.StrategyList, 0, len(resolved))
+	for name, value := range resolved {
+		overrides = append(overrides, storage.ValueStrategy(name, value))
+	}
+	sort.Sort(overrides)
+
+	inst.TrackBundle(ref)
+	inst.ParameterSets = target.ParameterSets
+	inst.CredentialSets = target.CredentialSets
+	inst.Parameters = inst.NewInternalParameterSet(overrides...)
+
+	opts := NewUpgradeOptions()
+	opts.Reference = ref.String()
+	opts.Name = inst.Name
+	opts.Namespace = inst.Namespace
+	opts.ParameterSets = inst.ParameterSets
+	opts.CredentialIdentifiers = inst.CredentialSets
+	opts.CurrentParamOverrides = overrides
+	opts.RollbackTo = target.ID
+
+	if err := p.applyActionOptionsToInstallation(ctx, opts, inst); err != nil {
+		return nil, err
+	}
+
+	bundleRef, err := opts.GetBundleReference(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if target.BundleDigest != "" && bundleRef.Digest.String() != target.BundleDigest {
+		log.Warnf("The bundle %s has changed since run %s, the digest was %s and is now %s", ref, target.ID, target.BundleDigest, bundleRef.Digest)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,160 @@
+package porter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	cnabtooci "get.porter.sh/porter/pkg/cnab/cnab-to-oci"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackOptions_Validate(t *testing.T) {
+	t.Run("previous", func(t *testing.T) {
+		opts := RollbackOptions{Previous: true}
+		require.NoError(t, opts.Validate([]string{"mybuns"}))
+		assert.Equal(t, "mybuns", opts.Name)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		opts := RollbackOptions{Previous: true}
+		require.EqualError(t, opts.Validate(nil), "the installation name is required")
+	})
+
+	t.Run("missing run", func(t *testing.T) {
+		opts := RollbackOptions{}
+		require.EqualError(t, opts.Validate([]string{"mybuns"}), "either --to-run or --previous must be specified")
+	})
+
+	t.Run("run and previous", func(t *testing.T) {
+		opts := RollbackOptions{RunID: "abc123", Previous: true}
+		require.EqualError(t, opts.Validate([]string{"mybuns"}), "either --to-run or --previous may be set, but not both")
+	})
+}
+
+func TestPorter_RollbackInstallation(t *testing.T) {
+	bun, err := cnab.LoadBundle(portercontext.New(), filepath.Join("testdata/bundle.json"))
+	require.NoError(t, err)
+
+	// setup creates an installation that was installed with v0.1.0 and then
+	// upgraded to v0.2.0 with a different value for the sensitive my-second-param
+	setup := func(t *testing.T) (*TestPorter, storage.Installation, storage.Run) {
+		p := NewTestPorter(t)
+		p.TestRegistry.MockPullBundle = func(ctx context.Context, ref cnab.OCIReference, opts cnabtooci.RegistryOptions) (cnab.BundleReference, error) {
+			return cnab.BundleReference{Reference: ref, Definition: bun}, nil
+		}
+
+		creds := storage.NewCredentialSet("dev", "azure",
+			storage.ValueStrategy("my-first-cred", "value-1"),
+			storage.ValueStrategy("my-second-cred", "value-2"))
+		require.NoError(t, p.Credentials.InsertCredentialSet(p.RootContext, creds))
+
+		inst := storage.NewInstallation("dev", "mybuns")
+		inst.TrackBundle(cnab.MustParseOCIReference("example.com/mybuns:v0.2.0"))
+		inst.Status.Installed = &now
+		p.TestInstallations.CreateInstallation(inst)
+
+		install := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionInstall, bun), func(r *storage.Run) {
+			r.ID = "run-1"
+			r.BundleReference = "example.com/mybuns:v0.1.0"
+			r.CredentialSets = []string{"azure"}
+			r.ParameterOverrides = storage.NewInternalParameterSet("dev", "mybuns", secrets.SourceMap{
+				Name:   "my-second-param",
+				Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: r.ID + "-my-second-param"},
+			})
+		})
+		require.NoError(t, p.Secrets.Create(p.RootContext, secrets.SourceSecret, "run-1-my-second-param", "old-secret"))
+		p.TestInstallations.CreateResult(install.NewResult(cnab.StatusSucceeded))
+
+		upgrade := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionUpgrade, bun), func(r *storage.Run) {
+			r.ID = "run-2"
+			r.BundleReference = "example.com/mybuns:v0.2.0"
+			r.ParameterOverrides = storage.NewInternalParameterSet("dev", "mybuns", storage.ValueStrategy("my-second-param", "new-secret"))
+		})
+		p.TestInstallations.CreateResult(upgrade.NewResult(cnab.StatusSucceeded))
+
+		failed := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionUpgrade, bun), func(r *storage.Run) {
+			r.ID = "run-3"
+			r.BundleReference = "example.com/mybuns:v0.3.0"
+		})
+		p.TestInstallations.CreateResult(failed.NewResult(cnab.StatusFailed))
+
+		return p, inst, install
+	}
+
+	t.Run("previous", func(t *testing.T) {
+		p, inst, install := setup(t)
+		defer p.Close()
+
+		target, err := p.getRollbackRun(p.RootContext, inst, RollbackOptions{Previous: true})
+		require.NoError(t, err)
+		assert.Equal(t, install.ID, target.ID, "the failed run should be ignored, and the current state is the upgrade run")
+	})
+
+	t.Run("to run", func(t *testing.T) {
+		p, inst, _ := setup(t)
+		defer p.Close()
+
+		target, err := p.getRollbackRun(p.RootContext, inst, RollbackOptions{RunID: "run-2"})
+		require.NoError(t, err)
+		assert.Equal(t, "run-2", target.ID)
+	})
+
+	t.Run("to failed run", func(t *testing.T) {
+		p, inst, _ := setup(t)
+		defer p.Close()
+
+		_, err := p.getRollbackRun(p.RootContext, inst, RollbackOptions{RunID: "run-3"})
+		require.EqualError(t, err, "cannot roll back to run run-3 because only successful install and upgrade runs can be restored")
+	})
+
+	t.Run("to run from another installation", func(t *testing.T) {
+		p, _, _ := setup(t)
+		defer p.Close()
+
+		other := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "other"))
+		_, err := p.getRollbackRun(p.RootContext, other, RollbackOptions{RunID: "run-1"})
+		require.EqualError(t, err, "run run-1 belongs to installation dev/mybuns, not dev/other")
+	})
+
+	t.Run("no previous run", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		_, err := p.getRollbackRun(p.RootContext, inst, RollbackOptions{Previous: true})
+		require.EqualError(t, err, "the installation dev/mybuns does not have a previous successful install or upgrade run to roll back to")
+	})
+
+	t.Run("restores the run", func(t *testing.T) {
+		p, inst, install := setup(t)
+		defer p.Close()
+
+		upgradeOpts, err := p.prepareRollback(p.RootContext, &inst, install)
+		require.NoError(t, err)
+
+		assert.Equal(t, "0.1.0", inst.Bundle.Version)
+		assert.Equal(t, []string{"azure"}, inst.CredentialSets)
+		assert.Equal(t, cnab.ActionUpgrade, upgradeOpts.GetAction())
+		assert.Equal(t, install.ID, upgradeOpts.RollbackTo, "the rollback should be recorded on the new run")
+		assert.Equal(t, "old-secret", upgradeOpts.GetParameters()["my-second-param"], "the sensitive parameter should be restored from the secret store")
+
+		require.Len(t, inst.Parameters.Parameters, 1)
+		assert.Equal(t, secrets.SourceSecret, inst.Parameters.Parameters[0].Source.Strategy,
+			"the restored sensitive parameter should be saved in the secret store again")
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		p := NewTestPorter(t)
+		defer p.Close()
+
+		p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		err := p.RollbackInstallation(p.RootContext, RollbackOptions{Namespace: "dev", Name: "mybuns", Previous: true})
+		require.EqualError(t, err, "the installation dev/mybuns cannot be rolled back because it is not installed")
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"get.porter.sh/porter/pkg/portercontext"
 	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/storage"
 	dtprinter "github.com/carolynvs/datetime-printer"
 )
 
@@ -63,20 +64,7 @@ func (p *Porter) ListInstallationRuns(ctx context.Context, opts RunListOptions)
 		results := runResults[run.ID]
 
 		displayRun := NewDisplayRun(run)
-
-		if len(results) > 0 {
-			displayRun.Status = results[len(results)-1].Status
-
-			switch len(results) {
-			case 2:
-				displayRun.Started = results[0].Created
-				displayRun.Stopped = &results[1].Created
-			case 1:
-				displayRun.Started = results[0].Created
-			default:
-				displayRun.Stopped = &results[len(results)-1].Created
-			}
-		}
+		displayRun.applyResults(results)
 
 		displayRuns = append(displayRuns, displayRun)
 	}
@@ -84,6 +72,26 @@ func (p *Porter) ListInstallationRuns(ctx context.Context, opts RunListOptions)
 	return displayRuns, nil
 }
 
+// applyResults sets the status, and when the run started and stopped, from the
+// results of the run.
+func (r *DisplayRun) applyResults(results []storage.Result) {
+	if len(results) == 0 {
+		return
+	}
+
+	r.Status = results[len(results)-1].Status
+
+	switch len(results) {
+	case 2:
+		r.Started = results[0].Created
+		r.Stopped = &results[1].Created
+	case 1:
+		r.Started = results[0].Created
+	default:
+		r.Stopped = &results[len(results)-1].Created
+	}
+}
+
 func (p *Porter) PrintInstallationRuns(ctx context.Context, opts RunListOptions) error {
 	displayRuns, err := p.ListInstallationRuns(ctx, opts)
 	if err != nil {
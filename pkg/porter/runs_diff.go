@@ -0,0 +1,319 @@
+package porter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RunDiffOptions are the options for comparing two runs of an installation.
+type RunDiffOptions struct {
+	printer.PrintOptions
+
+	// RunA is the id of the run to compare against, usually the older run.
+	RunA string
+
+	// RunB is the id of the run that is compared to RunA.
+	RunB string
+}
+
+// Validate the run diff options and arguments.
+func (o *RunDiffOptions) Validate(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("two positional arguments are required, the ids of the runs to compare, but received: %s", args)
+	}
+	o.RunA = strings.TrimSpace(args[0])
+	o.RunB = strings.TrimSpace(args[1])
+	if o.RunA == "" || o.RunB == "" {
+		return errors.New("the run ids cannot be empty")
+	}
+
+	return o.PrintOptions.Validate(ShowDefaultFormat, ShowAllowedFormats)
+}
+
+// RunDiff reports the differences between two runs.
+// Values that are the same in both runs are not included.
+type RunDiff struct {
+	// RunA is the id of the run that was compared against.
+	RunA string `json:"runA" yaml:"runA"`
+
+	// RunB is the id of the run that was compared to RunA.
+	RunB string `json:"runB" yaml:"runB"`
+
+	// Identical is true when no differences were found.
+	Identical bool `json:"identical" yaml:"identical"`
+
+	// Fields of the run, such as the action or bundle digest, that are different.
+	Fields []ValueDrift `json:"fields,omitempty" yaml:"fields,omitempty"`
+
+	// Parameters that are different, sorted by name.
+	Parameters []ValueDrift `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// ParameterSets that were added or removed.
+	ParameterSets *SetDrift `json:"parameterSets,omitempty" yaml:"parameterSets,omitempty"`
+
+	// CredentialSets that were added or removed.
+	CredentialSets *SetDrift `json:"credentialSets,omitempty" yaml:"credentialSets,omitempty"`
+
+	// Credentials whose mappings are different, sorted by name.
+	Credentials []ValueDrift `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+
+	// Outputs that are different, sorted by name.
+	Outputs []ValueDrift `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// PrintRunDiff compares two runs and prints the differences.
+func (p *Porter) PrintRunDiff(ctx context.Context, opts RunDiffOptions) error {
+	diff, err := p.DiffRuns(ctx, opts.RunA, opts.RunB)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, diff)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, diff)
+	case printer.FormatPlaintext:
+		if diff.Identical {
+			fmt.Fprintf(p.Out, "No differences found between runs %s and %s\n", diff.RunA, diff.RunB)
+			return nil
+		}
+
+		type runDiffRow struct {
+			kind string
+			ValueDrift
+		}
+		var rows []runDiffRow
+		addRows := func(kind string, changes []ValueDrift) {
+			for _, c := range changes {
+				rows = append(rows, runDiffRow{kind: kind, ValueDrift: c})
+			}
+		}
+		addSetRows := func(kind string, changes *SetDrift) {
+			if changes == nil {
+				return
+			}
+			for _, name := range changes.Added {
+				rows = append(rows, runDiffRow{kind: kind, ValueDrift: ValueDrift{Name: name, Change: DriftChangeAdded, NewValue: name}})
+			}
+			for _, name := range changes.Removed {
+				rows = append(rows, runDiffRow{kind: kind, ValueDrift: ValueDrift{Name: name, Change: DriftChangeRemoved, OldValue: name}})
+			}
+		}
+		addRows("run", diff.Fields)
+		addRows("parameter", diff.Parameters)
+		addSetRows("parameter set", diff.ParameterSets)
+		addSetRows("credential set", diff.CredentialSets)
+		addRows("credential", diff.Credentials)
+		addRows("output", diff.Outputs)
+
+		row := func(v interface{}) []string {
+			r, ok := v.(runDiffRow)
+			if !ok {
+				return nil
+			}
+			oldValue, newValue := r.OldValue, r.NewValue
+			if r.Sensitive {
+				oldValue, newValue = "******", "******"
+			}
+			return []string{r.kind, r.Name, r.Change, oldValue, newValue}
+		}
+		return printer.PrintTable(p.Out, rows, row, "Type", "Name", "Change", diff.RunA, diff.RunB)
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
+
+// DiffRuns compares two runs, including their parameters, credentials, outputs
+// and final status. Sensitive parameter and output values are compared but not
+// included in the report.
+func (p *Porter) DiffRuns(ctx context.Context, runAID string, runBID string) (RunDiff, error) {
+	ctx, log := tracing.StartSpan(ctx, attribute.String("runA", runAID), attribute.String("runB", runBID))
+	defer log.EndSpan()
+
+	diff := RunDiff{RunA: runAID, RunB: runBID}
+
+	runA, err := p.Installations.GetRun(ctx, runAID)
+	if err != nil {
+		return diff, log.Errorf("could not find run %s: %w", runAID, err)
+	}
+	runB, err := p.Installations.GetRun(ctx, runBID)
+	if err != nil {
+		return diff, log.Errorf("could not find run %s: %w", runBID, err)
+	}
+
+	statusA, err := p.getRunStatus(ctx, runA.ID)
+	if err != nil {
+		return diff, log.Error(err)
+	}
+	statusB, err := p.getRunStatus(ctx, runB.ID)
+	if err != nil {
+		return diff, log.Error(err)
+	}
+
+	diff.Fields = diffFields([][3]string{
+		{"installation", displayInstallationName(runA.Namespace, runA.Installation), displayInstallationName(runB.Namespace, runB.Installation)},
+		{"action", runA.Action, runB.Action},
+		{"bundleReference", runA.BundleReference, runB.BundleReference},
+		{"bundleVersion", runA.Bundle.Version, runB.Bundle.Version},
+		{"bundleDigest", runA.BundleDigest, runB.BundleDigest},
+		{"parametersDigest", runA.ParametersDigest, runB.ParametersDigest},
+		{"credentialsDigest", runA.CredentialsDigest, runB.CredentialsDigest},
+		{"status", statusA, statusB},
+	})
+
+	bunA := cnab.NewBundle(runA.Bundle)
+	bunB := cnab.NewBundle(runB.Bundle)
+	paramsA, err := p.getRunParametersForComparison(ctx, runA, bunA)
+	if err != nil {
+		return diff, log.Error(err)
+	}
+	paramsB, err := p.getRunParametersForComparison(ctx, runB, bunB)
+	if err != nil {
+		return diff, log.Error(err)
+	}
+	diff.Parameters = diffParameters(bunB, paramsA, paramsB)
+	for i, c := range diff.Parameters {
+		// A parameter may only be sensitive in one version of the bundle
+		if bunA.IsSensitiveParameter(c.Name) {
+			diff.Parameters[i] = ValueDrift{Name: c.Name, Change: c.Change, Sensitive: true}
+		}
+	}
+
+	diff.ParameterSets = diffSets(runA.ParameterSets, runB.ParameterSets)
+	diff.CredentialSets = diffSets(runA.CredentialSets, runB.CredentialSets)
+	diff.Credentials = diffCredentials(runA.Credentials.Credentials, runB.Credentials.Credentials)
+
+	outputsA, err := p.getRunOutputsForComparison(ctx, runA)
+	if err != nil {
+		return diff, log.Error(err)
+	}
+	outputsB, err := p.getRunOutputsForComparison(ctx, runB)
+	if err != nil {
+		return diff, log.Error(err)
+	}
+	diff.Outputs = diffOutputs(outputsA, outputsB)
+
+	diff.Identical = len(diff.Fields) == 0 && len(diff.Parameters) == 0 &&
+		diff.ParameterSets == nil && diff.CredentialSets == nil &&
+		len(diff.Credentials) == 0 && len(diff.Outputs) == 0
+	return diff, nil
+}
+
+// getRunStatus returns the status of the most recent result of the run.
+func (p *Porter) getRunStatus(ctx context.Context, runID string) (string, error) {
+	results, err := p.Installations.ListResults(ctx, runID)
+	if err != nil {
+		return "", fmt.Errorf("could not list results for run %s: %w", runID, err)
+	}
+	if len(results) == 0 {
+		return cnab.StatusUnknown, nil
+	}
+	return results[len(results)-1].Status, nil
+}
+
+// getRunParametersForComparison restores the parameters used by a run,
+// including sensitive values, and converts them to strings.
+func (p *Porter) getRunParametersForComparison(ctx context.Context, run storage.Run, b cnab.ExtendedBundle) (map[string]string, error) {
+	params, err := p.Sanitizer.RestoreParameterSet(ctx, run.Parameters, b)
+	if err != nil {
+		return nil, fmt.Errorf("could not restore the parameters for run %s: %w", run.ID, err)
+	}
+	compParams, err := prepParametersForComparison(b, params)
+	if err != nil {
+		return nil, fmt.Errorf("error prepping the parameters for run %s for comparison: %w", run.ID, err)
+	}
+	return compParams, nil
+}
+
+// runOutput is an output value prepared for comparison.
+type runOutput struct {
+	value     string
+	sensitive bool
+}
+
+// getRunOutputsForComparison restores the outputs generated by a run,
+// including sensitive values. The bundle logs and step report are not compared
+// because they are different for every run.
+func (p *Porter) getRunOutputsForComparison(ctx context.Context, run storage.Run) (map[string]runOutput, error) {
+	outputs, err := p.Installations.GetOutputs(ctx, run.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list outputs for run %s: %w", run.ID, err)
+	}
+
+	compOutputs := make(map[string]runOutput, outputs.Len())
+	for _, output := range outputs.Value() {
+		if output.Name == cnab.OutputInvocationImageLogs || output.Name == config.StepReportOutput {
+			continue
+		}
+
+		restored, err := p.Sanitizer.RestoreOutput(ctx, output)
+		if err != nil {
+			return nil, fmt.Errorf("could not restore output %s for run %s: %w", output.Name, run.ID, err)
+		}
+		compOutputs[output.Name] = runOutput{value: string(restored.Value), sensitive: output.Key != ""}
+	}
+	return compOutputs, nil
+}
+
+// diffFields compares named values, each specified as name, old value and new value.
+func diffFields(fields [][3]string) []ValueDrift {
+	var changes []ValueDrift
+	for _, f := range fields {
+		name, oldValue, newValue := f[0], f[1], f[2]
+		switch {
+		case oldValue == newValue:
+			continue
+		case oldValue == "":
+			changes = append(changes, ValueDrift{Name: name, Change: DriftChangeAdded, NewValue: newValue})
+		case newValue == "":
+			changes = append(changes, ValueDrift{Name: name, Change: DriftChangeRemoved, OldValue: oldValue})
+		default:
+			changes = append(changes, ValueDrift{Name: name, Change: DriftChangeModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return changes
+}
+
+func diffOutputs(oldOutputs map[string]runOutput, newOutputs map[string]runOutput) []ValueDrift {
+	var changes []ValueDrift
+	addChange := func(name string, change string, oldOutput runOutput, newOutput runOutput) {
+		c := ValueDrift{Name: name, Change: change}
+		if oldOutput.sensitive || newOutput.sensitive {
+			c.Sensitive = true
+		} else {
+			c.OldValue = oldOutput.value
+			c.NewValue = newOutput.value
+		}
+		changes = append(changes, c)
+	}
+
+	for name, oldOutput := range oldOutputs {
+		newOutput, ok := newOutputs[name]
+		if !ok {
+			addChange(name, DriftChangeRemoved, oldOutput, runOutput{})
+		} else if oldOutput.value != newOutput.value {
+			addChange(name, DriftChangeModified, oldOutput, newOutput)
+		}
+	}
+	for name, newOutput := range newOutputs {
+		if _, ok := oldOutputs[name]; !ok {
+			addChange(name, DriftChangeAdded, runOutput{}, newOutput)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
@@ -0,0 +1,163 @@
+package porter
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDiffOptions_Validate(t *testing.T) {
+	t.Run("two runs", func(t *testing.T) {
+		opts := RunDiffOptions{}
+		require.NoError(t, opts.Validate([]string{"run-1", "run-2"}))
+		assert.Equal(t, "run-1", opts.RunA)
+		assert.Equal(t, "run-2", opts.RunB)
+		assert.Equal(t, printer.FormatPlaintext, opts.Format)
+	})
+
+	t.Run("one run", func(t *testing.T) {
+		opts := RunDiffOptions{}
+		require.ErrorContains(t, opts.Validate([]string{"run-1"}), "two positional arguments are required")
+	})
+}
+
+func TestPorter_DiffRuns(t *testing.T) {
+	bun, err := cnab.LoadBundle(portercontext.New(), filepath.Join("testdata/bundle.json"))
+	require.NoError(t, err)
+
+	// setup creates an install run and an upgrade run that changed the bundle,
+	// the sensitive my-second-param, a credential mapping and the outputs
+	setup := func(t *testing.T) *TestPorter {
+		p := NewTestPorter(t)
+
+		inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		install := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionInstall, bun), func(r *storage.Run) {
+			r.ID = "run-1"
+			r.Bundle = bun.Bundle
+			r.BundleReference = "example.com/mybuns:v0.1.0"
+			r.BundleDigest = "sha256:abc"
+			r.ParametersDigest = "params-1"
+			r.Parameters = storage.NewInternalParameterSet("dev", "mybuns",
+				storage.ValueStrategy("my-first-param", "1"),
+				secrets.SourceMap{Name: "my-second-param", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: r.ID + "-my-second-param"}})
+			r.Credentials = storage.NewCredentialSet("dev", "mybuns",
+				secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: "env", Hint: "FIRST_CRED"}})
+		})
+		require.NoError(t, p.Secrets.Create(p.RootContext, secrets.SourceSecret, "run-1-my-second-param", "old-secret"))
+		result := p.TestInstallations.CreateResult(install.NewResult(cnab.StatusSucceeded))
+		p.TestInstallations.CreateOutput(result.NewOutput("port", []byte("8080")))
+		p.TestInstallations.CreateOutput(result.NewOutput(cnab.OutputInvocationImageLogs, []byte("install logs")))
+
+		upgrade := p.TestInstallations.CreateRun(inst.NewRun(cnab.ActionUpgrade, bun), func(r *storage.Run) {
+			r.ID = "run-2"
+			r.Bundle = bun.Bundle
+			r.BundleReference = "example.com/mybuns:v0.1.1"
+			r.BundleDigest = "sha256:def"
+			r.ParametersDigest = "params-2"
+			r.Parameters = storage.NewInternalParameterSet("dev", "mybuns",
+				storage.ValueStrategy("my-first-param", "1"),
+				secrets.SourceMap{Name: "my-second-param", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: r.ID + "-my-second-param"}})
+			r.Credentials = storage.NewCredentialSet("dev", "mybuns",
+				secrets.SourceMap{Name: "my-first-cred", Source: secrets.Source{Strategy: "env", Hint: "NEW_FIRST_CRED"}})
+		})
+		require.NoError(t, p.Secrets.Create(p.RootContext, secrets.SourceSecret, "run-2-my-second-param", "new-secret"))
+		result = p.TestInstallations.CreateResult(upgrade.NewResult(cnab.StatusFailed))
+		p.TestInstallations.CreateOutput(result.NewOutput("port", []byte("9090")))
+		p.TestInstallations.CreateOutput(result.NewOutput(cnab.OutputInvocationImageLogs, []byte("upgrade logs")))
+		p.TestInstallations.CreateOutput(result.NewOutput("kubeconfig", nil), func(o *storage.Output) {
+			o.Key = "run-2-kubeconfig"
+		})
+		require.NoError(t, p.Secrets.Create(p.RootContext, secrets.SourceSecret, "run-2-kubeconfig", "top-secret"))
+
+		return p
+	}
+
+	t.Run("different runs", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		diff, err := p.DiffRuns(p.RootContext, "run-1", "run-2")
+		require.NoError(t, err)
+		assert.False(t, diff.Identical)
+
+		assert.Equal(t, []ValueDrift{
+			{Name: "action", Change: DriftChangeModified, OldValue: cnab.ActionInstall, NewValue: cnab.ActionUpgrade},
+			{Name: "bundleReference", Change: DriftChangeModified, OldValue: "example.com/mybuns:v0.1.0", NewValue: "example.com/mybuns:v0.1.1"},
+			{Name: "bundleDigest", Change: DriftChangeModified, OldValue: "sha256:abc", NewValue: "sha256:def"},
+			{Name: "parametersDigest", Change: DriftChangeModified, OldValue: "params-1", NewValue: "params-2"},
+			{Name: "status", Change: DriftChangeModified, OldValue: cnab.StatusSucceeded, NewValue: cnab.StatusFailed},
+		}, diff.Fields)
+		assert.Equal(t, []ValueDrift{
+			{Name: "my-second-param", Change: DriftChangeModified, Sensitive: true},
+		}, diff.Parameters, "the sensitive parameter should be compared but its value should not be included")
+		assert.Equal(t, []ValueDrift{
+			{Name: "my-first-cred", Change: DriftChangeModified, OldValue: "env:FIRST_CRED", NewValue: "env:NEW_FIRST_CRED"},
+		}, diff.Credentials)
+		assert.Equal(t, []ValueDrift{
+			{Name: "kubeconfig", Change: DriftChangeAdded, Sensitive: true},
+			{Name: "port", Change: DriftChangeModified, OldValue: "8080", NewValue: "9090"},
+		}, diff.Outputs, "the logs should not be compared and sensitive outputs should be masked")
+	})
+
+	t.Run("same run", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		diff, err := p.DiffRuns(p.RootContext, "run-2", "run-2")
+		require.NoError(t, err)
+		assert.True(t, diff.Identical)
+	})
+
+	t.Run("missing run", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		_, err := p.DiffRuns(p.RootContext, "run-1", "run-3")
+		require.ErrorContains(t, err, "could not find run run-3")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		opts := RunDiffOptions{}
+		opts.RawFormat = "json"
+		require.NoError(t, opts.Validate([]string{"run-1", "run-2"}))
+		require.NoError(t, p.PrintRunDiff(p.RootContext, opts))
+
+		// Logs are printed in tests, skip ahead to the diff
+		output := p.TestConfig.TestContext.GetOutput()
+		start := strings.Index(output, "{\n")
+		require.GreaterOrEqual(t, start, 0, "the diff was not printed")
+		assert.NotContains(t, output, "new-secret", "sensitive values should not be printed")
+
+		var diff RunDiff
+		require.NoError(t, json.Unmarshal([]byte(output[start:]), &diff))
+		assert.Equal(t, "run-1", diff.RunA)
+		assert.Len(t, diff.Outputs, 2)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		opts := RunDiffOptions{}
+		require.NoError(t, opts.Validate([]string{"run-1", "run-2"}))
+		require.NoError(t, p.PrintRunDiff(p.RootContext, opts))
+
+		output := p.TestConfig.TestContext.GetOutput()
+		assert.Contains(t, output, "my-second-param")
+		assert.Contains(t, output, "******")
+		assert.NotContains(t, output, "old-secret")
+		assert.NotContains(t, output, "top-secret")
+	})
+}
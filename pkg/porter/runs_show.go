@@ -0,0 +1,160 @@
+package porter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/runtime"
+	"get.porter.sh/porter/pkg/tracing"
+	dtprinter "github.com/carolynvs/datetime-printer"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RunShowOptions are the options for showing a run of an installation.
+type RunShowOptions struct {
+	printer.PrintOptions
+
+	// RunID is the id of the run to show.
+	RunID string
+}
+
+// Validate the run show options and arguments.
+func (o *RunShowOptions) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("one positional argument is required, the id of the run, but received: %s", args)
+	}
+	o.RunID = strings.TrimSpace(args[0])
+	if o.RunID == "" {
+		return errors.New("the run id cannot be empty")
+	}
+
+	return o.PrintOptions.Validate(ShowDefaultFormat, ShowAllowedFormats)
+}
+
+// DisplayRunDetails is a run of an installation, including the result of each
+// step that it ran.
+type DisplayRunDetails struct {
+	DisplayRun `yaml:",inline"`
+
+	// Namespace of the installation.
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	// Installation that was run.
+	Installation string `json:"installation" yaml:"installation"`
+
+	// Steps run by the action, in the order that they completed. Runs of
+	// bundles built before the step report was supported do not have steps.
+	Steps []runtime.StepResult `json:"steps,omitempty" yaml:"steps,omitempty"`
+}
+
+// GetInstallationRun retrieves a run, along with the result of each step
+// that it ran.
+func (p *Porter) GetInstallationRun(ctx context.Context, runID string) (DisplayRunDetails, error) {
+	ctx, log := tracing.StartSpan(ctx, attribute.String("run", runID))
+	defer log.EndSpan()
+
+	run, err := p.Installations.GetRun(ctx, runID)
+	if err != nil {
+		return DisplayRunDetails{}, log.Errorf("could not find run %s: %w", runID, err)
+	}
+
+	results, err := p.Installations.ListResults(ctx, run.ID)
+	if err != nil {
+		return DisplayRunDetails{}, log.Errorf("could not list results for run %s: %w", run.ID, err)
+	}
+
+	details := DisplayRunDetails{
+		DisplayRun:   NewDisplayRun(run),
+		Namespace:    run.Namespace,
+		Installation: run.Installation,
+	}
+	details.applyResults(results)
+
+	outputs, err := p.Installations.GetOutputs(ctx, run.ID)
+	if err != nil {
+		return DisplayRunDetails{}, log.Errorf("could not list the outputs of run %s: %w", run.ID, err)
+	}
+
+	if output, ok := outputs.GetByName(config.StepReportOutput); ok {
+		var report runtime.StepReport
+		if err = json.Unmarshal(output.Value, &report); err != nil {
+			return DisplayRunDetails{}, log.Errorf("could not parse the step report of run %s: %w", run.ID, err)
+		}
+		details.Steps = report.Steps
+	}
+
+	return details, nil
+}
+
+// ShowInstallationRun prints a run, along with the result of each step that it ran.
+func (p *Porter) ShowInstallationRun(ctx context.Context, opts RunShowOptions) error {
+	details, err := p.GetInstallationRun(ctx, opts.RunID)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, details)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, details)
+	case printer.FormatPlaintext:
+		// Set up human friendly time formatter
+		now := time.Now()
+		tp := dtprinter.DateTimePrinter{
+			Now: func() time.Time { return now },
+		}
+
+		fmt.Fprintf(p.Out, "Run ID: %s\n", details.ID)
+		fmt.Fprintf(p.Out, "Installation: %s\n", displayInstallationName(details.Namespace, details.Installation))
+		fmt.Fprintf(p.Out, "Action: %s\n", details.Action)
+		if details.Bundle != "" {
+			fmt.Fprintf(p.Out, "Bundle: %s\n", details.Bundle)
+		}
+		fmt.Fprintf(p.Out, "Status: %s\n", details.Status)
+		fmt.Fprintf(p.Out, "Started: %s\n", tp.Format(details.Started))
+		if details.Stopped != nil {
+			fmt.Fprintf(p.Out, "Stopped: %s\n", tp.Format(*details.Stopped))
+		}
+		if details.ResumedFrom != "" {
+			fmt.Fprintf(p.Out, "Resumed From: %s\n", details.ResumedFrom)
+		}
+
+		fmt.Fprintln(p.Out)
+		if len(details.Steps) == 0 {
+			fmt.Fprintln(p.Out, "No step results were recorded for this run.")
+			return nil
+		}
+
+		fmt.Fprintln(p.Out, "Steps:")
+		row := func(v interface{}) []string {
+			s, ok := v.(runtime.StepResult)
+			if !ok {
+				return nil
+			}
+
+			step := strconv.Itoa(s.Index)
+			if s.Hook != "" {
+				step = fmt.Sprintf("%s[%d]", s.Hook, s.Index)
+			}
+
+			var duration, exitCode string
+			if s.Status != runtime.StepStatusSkipped {
+				duration = s.Duration().Round(time.Millisecond).String()
+				exitCode = strconv.Itoa(s.ExitCode)
+			}
+
+			return []string{step, s.Description, s.Mixin, s.Status, duration, exitCode, strings.Join(s.Outputs, ", ")}
+		}
+		return printer.PrintTable(p.Out, details.Steps, row, "Step", "Description", "Mixin", "Status", "Duration", "Exit Code", "Outputs")
+	default:
+		return fmt.Errorf("invalid format: %s", opts.Format)
+	}
+}
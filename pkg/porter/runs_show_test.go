@@ -0,0 +1,124 @@
+package porter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/runtime"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShowOptions_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		opts := RunShowOptions{}
+		require.NoError(t, opts.Validate([]string{"01FZVC5AVP8Z7A78CSCP1EJ604"}))
+		assert.Equal(t, "01FZVC5AVP8Z7A78CSCP1EJ604", opts.RunID)
+		assert.Equal(t, printer.FormatPlaintext, opts.Format)
+	})
+
+	t.Run("missing run id", func(t *testing.T) {
+		opts := RunShowOptions{}
+		require.ErrorContains(t, opts.Validate(nil), "one positional argument is required")
+	})
+
+	t.Run("empty run id", func(t *testing.T) {
+		opts := RunShowOptions{}
+		require.ErrorContains(t, opts.Validate([]string{" "}), "the run id cannot be empty")
+	})
+}
+
+// createRunWithStepReport creates a failed install run that recorded a step report.
+func createRunWithStepReport(t *testing.T, p *TestPorter) storage.Run {
+	installation := p.TestInstallations.CreateInstallation(storage.NewInstallation("staging", "mysql"), p.TestInstallations.SetMutableInstallationValues)
+	run := p.TestInstallations.CreateRun(installation.NewRun(cnab.ActionInstall, cnab.ExtendedBundle{}), p.TestInstallations.SetMutableRunValues)
+	p.TestInstallations.CreateResult(run.NewResult(cnab.StatusRunning), p.TestInstallations.SetMutableResultValues)
+	result := p.TestInstallations.CreateResult(run.NewResult(cnab.StatusFailed), p.TestInstallations.SetMutableResultValues)
+
+	report := runtime.StepReport{
+		Action: cnab.ActionInstall,
+		Steps: []runtime.StepResult{
+			{Index: 0, Mixin: "exec", Description: "Create a database", Status: runtime.StepStatusSucceeded,
+				Started: now, Stopped: now.Add(1500 * time.Millisecond), Outputs: []string{"connstr", "password"}},
+			{Index: 1, Mixin: "exec", Description: "Seed the database", Status: runtime.StepStatusSkipped,
+				Started: now.Add(2 * time.Second), Stopped: now.Add(2 * time.Second)},
+			{Index: 2, Mixin: "helm3", Description: "Install the chart", Status: runtime.StepStatusFailed,
+				Started: now.Add(2 * time.Second), Stopped: now.Add(62 * time.Second), ExitCode: 2},
+			{Index: 0, Hook: "finally", Mixin: "exec", Description: "Clean up", Status: runtime.StepStatusSucceeded,
+				Started: now.Add(63 * time.Second), Stopped: now.Add(64 * time.Second)},
+		},
+	}
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	p.TestInstallations.CreateOutput(result.NewOutput(config.StepReportOutput, data))
+	return run
+}
+
+func TestPorter_GetInstallationRun(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := context.Background()
+
+	run := createRunWithStepReport(t, p)
+
+	details, err := p.GetInstallationRun(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, run.ID, details.ID)
+	assert.Equal(t, "staging", details.Namespace)
+	assert.Equal(t, "mysql", details.Installation)
+	assert.Equal(t, cnab.StatusFailed, details.Status)
+	require.NotNil(t, details.Stopped, "the run should have stopped")
+	require.Len(t, details.Steps, 4)
+	assert.Equal(t, "Install the chart", details.Steps[2].Description)
+	assert.Equal(t, 2, details.Steps[2].ExitCode)
+	assert.Equal(t, time.Minute, details.Steps[2].Duration())
+
+	t.Run("no step report", func(t *testing.T) {
+		installation := p.TestInstallations.CreateInstallation(storage.NewInstallation("", "legacy"), p.TestInstallations.SetMutableInstallationValues)
+		legacyRun := p.TestInstallations.CreateRun(installation.NewRun(cnab.ActionInstall, cnab.ExtendedBundle{}), p.TestInstallations.SetMutableRunValues)
+		p.TestInstallations.CreateResult(legacyRun.NewResult(cnab.StatusSucceeded), p.TestInstallations.SetMutableResultValues)
+
+		details, err := p.GetInstallationRun(ctx, legacyRun.ID)
+		require.NoError(t, err)
+		assert.Equal(t, cnab.StatusSucceeded, details.Status)
+		assert.Empty(t, details.Steps, "runs of bundles that do not record a step report should not have steps")
+	})
+
+	t.Run("run not found", func(t *testing.T) {
+		_, err := p.GetInstallationRun(ctx, "missing")
+		require.ErrorContains(t, err, "could not find run missing")
+	})
+}
+
+func TestPorter_ShowInstallationRun(t *testing.T) {
+	testcases := []struct {
+		name       string
+		format     printer.Format
+		outputFile string
+	}{
+		{name: "yaml", format: printer.FormatYaml, outputFile: "testdata/runs/show/expected-output.yaml"},
+		{name: "json", format: printer.FormatJson, outputFile: "testdata/runs/show/expected-output.json"},
+		{name: "plaintext", format: printer.FormatPlaintext, outputFile: "testdata/runs/show/expected-output.txt"},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestPorter(t)
+			defer p.Close()
+
+			run := createRunWithStepReport(t, p)
+
+			opts := RunShowOptions{RunID: run.ID, PrintOptions: printer.PrintOptions{Format: tc.format}}
+			require.NoError(t, p.ShowInstallationRun(context.Background(), opts))
+
+			p.CompareGoldenFile(tc.outputFile, p.TestConfig.TestContext.GetOutput())
+		})
+	}
+}
@@ -154,6 +154,9 @@ func (p *Porter) injectMixinSchemas(ctx context.Context, manifestSchema jsonSche
 			mixinDeclSchema = append(mixinDeclSchema, jsonObject{"$ref": mixinConfigRef})
 		}
 
+		// Allow the Porter-level step fields, such as when and retry, on each of the mixin's steps
+		injectStepProperties(mixinSchemaMap, append(coreActions, "invoke"))
+
 		// embed the entire mixin schema in the root
 		manifestSchema["mixin."+mixin] = mixinSchemaMap
 
@@ -204,6 +207,30 @@ func (p *Porter) injectMixinSchemas(ctx context.Context, manifestSchema jsonSche
 	return manifestSchema, span.Error(err)
 }
 
+// injectStepProperties adds the Porter-level step fields to the properties of
+// the mixin's step definitions, e.g. installStep, so that they are allowed
+// alongside the mixin's own step.
+func injectStepProperties(mixinSchemaMap jsonSchema, actions []string) {
+	definitions, ok := mixinSchemaMap["definitions"].(jsonSchema)
+	if !ok {
+		return
+	}
+
+	for _, action := range actions {
+		stepSchema, ok := definitions[action+"Step"].(jsonSchema)
+		if !ok {
+			continue
+		}
+		stepProperties, ok := stepSchema["properties"].(jsonSchema)
+		if !ok {
+			continue
+		}
+		stepProperties["when"] = jsonObject{"$ref": "#/definitions/stepCondition"}
+		stepProperties["retry"] = jsonObject{"$ref": "#/definitions/stepRetry"}
+		stepProperties["timeout"] = jsonObject{"$ref": "#/definitions/stepTimeout"}
+	}
+}
+
 func (p *Porter) GetReplacementSchema() (jsonSchema, error) {
 	home, err := p.GetHomeDir()
 	if err != nil {
@@ -0,0 +1,104 @@
+package porter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"get.porter.sh/porter/pkg/plugins"
+	secretsplugins "get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/secrets/plugins/encrypted_filesystem"
+	"get.porter.sh/porter/pkg/tracing"
+)
+
+// RotateSecretsKeyOptions are the options for porter secrets rotate-key.
+type RotateSecretsKeyOptions struct {
+	// Secrets is the name of the secrets plugin configuration in the config file.
+	// Defaults to the default-secrets configuration.
+	Secrets string
+
+	// NewKeyFile is the path to the file with the new key.
+	NewKeyFile string
+
+	// NewKeyEnv is the environment variable with the new key.
+	NewKeyEnv string
+
+	// NewPassphraseEnv is the environment variable with the new passphrase.
+	NewPassphraseEnv string
+
+	// GenerateKey creates NewKeyFile with a random key before the rotation.
+	GenerateKey bool
+}
+
+func (o RotateSecretsKeyOptions) newKeySource() encrypted_filesystem.KeySource {
+	return encrypted_filesystem.KeySource{
+		KeyFile:       o.NewKeyFile,
+		KeyEnv:        o.NewKeyEnv,
+		PassphraseEnv: o.NewPassphraseEnv,
+	}
+}
+
+func (o RotateSecretsKeyOptions) Validate() error {
+	if err := o.newKeySource().Validate(); err != nil {
+		return errors.New("exactly one of --new-key-file, --new-key-env or --new-passphrase-env must be specified")
+	}
+	if o.GenerateKey && o.NewKeyFile == "" {
+		return errors.New("--generate-key requires --new-key-file")
+	}
+	return nil
+}
+
+// RotateSecretsKey re-encrypts the secrets stored by an encrypted-filesystem
+// secrets plugin with a new key.
+func (p *Porter) RotateSecretsKey(ctx context.Context, opts RotateSecretsKeyOptions) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := opts.Validate(); err != nil {
+		return log.Error(err)
+	}
+
+	name := opts.Secrets
+	if name == "" {
+		name = p.Config.Data.DefaultSecrets
+	}
+	if name == "" {
+		return log.Error(errors.New("no secrets plugin configuration was specified, use --secrets or set default-secrets in the config file"))
+	}
+
+	pluginCfg, err := p.Config.GetSecretsPlugin(name)
+	if err != nil {
+		return log.Error(err)
+	}
+	key, err := plugins.ParsePluginKey(pluginCfg.PluginSubKey)
+	if err != nil {
+		return log.Error(err)
+	}
+	key.Interface = secretsplugins.PluginInterface
+	if key.String() != encrypted_filesystem.PluginKey {
+		return log.Error(fmt.Errorf("secrets %s uses the %s plugin, only the encrypted-filesystem plugin supports rotating the key", name, pluginCfg.PluginSubKey))
+	}
+
+	cfg, err := encrypted_filesystem.ParsePluginConfig(pluginCfg.Config)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	if opts.GenerateKey {
+		if err := encrypted_filesystem.GenerateKeyFile(p.Context, opts.NewKeyFile); err != nil {
+			return log.Error(err)
+		}
+		fmt.Fprintf(p.Out, "Generated a new key in %s\n", opts.NewKeyFile)
+	}
+
+	store := encrypted_filesystem.NewStore(p.Config, cfg)
+	defer store.Close()
+	count, err := store.RotateKey(ctx, opts.newKeySource())
+	if err != nil {
+		return log.Error(err)
+	}
+
+	fmt.Fprintf(p.Out, "Re-encrypted %d secrets in %s with the new key\n", count, store.SecretDir())
+	fmt.Fprintf(p.Out, "Update the configuration of the %s secrets in your config file to use the new key\n", name)
+	return nil
+}
@@ -0,0 +1,74 @@
+package porter
+
+import (
+	"context"
+	"testing"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/secrets/plugins/encrypted_filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateSecretsKeyOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    RotateSecretsKeyOptions
+		wantErr string
+	}{
+		{name: "key file", opts: RotateSecretsKeyOptions{NewKeyFile: "new.key", GenerateKey: true}},
+		{name: "passphrase", opts: RotateSecretsKeyOptions{NewPassphraseEnv: "PASSPHRASE"}},
+		{name: "no key", opts: RotateSecretsKeyOptions{}, wantErr: "exactly one of --new-key-file, --new-key-env or --new-passphrase-env must be specified"},
+		{name: "multiple keys", opts: RotateSecretsKeyOptions{NewKeyFile: "new.key", NewKeyEnv: "KEY"}, wantErr: "exactly one of"},
+		{name: "generate without file", opts: RotateSecretsKeyOptions{NewKeyEnv: "KEY", GenerateKey: true}, wantErr: "--generate-key requires --new-key-file"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPorter_RotateSecretsKey(t *testing.T) {
+	ctx := context.Background()
+	p := NewTestPorter(t)
+	defer p.Close()
+
+	require.NoError(t, encrypted_filesystem.GenerateKeyFile(p.Context, "/old.key"))
+	p.Config.Data.DefaultSecrets = "team"
+	p.Config.Data.SecretsPlugin = []config.SecretsPlugin{
+		{PluginConfig: config.PluginConfig{Name: "team", PluginSubKey: "encrypted-filesystem", Config: map[string]interface{}{
+			"path": "/shared/secrets", "key-file": "/old.key"}}},
+		{PluginConfig: config.PluginConfig{Name: "vault", PluginSubKey: "hashicorp.vault"}},
+	}
+
+	oldStore := encrypted_filesystem.NewStore(p.Config, encrypted_filesystem.PluginConfig{
+		Path: "/shared/secrets", KeySource: encrypted_filesystem.KeySource{KeyFile: "/old.key"}})
+	require.NoError(t, oldStore.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+
+	t.Run("unsupported plugin", func(t *testing.T) {
+		err := p.RotateSecretsKey(ctx, RotateSecretsKeyOptions{Secrets: "vault", NewKeyEnv: "KEY"})
+		require.ErrorContains(t, err, "secrets vault uses the hashicorp.vault plugin, only the encrypted-filesystem plugin supports rotating the key")
+	})
+
+	t.Run("undefined secrets", func(t *testing.T) {
+		err := p.RotateSecretsKey(ctx, RotateSecretsKeyOptions{Secrets: "missing", NewKeyEnv: "KEY"})
+		require.ErrorContains(t, err, `secrets "missing" not defined`)
+	})
+
+	err := p.RotateSecretsKey(ctx, RotateSecretsKeyOptions{NewKeyFile: "/new.key", GenerateKey: true})
+	require.NoError(t, err)
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Re-encrypted 1 secrets in /shared/secrets with the new key")
+
+	newStore := encrypted_filesystem.NewStore(p.Config, encrypted_filesystem.PluginConfig{
+		Path: "/shared/secrets", KeySource: encrypted_filesystem.KeySource{KeyFile: "/new.key"}})
+	value, err := newStore.Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err)
+	assert.Equal(t, "supersecret", value)
+}
@@ -1,10 +1,61 @@
 package porter
 
+import (
+	"errors"
+	"fmt"
+)
+
 type ServiceOptions struct {
 	Port        int64
 	ServiceName string
+
+	// GatewayPort is the port of the HTTP/JSON gateway to the gRPC services.
+	// The gateway is disabled when the port is 0.
+	GatewayPort int64
+
+	// TLSCertFile is the path to the certificate that the server presents to clients.
+	TLSCertFile string
+
+	// TLSKeyFile is the path to the private key of the server certificate.
+	TLSKeyFile string
+
+	// ClientCAFile is the path to the certificate authorities that sign client certificates.
+	// When set, clients must authenticate with a client certificate or a bearer token.
+	ClientCAFile string
+
+	// TokenFile is the path to the file of bearer tokens accepted by the server.
+	// When set, clients must authenticate with a client certificate or a bearer token.
+	TokenFile string
+
+	// AuthorizationFile is the path to the file of rules that grant authenticated
+	// clients access to namespaces.
+	AuthorizationFile string
+}
+
+// AuthenticationEnabled returns if clients must authenticate with the server.
+func (o *ServiceOptions) AuthenticationEnabled() bool {
+	return o.ClientCAFile != "" || o.TokenFile != ""
 }
 
 func (o *ServiceOptions) Validate() error {
+	if o.GatewayPort < 0 {
+		return fmt.Errorf("invalid --gateway-port %d, the port must be positive, or 0 to disable the gateway", o.GatewayPort)
+	}
+	if o.GatewayPort != 0 && o.GatewayPort == o.Port {
+		return fmt.Errorf("--gateway-port %d must be different from --port", o.GatewayPort)
+	}
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return errors.New("--tls-cert and --tls-key must be specified together")
+	}
+	if o.ClientCAFile != "" && o.TLSCertFile == "" {
+		return errors.New("--client-ca requires --tls-cert and --tls-key")
+	}
+	if o.AuthorizationFile != "" && !o.AuthenticationEnabled() {
+		return errors.New("--authz-file requires authentication, specify --client-ca or --token-file")
+	}
+	if o.GatewayPort != 0 && o.ClientCAFile != "" && o.TokenFile == "" {
+		// The gateway does not request client certificates, so its clients could never authenticate
+		return errors.New("--gateway-port requires --token-file when --client-ca is specified, clients of the gateway authenticate with a bearer token")
+	}
 	return nil
 }
@@ -0,0 +1,36 @@
+package porter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceOptions_Validate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    ServiceOptions
+		wantErr string
+	}{
+		{name: "no auth", opts: ServiceOptions{Port: 3001, GatewayPort: 3002}},
+		{name: "mtls", opts: ServiceOptions{TLSCertFile: "server.crt", TLSKeyFile: "server.key", ClientCAFile: "ca.crt", AuthorizationFile: "authz.yaml"}},
+		{name: "tokens", opts: ServiceOptions{TokenFile: "tokens.yaml", AuthorizationFile: "authz.yaml"}},
+		{name: "cert without key", opts: ServiceOptions{TLSCertFile: "server.crt"}, wantErr: "--tls-cert and --tls-key must be specified together"},
+		{name: "client ca without tls", opts: ServiceOptions{ClientCAFile: "ca.crt"}, wantErr: "--client-ca requires --tls-cert and --tls-key"},
+		{name: "negative gateway port", opts: ServiceOptions{Port: 3001, GatewayPort: -1}, wantErr: "invalid --gateway-port -1"},
+		{name: "gateway on the grpc port", opts: ServiceOptions{Port: 3001, GatewayPort: 3001}, wantErr: "--gateway-port 3001 must be different from --port"},
+		{name: "authz without authn", opts: ServiceOptions{AuthorizationFile: "authz.yaml"}, wantErr: "--authz-file requires authentication"},
+		{name: "gateway with mtls and tokens", opts: ServiceOptions{Port: 3001, GatewayPort: 3002, TLSCertFile: "server.crt", TLSKeyFile: "server.key", ClientCAFile: "ca.crt", TokenFile: "tokens.yaml"}},
+		{name: "gateway with only mtls", opts: ServiceOptions{Port: 3001, GatewayPort: 3002, TLSCertFile: "server.crt", TLSKeyFile: "server.key", ClientCAFile: "ca.crt"}, wantErr: "--gateway-port requires --token-file when --client-ca is specified"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,612 @@
+package porter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/schema"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// SchemaTypeStorageArchive is the schemaType value for the manifest of a storage archive.
+	SchemaTypeStorageArchive = "StorageArchive"
+
+	// DefaultStorageArchiveSchemaVersion is the version of the storage archive
+	// format written by porter storage export.
+	DefaultStorageArchiveSchemaVersion = cnab.SchemaVersion("1.0.0")
+
+	// storageArchiveManifest is the name of the manifest file in a storage archive.
+	storageArchiveManifest = "manifest.json"
+)
+
+// SupportedStorageArchiveSchemaVersions represents the set of storage archive versions that can be imported.
+var SupportedStorageArchiveSchemaVersions = schema.MustParseConstraint("1.x")
+
+// Conflict policies for porter storage import.
+const (
+	// ImportConflictFail stops the import before any data is written when a
+	// document in the archive already exists.
+	ImportConflictFail = "fail"
+
+	// ImportConflictSkip keeps the existing document and skips the document from the archive.
+	ImportConflictSkip = "skip"
+
+	// ImportConflictOverwrite replaces the existing document with the document from the archive.
+	ImportConflictOverwrite = "overwrite"
+)
+
+// StorageArchiveManifest describes the contents of a storage archive.
+type StorageArchiveManifest struct {
+	// SchemaType of the manifest, StorageArchive.
+	SchemaType string `json:"schemaType"`
+
+	// SchemaVersion of the storage archive format.
+	SchemaVersion cnab.SchemaVersion `json:"schemaVersion"`
+
+	// PorterVersion is the version of Porter that created the archive.
+	PorterVersion string `json:"porterVersion"`
+
+	// Created timestamp of the archive.
+	Created time.Time `json:"created"`
+
+	// Namespaces included in the archive. All namespaces were exported when empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// StorageSchema is the schema of the exported documents.
+	StorageSchema storage.Schema `json:"storageSchema"`
+}
+
+// StorageArchive is the set of documents saved in a storage archive.
+type StorageArchive struct {
+	Manifest       StorageArchiveManifest
+	Installations  []storage.Installation
+	Runs           []storage.Run
+	Results        []storage.Result
+	Outputs        []storage.Output
+	CredentialSets []storage.CredentialSet
+	ParameterSets  []storage.ParameterSet
+}
+
+// files returns the name of each file in the archive and the documents that it contains.
+func (a *StorageArchive) files() []struct {
+	name string
+	data interface{}
+} {
+	return []struct {
+		name string
+		data interface{}
+	}{
+		{storageArchiveManifest, &a.Manifest},
+		{storage.CollectionInstallations + ".json", &a.Installations},
+		{storage.CollectionRuns + ".json", &a.Runs},
+		{storage.CollectionResults + ".json", &a.Results},
+		{storage.CollectionOutputs + ".json", &a.Outputs},
+		{storage.CollectionCredentials + ".json", &a.CredentialSets},
+		{storage.CollectionParameters + ".json", &a.ParameterSets},
+	}
+}
+
+// StorageExportOptions are the options available to porter storage export.
+type StorageExportOptions struct {
+	// File is the path to the storage archive to create.
+	File string
+
+	// Namespaces to export. All namespaces are exported when empty.
+	Namespaces []string
+}
+
+func (o *StorageExportOptions) Validate(args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("the destination file is required")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("only one positional argument may be specified, the archive file name, but multiple were received: %s", args)
+	}
+	o.File = args[0]
+	return nil
+}
+
+// StorageImportOptions are the options available to porter storage import.
+type StorageImportOptions struct {
+	// File is the path to the storage archive to import.
+	File string
+
+	// Namespaces to import. All namespaces in the archive are imported when empty.
+	Namespaces []string
+
+	// OnConflict is the policy applied when a document in the archive already exists.
+	OnConflict string
+}
+
+func (o *StorageImportOptions) Validate(args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("the archive file is required")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("only one positional argument may be specified, the archive file name, but multiple were received: %s", args)
+	}
+	o.File = args[0]
+
+	if o.OnConflict == "" {
+		o.OnConflict = ImportConflictFail
+	}
+	switch o.OnConflict {
+	case ImportConflictFail, ImportConflictSkip, ImportConflictOverwrite:
+	default:
+		return fmt.Errorf("invalid --on-conflict value %q, allowed values are: %s", o.OnConflict,
+			strings.Join([]string{ImportConflictFail, ImportConflictSkip, ImportConflictOverwrite}, ", "))
+	}
+	return nil
+}
+
+// includesNamespace determines if a namespace was selected by the --namespace flag.
+func includesNamespace(namespaces []string, namespace string) bool {
+	if len(namespaces) == 0 {
+		return true
+	}
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportStorage saves Porter's installations, runs, results, outputs,
+// credential sets and parameter sets to a storage archive.
+// Secret values are not included, only the references to the secrets.
+func (p *Porter) ExportStorage(ctx context.Context, opts StorageExportOptions) error {
+	ctx, span := tracing.StartSpan(ctx, attribute.String("file", opts.File))
+	defer span.EndSpan()
+
+	dir := filepath.Dir(opts.File)
+	if _, err := p.FileSystem.Stat(dir); os.IsNotExist(err) {
+		return span.Error(fmt.Errorf("parent directory %q does not exist", filepath.ToSlash(dir)))
+	}
+
+	archive, err := p.collectStorageArchive(ctx, opts.Namespaces)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	f, err := p.FileSystem.OpenFile(opts.File, os.O_RDWR|os.O_CREATE|os.O_TRUNC, pkg.FileModeWritable)
+	if err != nil {
+		return span.Error(fmt.Errorf("could not create the storage archive %s: %w", opts.File, err))
+	}
+	defer f.Close()
+
+	if err = writeStorageArchive(f, archive); err != nil {
+		return span.Error(fmt.Errorf("could not write the storage archive %s: %w", opts.File, err))
+	}
+
+	fmt.Fprintf(p.Out, "Exported %d installations, %d runs, %d results, %d outputs, %d credential sets and %d parameter sets to %s\n",
+		len(archive.Installations), len(archive.Runs), len(archive.Results), len(archive.Outputs),
+		len(archive.CredentialSets), len(archive.ParameterSets), opts.File)
+	return nil
+}
+
+func (p *Porter) collectStorageArchive(ctx context.Context, namespaces []string) (*StorageArchive, error) {
+	archive := &StorageArchive{
+		Manifest: StorageArchiveManifest{
+			SchemaType:    SchemaTypeStorageArchive,
+			SchemaVersion: DefaultStorageArchiveSchemaVersion,
+			PorterVersion: pkg.Version,
+			Created:       time.Now(),
+			Namespaces:    namespaces,
+			StorageSchema: storage.NewSchema(),
+		},
+	}
+
+	allNamespaces := storage.ListOptions{Namespace: "*"}
+	installations, err := p.Installations.ListInstallations(ctx, allNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("could not list installations: %w", err)
+	}
+	for _, i := range installations {
+		if !includesNamespace(namespaces, i.Namespace) {
+			continue
+		}
+		archive.Installations = append(archive.Installations, i)
+
+		runs, results, err := p.Installations.ListRuns(ctx, i.Namespace, i.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not list runs for installation %s: %w", i, err)
+		}
+		archive.Runs = append(archive.Runs, runs...)
+
+		for _, run := range runs {
+			archive.Results = append(archive.Results, results[run.ID]...)
+			for _, result := range results[run.ID] {
+				outputs, err := p.Installations.ListOutputs(ctx, result.ID)
+				if err != nil {
+					return nil, fmt.Errorf("could not list outputs for result %s: %w", result.ID, err)
+				}
+				archive.Outputs = append(archive.Outputs, outputs...)
+			}
+		}
+	}
+
+	creds, err := p.Credentials.ListCredentialSets(ctx, allNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("could not list credential sets: %w", err)
+	}
+	for _, cs := range creds {
+		if includesNamespace(namespaces, cs.Namespace) {
+			archive.CredentialSets = append(archive.CredentialSets, cs)
+		}
+	}
+
+	params, err := p.Parameters.ListParameterSets(ctx, allNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("could not list parameter sets: %w", err)
+	}
+	for _, ps := range params {
+		if includesNamespace(namespaces, ps.Namespace) {
+			archive.ParameterSets = append(archive.ParameterSets, ps)
+		}
+	}
+
+	return archive, nil
+}
+
+// writeStorageArchive writes the archive as a gzipped tarball with a json file
+// for the manifest and for each collection.
+func writeStorageArchive(w io.Writer, archive *StorageArchive) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	for _, file := range archive.files() {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal %s: %w", file.name, err)
+		}
+
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    int64(pkg.FileModeWritable),
+			Size:    int64(len(data)),
+			ModTime: archive.Manifest.Created,
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err = tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// readStorageArchive reads a storage archive, validating that the archive
+// format and the schema of its documents are supported.
+func readStorageArchive(r io.Reader) (*StorageArchive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("the file is not a storage archive: %w", err)
+	}
+	defer gzr.Close()
+
+	contents := make(map[string][]byte)
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("the file is not a storage archive: %w", err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s from the storage archive: %w", header.Name, err)
+		}
+		contents[header.Name] = data
+	}
+
+	archive := &StorageArchive{}
+	for _, file := range archive.files() {
+		data, ok := contents[file.name]
+		if !ok {
+			return nil, fmt.Errorf("invalid storage archive: %s is missing", file.name)
+		}
+		if err = json.Unmarshal(data, file.data); err != nil {
+			return nil, fmt.Errorf("could not parse %s from the storage archive: %w", file.name, err)
+		}
+
+		// Check the manifest before trying to parse documents in a format that we don't understand
+		if file.name == storageArchiveManifest {
+			if err = archive.Manifest.Validate(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return archive, nil
+}
+
+// Validate checks that the archive can be imported by this version of Porter.
+func (m StorageArchiveManifest) Validate() error {
+	if m.SchemaType != SchemaTypeStorageArchive {
+		return fmt.Errorf("invalid storage archive: the manifest schemaType is %q instead of %s", m.SchemaType, SchemaTypeStorageArchive)
+	}
+
+	if _, err := schema.ValidateSchemaVersion(schema.CheckStrategyExact, SupportedStorageArchiveSchemaVersions, string(m.SchemaVersion), nil); err != nil {
+		return fmt.Errorf("the storage archive format is not supported by this version of Porter: %w", err)
+	}
+
+	if m.StorageSchema.IsOutOfDate() {
+		return fmt.Errorf("the storage archive was exported by Porter %s with a schema that is not supported by this version of Porter. Import the archive with that version of Porter, run porter storage migrate, and export it again", m.PorterVersion)
+	}
+	return nil
+}
+
+// ImportStorage loads the documents from a storage archive into Porter's storage.
+func (p *Porter) ImportStorage(ctx context.Context, opts StorageImportOptions) error {
+	ctx, span := tracing.StartSpan(ctx, attribute.String("file", opts.File), attribute.String("on-conflict", opts.OnConflict))
+	defer span.EndSpan()
+
+	f, err := p.FileSystem.Open(opts.File)
+	if err != nil {
+		return span.Error(fmt.Errorf("could not open the storage archive %s: %w", opts.File, err))
+	}
+	defer f.Close()
+
+	archive, err := readStorageArchive(f)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	imp := storageImporter{p: p, opts: opts, archive: archive}
+	if opts.OnConflict == ImportConflictFail {
+		if err = imp.checkConflicts(ctx); err != nil {
+			return span.Error(err)
+		}
+	}
+
+	if err = imp.importInstallations(ctx); err != nil {
+		return span.Error(err)
+	}
+	if err = imp.importCredentialSets(ctx); err != nil {
+		return span.Error(err)
+	}
+	if err = imp.importParameterSets(ctx); err != nil {
+		return span.Error(err)
+	}
+
+	fmt.Fprintf(p.Out, "Imported %d installations, %d runs, %d results, %d outputs, %d credential sets and %d parameter sets from %s\n",
+		imp.installations, imp.runs, imp.results, imp.outputs, imp.credentialSets, imp.parameterSets, opts.File)
+	if imp.skipped > 0 {
+		fmt.Fprintf(p.Out, "Skipped %d documents that already exist\n", imp.skipped)
+	}
+	return nil
+}
+
+// storageImporter tracks the state of an import.
+type storageImporter struct {
+	p       *Porter
+	opts    StorageImportOptions
+	archive *StorageArchive
+
+	// Counts of the imported documents
+	installations  int
+	runs           int
+	results        int
+	outputs        int
+	credentialSets int
+	parameterSets  int
+	skipped        int
+}
+
+// checkConflicts returns an error listing the installations, credential sets
+// and parameter sets in the archive that already exist.
+func (i *storageImporter) checkConflicts(ctx context.Context) error {
+	var conflicts []string
+	for _, inst := range i.archive.Installations {
+		if !includesNamespace(i.opts.Namespaces, inst.Namespace) {
+			continue
+		}
+		if _, err := i.p.Installations.GetInstallation(ctx, inst.Namespace, inst.Name); err == nil {
+			conflicts = append(conflicts, "installation "+inst.String())
+		} else if !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+	}
+
+	for _, cs := range i.archive.CredentialSets {
+		if !includesNamespace(i.opts.Namespaces, cs.Namespace) {
+			continue
+		}
+		if _, err := i.p.Credentials.GetCredentialSet(ctx, cs.Namespace, cs.Name); err == nil {
+			conflicts = append(conflicts, "credential set "+cs.String())
+		} else if !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+	}
+
+	for _, ps := range i.archive.ParameterSets {
+		if !includesNamespace(i.opts.Namespaces, ps.Namespace) {
+			continue
+		}
+		if _, err := i.p.Parameters.GetParameterSet(ctx, ps.Namespace, ps.Name); err == nil {
+			conflicts = append(conflicts, "parameter set "+ps.String())
+		} else if !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("the following documents already exist, use --on-conflict skip or --on-conflict overwrite to import the archive anyway:\n  %s",
+			strings.Join(conflicts, "\n  "))
+	}
+	return nil
+}
+
+// importInstallations imports each installation along with its runs, results
+// and outputs. Runs, results and outputs are never modified once they are
+// recorded, so existing ones are left as-is and only missing ones are imported.
+func (i *storageImporter) importInstallations(ctx context.Context) error {
+	resultsByRun := make(map[string][]storage.Result)
+	for _, result := range i.archive.Results {
+		resultsByRun[result.RunID] = append(resultsByRun[result.RunID], result)
+	}
+	outputsByResult := make(map[string][]storage.Output)
+	for _, output := range i.archive.Outputs {
+		outputsByResult[output.ResultID] = append(outputsByResult[output.ResultID], output)
+	}
+	runsByInstallation := make(map[string][]storage.Run)
+	for _, run := range i.archive.Runs {
+		key := run.Namespace + "/" + run.Installation
+		runsByInstallation[key] = append(runsByInstallation[key], run)
+	}
+
+	for _, inst := range i.archive.Installations {
+		if !includesNamespace(i.opts.Namespaces, inst.Namespace) {
+			continue
+		}
+
+		existing, err := i.p.Installations.GetInstallation(ctx, inst.Namespace, inst.Name)
+		exists := err == nil
+		if err != nil && !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+
+		switch {
+		case !exists:
+			err = i.p.Installations.InsertInstallation(ctx, inst)
+		case i.opts.OnConflict == ImportConflictOverwrite:
+			// The installation may have been recreated since the archive was made,
+			// so replace it in place instead of conflicting with its ID.
+			inst.ID = existing.ID
+			err = i.p.Installations.UpsertInstallation(ctx, inst)
+		default:
+			// Leave the existing installation and its history alone
+			i.skipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not import installation %s: %w", inst, err)
+		}
+		i.installations++
+
+		for _, run := range runsByInstallation[inst.Namespace+"/"+inst.Name] {
+			if err = i.importRun(ctx, run, resultsByRun[run.ID], outputsByResult); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (i *storageImporter) importRun(ctx context.Context, run storage.Run, results []storage.Result, outputsByResult map[string][]storage.Output) error {
+	if _, err := i.p.Installations.GetRun(ctx, run.ID); err == nil {
+		i.skipped++
+	} else if errors.Is(err, storage.ErrNotFound{}) {
+		if err = i.p.Installations.InsertRun(ctx, run); err != nil {
+			return fmt.Errorf("could not import run %s: %w", run.ID, err)
+		}
+		i.runs++
+	} else {
+		return err
+	}
+
+	for _, result := range results {
+		if _, err := i.p.Installations.GetResult(ctx, result.ID); err == nil {
+			i.skipped++
+			continue
+		} else if !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+
+		if err := i.p.Installations.InsertResult(ctx, result); err != nil {
+			return fmt.Errorf("could not import result %s: %w", result.ID, err)
+		}
+		i.results++
+
+		for _, output := range outputsByResult[result.ID] {
+			if err := i.p.Installations.InsertOutput(ctx, output); err != nil {
+				return fmt.Errorf("could not import output %s for result %s: %w", output.Name, result.ID, err)
+			}
+			i.outputs++
+		}
+	}
+	return nil
+}
+
+func (i *storageImporter) importCredentialSets(ctx context.Context) error {
+	for _, cs := range i.archive.CredentialSets {
+		if !includesNamespace(i.opts.Namespaces, cs.Namespace) {
+			continue
+		}
+
+		_, err := i.p.Credentials.GetCredentialSet(ctx, cs.Namespace, cs.Name)
+		exists := err == nil
+		if err != nil && !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+
+		switch {
+		case !exists:
+			err = i.p.Credentials.InsertCredentialSet(ctx, cs)
+		case i.opts.OnConflict == ImportConflictOverwrite:
+			err = i.p.Credentials.UpsertCredentialSet(ctx, cs)
+		default:
+			i.skipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not import credential set %s: %w", cs, err)
+		}
+		i.credentialSets++
+	}
+	return nil
+}
+
+func (i *storageImporter) importParameterSets(ctx context.Context) error {
+	for _, ps := range i.archive.ParameterSets {
+		if !includesNamespace(i.opts.Namespaces, ps.Namespace) {
+			continue
+		}
+
+		_, err := i.p.Parameters.GetParameterSet(ctx, ps.Namespace, ps.Name)
+		exists := err == nil
+		if err != nil && !errors.Is(err, storage.ErrNotFound{}) {
+			return err
+		}
+
+		switch {
+		case !exists:
+			err = i.p.Parameters.InsertParameterSet(ctx, ps)
+		case i.opts.OnConflict == ImportConflictOverwrite:
+			err = i.p.Parameters.UpsertParameterSet(ctx, ps)
+		default:
+			i.skipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not import parameter set %s: %w", ps, err)
+		}
+		i.parameterSets++
+	}
+	return nil
+}
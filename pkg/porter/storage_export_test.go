@@ -0,0 +1,187 @@
+package porter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageImportOptions_Validate(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		opts := StorageImportOptions{}
+		require.NoError(t, opts.Validate([]string{"backup.tgz"}))
+		assert.Equal(t, "backup.tgz", opts.File)
+		assert.Equal(t, ImportConflictFail, opts.OnConflict)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		opts := StorageImportOptions{}
+		require.EqualError(t, opts.Validate(nil), "the archive file is required")
+	})
+
+	t.Run("invalid conflict policy", func(t *testing.T) {
+		opts := StorageImportOptions{OnConflict: "merge"}
+		require.ErrorContains(t, opts.Validate([]string{"backup.tgz"}), `invalid --on-conflict value "merge"`)
+	})
+}
+
+// seedStorage creates installations with runs, results and outputs, and
+// credential and parameter sets, in the dev and test namespaces.
+func seedStorage(p *TestPorter) {
+	for _, ns := range []string{"dev", "test"} {
+		p.TestInstallations.CreateInstallation(storage.NewInstallation(ns, "mysql"), p.TestInstallations.SetMutableInstallationValues)
+		run := p.TestInstallations.CreateRun(storage.NewRun(ns, "mysql"), p.TestInstallations.SetMutableRunValues)
+		result := p.TestInstallations.CreateResult(run.NewResult(cnab.StatusSucceeded), p.TestInstallations.SetMutableResultValues)
+		p.TestInstallations.CreateOutput(result.NewOutput("connstr", []byte("mysql://"+ns)))
+
+		cs := storage.NewCredentialSet(ns, "azure", secrets.SourceMap{Name: "token", Source: secrets.Source{Strategy: "env", Hint: "AZURE_TOKEN"}})
+		require.NoError(p.T(), p.Credentials.InsertCredentialSet(context.Background(), cs))
+		ps := storage.NewParameterSet(ns, "mysql", secrets.SourceMap{Name: "port", Source: secrets.Source{Strategy: "value", Hint: "3306"}})
+		require.NoError(p.T(), p.Parameters.InsertParameterSet(context.Background(), ps))
+	}
+}
+
+func TestPorter_ExportImportStorage(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := p.RootContext
+
+	seedStorage(p)
+
+	err := p.ExportStorage(ctx, StorageExportOptions{File: "backup.tgz"})
+	require.NoError(t, err)
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Exported 2 installations, 2 runs, 2 results, 2 outputs, 2 credential sets and 2 parameter sets to backup.tgz")
+
+	// Simulate losing the data
+	for _, ns := range []string{"dev", "test"} {
+		require.NoError(t, p.Installations.RemoveInstallation(ctx, ns, "mysql"))
+		require.NoError(t, p.Credentials.RemoveCredentialSet(ctx, ns, "azure"))
+		require.NoError(t, p.Parameters.RemoveParameterSet(ctx, ns, "mysql"))
+	}
+
+	err = p.ImportStorage(ctx, StorageImportOptions{File: "backup.tgz", Namespaces: []string{"dev"}, OnConflict: ImportConflictFail})
+	require.NoError(t, err)
+	assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Imported 1 installations, 1 runs, 1 results, 1 outputs, 1 credential sets and 1 parameter sets from backup.tgz")
+
+	_, err = p.Installations.GetInstallation(ctx, "dev", "mysql")
+	require.NoError(t, err, "the installation should be restored")
+	_, err = p.Installations.GetInstallation(ctx, "test", "mysql")
+	require.ErrorIs(t, err, storage.ErrNotFound{}, "only the dev namespace should be imported")
+
+	outputs, err := p.Installations.GetLastOutputs(ctx, "dev", "mysql")
+	require.NoError(t, err)
+	connstr, ok := outputs.GetByName("connstr")
+	require.True(t, ok, "the outputs should be restored")
+	assert.Equal(t, "mysql://dev", string(connstr.Value))
+
+	cs, err := p.Credentials.GetCredentialSet(ctx, "dev", "azure")
+	require.NoError(t, err)
+	assert.Equal(t, "AZURE_TOKEN", cs.Credentials[0].Source.Hint)
+	_, err = p.Parameters.GetParameterSet(ctx, "dev", "mysql")
+	require.NoError(t, err)
+}
+
+func TestPorter_ImportStorage_Conflicts(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := p.RootContext
+
+	seedStorage(p)
+	require.NoError(t, p.ExportStorage(ctx, StorageExportOptions{File: "backup.tgz"}))
+
+	// Change the data after the backup
+	cs, err := p.Credentials.GetCredentialSet(ctx, "dev", "azure")
+	require.NoError(t, err)
+	cs.Credentials[0].Source.Hint = "NEW_TOKEN"
+	require.NoError(t, p.Credentials.UpdateCredentialSet(ctx, cs))
+	require.NoError(t, p.Installations.RemoveInstallation(ctx, "test", "mysql"))
+
+	t.Run("fail", func(t *testing.T) {
+		err := p.ImportStorage(ctx, StorageImportOptions{File: "backup.tgz", OnConflict: ImportConflictFail})
+		require.ErrorContains(t, err, "the following documents already exist")
+		assert.Contains(t, err.Error(), "credential set dev/azure")
+
+		_, err = p.Installations.GetInstallation(ctx, "test", "mysql")
+		require.ErrorIs(t, err, storage.ErrNotFound{}, "no data should be imported when there is a conflict")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		err := p.ImportStorage(ctx, StorageImportOptions{File: "backup.tgz", OnConflict: ImportConflictSkip})
+		require.NoError(t, err)
+
+		_, err = p.Installations.GetInstallation(ctx, "test", "mysql")
+		require.NoError(t, err, "the missing installation should be imported")
+
+		cs, err := p.Credentials.GetCredentialSet(ctx, "dev", "azure")
+		require.NoError(t, err)
+		assert.Equal(t, "NEW_TOKEN", cs.Credentials[0].Source.Hint, "existing documents should not be changed")
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		err := p.ImportStorage(ctx, StorageImportOptions{File: "backup.tgz", OnConflict: ImportConflictOverwrite})
+		require.NoError(t, err)
+
+		cs, err := p.Credentials.GetCredentialSet(ctx, "dev", "azure")
+		require.NoError(t, err)
+		assert.Equal(t, "AZURE_TOKEN", cs.Credentials[0].Source.Hint, "existing documents should be replaced")
+
+		runs, _, err := p.Installations.ListRuns(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		assert.Len(t, runs, 1, "existing runs should not be duplicated")
+	})
+}
+
+func TestPorter_ImportStorage_OverwriteRecreatedInstallation(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+	ctx := p.RootContext
+
+	seedStorage(p)
+	require.NoError(t, p.ExportStorage(ctx, StorageExportOptions{File: "backup.tgz"}))
+
+	// Recreate the installation after the backup, which gives it a new ID
+	original, err := p.Installations.GetInstallation(ctx, "dev", "mysql")
+	require.NoError(t, err)
+	require.NoError(t, p.Installations.RemoveInstallation(ctx, "dev", "mysql"))
+	recreated := storage.NewInstallation("dev", "mysql")
+	recreated.Labels = map[string]string{"recreated": "true"}
+	require.NoError(t, p.Installations.InsertInstallation(ctx, recreated))
+	require.NotEqual(t, original.ID, recreated.ID)
+
+	err = p.ImportStorage(ctx, StorageImportOptions{File: "backup.tgz", OnConflict: ImportConflictOverwrite})
+	require.NoError(t, err)
+
+	installations, err := p.Installations.ListInstallations(ctx, storage.ListOptions{Namespace: "dev", Name: "mysql"})
+	require.NoError(t, err)
+	require.Len(t, installations, 1, "the existing installation should be replaced")
+	assert.Equal(t, recreated.ID, installations[0].ID, "the existing installation ID should be kept")
+	assert.Empty(t, installations[0].Labels, "the installation should match the archive")
+}
+
+func TestReadStorageArchive_Unsupported(t *testing.T) {
+	archive := &StorageArchive{
+		Manifest: StorageArchiveManifest{
+			SchemaType:    SchemaTypeStorageArchive,
+			SchemaVersion: "2.0.0",
+			StorageSchema: storage.NewSchema(),
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeStorageArchive(&buf, archive))
+	_, err := readStorageArchive(&buf)
+	require.ErrorContains(t, err, "the storage archive format is not supported by this version of Porter")
+
+	archive.Manifest.SchemaVersion = DefaultStorageArchiveSchemaVersion
+	archive.Manifest.StorageSchema.Installations = "0.0.1"
+	buf.Reset()
+	require.NoError(t, writeStorageArchive(&buf, archive))
+	_, err = readStorageArchive(&buf)
+	require.ErrorContains(t, err, "run porter storage migrate")
+}
@@ -0,0 +1,313 @@
+package porter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/tracing"
+	dtprinter "github.com/carolynvs/datetime-printer"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// StoragePruneOptions are the options for removing expired runs with porter storage prune.
+// Retention options that are nil default to the retention policy in the config file.
+type StoragePruneOptions struct {
+	printer.PrintOptions
+
+	// Namespaces to prune. All namespaces are pruned when empty.
+	Namespaces []string
+
+	// KeepRuns is the number of most recent runs kept for each installation.
+	KeepRuns *int
+
+	// MaxAge is the maximum age of a run before it is removed, for example 720h.
+	MaxAge *string
+
+	// KeepFailed keeps failed runs regardless of KeepRuns and MaxAge.
+	KeepFailed *bool
+
+	// DryRun reports the runs that would be removed without removing them.
+	DryRun bool
+}
+
+func (o *StoragePruneOptions) Validate(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("porter storage prune does not accept positional arguments, but received: %s", args)
+	}
+
+	if o.KeepRuns != nil && *o.KeepRuns < 0 {
+		return fmt.Errorf("invalid --keep-runs %d, must be zero or greater", *o.KeepRuns)
+	}
+
+	if o.MaxAge != nil && *o.MaxAge != "" {
+		if _, err := time.ParseDuration(*o.MaxAge); err != nil {
+			return fmt.Errorf("invalid --max-age %s: %w", *o.MaxAge, err)
+		}
+	}
+
+	return o.PrintOptions.Validate(printer.FormatPlaintext, printer.Formats{printer.FormatPlaintext, printer.FormatJson, printer.FormatYaml})
+}
+
+// StoragePruneReport lists the runs removed by porter storage prune.
+type StoragePruneReport struct {
+	// DryRun is true when the runs were not actually removed.
+	DryRun bool `json:"dryRun" yaml:"dryRun"`
+
+	// Runs that were removed.
+	Runs []PrunedRun `json:"runs" yaml:"runs"`
+
+	// Results is the number of results removed with the runs.
+	Results int `json:"results" yaml:"results"`
+
+	// Outputs is the number of outputs, including logs, removed with the runs.
+	Outputs int `json:"outputs" yaml:"outputs"`
+
+	// LogChunks is the number of log chunks, persisted while the runs were in progress, removed with the runs.
+	LogChunks int `json:"logChunks" yaml:"logChunks"`
+}
+
+// PrunedRun is a run that was removed by porter storage prune.
+type PrunedRun struct {
+	ID           string    `json:"id" yaml:"id"`
+	Namespace    string    `json:"namespace" yaml:"namespace"`
+	Installation string    `json:"installation" yaml:"installation"`
+	Action       string    `json:"action" yaml:"action"`
+	Status       string    `json:"status" yaml:"status"`
+	Created      time.Time `json:"created" yaml:"created"`
+
+	// Reason the run expired.
+	Reason string `json:"reason" yaml:"reason"`
+
+	// Secrets created by Porter for the sensitive parameters and outputs of the run.
+	Secrets []string `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+}
+
+// retentionPolicy is the parsed retention policy applied to each installation.
+type retentionPolicy struct {
+	keepRuns   int
+	maxAge     time.Duration
+	keepFailed bool
+}
+
+// PruneStorage removes the runs that have expired according to the retention
+// policy, along with their results, outputs, log chunks and the secrets Porter
+// created for them, and prints what was removed.
+func (p *Porter) PruneStorage(ctx context.Context, opts StoragePruneOptions) error {
+	ctx, log := tracing.StartSpan(ctx, attribute.Bool("dryRun", opts.DryRun))
+	defer log.EndSpan()
+
+	policy, err := p.getRetentionPolicy(opts)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	report, err := p.pruneRuns(ctx, opts, policy)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	return p.printStoragePruneReport(report, opts.Format)
+}
+
+// getRetentionPolicy applies the retention policy from the config file to the
+// retention options that were not set with a flag. Options that were set,
+// including to zero or false, override the config file.
+func (p *Porter) getRetentionPolicy(opts StoragePruneOptions) (retentionPolicy, error) {
+	cfg := p.Data.Retention
+	if opts.KeepRuns != nil {
+		cfg.KeepRuns = *opts.KeepRuns
+	}
+	if opts.MaxAge != nil {
+		cfg.MaxAge = *opts.MaxAge
+	}
+	if opts.KeepFailed != nil {
+		cfg.KeepFailed = *opts.KeepFailed
+	}
+
+	policy := retentionPolicy{
+		keepRuns:   cfg.KeepRuns,
+		keepFailed: cfg.KeepFailed,
+	}
+	if policy.keepRuns < 0 {
+		return retentionPolicy{}, fmt.Errorf("invalid retention keep-runs %d, must be zero or greater", policy.keepRuns)
+	}
+	if cfg.MaxAge != "" {
+		maxAge, err := time.ParseDuration(cfg.MaxAge)
+		if err != nil {
+			return retentionPolicy{}, fmt.Errorf("invalid retention max-age %s: %w", cfg.MaxAge, err)
+		}
+		policy.maxAge = maxAge
+	}
+
+	if policy.keepRuns == 0 && policy.maxAge == 0 {
+		return retentionPolicy{}, errors.New("no retention policy is defined, set retention.keep-runs or retention.max-age in the config file, or specify --keep-runs or --max-age")
+	}
+	return policy, nil
+}
+
+func (p *Porter) pruneRuns(ctx context.Context, opts StoragePruneOptions, policy retentionPolicy) (StoragePruneReport, error) {
+	log := tracing.LoggerFromContext(ctx)
+
+	// Delete the secrets of the removed runs, even when a later run could not be removed
+	var removedSecrets []string
+	defer func() { p.removeSecrets(ctx, removedSecrets) }()
+
+	report := StoragePruneReport{DryRun: opts.DryRun, Runs: []PrunedRun{}}
+	installations, err := p.Installations.ListInstallations(ctx, storage.ListOptions{Namespace: "*"})
+	if err != nil {
+		return report, fmt.Errorf("could not list installations: %w", err)
+	}
+
+	now := time.Now()
+	for _, inst := range installations {
+		if !includesNamespace(opts.Namespaces, inst.Namespace) {
+			continue
+		}
+
+		runs, results, err := p.Installations.ListRuns(ctx, inst.Namespace, inst.Name)
+		if err != nil {
+			return report, fmt.Errorf("could not list runs for installation %s: %w", inst, err)
+		}
+
+		for _, expired := range expiredRuns(runs, results, policy, now) {
+			run := expired.run
+			pruned := PrunedRun{
+				ID:           run.ID,
+				Namespace:    run.Namespace,
+				Installation: run.Installation,
+				Action:       run.Action,
+				Status:       expired.status,
+				Created:      run.Created,
+				Reason:       expired.reason,
+				Secrets:      storage.SanitizedParameterSecrets(run.Parameters.Parameters, run.ID),
+			}
+
+			for _, result := range results[run.ID] {
+				outputs, err := p.Installations.ListOutputs(ctx, result.ID)
+				if err != nil {
+					return report, fmt.Errorf("could not list outputs for result %s: %w", result.ID, err)
+				}
+				for _, output := range outputs {
+					if output.Key != "" {
+						pruned.Secrets = append(pruned.Secrets, output.Key)
+					}
+				}
+				report.Outputs += len(outputs)
+			}
+			report.Results += len(results[run.ID])
+
+			chunks, err := p.Installations.ListLogChunks(ctx, run.ID, 0)
+			if err != nil {
+				return report, fmt.Errorf("could not list log chunks for run %s: %w", run.ID, err)
+			}
+			report.LogChunks += len(chunks)
+
+			if !opts.DryRun {
+				log.Debugf("Removing run %s from installation %s", run.ID, inst)
+				if err := p.Installations.RemoveRun(ctx, run.ID); err != nil {
+					return report, fmt.Errorf("could not remove run %s: %w", run.ID, err)
+				}
+				removedSecrets = append(removedSecrets, pruned.Secrets...)
+			}
+			report.Runs = append(report.Runs, pruned)
+		}
+	}
+
+	return report, nil
+}
+
+type expiredRun struct {
+	run    storage.Run
+	status string
+	reason string
+}
+
+// expiredRuns returns the runs of an installation that have expired according to the policy.
+// The most recent run, the most recent successful run and runs that have not
+// completed are always kept because they are still used by Porter.
+func expiredRuns(runs []storage.Run, results map[string][]storage.Result, policy retentionPolicy, now time.Time) []expiredRun {
+	var expired []expiredRun
+	var kept int
+	var foundSuccess bool
+
+	// Runs are sorted from oldest to newest
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		status := cnab.StatusUnknown
+		if runResults := results[run.ID]; len(runResults) > 0 {
+			status = runResults[len(runResults)-1].Status
+		}
+
+		keep := false
+		switch {
+		case i == len(runs)-1:
+			keep = true
+		case status == cnab.StatusSucceeded && !foundSuccess:
+			keep = true
+		case status != cnab.StatusSucceeded && status != cnab.StatusFailed && status != cnab.StatusCanceled:
+			keep = true
+		case status == cnab.StatusFailed && policy.keepFailed:
+			keep = true
+		}
+		if status == cnab.StatusSucceeded {
+			foundSuccess = true
+		}
+
+		var reason string
+		if policy.keepRuns > 0 && kept >= policy.keepRuns {
+			reason = fmt.Sprintf("exceeds keep-runs %d", policy.keepRuns)
+		} else if policy.maxAge > 0 && now.Sub(run.Created) > policy.maxAge {
+			reason = fmt.Sprintf("older than max-age %s", policy.maxAge)
+		}
+
+		if keep || reason == "" {
+			kept++
+			continue
+		}
+		expired = append(expired, expiredRun{run: run, status: status, reason: reason})
+	}
+
+	return expired
+}
+
+func (p *Porter) printStoragePruneReport(report StoragePruneReport, format printer.Format) error {
+	switch format {
+	case printer.FormatJson:
+		return printer.PrintJson(p.Out, report)
+	case printer.FormatYaml:
+		return printer.PrintYaml(p.Out, report)
+	case printer.FormatPlaintext:
+		verb := "Removed"
+		if report.DryRun {
+			verb = "Would remove"
+		}
+		if len(report.Runs) == 0 {
+			fmt.Fprintln(p.Out, "No runs have expired")
+			return nil
+		}
+
+		now := time.Now()
+		tp := dtprinter.DateTimePrinter{
+			Now: func() time.Time { return now },
+		}
+		row := func(v interface{}) []string {
+			r, ok := v.(PrunedRun)
+			if !ok {
+				return nil
+			}
+			return []string{displayInstallationName(r.Namespace, r.Installation), r.ID, r.Action, r.Status, tp.Format(r.Created), r.Reason}
+		}
+		if err := printer.PrintTable(p.Out, report.Runs, row, "Installation", "Run ID", "Action", "Status", "Created", "Reason"); err != nil {
+			return err
+		}
+		fmt.Fprintf(p.Out, "\n%s %d runs, %d results, %d outputs and %d log chunks\n", verb, len(report.Runs), report.Results, report.Outputs, report.LogChunks)
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", format)
+	}
+}
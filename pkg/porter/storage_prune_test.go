@@ -0,0 +1,212 @@
+package porter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoragePruneOptions_Validate(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		opts := StoragePruneOptions{}
+		require.NoError(t, opts.Validate(nil))
+		assert.Equal(t, printer.FormatPlaintext, opts.Format)
+	})
+
+	t.Run("invalid keep-runs", func(t *testing.T) {
+		keepRuns := -1
+		opts := StoragePruneOptions{KeepRuns: &keepRuns}
+		require.EqualError(t, opts.Validate(nil), "invalid --keep-runs -1, must be zero or greater")
+	})
+
+	t.Run("invalid max-age", func(t *testing.T) {
+		maxAge := "30 days"
+		opts := StoragePruneOptions{MaxAge: &maxAge}
+		require.ErrorContains(t, opts.Validate(nil), "invalid --max-age 30 days")
+	})
+}
+
+func TestPorter_getRetentionPolicy(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+	p.Data.Retention = config.RetentionConfig{KeepRuns: 5, MaxAge: "720h", KeepFailed: true}
+
+	t.Run("config file", func(t *testing.T) {
+		policy, err := p.getRetentionPolicy(StoragePruneOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, retentionPolicy{keepRuns: 5, maxAge: 720 * time.Hour, keepFailed: true}, policy)
+	})
+
+	t.Run("flags set to zero values override the config file", func(t *testing.T) {
+		keepRuns, maxAge, keepFailed := 0, "24h", false
+		policy, err := p.getRetentionPolicy(StoragePruneOptions{KeepRuns: &keepRuns, MaxAge: &maxAge, KeepFailed: &keepFailed})
+		require.NoError(t, err)
+		assert.Equal(t, retentionPolicy{maxAge: 24 * time.Hour}, policy)
+	})
+}
+
+func TestPorter_PruneStorage(t *testing.T) {
+	// setup creates an installation with the following runs, from oldest to newest:
+	// 1 install succeeded, 90 days ago, with a sensitive parameter and output
+	// 2 upgrade failed, 60 days ago
+	// 3 upgrade succeeded, 10 days ago
+	// 4 upgrade failed, 1 day ago
+	setup := func(t *testing.T) *TestPorter {
+		p := NewTestPorter(t)
+
+		inst := p.TestInstallations.CreateInstallation(storage.NewInstallation("dev", "mybuns"))
+		runs := []struct {
+			action string
+			status string
+			age    time.Duration
+		}{
+			{cnab.ActionInstall, cnab.StatusSucceeded, 90 * 24 * time.Hour},
+			{cnab.ActionUpgrade, cnab.StatusFailed, 60 * 24 * time.Hour},
+			{cnab.ActionUpgrade, cnab.StatusSucceeded, 10 * 24 * time.Hour},
+			{cnab.ActionUpgrade, cnab.StatusFailed, 24 * time.Hour},
+		}
+		for i, r := range runs {
+			run := p.TestInstallations.CreateRun(inst.NewRun(r.action, cnab.ExtendedBundle{}), func(run *storage.Run) {
+				run.ID = fmt.Sprintf("run-%d", i+1)
+				run.Created = time.Now().Add(-r.age)
+				if i == 0 {
+					run.Parameters.Parameters = []secrets.SourceMap{
+						{Name: "password", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: run.ID + "-password"}},
+						{Name: "token", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: "my-token"}},
+					}
+				}
+			})
+			result := p.TestInstallations.CreateResult(run.NewResult(r.status), func(result *storage.Result) {
+				result.ID = fmt.Sprintf("result-%d", i+1)
+			})
+			p.TestInstallations.CreateOutput(result.NewOutput(cnab.OutputInvocationImageLogs, []byte("logs")))
+			if r.status == cnab.StatusFailed {
+				// The logs of a failed run are not saved as an output, so the log chunks are kept
+				require.NoError(t, p.Installations.InsertLogChunk(p.RootContext, run.NewLogChunk(1, []byte("logs"))))
+			}
+			if i == 0 {
+				p.TestInstallations.CreateOutput(result.NewOutput("kubeconfig", nil), func(o *storage.Output) {
+					o.Key = run.ID + "-kubeconfig"
+				})
+			}
+		}
+		for _, key := range []string{"run-1-password", "run-1-kubeconfig", "my-token"} {
+			require.NoError(t, p.TestSecrets.Create(p.RootContext, secrets.SourceSecret, key, "supersecret"))
+		}
+		return p
+	}
+
+	secretExists := func(p *TestPorter, key string) bool {
+		_, err := p.TestSecrets.Resolve(p.RootContext, secrets.SourceSecret, key)
+		return err == nil
+	}
+
+	listRunIDs := func(t *testing.T, p *TestPorter) []string {
+		runs, _, err := p.Installations.ListRuns(p.RootContext, "dev", "mybuns")
+		require.NoError(t, err)
+		ids := make([]string, len(runs))
+		for i, run := range runs {
+			ids[i] = run.ID
+		}
+		return ids
+	}
+
+	t.Run("keep runs", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		report, err := p.pruneRuns(p.RootContext, StoragePruneOptions{}, retentionPolicy{keepRuns: 1})
+		require.NoError(t, err)
+		require.Len(t, report.Runs, 2)
+		assert.Equal(t, "run-2", report.Runs[0].ID)
+		assert.Equal(t, "exceeds keep-runs 1", report.Runs[0].Reason)
+		assert.Equal(t, "run-1", report.Runs[1].ID)
+		assert.Equal(t, []string{"run-1-password", "run-1-kubeconfig"}, report.Runs[1].Secrets,
+			"only the secrets created by Porter should be included")
+		assert.Equal(t, 2, report.Results)
+		assert.Equal(t, 3, report.Outputs)
+		assert.Equal(t, 1, report.LogChunks)
+
+		assert.Equal(t, []string{"run-3", "run-4"}, listRunIDs(t, p),
+			"the most recent run and the most recent successful run should be kept")
+		outputs, err := p.Installations.GetOutputs(p.RootContext, "run-1")
+		require.NoError(t, err)
+		assert.Equal(t, 0, outputs.Len(), "the outputs of the removed runs should be deleted")
+		chunks, err := p.Installations.ListLogChunks(p.RootContext, "run-2", 0)
+		require.NoError(t, err)
+		assert.Empty(t, chunks, "the log chunks of the removed runs should be deleted")
+		assert.False(t, secretExists(p, "run-1-password"), "the secrets created for the removed runs should be deleted")
+		assert.False(t, secretExists(p, "run-1-kubeconfig"), "the secrets created for the removed runs should be deleted")
+		assert.True(t, secretExists(p, "my-token"), "secrets that were not created by Porter should be kept")
+	})
+
+	t.Run("keep failed", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		report, err := p.pruneRuns(p.RootContext, StoragePruneOptions{}, retentionPolicy{keepRuns: 1, keepFailed: true})
+		require.NoError(t, err)
+		require.Len(t, report.Runs, 1)
+		assert.Equal(t, "run-1", report.Runs[0].ID)
+		assert.Equal(t, []string{"run-2", "run-3", "run-4"}, listRunIDs(t, p))
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		report, err := p.pruneRuns(p.RootContext, StoragePruneOptions{}, retentionPolicy{maxAge: 30 * 24 * time.Hour})
+		require.NoError(t, err)
+		require.Len(t, report.Runs, 2)
+		assert.Equal(t, "older than max-age 720h0m0s", report.Runs[0].Reason)
+		assert.Equal(t, []string{"run-3", "run-4"}, listRunIDs(t, p))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		report, err := p.pruneRuns(p.RootContext, StoragePruneOptions{DryRun: true}, retentionPolicy{keepRuns: 1})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Len(t, report.Runs, 2)
+		assert.Equal(t, []string{"run-1", "run-2", "run-3", "run-4"}, listRunIDs(t, p), "no runs should be removed")
+		assert.True(t, secretExists(p, "run-1-password"), "no secrets should be removed")
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		report, err := p.pruneRuns(p.RootContext, StoragePruneOptions{Namespaces: []string{"test"}}, retentionPolicy{keepRuns: 1})
+		require.NoError(t, err)
+		assert.Empty(t, report.Runs)
+	})
+
+	t.Run("config file", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+		p.Data.Retention.KeepRuns = 1
+
+		opts := StoragePruneOptions{DryRun: true}
+		require.NoError(t, opts.Validate(nil))
+		require.NoError(t, p.PruneStorage(p.RootContext, opts))
+		assert.Contains(t, p.TestConfig.TestContext.GetOutput(), "Would remove 2 runs, 2 results, 3 outputs and 1 log chunks")
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		p := setup(t)
+		defer p.Close()
+
+		err := p.PruneStorage(p.RootContext, StoragePruneOptions{})
+		require.ErrorContains(t, err, "no retention policy is defined")
+	})
+}
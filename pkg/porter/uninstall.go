@@ -132,7 +132,7 @@ func (p *Porter) UninstallBundle(ctx context.Context, opts UninstallOptions) err
 
 	if opts.shouldDelete() {
 		log.Info("deleting installation records")
-		return p.Installations.RemoveInstallation(ctx, opts.Namespace, opts.Name)
+		return p.removeInstallation(ctx, opts.Namespace, opts.Name)
 	}
 	return nil
 }
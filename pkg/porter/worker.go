@@ -0,0 +1,180 @@
+package porter
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	cnabprovider "get.porter.sh/porter/pkg/cnab/provider"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/storage"
+)
+
+// workerLocks are shared by Porter and the workers created from it.
+type workerLocks struct {
+	// stores serializes access to the storage and secret stores, so that the
+	// workers can share the plugin connections.
+	stores sync.Mutex
+
+	// output serializes writing the output of the workers to Porter's output.
+	output sync.Mutex
+}
+
+// NewWorker returns a copy of Porter that can reconcile an installation at
+// the same time as the other workers created from p. The worker writes its
+// output to out, and shares Porter's storage and secret stores, which are
+// used by one worker at a time.
+func (p *Porter) NewWorker(out io.Writer) *Porter {
+	pctx := *p.Context
+	pctx.Out = out
+	pctx.Err = out
+	c := *p.Config
+	c.Context = &pctx
+
+	store := &lockedStore{Provider: p.Storage, mu: &p.workers.stores}
+	secretStore := &lockedSecretStore{Store: p.Secrets, mu: &p.workers.stores}
+	installations := storage.NewInstallationStore(store)
+	credentials := storage.NewCredentialStore(store, secretStore)
+	parameters := storage.NewParameterStore(store, secretStore)
+	sanitizer := storage.NewSanitizer(parameters, secretStore)
+
+	w := *p
+	w.Config = &c
+	w.Storage = store
+	w.Secrets = secretStore
+	w.Installations = installations
+	w.Credentials = credentials
+	w.Parameters = parameters
+	w.Sanitizer = sanitizer
+	w.CNAB = cnabprovider.NewRuntime(&c, installations, credentials, parameters, secretStore, sanitizer)
+	return &w
+}
+
+// writeWorkerOutput writes the output of a worker to Porter's output, without
+// interleaving it with the output of the other workers.
+func (p *Porter) writeWorkerOutput(output []byte) {
+	p.workers.output.Lock()
+	defer p.workers.output.Unlock()
+
+	_, _ = p.Out.Write(output)
+}
+
+// lockedStore allows one worker at a time to use the storage.
+type lockedStore struct {
+	storage.Provider
+	mu *sync.Mutex
+}
+
+func (s *lockedStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Close()
+}
+
+func (s *lockedStore) Aggregate(ctx context.Context, collection string, opts storage.AggregateOptions, out interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Aggregate(ctx, collection, opts, out)
+}
+
+func (s *lockedStore) Count(ctx context.Context, collection string, opts storage.CountOptions) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Count(ctx, collection, opts)
+}
+
+func (s *lockedStore) EnsureIndex(ctx context.Context, opts storage.EnsureIndexOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.EnsureIndex(ctx, opts)
+}
+
+func (s *lockedStore) Find(ctx context.Context, collection string, opts storage.FindOptions, out interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Find(ctx, collection, opts, out)
+}
+
+func (s *lockedStore) FindOne(ctx context.Context, collection string, opts storage.FindOptions, out interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.FindOne(ctx, collection, opts, out)
+}
+
+func (s *lockedStore) Get(ctx context.Context, collection string, opts storage.GetOptions, out interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Get(ctx, collection, opts, out)
+}
+
+func (s *lockedStore) Insert(ctx context.Context, collection string, opts storage.InsertOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Insert(ctx, collection, opts)
+}
+
+func (s *lockedStore) Patch(ctx context.Context, collection string, opts storage.PatchOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Patch(ctx, collection, opts)
+}
+
+func (s *lockedStore) Remove(ctx context.Context, collection string, opts storage.RemoveOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Remove(ctx, collection, opts)
+}
+
+func (s *lockedStore) Update(ctx context.Context, collection string, opts storage.UpdateOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Update(ctx, collection, opts)
+}
+
+func (s *lockedStore) WriteSchema(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.WriteSchema(ctx)
+}
+
+func (s *lockedStore) Migrate(ctx context.Context, opts storage.MigrateOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Provider.Migrate(ctx, opts)
+}
+
+// lockedSecretStore allows one worker at a time to use the secret store.
+type lockedSecretStore struct {
+	secrets.Store
+	mu *sync.Mutex
+}
+
+func (s *lockedSecretStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Store.Close()
+}
+
+func (s *lockedSecretStore) Resolve(ctx context.Context, keyName string, keyValue string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Store.Resolve(ctx, keyName, keyValue)
+}
+
+func (s *lockedSecretStore) Create(ctx context.Context, keyName string, keyValue string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Store.Create(ctx, keyName, keyValue, value)
+}
+
+func (s *lockedSecretStore) Delete(ctx context.Context, keyName string, keyValue string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Store.Delete(ctx, keyName, keyValue)
+}
+
+func (s *lockedSecretStore) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Store.List(ctx, keyName, prefix)
+}
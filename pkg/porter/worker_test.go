@@ -0,0 +1,27 @@
+package porter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"get.porter.sh/porter/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPorter_NewWorker(t *testing.T) {
+	p := NewTestPorter(t)
+	defer p.Close()
+
+	var out bytes.Buffer
+	w := p.NewWorker(&out)
+	fmt.Fprint(w.Out, "worker output")
+	assert.Equal(t, "worker output", out.String(), "the worker should write to its own output")
+	assert.Empty(t, p.TestConfig.TestContext.GetOutput(), "the worker should not write to Porter's output")
+
+	i := storage.NewInstallation("dev", "mybuns")
+	require.NoError(t, w.Installations.InsertInstallation(p.RootContext, i))
+	_, err := p.Installations.GetInstallation(p.RootContext, "dev", "mybuns")
+	require.NoError(t, err, "the worker should share Porter's storage")
+}
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 
 	"get.porter.sh/porter/pkg"
@@ -38,11 +39,13 @@ type TestContext struct {
 func NewTestContext(t *testing.T) *TestContext {
 	// Provide a way for tests to provide and capture stdin and stdout
 	// Copy output to the test log simultaneously, use go test -v to see the output
+	// The writes are locked so that the output can be written by concurrent workers
 	logs := &bytes.Buffer{}
+	mu := &sync.Mutex{}
 	err := &bytes.Buffer{}
-	aggErr := io.MultiWriter(err, test.Logger{T: t}, logs)
+	aggErr := &lockedWriter{mu: mu, w: io.MultiWriter(err, test.Logger{T: t}, logs)}
 	out := &bytes.Buffer{}
-	aggOut := io.MultiWriter(out, test.Logger{T: t}, logs)
+	aggOut := &lockedWriter{mu: mu, w: io.MultiWriter(out, test.Logger{T: t}, logs)}
 
 	innerContext := New()
 	innerContext.correlationId = "0"
@@ -75,6 +78,18 @@ func NewTestContext(t *testing.T) *TestContext {
 	return c
 }
 
+// lockedWriter serializes the writes to a writer.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
 func (c *TestContext) NewTestCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
 	testArgs := append([]string{name}, args...)
 	cmd := exec.CommandContext(ctx, os.Args[0], testArgs...)
@@ -3,6 +3,7 @@ package runtime
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -22,6 +23,15 @@ type PorterRuntime struct {
 	config          RuntimeConfig
 	mixins          pkgmgmt.PackageManager
 	RuntimeManifest *RuntimeManifest
+
+	// progress of the current run, recorded as each step completes.
+	progress StepProgress
+
+	// resumedProgress of the failed run that is being resumed.
+	resumedProgress StepProgress
+
+	// report of the result of each step in the current run.
+	report StepReport
 }
 
 func NewPorterRuntime(runtimeCfg RuntimeConfig, mixins pkgmgmt.PackageManager) *PorterRuntime {
@@ -71,15 +81,28 @@ func (r *PorterRuntime) Execute(ctx context.Context, rm *RuntimeManifest) error
 		return fmt.Errorf("could not create outputs directory %s: %w", portercontext.MixinOutputsDir, err)
 	}
 
+	err = r.loadResumedProgress()
+	if err != nil {
+		return err
+	}
+
 	var bigErr *multierror.Error
+	var failure *stepFailure
 	for stepIndex, step := range r.RuntimeManifest.GetSteps() {
 		err = r.executeStep(ctx, stepIndex, step)
 		if err != nil {
 			bigErr = multierror.Append(bigErr, err)
+			description, _ := step.GetDescription()
+			failure = &stepFailure{Description: description, Err: err}
 			break
 		}
 	}
 
+	if failure != nil {
+		bigErr = multierror.Append(bigErr, r.executeHookSteps(ctx, "onFailure", r.RuntimeManifest.GetOnFailureSteps(), failure))
+	}
+	bigErr = multierror.Append(bigErr, r.executeHookSteps(ctx, "finally", r.RuntimeManifest.GetFinallySteps(), failure))
+
 	// Always run Finalize (packs state bag) even when the context is cancelled,
 	// so state written by a gracefully-stopped step is preserved.
 	err = r.RuntimeManifest.Finalize(context.WithoutCancel(ctx))
@@ -94,11 +117,45 @@ func (r *PorterRuntime) executeStep(ctx context.Context, stepIndex int, step *ma
 	if step == nil {
 		return nil
 	}
+	if completed, ok := r.resumedProgress.GetCompletedStep(stepIndex); ok {
+		if err := r.restoreCompletedStep(stepIndex, step, completed); err != nil {
+			return err
+		}
+		r.reportSkippedStep(stepIndex, step)
+		return nil
+	}
+	if step.IsParallel() {
+		return r.executeParallelStep(ctx, stepIndex, step)
+	}
+
+	// Record the untemplated description so that it can be compared when the run is resumed
+	untemplatedDescription, _ := step.GetDescription()
+
+	result := newStepResult(stepIndex, step)
+
 	err := r.RuntimeManifest.ResolveStep(ctx, stepIndex, step)
 	if err != nil {
+		if errors.Is(err, ErrStepSkipped{}) {
+			fmt.Fprintln(r.config.Out, err.Error())
+			r.reportStep(result.skip())
+			return nil
+		}
+		r.reportStep(result.complete(nil, err))
 		return fmt.Errorf("unable to resolve step: %w", err)
 	}
 
+	outputs, err := r.runStep(ctx, step)
+	r.reportStep(result.complete(outputs, err))
+	if err != nil {
+		return err
+	}
+	return r.recordCompletedStep(stepIndex, untemplatedDescription, outputs)
+}
+
+// runStep runs the mixin for a step that has been resolved, and applies the
+// outputs that it generated. The outputs are returned even when the mixin
+// fails, so that they can be reported.
+func (r *PorterRuntime) runStep(ctx context.Context, step *manifest.Step) (map[string]string, error) {
 	description, _ := step.GetDescription()
 	if len(description) > 0 {
 		fmt.Fprintln(r.config.Out, description)
@@ -107,38 +164,42 @@ func (r *PorterRuntime) executeStep(ctx context.Context, stepIndex int, step *ma
 	// Hand over values needing masking in config output streams
 	r.config.SetSensitiveValues(r.RuntimeManifest.GetSensitiveValues())
 
-	input := &ActionInput{
-		action: r.RuntimeManifest.Action,
-		Steps:  []*manifest.Step{step},
-	}
-	inputBytes, _ := yaml.Marshal(input)
-	cmd := pkgmgmt.CommandOptions{
-		Command: string(r.RuntimeManifest.Action),
-		Input:   string(inputBytes),
-		Runtime: true,
-	}
-	mixinErr := r.mixins.Run(ctx, r.config.Context, step.GetMixinName(), cmd)
+	mixinErr := r.runMixin(ctx, r.config.Context, step, r.newMixinCommand(step))
 
 	// Read any outputs the mixin managed to write, even if it was cancelled or
 	// exited with an error, so that state (e.g. terraform state) is not lost.
 	outputs, err := r.readMixinOutputs()
 	if err != nil {
-		return fmt.Errorf("could not read step outputs: %w", err)
+		return nil, fmt.Errorf("could not read step outputs: %w", err)
 	}
 
 	if err = r.RuntimeManifest.ApplyStepOutputs(outputs); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Apply any Bundle Outputs declared in this step
 	if err = r.applyStepOutputsToBundle(outputs); err != nil {
-		return err
+		return nil, err
 	}
 
 	if mixinErr != nil {
-		return fmt.Errorf("mixin execution failed: %w", mixinErr)
+		return outputs, fmt.Errorf("mixin execution failed: %w", mixinErr)
+	}
+	return outputs, nil
+}
+
+// newMixinCommand creates the command that runs the mixin for a step.
+func (r *PorterRuntime) newMixinCommand(step *manifest.Step) pkgmgmt.CommandOptions {
+	input := &ActionInput{
+		action: r.RuntimeManifest.Action,
+		Steps:  []*manifest.Step{step.GetMixinStep()},
+	}
+	inputBytes, _ := yaml.Marshal(input)
+	return pkgmgmt.CommandOptions{
+		Command: string(r.RuntimeManifest.Action),
+		Input:   string(inputBytes),
+		Runtime: true,
 	}
-	return nil
 }
 
 // applyStepOutputsToBundle writes the provided step outputs to the proper location
@@ -269,6 +269,16 @@ func (m *RuntimeManifest) GetSteps() manifest.Steps {
 	return m.steps
 }
 
+// GetOnFailureSteps returns the steps that are run after a step in the action fails.
+func (m *RuntimeManifest) GetOnFailureSteps() manifest.Steps {
+	return m.OnFailure[m.Action]
+}
+
+// GetFinallySteps returns the steps that are run after the action's steps.
+func (m *RuntimeManifest) GetFinallySteps() manifest.Steps {
+	return m.Finally[m.Action]
+}
+
 func (m *RuntimeManifest) GetOutputs() map[string]string {
 	outputs := make(map[string]string, len(m.outputs))
 
@@ -544,9 +554,32 @@ func (m *RuntimeManifest) buildAndResolveMappedDependencyOutputs(sourceData map[
 
 // ResolveStep will walk through the Step's data and resolve any placeholder
 // data using the definitions in the manifest, like parameters or credentials.
+// When the step has a when condition that evaluates to false, ErrStepSkipped
+// is returned and the step should not be run.
 func (m *RuntimeManifest) ResolveStep(ctx context.Context, stepIndex int, step *manifest.Step) error {
+	return m.resolveStep(ctx, fmt.Sprintf("%s[%d]", m.Action, stepIndex), step)
+}
+
+// ResolveParallelStep resolves a step in the parallel group at the specified
+// index in the action, in the same way as ResolveStep.
+func (m *RuntimeManifest) ResolveParallelStep(ctx context.Context, stepIndex int, groupIndex int, step *manifest.Step) error {
+	return m.resolveStep(ctx, fmt.Sprintf("%s[%d].parallel[%d]", m.Action, stepIndex, groupIndex), step)
+}
+
+// ResolveHookStep resolves a step in the onFailure or finally steps of the
+// action, in the same way as ResolveStep.
+func (m *RuntimeManifest) ResolveHookStep(ctx context.Context, hook string, stepIndex int, step *manifest.Step) error {
+	return m.resolveStep(ctx, fmt.Sprintf("%s.%s[%d]", hook, m.Action, stepIndex), step)
+}
+
+// resolveStep resolves the step defined at the specified path in the manifest.
+func (m *RuntimeManifest) resolveStep(ctx context.Context, stepPath string, step *manifest.Step) error {
 	log := tracing.LoggerFromContext(ctx)
 
+	// The condition is only templated while it is evaluated, so that it can be
+	// logged without exposing sensitive values
+	condition := step.When
+
 	// Refresh our template data
 	sourceData, err := m.buildSourceData()
 	if err != nil {
@@ -562,8 +595,23 @@ func (m *RuntimeManifest) ResolveStep(ctx context.Context, stepIndex int, step *
 		}
 	}
 
+	// Evaluate the condition before rendering the step, so that steps that
+	// are skipped are never rendered
+	if condition != "" {
+		render := func(value string) (string, error) {
+			return mustache.RenderRaw(m.GetTemplatePrefix()+value, true, sourceData)
+		}
+		run, err := evaluateStepCondition(condition, render)
+		if err != nil {
+			return log.Errorf("could not evaluate the when condition for step %s: %w", stepPath, err)
+		}
+		if !run {
+			description, _ := step.GetDescription()
+			return ErrStepSkipped{Description: description, Condition: condition}
+		}
+	}
+
 	// Get the original yaml for the current step
-	stepPath := fmt.Sprintf("%s[%d]", m.Action, stepIndex)
 	stepTemplate, err := m.getStepTemplate(stepPath)
 	if err != nil {
 		return log.Error(fmt.Errorf("unable to retrieve original yaml for step %s: %w", stepPath, err))
@@ -586,6 +634,7 @@ func (m *RuntimeManifest) ResolveStep(ctx context.Context, stepIndex int, step *
 	if err != nil {
 		return log.Error(fmt.Errorf("invalid step yaml after rendering template\n%s: %w", stepTemplate, err))
 	}
+	step.When = condition
 
 	return nil
 }
@@ -967,6 +1016,7 @@ func (m *RuntimeManifest) getStepTemplate(stepPath string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("unable to retrieve original yaml for step %s: %w", stepPath, err)
 	}
+	stepNode = withoutStepCondition(stepNode)
 
 	var stepYAML bytes.Buffer
 	enc := yaml3.NewEncoder(&stepYAML)
@@ -979,6 +1029,24 @@ func (m *RuntimeManifest) getStepTemplate(stepPath string) (string, error) {
 	return stepTemplate, nil
 }
 
+// withoutStepCondition returns a copy of the step without its when condition,
+// which is evaluated separately before the step is rendered.
+func withoutStepCondition(stepNode *yaml3.Node) *yaml3.Node {
+	if stepNode.Kind != yaml3.MappingNode {
+		return stepNode
+	}
+
+	step := *stepNode
+	step.Content = make([]*yaml3.Node, 0, len(stepNode.Content))
+	for i := 0; i+1 < len(stepNode.Content); i += 2 {
+		if stepNode.Content[i].Value == "when" {
+			continue
+		}
+		step.Content = append(step.Content, stepNode.Content[i], stepNode.Content[i+1])
+	}
+	return &step
+}
+
 func resolveImage(image *manifest.MappedImage, refString string) error {
 	//figure out what type of Reference it is so we can extract useful things for our image map
 	ref, err := cnab.ParseOCIReference(refString)
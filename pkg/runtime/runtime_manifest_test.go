@@ -1510,3 +1510,78 @@ func TestResolveInvocationImage(t *testing.T) {
 	}
 
 }
+
+func TestResolveStep_When(t *testing.T) {
+	testcases := []struct {
+		name    string
+		env     string
+		when    string
+		wantRun bool
+	}{
+		{name: "equal", env: "prod", when: "${ bundle.parameters.env } == prod", wantRun: true},
+		{name: "not equal", env: "dev", when: "${ bundle.parameters.env } == prod", wantRun: false},
+		{name: "negated", env: "dev", when: "${ bundle.parameters.env } != 'prod'", wantRun: true},
+		{name: "truthy", env: "true", when: "${ bundle.parameters.env }", wantRun: true},
+		{name: "falsy", env: "false", when: "${ bundle.parameters.env }", wantRun: false},
+		{name: "value ends with operator", env: "prod==", when: "${ bundle.parameters.env } == prod", wantRun: false},
+		{name: "value contains operator", env: "!= prod", when: "${ bundle.parameters.env } != prod", wantRun: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			testConfig := config.NewTestConfig(t)
+			testConfig.Setenv("ENV", tc.env)
+
+			mContent := fmt.Sprintf(`schemaVersion: 1.0.0-alpha.2
+parameters:
+- name: env
+  sensitive: true
+
+install:
+- when: "%s"
+  mymixin:
+    description: Deploy to production
+    Parameters:
+      Thing: ${ bundle.parameters.env }
+`, tc.when)
+			rm := runtimeManifestFromStepYaml(t, testConfig, mContent)
+			s := rm.Install[0]
+
+			err := rm.ResolveStep(ctx, 0, s)
+			if tc.wantRun {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrStepSkipped{})
+			assert.Equal(t, fmt.Sprintf(`skipping step "Deploy to production" because its condition is false: %s`, tc.when), err.Error(),
+				"the untemplated condition should be logged so that sensitive values are not exposed")
+		})
+	}
+}
+
+func TestResolveStep_WhenSkipsRendering(t *testing.T) {
+	ctx := context.Background()
+	testConfig := config.NewTestConfig(t)
+	testConfig.Setenv("ENV", "dev")
+
+	// The step uses an output that is only generated in production, and
+	// cannot be rendered when the step is skipped
+	mContent := `schemaVersion: 1.0.0-alpha.2
+parameters:
+- name: env
+
+install:
+- when: ${ bundle.parameters.env } == prod
+  mymixin:
+    description: Deploy to production
+    Parameters:
+      Thing: ${ bundle.outputs.prod-only }
+`
+	rm := runtimeManifestFromStepYaml(t, testConfig, mContent)
+	s := rm.Install[0]
+
+	err := rm.ResolveStep(ctx, 0, s)
+	require.ErrorIs(t, err, ErrStepSkipped{}, "the step should be skipped without rendering it")
+}
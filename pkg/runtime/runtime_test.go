@@ -374,3 +374,30 @@ install:
 	require.NoError(t, readErr, "bundle output should be written even when the mixin exits with an error")
 	assert.Equal(t, "important-state", string(contents))
 }
+
+func TestExecuteStep_SkipsStepWhenConditionIsFalse(t *testing.T) {
+	ctx := context.Background()
+	r := NewTestPorterRuntime(t)
+
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, `schemaVersion: 1.0.0-alpha.2
+install:
+- when: ${ installation.name } == prod
+  exec:
+    description: "Production only"
+    command: echo
+    arguments:
+    - hello
+`)
+	r.RuntimeManifest = rm
+
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		func(_ *portercontext.Context, _ string, _ pkgmgmt.CommandOptions) error {
+			return errors.New("the mixin should not be run when the step is skipped")
+		},
+	}
+
+	err := r.executeStep(ctx, 0, rm.Install[0])
+	require.NoError(t, err)
+	assert.Contains(t, r.TestContext.GetOutput(), `skipping step "Production only" because its condition is false: ${ installation.name } == prod`)
+}
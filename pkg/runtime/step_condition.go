@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrStepSkipped is returned by RuntimeManifest.ResolveStep when the step's
+// when condition evaluated to false and the step should not be run.
+// Test for this error using errors.Is(err, ErrStepSkipped{}).
+type ErrStepSkipped struct {
+	// Description of the step that was skipped.
+	Description string
+
+	// Condition is the untemplated when condition of the step, so that it is
+	// safe to log without exposing sensitive values.
+	Condition string
+}
+
+func (e ErrStepSkipped) Error() string {
+	step := "step"
+	if e.Description != "" {
+		step = fmt.Sprintf("step %q", e.Description)
+	}
+	return fmt.Sprintf("skipping %s because its condition is false: %s", step, e.Condition)
+}
+
+func (e ErrStepSkipped) Is(err error) bool {
+	_, ok := err.(ErrStepSkipped)
+	return ok
+}
+
+// evaluateStepCondition evaluates an untemplated when condition.
+// The condition supports comparing two values with == or !=, negating a
+// value with !, or a single value that is false when it is empty, false, no,
+// off or 0, and true otherwise. The operator is found in the untemplated
+// condition, and then each value is rendered separately, so that the
+// rendered values cannot change how the condition is parsed.
+func evaluateStepCondition(condition string, render func(string) (string, error)) (bool, error) {
+	condition = strings.TrimSpace(condition)
+
+	renderValue := func(value string) (string, error) {
+		rendered, err := render(strings.TrimSpace(value))
+		if err != nil {
+			return "", err
+		}
+		return unquoteConditionValue(rendered), nil
+	}
+
+	for _, op := range []string{"==", "!="} {
+		left, right, found := strings.Cut(condition, op)
+		if !found {
+			continue
+		}
+		if strings.Contains(right, "==") || strings.Contains(right, "!=") {
+			return false, fmt.Errorf("invalid condition, only a single comparison is supported: %s", condition)
+		}
+
+		leftValue, err := renderValue(left)
+		if err != nil {
+			return false, err
+		}
+		rightValue, err := renderValue(right)
+		if err != nil {
+			return false, err
+		}
+		equal := leftValue == rightValue
+		return equal == (op == "=="), nil
+	}
+
+	negated, isNegated := strings.CutPrefix(condition, "!")
+	if isNegated {
+		condition = negated
+	}
+	value, err := renderValue(condition)
+	if err != nil {
+		return false, err
+	}
+	return isTruthy(value) != isNegated, nil
+}
+
+func unquoteConditionValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+func isTruthy(value string) bool {
+	switch strings.ToLower(value) {
+	case "", "false", "no", "off", "0":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,88 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateStepCondition(t *testing.T) {
+	testcases := []struct {
+		condition string
+		want      bool
+		wantErr   string
+	}{
+		{condition: "prod == prod", want: true},
+		{condition: "prod == dev", want: false},
+		{condition: `"prod" == 'prod'`, want: true},
+		{condition: "prod != dev", want: true},
+		{condition: " prod != prod ", want: false},
+		{condition: "true", want: true},
+		{condition: "something", want: true},
+		{condition: "", want: false},
+		{condition: "false", want: false},
+		{condition: "FALSE", want: false},
+		{condition: "no", want: false},
+		{condition: "off", want: false},
+		{condition: "0", want: false},
+		{condition: `""`, want: false},
+		{condition: "!false", want: true},
+		{condition: "!true", want: false},
+		{condition: "!", want: true},
+		{condition: "a == b == c", wantErr: "only a single comparison is supported"},
+		{condition: "a == b != c", wantErr: "only a single comparison is supported"},
+	}
+
+	// Values are not templated in these test cases
+	render := func(value string) (string, error) {
+		return value, nil
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.condition, func(t *testing.T) {
+			got, err := evaluateStepCondition(tc.condition, render)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEvaluateStepCondition_RenderedOperators(t *testing.T) {
+	// The rendered values contain operators, which should be compared as values
+	values := map[string]string{
+		"${ env }":   "prod==",
+		"${ match }": "prod==",
+		"${ other }": "!= prod",
+	}
+	render := func(value string) (string, error) {
+		if rendered, ok := values[value]; ok {
+			return rendered, nil
+		}
+		return value, nil
+	}
+
+	testcases := []struct {
+		condition string
+		want      bool
+	}{
+		{condition: "${ env } == ${ match }", want: true},
+		{condition: "${ env } == prod", want: false},
+		{condition: "${ env } != prod", want: true},
+		{condition: "${ other } == ${ other }", want: true},
+		{condition: "${ env }", want: true},
+		{condition: "!${ env }", want: false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.condition, func(t *testing.T) {
+			got, err := evaluateStepCondition(tc.condition, render)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
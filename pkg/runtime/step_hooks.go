@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/tracing"
+	"github.com/hashicorp/go-multierror"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// hookStepsTimeout limits how long the onFailure or finally steps of an
+// action may run, because they are run even after the action is cancelled.
+const hookStepsTimeout = 10 * time.Minute
+
+// stepFailure describes the step that failed while running an action.
+type stepFailure struct {
+	// Description of the step that failed.
+	Description string
+
+	// Err returned by the step that failed.
+	Err error
+}
+
+// executeHookSteps runs the onFailure or finally steps of the action. The
+// description and error of the step that failed, if any, are available to the
+// steps in environment variables. Each step is run even when a previous step
+// fails, or when the action was cancelled or timed out.
+func (r *PorterRuntime) executeHookSteps(ctx context.Context, hook string, steps manifest.Steps, failure *stepFailure) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hookStepsTimeout)
+	defer cancel()
+
+	ctx, span := tracing.StartSpan(ctx, attribute.String("hook", hook))
+	defer span.EndSpan()
+
+	var failedStep, failedStepError string
+	if failure != nil {
+		failedStep = failure.Description
+		failedStepError = failure.Err.Error()
+	}
+	r.config.Setenv(config.EnvPorterFailedStep, failedStep)
+	r.config.Setenv(config.EnvPorterFailedStepError, failedStepError)
+
+	fmt.Fprintf(r.config.Out, "running the %s steps\n", hook)
+
+	var result *multierror.Error
+	for stepIndex, step := range steps {
+		if err := r.executeHookStep(ctx, hook, stepIndex, step); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s step failed: %w", hook, err))
+		}
+	}
+	return span.Error(result.ErrorOrNil())
+}
+
+func (r *PorterRuntime) executeHookStep(ctx context.Context, hook string, stepIndex int, step *manifest.Step) error {
+	if step == nil {
+		return nil
+	}
+
+	result := newStepResult(stepIndex, step)
+	result.Hook = hook
+
+	err := r.RuntimeManifest.ResolveHookStep(ctx, hook, stepIndex, step)
+	if err != nil {
+		if errors.Is(err, ErrStepSkipped{}) {
+			fmt.Fprintln(r.config.Out, err.Error())
+			r.reportStep(result.skip())
+			return nil
+		}
+		r.reportStep(result.complete(nil, err))
+		return fmt.Errorf("unable to resolve step: %w", err)
+	}
+
+	outputs, err := r.runStep(ctx, step)
+	r.reportStep(result.complete(outputs, err))
+	return err
+}
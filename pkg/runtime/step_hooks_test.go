@@ -0,0 +1,178 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/mixin"
+	"get.porter.sh/porter/pkg/pkgmgmt"
+	"get.porter.sh/porter/pkg/portercontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const actionHooksManifest = `schemaVersion: 1.0.0
+name: mybuns
+version: 0.1.0
+mixins:
+- exec
+install:
+- exec:
+    description: "Deploy the application"
+    command: ./helpers.sh
+uninstall:
+- exec:
+    description: "Remove the application"
+    command: ./helpers.sh
+onFailure:
+  install:
+  - exec:
+      description: "Collect pod logs"
+      command: ./helpers.sh
+finally:
+  install:
+  - exec:
+      description: "Release the lock"
+      command: ./helpers.sh
+`
+
+// hookStep is a step run by the test mixin, with the failure that was
+// available to it in the environment.
+type hookStep struct {
+	description     string
+	failedStep      string
+	failedStepError string
+}
+
+func TestExecute_ActionHooks(t *testing.T) {
+	testcases := []struct {
+		name      string
+		failSteps []string
+		wantSteps []hookStep
+		wantErr   string
+	}{
+		{
+			name: "action succeeded",
+			wantSteps: []hookStep{
+				{description: "Deploy the application"},
+				{description: "Release the lock"},
+			},
+		},
+		{
+			name:      "action failed",
+			failSteps: []string{"Deploy the application"},
+			wantSteps: []hookStep{
+				{description: "Deploy the application"},
+				{description: "Collect pod logs", failedStep: "Deploy the application", failedStepError: "mixin execution failed: exit status 1"},
+				{description: "Release the lock", failedStep: "Deploy the application", failedStepError: "mixin execution failed: exit status 1"},
+			},
+			wantErr: "exit status 1",
+		},
+		{
+			name:      "onFailure failed",
+			failSteps: []string{"Deploy the application", "Collect pod logs"},
+			wantSteps: []hookStep{
+				{description: "Deploy the application"},
+				{description: "Collect pod logs", failedStep: "Deploy the application", failedStepError: "mixin execution failed: exit status 1"},
+				{description: "Release the lock", failedStep: "Deploy the application", failedStepError: "mixin execution failed: exit status 1"},
+			},
+			wantErr: "onFailure step failed: mixin execution failed: exit status 1",
+		},
+		{
+			name:      "finally failed",
+			failSteps: []string{"Release the lock"},
+			wantSteps: []hookStep{
+				{description: "Deploy the application"},
+				{description: "Release the lock"},
+			},
+			wantErr: "finally step failed: mixin execution failed: exit status 1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := NewTestPorterRuntime(t)
+			rm := runtimeManifestFromStepYaml(t, r.TestConfig, actionHooksManifest)
+			r.TestContext.AddTestFileContents([]byte(`{"schemaVersion":"v1.0.0","name":"mybuns","version":"0.1.0"}`), "/cnab/bundle.json")
+			require.NoError(t, r.config.FileSystem.MkdirAll(config.BundleOutputsDir, pkg.FileModeDirectory))
+
+			var gotSteps []hookStep
+			testMixin := r.mixins.(*mixin.TestMixinProvider)
+			testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+				func(pkgCtx *portercontext.Context, _ string, cmd pkgmgmt.CommandOptions) error {
+					var description string
+					for _, step := range []string{"Deploy the application", "Collect pod logs", "Release the lock"} {
+						if strings.Contains(cmd.Input, step) {
+							description = step
+						}
+					}
+					gotSteps = append(gotSteps, hookStep{
+						description:     description,
+						failedStep:      pkgCtx.Getenv(config.EnvPorterFailedStep),
+						failedStepError: pkgCtx.Getenv(config.EnvPorterFailedStepError),
+					})
+					for _, failStep := range tc.failSteps {
+						if description == failStep {
+							return testExitError{code: 1}
+						}
+					}
+					return nil
+				},
+			}
+
+			err := r.Execute(ctx, rm)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+			assert.Equal(t, tc.wantSteps, gotSteps)
+		})
+	}
+}
+
+// hookMixinProvider is a mixin provider that runs the action's steps until
+// the context is cancelled, and records the hook steps that were run.
+type hookMixinProvider struct {
+	*mixin.TestMixinProvider
+	hooks       []string
+	hookCtxErrs []error
+}
+
+func (p *hookMixinProvider) Run(ctx context.Context, _ *portercontext.Context, _ string, cmd pkgmgmt.CommandOptions) error {
+	if strings.Contains(cmd.Input, "Deploy the application") {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	for _, step := range []string{"Collect pod logs", "Release the lock"} {
+		if strings.Contains(cmd.Input, step) {
+			p.hooks = append(p.hooks, step)
+		}
+	}
+	p.hookCtxErrs = append(p.hookCtxErrs, ctx.Err())
+	return nil
+}
+
+func TestExecute_ActionHooksAfterTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	r := NewTestPorterRuntime(t)
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, actionHooksManifest)
+	r.TestContext.AddTestFileContents([]byte(`{"schemaVersion":"v1.0.0","name":"mybuns","version":"0.1.0"}`), "/cnab/bundle.json")
+	require.NoError(t, r.config.FileSystem.MkdirAll(config.BundleOutputsDir, pkg.FileModeDirectory))
+	mixins := &hookMixinProvider{TestMixinProvider: mixin.NewTestMixinProvider()}
+	r.mixins = mixins
+
+	err := r.Execute(ctx, rm)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"Collect pod logs", "Release the lock"}, mixins.hooks,
+		"the onFailure and finally steps should run after the action timed out")
+	assert.Equal(t, []error{nil, nil}, mixins.hookCtxErrs, "the hook steps should not be run with the cancelled context")
+}
@@ -0,0 +1,169 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/tracing"
+	"github.com/hashicorp/go-multierror"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// executeParallelStep runs the steps in a parallel group at the same time.
+// Each line of output from a step is prefixed with the step's description.
+// The outputs generated by the steps are applied after all the steps have
+// completed, and the group fails when any of its steps fail.
+func (r *PorterRuntime) executeParallelStep(ctx context.Context, stepIndex int, group *manifest.Step) error {
+	ctx, span := tracing.StartSpan(ctx, attribute.Int("steps", len(group.Parallel)))
+	defer span.EndSpan()
+
+	// Record the untemplated description so that it can be compared when the run is resumed
+	untemplatedDescription, _ := group.GetDescription()
+
+	// Resolve all the steps before running them because the runtime manifest
+	// is not safe for concurrent use
+	steps := make([]*manifest.Step, 0, len(group.Parallel))
+	results := make([]StepResult, 0, len(group.Parallel))
+	for i, step := range group.Parallel {
+		result := newStepResult(stepIndex, step)
+		err := r.RuntimeManifest.ResolveParallelStep(ctx, stepIndex, i, step)
+		if err != nil {
+			if errors.Is(err, ErrStepSkipped{}) {
+				fmt.Fprintln(r.config.Out, err.Error())
+				r.reportStep(result.skip())
+				continue
+			}
+			r.reportStep(result.complete(nil, err))
+			return span.Errorf("unable to resolve step: %w", err)
+		}
+		steps = append(steps, step)
+		results = append(results, result)
+	}
+
+	// Hand over values needing masking in config output streams
+	r.config.SetSensitiveValues(r.RuntimeManifest.GetSensitiveValues())
+
+	fmt.Fprintf(r.config.Out, "running %d steps in parallel\n", len(steps))
+
+	var outputLock sync.Mutex
+	mixinErrs := make([]error, len(steps))
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		wg.Add(1)
+		go func(i int, step *manifest.Step) {
+			defer wg.Done()
+			results[i].Started = time.Now()
+			mixinErrs[i] = r.runParallelMixin(ctx, step, &outputLock)
+			results[i].Stopped = time.Now()
+		}(i, step)
+	}
+	wg.Wait()
+
+	// The steps in a group cannot generate the same output, so reading the
+	// outputs after all the steps are done merges them deterministically
+	outputs, err := r.readMixinOutputs()
+	if err != nil {
+		return span.Errorf("could not read step outputs: %w", err)
+	}
+
+	if err = r.RuntimeManifest.ApplyStepOutputs(outputs); err != nil {
+		return span.Error(err)
+	}
+
+	if err = r.applyStepOutputsToBundle(outputs); err != nil {
+		return span.Error(err)
+	}
+
+	// Attribute each output to the step that declared it
+	for i, step := range steps {
+		stepOutputs := make(map[string]string)
+		for _, name := range step.GetOutputNames() {
+			if value, ok := outputs[name]; ok {
+				stepOutputs[name] = value
+			}
+		}
+		r.reportStep(results[i].complete(stepOutputs, mixinErrs[i]))
+	}
+
+	var groupErr *multierror.Error
+	for i, mixinErr := range mixinErrs {
+		if mixinErr != nil {
+			description, _ := steps[i].GetDescription()
+			groupErr = multierror.Append(groupErr, fmt.Errorf("step %q failed: %w", description, mixinErr))
+		}
+	}
+	if groupErr != nil {
+		return span.Errorf("mixin execution failed: %w", groupErr)
+	}
+	return r.recordCompletedStep(stepIndex, untemplatedDescription, outputs)
+}
+
+// runParallelMixin runs the mixin for a step in a parallel group, prefixing
+// each line of its output with the step's description.
+func (r *PorterRuntime) runParallelMixin(ctx context.Context, step *manifest.Step, outputLock *sync.Mutex) error {
+	prefix, _ := step.GetDescription()
+	if prefix == "" {
+		prefix = step.GetMixinName()
+	}
+
+	stdout := &linePrefixWriter{lock: outputLock, out: r.config.Out, prefix: prefix}
+	stderr := &linePrefixWriter{lock: outputLock, out: r.config.Err, prefix: prefix}
+	defer stdout.Flush()
+	defer stderr.Flush()
+
+	// Give each step its own output streams, the rest of the context is shared
+	pkgCtx := *r.config.Context
+	pkgCtx.Out = stdout
+	pkgCtx.Err = stderr
+
+	return r.runMixin(ctx, &pkgCtx, step, r.newMixinCommand(step))
+}
+
+// linePrefixWriter prefixes each line written to it, and only writes complete
+// lines so that the output of steps that run at the same time is not mixed
+// within a line. The lock is shared by all the writers to the same output.
+type linePrefixWriter struct {
+	lock   *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    bytes.Buffer
+}
+
+func (w *linePrefixWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf.Next(i + 1)); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Flush writes the last line when it did not end with a newline.
+func (w *linePrefixWriter) Flush() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w.out, "[%s] %s\n", w.prefix, w.buf.Next(w.buf.Len()))
+	return err
+}
+
+func (w *linePrefixWriter) writeLine(line []byte) error {
+	_, err := fmt.Fprintf(w.out, "[%s] %s", w.prefix, line)
+	return err
+}
@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/mixin"
+	"get.porter.sh/porter/pkg/pkgmgmt"
+	"get.porter.sh/porter/pkg/portercontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const parallelStepManifest = `schemaVersion: 1.0.0-alpha.2
+install:
+- parallel:
+  - exec:
+      description: "Create the database"
+      command: ./helpers.sh
+      arguments: [db]
+      outputs:
+      - name: connstr
+  - exec:
+      description: "Create the cache"
+      command: ./helpers.sh
+      arguments: [cache]
+      outputs:
+      - name: host
+`
+
+// parallelMixin returns a mixin run assertion for the steps in the parallel
+// group, that prints a message and generates the step's output. The step for
+// the cache fails when failCache is set.
+func parallelMixin(ran *sync.Map, failCache bool) func(*portercontext.Context, string, pkgmgmt.CommandOptions) error {
+	return func(pkgCtx *portercontext.Context, _ string, cmd pkgmgmt.CommandOptions) error {
+		name, output, value := "db", "connstr", "db://mydb"
+		if strings.Contains(cmd.Input, "cache") {
+			name, output, value = "cache", "host", "cache.local"
+		}
+		ran.Store(name, true)
+
+		fmt.Fprintf(pkgCtx.Out, "creating %s\n", name)
+		if err := pkgCtx.FileSystem.WriteFile(filepath.Join(portercontext.MixinOutputsDir, output), []byte(value), pkg.FileModeWritable); err != nil {
+			return err
+		}
+		if name == "cache" && failCache {
+			return testExitError{code: 1}
+		}
+		return nil
+	}
+}
+
+func TestExecuteStep_Parallel(t *testing.T) {
+	ctx := context.Background()
+	r := NewTestPorterRuntime(t)
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, parallelStepManifest)
+	r.RuntimeManifest = rm
+	require.NoError(t, r.config.FileSystem.MkdirAll(portercontext.MixinOutputsDir, pkg.FileModeDirectory))
+
+	var ran sync.Map
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		parallelMixin(&ran, false),
+	}
+
+	require.NoError(t, r.executeStep(ctx, 0, rm.Install[0]))
+
+	_, ranDB := ran.Load("db")
+	_, ranCache := ran.Load("cache")
+	assert.True(t, ranDB && ranCache, "all the steps in the parallel group should be run")
+	assert.Equal(t, "db://mydb", rm.outputs["connstr"], "the outputs of each step should be applied")
+	assert.Equal(t, "cache.local", rm.outputs["host"], "the outputs of each step should be applied")
+
+	gotOutput := r.TestContext.GetOutput()
+	assert.Contains(t, gotOutput, "running 2 steps in parallel")
+	assert.Contains(t, gotOutput, "[Create the database] creating db\n", "the output of each step should be prefixed with its description")
+	assert.Contains(t, gotOutput, "[Create the cache] creating cache\n", "the output of each step should be prefixed with its description")
+}
+
+func TestExecuteStep_ParallelFailure(t *testing.T) {
+	ctx := context.Background()
+	r := NewTestPorterRuntime(t)
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, parallelStepManifest)
+	r.RuntimeManifest = rm
+	require.NoError(t, r.config.FileSystem.MkdirAll(portercontext.MixinOutputsDir, pkg.FileModeDirectory))
+
+	var ran sync.Map
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		parallelMixin(&ran, true),
+	}
+
+	err := r.executeStep(ctx, 0, rm.Install[0])
+	require.ErrorContains(t, err, `step "Create the cache" failed: exit status 1`, "the group should fail when one of its steps fails")
+	assert.NotContains(t, err.Error(), "Create the database", "only the failed step should be reported")
+
+	_, ranDB := ran.Load("db")
+	assert.True(t, ranDB, "the other steps in the group should run to completion")
+	assert.Equal(t, "db://mydb", rm.outputs["connstr"], "the outputs generated before the group failed should be applied")
+}
+
+func TestLinePrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &linePrefixWriter{lock: &sync.Mutex{}, out: &out, prefix: "Create the database"}
+
+	_, err := w.Write([]byte("first line\nsecond "))
+	require.NoError(t, err)
+	assert.Equal(t, "[Create the database] first line\n", out.String(), "only complete lines should be written")
+
+	_, err = w.Write([]byte("line\nlast line"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "[Create the database] first line\n[Create the database] second line\n[Create the database] last line\n", out.String())
+}
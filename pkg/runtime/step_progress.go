@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/manifest"
+)
+
+// StepProgress records the steps of an action that were completed by a run,
+// so that a failed run can be resumed from the step that failed.
+type StepProgress struct {
+	// Action that was run.
+	Action string `json:"action"`
+
+	// CompletedSteps in the order that they were run.
+	CompletedSteps []CompletedStep `json:"completedSteps,omitempty"`
+}
+
+// CompletedStep is a step that was run successfully.
+type CompletedStep struct {
+	// Index of the step in the action.
+	Index int `json:"index"`
+
+	// Description of the step.
+	Description string `json:"description,omitempty"`
+
+	// Outputs generated by the step.
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// GetCompletedStep returns the completed step at the specified index in the action.
+func (p StepProgress) GetCompletedStep(index int) (CompletedStep, bool) {
+	for _, step := range p.CompletedSteps {
+		if step.Index == index {
+			return step, true
+		}
+	}
+	return CompletedStep{}, false
+}
+
+// loadResumedProgress loads the progress of the failed run that is being
+// resumed, when Porter has placed it in the bundle.
+func (r *PorterRuntime) loadResumedProgress() error {
+	exists, _ := r.config.FileSystem.Exists(config.ResumeFilepath)
+	if !exists {
+		return nil
+	}
+
+	data, err := r.config.FileSystem.ReadFile(config.ResumeFilepath)
+	if err != nil {
+		return fmt.Errorf("could not read the progress of the run to resume from %s: %w", config.ResumeFilepath, err)
+	}
+
+	var progress StepProgress
+	if err = json.Unmarshal(data, &progress); err != nil {
+		return fmt.Errorf("could not parse the progress of the run to resume: %w", err)
+	}
+	if progress.Action != r.RuntimeManifest.Action {
+		return fmt.Errorf("cannot resume a %s run with the %s action", progress.Action, r.RuntimeManifest.Action)
+	}
+
+	r.resumedProgress = progress
+	fmt.Fprintf(r.config.Out, "resuming the %s action, %d completed steps will be skipped\n", progress.Action, len(progress.CompletedSteps))
+	return nil
+}
+
+// restoreCompletedStep skips a step that was completed by the run being
+// resumed, and applies the outputs that it generated.
+func (r *PorterRuntime) restoreCompletedStep(stepIndex int, step *manifest.Step, completed CompletedStep) error {
+	description, _ := step.GetDescription()
+	if description != completed.Description {
+		return fmt.Errorf("cannot resume the %s step %q because the resumed run completed a different step, %q", r.RuntimeManifest.Action, description, completed.Description)
+	}
+
+	fmt.Fprintf(r.config.Out, "skipping step %q because it was completed by the resumed run\n", description)
+
+	if err := r.RuntimeManifest.ApplyStepOutputs(completed.Outputs); err != nil {
+		return err
+	}
+	if err := r.applyStepOutputsToBundle(completed.Outputs); err != nil {
+		return err
+	}
+
+	return r.recordCompletedStep(stepIndex, description, completed.Outputs)
+}
+
+// recordCompletedStep saves that the step was completed, along with its
+// outputs, to the step progress output so that the run can be resumed if a
+// later step fails.
+func (r *PorterRuntime) recordCompletedStep(stepIndex int, description string, outputs map[string]string) error {
+	// Bundles built before step progress was supported do not define the output
+	progressOutput, ok := r.RuntimeManifest.bundle.Outputs[config.StepProgressOutput]
+	if !ok || !progressOutput.AppliesTo(r.RuntimeManifest.Action) {
+		return nil
+	}
+
+	r.progress.Action = r.RuntimeManifest.Action
+	r.progress.CompletedSteps = append(r.progress.CompletedSteps, CompletedStep{
+		Index:       stepIndex,
+		Description: description,
+		Outputs:     outputs,
+	})
+
+	data, err := json.Marshal(r.progress)
+	if err != nil {
+		return fmt.Errorf("could not marshal the step progress: %w", err)
+	}
+
+	progressPath := filepath.Join(config.BundleOutputsDir, config.StepProgressOutput)
+	if err = r.config.FileSystem.WriteFile(progressPath, data, pkg.FileModeWritable); err != nil {
+		return fmt.Errorf("could not save the step progress to %s: %w", progressPath, err)
+	}
+	return nil
+}
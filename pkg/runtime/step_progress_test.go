@@ -0,0 +1,142 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/mixin"
+	"get.porter.sh/porter/pkg/pkgmgmt"
+	"get.porter.sh/porter/pkg/portercontext"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stepProgressManifest = `schemaVersion: 1.0.0-alpha.2
+install:
+- exec:
+    description: "Create a database"
+    command: ./helpers.sh
+    outputs:
+    - name: connstr
+- exec:
+    description: "Migrate the database"
+    command: ./helpers.sh
+`
+
+// newStepProgressRuntime creates a runtime for a bundle that records its step progress.
+func newStepProgressRuntime(t *testing.T) (*TestPorterRuntime, *RuntimeManifest) {
+	r := NewTestPorterRuntime(t)
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, stepProgressManifest)
+	rm.bundle = cnab.NewBundle(bundle.Bundle{
+		Outputs: map[string]bundle.Output{
+			config.StepProgressOutput: {
+				Definition: config.StepProgressOutput + "-output",
+				ApplyTo:    []string{"install", "upgrade"},
+			},
+		},
+	})
+	r.RuntimeManifest = rm
+	require.NoError(t, r.config.FileSystem.MkdirAll(portercontext.MixinOutputsDir, pkg.FileModeDirectory))
+	require.NoError(t, r.config.FileSystem.MkdirAll(config.BundleOutputsDir, pkg.FileModeDirectory))
+	return r, rm
+}
+
+func readStepProgress(t *testing.T, r *TestPorterRuntime) StepProgress {
+	data, err := r.config.FileSystem.ReadFile(filepath.Join(config.BundleOutputsDir, config.StepProgressOutput))
+	require.NoError(t, err, "the step progress output was not written")
+
+	var progress StepProgress
+	require.NoError(t, json.Unmarshal(data, &progress))
+	return progress
+}
+
+func TestExecuteStep_RecordsStepProgress(t *testing.T) {
+	ctx := context.Background()
+	r, rm := newStepProgressRuntime(t)
+
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		func(pkgCtx *portercontext.Context, _ string, _ pkgmgmt.CommandOptions) error {
+			return pkgCtx.FileSystem.WriteFile(filepath.Join(portercontext.MixinOutputsDir, "connstr"), []byte("db://mydb"), pkg.FileModeWritable)
+		},
+	}
+
+	require.NoError(t, r.executeStep(ctx, 0, rm.Install[0]))
+
+	progress := readStepProgress(t, r)
+	assert.Equal(t, "install", progress.Action)
+	require.Len(t, progress.CompletedSteps, 1)
+	assert.Equal(t, CompletedStep{
+		Index:       0,
+		Description: "Create a database",
+		Outputs:     map[string]string{"connstr": "db://mydb"},
+	}, progress.CompletedSteps[0])
+}
+
+func TestExecuteStep_ResumeSkipsCompletedSteps(t *testing.T) {
+	ctx := context.Background()
+	r, rm := newStepProgressRuntime(t)
+
+	resumed := StepProgress{
+		Action: "install",
+		CompletedSteps: []CompletedStep{
+			{Index: 0, Description: "Create a database", Outputs: map[string]string{"connstr": "db://mydb"}},
+		},
+	}
+	data, err := json.Marshal(resumed)
+	require.NoError(t, err)
+	require.NoError(t, r.config.FileSystem.WriteFile(config.ResumeFilepath, data, pkg.FileModeWritable))
+	require.NoError(t, r.loadResumedProgress())
+
+	var runs int
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		func(_ *portercontext.Context, _ string, _ pkgmgmt.CommandOptions) error {
+			runs++
+			return nil
+		},
+	}
+
+	require.NoError(t, r.executeStep(ctx, 0, rm.Install[0]))
+	assert.Equal(t, 0, runs, "the completed step should not have been run again")
+	assert.Equal(t, "db://mydb", rm.outputs["connstr"], "the outputs of the completed step should be restored")
+	assert.Contains(t, r.TestContext.GetOutput(), `skipping step "Create a database" because it was completed by the resumed run`)
+
+	require.NoError(t, r.executeStep(ctx, 1, rm.Install[1]))
+	assert.Equal(t, 1, runs, "the step that was not completed should be run")
+
+	progress := readStepProgress(t, r)
+	require.Len(t, progress.CompletedSteps, 2, "the resumed run should record both the restored and the new steps")
+	assert.Equal(t, "Migrate the database", progress.CompletedSteps[1].Description)
+}
+
+func TestLoadResumedProgress(t *testing.T) {
+	t.Run("no resumed run", func(t *testing.T) {
+		r, _ := newStepProgressRuntime(t)
+		require.NoError(t, r.loadResumedProgress())
+		assert.Empty(t, r.resumedProgress.CompletedSteps)
+	})
+
+	t.Run("different action", func(t *testing.T) {
+		r, _ := newStepProgressRuntime(t)
+		require.NoError(t, r.config.FileSystem.WriteFile(config.ResumeFilepath, []byte(`{"action":"upgrade"}`), pkg.FileModeWritable))
+		err := r.loadResumedProgress()
+		require.ErrorContains(t, err, "cannot resume a upgrade run with the install action")
+	})
+
+	t.Run("different step", func(t *testing.T) {
+		r, rm := newStepProgressRuntime(t)
+		require.NoError(t, r.config.FileSystem.WriteFile(config.ResumeFilepath,
+			[]byte(`{"action":"install","completedSteps":[{"index":0,"description":"Create a cache"}]}`), pkg.FileModeWritable))
+		require.NoError(t, r.loadResumedProgress())
+
+		err := r.executeStep(context.Background(), 0, rm.Install[0])
+		require.ErrorContains(t, err, `the resumed run completed a different step, "Create a cache"`)
+	})
+}
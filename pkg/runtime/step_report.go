@@ -0,0 +1,151 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/manifest"
+)
+
+const (
+	// StepStatusSucceeded indicates that the step ran successfully.
+	StepStatusSucceeded = "succeeded"
+
+	// StepStatusFailed indicates that the step failed.
+	StepStatusFailed = "failed"
+
+	// StepStatusSkipped indicates that the step did not run, either because its
+	// when condition was false, or because it completed in the run that was resumed.
+	StepStatusSkipped = "skipped"
+)
+
+// StepReport is the result of each step run by an action. It is saved to the
+// step report output so that it is available after the run completes.
+type StepReport struct {
+	// Action that ran the steps.
+	Action string `json:"action" yaml:"action"`
+
+	// Steps that were run, in the order that they completed.
+	Steps []StepResult `json:"steps,omitempty" yaml:"steps,omitempty"`
+}
+
+// StepResult is the result of running a single step.
+type StepResult struct {
+	// Index of the step in the action. Steps in a parallel group have the
+	// index of the group.
+	Index int `json:"index" yaml:"index"`
+
+	// Hook is the name of the hook, onFailure or finally, when the step is not
+	// one of the action's steps.
+	Hook string `json:"hook,omitempty" yaml:"hook,omitempty"`
+
+	// Mixin that ran the step.
+	Mixin string `json:"mixin" yaml:"mixin"`
+
+	// Description of the step, before it is templated so that it does not
+	// expose sensitive values.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Status of the step: succeeded, failed or skipped.
+	Status string `json:"status" yaml:"status"`
+
+	// Started is when the step started.
+	Started time.Time `json:"started" yaml:"started"`
+
+	// Stopped is when the step completed.
+	Stopped time.Time `json:"stopped" yaml:"stopped"`
+
+	// ExitCode of the mixin, or -1 when it is not known, such as when the step
+	// timed out.
+	ExitCode int `json:"exitCode" yaml:"exitCode"`
+
+	// Outputs generated by the step. Only the names are recorded, the values
+	// may be sensitive.
+	Outputs []string `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// Duration of the step.
+func (s StepResult) Duration() time.Duration {
+	return s.Stopped.Sub(s.Started)
+}
+
+// newStepResult starts recording the result of a step. It must be called
+// before the step is resolved.
+func newStepResult(stepIndex int, step *manifest.Step) StepResult {
+	description, _ := step.GetDescription()
+	return StepResult{
+		Index:       stepIndex,
+		Mixin:       step.GetMixinName(),
+		Description: description,
+		Started:     time.Now(),
+	}
+}
+
+// complete records the result of a step that has run.
+func (s StepResult) complete(outputs map[string]string, err error) StepResult {
+	if s.Stopped.IsZero() {
+		s.Stopped = time.Now()
+	}
+
+	s.Outputs = make([]string, 0, len(outputs))
+	for name := range outputs {
+		s.Outputs = append(s.Outputs, name)
+	}
+	sort.Strings(s.Outputs)
+
+	if err != nil {
+		s.Status = StepStatusFailed
+		s.ExitCode = getExitCode(err)
+	} else {
+		s.Status = StepStatusSucceeded
+	}
+	return s
+}
+
+// skip records that a step was not run.
+func (s StepResult) skip() StepResult {
+	s.Stopped = s.Started
+	s.Status = StepStatusSkipped
+	return s
+}
+
+// reportStep adds the result of a step to the step report output. The report
+// is informational, so a run does not fail when it cannot be saved.
+func (r *PorterRuntime) reportStep(result StepResult) {
+	// Bundles built before the step report was supported do not define the output
+	reportOutput, ok := r.RuntimeManifest.bundle.Outputs[config.StepReportOutput]
+	if !ok || !reportOutput.AppliesTo(r.RuntimeManifest.Action) {
+		return
+	}
+
+	r.report.Action = r.RuntimeManifest.Action
+	r.report.Steps = append(r.report.Steps, result)
+
+	data, err := json.Marshal(r.report)
+	if err != nil {
+		fmt.Fprintf(r.config.Err, "WARNING: could not marshal the step report: %s\n", err)
+		return
+	}
+
+	reportPath := filepath.Join(config.BundleOutputsDir, config.StepReportOutput)
+	if err = r.config.FileSystem.WriteFile(reportPath, data, pkg.FileModeWritable); err != nil {
+		fmt.Fprintf(r.config.Err, "WARNING: could not save the step report to %s: %s\n", reportPath, err)
+	}
+}
+
+// reportSkippedStep reports that a step, or each of the steps in a parallel
+// group, was not run.
+func (r *PorterRuntime) reportSkippedStep(stepIndex int, step *manifest.Step) {
+	if step.IsParallel() {
+		for _, member := range step.Parallel {
+			r.reportStep(newStepResult(stepIndex, member).skip())
+		}
+		return
+	}
+	r.reportStep(newStepResult(stepIndex, step).skip())
+}
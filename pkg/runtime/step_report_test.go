@@ -0,0 +1,147 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/mixin"
+	"get.porter.sh/porter/pkg/pkgmgmt"
+	"get.porter.sh/porter/pkg/portercontext"
+	"github.com/cnabio/cnab-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stepReportManifest = `schemaVersion: 1.0.0-alpha.2
+install:
+- exec:
+    description: "Create a database"
+    command: ./helpers.sh
+    outputs:
+    - name: connstr
+- when: "false"
+  exec:
+    description: "Seed the database"
+    command: ./helpers.sh
+- exec:
+    description: "Migrate the database"
+    command: ./helpers.sh
+    arguments: [migrate]
+`
+
+// newStepReportRuntime creates a runtime for a bundle that records a step report.
+func newStepReportRuntime(t *testing.T, manifestYaml string) (*TestPorterRuntime, *RuntimeManifest) {
+	r := NewTestPorterRuntime(t)
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, manifestYaml)
+	rm.bundle = cnab.NewBundle(bundle.Bundle{
+		Outputs: map[string]bundle.Output{
+			config.StepReportOutput: {
+				Definition: config.StepReportOutput + "-output",
+			},
+		},
+	})
+	r.RuntimeManifest = rm
+	require.NoError(t, r.config.FileSystem.MkdirAll(portercontext.MixinOutputsDir, pkg.FileModeDirectory))
+	require.NoError(t, r.config.FileSystem.MkdirAll(config.BundleOutputsDir, pkg.FileModeDirectory))
+	return r, rm
+}
+
+func readStepReport(t *testing.T, r *TestPorterRuntime) StepReport {
+	data, err := r.config.FileSystem.ReadFile(filepath.Join(config.BundleOutputsDir, config.StepReportOutput))
+	require.NoError(t, err, "the step report output was not written")
+
+	var report StepReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	return report
+}
+
+func TestExecuteStep_ReportsStepResults(t *testing.T) {
+	ctx := context.Background()
+	r, rm := newStepReportRuntime(t, stepReportManifest)
+
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		func(pkgCtx *portercontext.Context, _ string, cmd pkgmgmt.CommandOptions) error {
+			if strings.Contains(cmd.Input, "migrate") {
+				return testExitError{code: 2}
+			}
+			return pkgCtx.FileSystem.WriteFile(filepath.Join(portercontext.MixinOutputsDir, "connstr"), []byte("db://mydb"), pkg.FileModeWritable)
+		},
+	}
+
+	require.NoError(t, r.executeStep(ctx, 0, rm.Install[0]))
+	require.NoError(t, r.executeStep(ctx, 1, rm.Install[1]))
+	require.Error(t, r.executeStep(ctx, 2, rm.Install[2]))
+
+	report := readStepReport(t, r)
+	assert.Equal(t, "install", report.Action)
+	require.Len(t, report.Steps, 3)
+
+	created := report.Steps[0]
+	assert.Equal(t, 0, created.Index)
+	assert.Equal(t, "exec", created.Mixin)
+	assert.Equal(t, "Create a database", created.Description)
+	assert.Equal(t, StepStatusSucceeded, created.Status)
+	assert.Equal(t, 0, created.ExitCode)
+	assert.Equal(t, []string{"connstr"}, created.Outputs, "only the names of the outputs should be reported")
+	assert.False(t, created.Started.IsZero(), "the start time should be recorded")
+	assert.False(t, created.Stopped.Before(created.Started), "the step should stop after it started")
+
+	seeded := report.Steps[1]
+	assert.Equal(t, 1, seeded.Index)
+	assert.Equal(t, StepStatusSkipped, seeded.Status, "steps with a false condition should be reported as skipped")
+
+	migrated := report.Steps[2]
+	assert.Equal(t, 2, migrated.Index)
+	assert.Equal(t, StepStatusFailed, migrated.Status)
+	assert.Equal(t, 2, migrated.ExitCode, "the exit code of the mixin should be reported")
+	assert.Empty(t, migrated.Outputs)
+}
+
+func TestExecuteStep_ReportsParallelStepResults(t *testing.T) {
+	ctx := context.Background()
+	r, rm := newStepReportRuntime(t, parallelStepManifest)
+
+	var ran sync.Map
+	testMixin := r.mixins.(*mixin.TestMixinProvider)
+	testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+		parallelMixin(&ran, true),
+	}
+
+	require.Error(t, r.executeStep(ctx, 0, rm.Install[0]))
+
+	report := readStepReport(t, r)
+	require.Len(t, report.Steps, 2, "each step in the group should be reported")
+
+	assert.Equal(t, "Create the database", report.Steps[0].Description)
+	assert.Equal(t, StepStatusSucceeded, report.Steps[0].Status)
+	assert.Equal(t, []string{"connstr"}, report.Steps[0].Outputs, "outputs should be attributed to the step that declared them")
+
+	assert.Equal(t, "Create the cache", report.Steps[1].Description)
+	assert.Equal(t, StepStatusFailed, report.Steps[1].Status)
+	assert.Equal(t, 1, report.Steps[1].ExitCode)
+	assert.Equal(t, []string{"host"}, report.Steps[1].Outputs, "outputs written before the step failed should be reported")
+
+	for _, step := range report.Steps {
+		assert.Equal(t, 0, step.Index, "steps in a group should have the index of the group")
+	}
+}
+
+func TestReportStep_OutputNotDefined(t *testing.T) {
+	r := NewTestPorterRuntime(t)
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, stepReportManifest)
+	rm.bundle = cnab.NewBundle(bundle.Bundle{})
+	r.RuntimeManifest = rm
+
+	r.reportStep(newStepResult(0, rm.Install[0]).complete(nil, nil))
+
+	_, err := r.config.FileSystem.Stat(filepath.Join(config.BundleOutputsDir, config.StepReportOutput))
+	assert.Error(t, err, "the step report should not be written when the bundle does not define the output")
+}
@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"get.porter.sh/porter/pkg/manifest"
+	"get.porter.sh/porter/pkg/pkgmgmt"
+	"get.porter.sh/porter/pkg/portercontext"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// stepTimeoutError is returned when an attempt to run a step takes longer
+// than the step's timeout.
+type stepTimeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+func (e stepTimeoutError) Error() string {
+	return fmt.Sprintf("step timed out after %s: %s", e.timeout, e.err)
+}
+
+func (e stepTimeoutError) Unwrap() error {
+	return e.err
+}
+
+// runMixin runs the mixin for a step with the specified context, running it
+// again when it fails according to the step's retry policy. Each attempt is
+// stopped when it takes longer than the step's timeout.
+func (r *PorterRuntime) runMixin(ctx context.Context, pkgCtx *portercontext.Context, step *manifest.Step, cmd pkgmgmt.CommandOptions) error {
+	timeout, err := step.GetTimeout()
+	if err != nil {
+		return err
+	}
+	backoff, err := step.Retry.GetBackoff()
+	if err != nil {
+		return err
+	}
+
+	attempts := step.GetAttempts()
+	for attempt := 1; ; attempt++ {
+		err = r.runMixinAttempt(ctx, pkgCtx, step, cmd, attempt, timeout)
+		if err == nil || attempt >= attempts || ctx.Err() != nil {
+			return err
+		}
+
+		exitCode := getExitCode(err)
+		if !step.Retry.ShouldRetry(exitCode) {
+			return err
+		}
+
+		fmt.Fprintf(pkgCtx.Err, "attempt %d of %d failed with exit code %d, retrying in %s\n", attempt, attempts, exitCode, backoff)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (r *PorterRuntime) runMixinAttempt(ctx context.Context, pkgCtx *portercontext.Context, step *manifest.Step, cmd pkgmgmt.CommandOptions, attempt int, timeout time.Duration) error {
+	ctx, span := tracing.StartSpan(ctx,
+		attribute.String("mixin", step.GetMixinName()),
+		attribute.Int("attempt", attempt),
+		attribute.String("timeout", timeout.String()),
+	)
+	defer span.EndSpan()
+
+	attemptCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := r.mixins.Run(attemptCtx, pkgCtx, step.GetMixinName(), cmd)
+	if err == nil {
+		return nil
+	}
+
+	// Only report a timeout when the step's deadline was exceeded, and not when
+	// the bundle run itself was cancelled
+	if ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		err = stepTimeoutError{timeout: timeout, err: err}
+	}
+	span.SetAttributes(attribute.Int("exitCode", getExitCode(err)))
+	return span.Error(err)
+}
+
+// getExitCode returns the exit code of a failed mixin command, or -1 when it
+// is not known, such as when the step timed out.
+func getExitCode(err error) int {
+	if errors.As(err, &stepTimeoutError{}) {
+		return -1
+	}
+
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,131 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"get.porter.sh/porter/pkg"
+	"get.porter.sh/porter/pkg/mixin"
+	"get.porter.sh/porter/pkg/pkgmgmt"
+	"get.porter.sh/porter/pkg/portercontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testExitError is a mixin failure with an exit code, like an *exec.ExitError.
+type testExitError struct {
+	code int
+}
+
+func (e testExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func (e testExitError) ExitCode() int {
+	return e.code
+}
+
+// failingMixin returns a mixin run assertion that fails with the specified
+// exit codes, one per attempt, and then succeeds.
+func failingMixin(attempts *int, exitCodes ...int) func(*portercontext.Context, string, pkgmgmt.CommandOptions) error {
+	return func(_ *portercontext.Context, _ string, _ pkgmgmt.CommandOptions) error {
+		*attempts++
+		if *attempts <= len(exitCodes) {
+			return testExitError{code: exitCodes[*attempts-1]}
+		}
+		return nil
+	}
+}
+
+// blockingMixinProvider is a mixin provider that runs until the context is cancelled.
+type blockingMixinProvider struct {
+	*mixin.TestMixinProvider
+	attempts int
+}
+
+func (p *blockingMixinProvider) Run(ctx context.Context, _ *portercontext.Context, _ string, _ pkgmgmt.CommandOptions) error {
+	p.attempts++
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestExecuteStep_Retry(t *testing.T) {
+	testcases := []struct {
+		name         string
+		retry        string
+		exitCodes    []int
+		wantAttempts int
+		wantErr      string
+	}{
+		{name: "no retry", exitCodes: []int{1}, wantAttempts: 1, wantErr: "exit status 1"},
+		{name: "retry until success", retry: "{attempts: 3}", exitCodes: []int{1, 1}, wantAttempts: 3},
+		{name: "attempts exhausted", retry: "{attempts: 2}", exitCodes: []int{1, 2, 3}, wantAttempts: 2, wantErr: "exit status 2"},
+		{name: "matching exit code", retry: "{attempts: 3, onExitCodes: [75]}", exitCodes: []int{75}, wantAttempts: 2},
+		{name: "other exit code", retry: "{attempts: 3, onExitCodes: [75]}", exitCodes: []int{1}, wantAttempts: 1, wantErr: "exit status 1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := NewTestPorterRuntime(t)
+
+			retry := ""
+			if tc.retry != "" {
+				retry = fmt.Sprintf("\n  retry: %s", tc.retry)
+			}
+			rm := runtimeManifestFromStepYaml(t, r.TestConfig, fmt.Sprintf(`schemaVersion: 1.0.0-alpha.2
+install:
+- exec:
+    description: "Call a flaky API"
+    command: ./helpers.sh%s
+`, retry))
+			r.RuntimeManifest = rm
+			require.NoError(t, r.config.FileSystem.MkdirAll(portercontext.MixinOutputsDir, pkg.FileModeDirectory))
+
+			var attempts int
+			testMixin := r.mixins.(*mixin.TestMixinProvider)
+			testMixin.RunAssertions = []func(*portercontext.Context, string, pkgmgmt.CommandOptions) error{
+				failingMixin(&attempts, tc.exitCodes...),
+			}
+
+			err := r.executeStep(ctx, 0, rm.Install[0])
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+			assert.Equal(t, tc.wantAttempts, attempts, "the mixin was not run the expected number of times")
+		})
+	}
+}
+
+func TestExecuteStep_Timeout(t *testing.T) {
+	ctx := context.Background()
+	r := NewTestPorterRuntime(t)
+
+	rm := runtimeManifestFromStepYaml(t, r.TestConfig, `schemaVersion: 1.0.0-alpha.2
+install:
+- exec:
+    description: "Wait for a hung deployment"
+    command: ./helpers.sh
+  timeout: 10ms
+  retry:
+    attempts: 2
+`)
+	r.RuntimeManifest = rm
+	require.NoError(t, r.config.FileSystem.MkdirAll(portercontext.MixinOutputsDir, pkg.FileModeDirectory))
+	mixins := &blockingMixinProvider{TestMixinProvider: mixin.NewTestMixinProvider()}
+	r.mixins = mixins
+
+	err := r.executeStep(ctx, 0, rm.Install[0])
+	require.ErrorContains(t, err, "step timed out after 10ms")
+	assert.Equal(t, 2, mixins.attempts, "a step that times out should be retried")
+	assert.Contains(t, r.TestContext.GetError(), "attempt 1 of 2 failed with exit code -1")
+}
+
+func TestGetExitCode(t *testing.T) {
+	assert.Equal(t, 2, getExitCode(fmt.Errorf("mixin failed: %w", testExitError{code: 2})))
+	assert.Equal(t, -1, getExitCode(stepTimeoutError{err: testExitError{code: 143}}), "timeouts should not report the exit code of the stopped mixin")
+	assert.Equal(t, -1, getExitCode(fmt.Errorf("unknown failure")))
+}
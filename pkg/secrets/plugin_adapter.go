@@ -34,3 +34,11 @@ func (a PluginAdapter) Resolve(ctx context.Context, keyName string, keyValue str
 func (a PluginAdapter) Create(ctx context.Context, keyName string, keyValue string, value string) error {
 	return a.plugin.Create(ctx, keyName, keyValue, value)
 }
+
+func (a PluginAdapter) Delete(ctx context.Context, keyName string, keyValue string) error {
+	return a.plugin.Delete(ctx, keyName, keyValue)
+}
+
+func (a PluginAdapter) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	return a.plugin.List(ctx, keyName, prefix)
+}
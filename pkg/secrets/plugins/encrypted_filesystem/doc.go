@@ -0,0 +1,6 @@
+// Package encrypted_filesystem provides a plugin implementing the secret plugin
+// protocol that stores secrets in the local filesystem, encrypted with
+// AES-256-GCM. The key is read from a file, an environment variable, or derived
+// from a passphrase, so the directory can be shared between machines that have
+// the key, such as a team's CI runners.
+package encrypted_filesystem
@@ -0,0 +1,250 @@
+package encrypted_filesystem
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"get.porter.sh/porter/pkg/portercontext"
+)
+
+const (
+	// KeySize is the size in bytes of the AES-256 keys that encrypt secrets.
+	KeySize = 32
+
+	// kdfPBKDF2 identifies secrets encrypted with a key derived from a passphrase.
+	kdfPBKDF2 = "pbkdf2-sha256"
+
+	// pbkdf2Iterations is the number of iterations used to derive a key from a
+	// passphrase, following the OWASP recommendation for PBKDF2-HMAC-SHA256.
+	pbkdf2Iterations = 600000
+
+	saltSize = 16
+)
+
+// KeySource identifies where the encryption key is read from.
+// Exactly one of the fields must be set.
+type KeySource struct {
+	// KeyFile is the path to a file containing a base64 encoded 256-bit key.
+	KeyFile string `mapstructure:"key-file,omitempty"`
+
+	// KeyEnv is the name of an environment variable containing a base64 encoded 256-bit key.
+	KeyEnv string `mapstructure:"key-env,omitempty"`
+
+	// PassphraseEnv is the name of an environment variable containing a
+	// passphrase. The key of each secret is derived from the passphrase.
+	PassphraseEnv string `mapstructure:"passphrase-env,omitempty"`
+}
+
+// Validate that exactly one source of the key is set.
+func (s KeySource) Validate() error {
+	count := 0
+	for _, value := range []string{s.KeyFile, s.KeyEnv, s.PassphraseEnv} {
+		if value != "" {
+			count++
+		}
+	}
+	if count != 1 {
+		return errors.New("exactly one of key-file, key-env or passphrase-env must be set")
+	}
+	return nil
+}
+
+// Key encrypts and decrypts secrets.
+type Key struct {
+	// raw is the key when it is used directly.
+	raw []byte
+
+	// passphrase from which keys are derived, when raw is not set.
+	passphrase string
+
+	// salt used to derive the key of secrets encrypted with the passphrase.
+	salt []byte
+
+	// derived keys by their salt, so that the key is derived once per salt.
+	derived map[string][]byte
+	lock    sync.Mutex
+}
+
+// LoadKey reads the key from its source.
+func LoadKey(cx *portercontext.Context, src KeySource) (*Key, error) {
+	if err := src.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case src.KeyFile != "":
+		data, err := cx.FileSystem.ReadFile(src.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the key file %s: %w", src.KeyFile, err)
+		}
+		key, err := decodeKey(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key in %s: %w", src.KeyFile, err)
+		}
+		return &Key{raw: key}, nil
+	case src.KeyEnv != "":
+		value, ok := cx.LookupEnv(src.KeyEnv)
+		if !ok {
+			return nil, fmt.Errorf("the environment variable %s with the key is not set", src.KeyEnv)
+		}
+		key, err := decodeKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key in the environment variable %s: %w", src.KeyEnv, err)
+		}
+		return &Key{raw: key}, nil
+	default:
+		passphrase := cx.Getenv(src.PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("the environment variable %s with the passphrase is not set", src.PassphraseEnv)
+		}
+		return &Key{passphrase: passphrase, derived: make(map[string][]byte)}, nil
+	}
+}
+
+func decodeKey(value string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, errors.New("the key is not base64 encoded")
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("the key must be %d bytes but was %d bytes", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// GenerateKeyFile writes a new random key to path. The file must not already exist.
+func GenerateKeyFile(cx *portercontext.Context, path string) error {
+	if _, err := cx.FileSystem.Stat(path); err == nil {
+		return fmt.Errorf("the key file %s already exists", path)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not check if the key file %s exists: %w", path, err)
+	}
+
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("could not generate a key: %w", err)
+	}
+	data := base64.StdEncoding.EncodeToString(key) + "\n"
+	if err := cx.FileSystem.WriteFile(path, []byte(data), FileModeSensitiveWritable); err != nil {
+		return fmt.Errorf("could not write the key file %s: %w", path, err)
+	}
+	return nil
+}
+
+// seal encrypts the value of the named secret. The name is authenticated with
+// the value, so that an encrypted secret cannot be copied to another name.
+func (k *Key) seal(name string, value []byte) (envelope, error) {
+	env := envelope{Version: envelopeVersion}
+	if k.raw == nil {
+		salt, err := k.newSecretSalt()
+		if err != nil {
+			return envelope{}, err
+		}
+		env.KDF = kdfPBKDF2
+		env.Salt = salt
+		env.Iterations = pbkdf2Iterations
+	}
+
+	aead, err := k.aead(env)
+	if err != nil {
+		return envelope{}, err
+	}
+	env.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return envelope{}, fmt.Errorf("could not generate a nonce: %w", err)
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, value, []byte(name))
+	return env, nil
+}
+
+// open decrypts the value of the named secret.
+func (k *Key) open(name string, env envelope) ([]byte, error) {
+	if env.Version != envelopeVersion {
+		return nil, fmt.Errorf("unsupported version %d", env.Version)
+	}
+	if (env.KDF == "") != (k.raw != nil) {
+		if k.raw != nil {
+			return nil, errors.New("the secret was encrypted with a passphrase but a key is configured")
+		}
+		return nil, errors.New("the secret was encrypted with a key but a passphrase is configured")
+	}
+
+	aead, err := k.aead(env)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	value, err := aead.Open(nil, env.Nonce, env.Ciphertext, []byte(name))
+	if err != nil {
+		return nil, errors.New("the secret could not be decrypted, it was encrypted with a different key or has been modified")
+	}
+	return value, nil
+}
+
+// aead returns the cipher for the key used by the envelope.
+func (k *Key) aead(env envelope) (cipher.AEAD, error) {
+	key := k.raw
+	if key == nil {
+		if env.KDF != kdfPBKDF2 {
+			return nil, fmt.Errorf("unsupported key derivation function %q", env.KDF)
+		}
+		var err error
+		if key, err = k.derive(env.Salt, env.Iterations); err != nil {
+			return nil, err
+		}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// derive the key for a salt from the passphrase.
+func (k *Key) derive(salt []byte, iterations int) ([]byte, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	cacheKey := fmt.Sprintf("%d/%s", iterations, base64.StdEncoding.EncodeToString(salt))
+	if key, ok := k.derived[cacheKey]; ok {
+		return key, nil
+	}
+	if len(salt) == 0 || iterations <= 0 {
+		return nil, errors.New("invalid key derivation parameters")
+	}
+	key, err := pbkdf2.Key(sha256.New, k.passphrase, salt, iterations, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive the key from the passphrase: %w", err)
+	}
+	k.derived[cacheKey] = key
+	return key, nil
+}
+
+// newSecretSalt returns the salt used to derive the key of new secrets.
+// Deriving a key is deliberately slow, so the salt is generated once and
+// reused for the secrets that are encrypted while the key is loaded.
+func (k *Key) newSecretSalt() ([]byte, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if k.salt == nil {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("could not generate a salt: %w", err)
+		}
+		k.salt = salt
+	}
+	return k.salt, nil
+}
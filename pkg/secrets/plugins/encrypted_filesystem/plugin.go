@@ -0,0 +1,47 @@
+package encrypted_filesystem
+
+import (
+	"fmt"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/secrets/pluginstore"
+	"github.com/hashicorp/go-plugin"
+	"github.com/mitchellh/mapstructure"
+)
+
+// PluginKey is the identifier of the internal encrypted-filesystem secrets plugin.
+const PluginKey = plugins.PluginInterface + ".porter.encrypted-filesystem"
+
+// PluginConfig supported by the encrypted-filesystem plugin as defined in porter.yaml
+type PluginConfig struct {
+	// Path to the directory where secrets are stored. Defaults to PORTER_HOME/encrypted-secrets.
+	Path string `mapstructure:"path,omitempty"`
+
+	// KeySource is where the encryption key is read from.
+	KeySource `mapstructure:",squash"`
+}
+
+// NewPlugin creates an instance of the secrets.porter.encrypted-filesystem plugin
+func NewPlugin(c *config.Config, rawCfg interface{}) (plugin.Plugin, error) {
+	cfg, err := ParsePluginConfig(rawCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	store := NewStore(c, cfg)
+	return pluginstore.NewPlugin(c.Context, store), nil
+}
+
+// ParsePluginConfig reads the plugin configuration from the config section of
+// the secrets plugin in porter.yaml.
+func ParsePluginConfig(rawCfg interface{}) (PluginConfig, error) {
+	cfg := PluginConfig{}
+	if err := mapstructure.Decode(rawCfg, &cfg); err != nil {
+		return PluginConfig{}, fmt.Errorf("error reading plugin configuration: %w", err)
+	}
+	if err := cfg.KeySource.Validate(); err != nil {
+		return PluginConfig{}, fmt.Errorf("invalid plugin configuration: %w", err)
+	}
+	return cfg, nil
+}
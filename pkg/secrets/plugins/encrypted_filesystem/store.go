@@ -0,0 +1,345 @@
+package encrypted_filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/tracing"
+)
+
+var _ plugins.SecretsProtocol = &Store{}
+
+const (
+	// SecretsDirectory is the name of the directory created in PORTER_HOME
+	// when a path is not configured.
+	SecretsDirectory = "encrypted-secrets"
+
+	// secretFileExt is the extension of the files that hold a secret.
+	secretFileExt = ".json"
+
+	// envelopeVersion is the version of the format of the secret files.
+	envelopeVersion = 1
+
+	FileModeSensitiveDirectory os.FileMode = 0700
+	FileModeSensitiveWritable  os.FileMode = 0600
+)
+
+// envelope is the format of the file that holds an encrypted secret.
+type envelope struct {
+	Version int `json:"version"`
+
+	// KDF is the function used to derive the key from a passphrase, and is
+	// empty when the secret was encrypted with a key.
+	KDF        string `json:"kdf,omitempty"`
+	Salt       []byte `json:"salt,omitempty"`
+	Iterations int    `json:"iterations,omitempty"`
+
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Store saves each secret in its own file, encrypted with AES-256-GCM.
+// Files are replaced atomically, so the directory may be shared by
+// multiple processes, for example on a volume mounted by CI runners.
+type Store struct {
+	config    *config.Config
+	cfg       PluginConfig
+	secretDir string
+	key       *Key
+}
+
+// NewStore creates a new instance of the encrypted filesystem secret store.
+func NewStore(c *config.Config, cfg PluginConfig) *Store {
+	return &Store{
+		config: c,
+		cfg:    cfg,
+	}
+}
+
+// Connect initializes the plugin for use.
+// The plugin itself is responsible for ensuring it was called.
+// Close is called automatically when the plugin is used by Porter.
+func (s *Store) Connect(ctx context.Context) error {
+	if s.key != nil {
+		return nil
+	}
+
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	secretDir := s.cfg.Path
+	if secretDir == "" {
+		home, err := s.config.GetHomeDir()
+		if err != nil {
+			return log.Error(fmt.Errorf("could not get the porter home directory: %w", err))
+		}
+		secretDir = filepath.Join(home, SecretsDirectory)
+	}
+
+	key, err := LoadKey(s.config.Context, s.cfg.KeySource)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	if err := s.config.FileSystem.MkdirAll(secretDir, FileModeSensitiveDirectory); err != nil && !errors.Is(err, os.ErrExist) {
+		return log.Error(fmt.Errorf("could not create the secrets directory %s: %w", secretDir, err))
+	}
+
+	s.secretDir = secretDir
+	s.key = key
+	log.Debugf("storing encrypted secrets in %s", s.secretDir)
+	return nil
+}
+
+// Close implements the Close method on the secret plugins' interface.
+func (s *Store) Close() error {
+	return nil
+}
+
+// SecretDir is the directory where the secrets are stored.
+func (s *Store) SecretDir() string {
+	return s.secretDir
+}
+
+// Resolve implements the Resolve method on the secret plugins' interface.
+func (s *Store) Resolve(ctx context.Context, keyName string, keyValue string) (string, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return "", err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return "", log.Errorf("unsupported keyName %s", keyName)
+	}
+
+	value, err := s.read(s.key, keyValue)
+	if err != nil {
+		return "", log.Error(err)
+	}
+	return string(value), nil
+}
+
+// Create implements the Create method on the secret plugins' interface.
+func (s *Store) Create(ctx context.Context, keyName string, keyValue string, value string) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return log.Error(errors.New("invalid key name: " + keyName))
+	}
+
+	if err := s.write(s.key, keyValue, []byte(value)); err != nil {
+		return log.Error(err)
+	}
+	return nil
+}
+
+// Delete implements the Delete method on the secret plugins' interface.
+func (s *Store) Delete(ctx context.Context, keyName string, keyValue string) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return log.Error(errors.New("invalid key name: " + keyName))
+	}
+
+	path, err := s.secretPath(keyValue)
+	if err != nil {
+		return log.Error(err)
+	}
+	if err := s.config.FileSystem.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return log.Error(fmt.Errorf("error deleting secret %s from the filesystem: %w", keyValue, err))
+	}
+	return nil
+}
+
+// List implements the List method on the secret plugins' interface.
+func (s *Store) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return nil, log.Errorf("unsupported keyName %s", keyName)
+	}
+
+	names, err := s.list()
+	if err != nil {
+		return nil, log.Error(err)
+	}
+
+	var keys []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			keys = append(keys, name)
+		}
+	}
+	return keys, nil
+}
+
+// RotateKey re-encrypts every secret with a new key and returns the number of
+// secrets that were re-encrypted. Every secret is decrypted before any file
+// is changed, so that secrets that cannot be decrypted with the current key
+// fail the rotation without modifying the store. Secrets that are already
+// encrypted with the new key are skipped, so an interrupted rotation can be
+// repeated. Update the plugin configuration to use the new key afterwards.
+func (s *Store) RotateKey(ctx context.Context, newKeySource KeySource) (int, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return 0, err
+	}
+
+	newKey, err := LoadKey(s.config.Context, newKeySource)
+	if err != nil {
+		return 0, log.Error(fmt.Errorf("could not load the new key: %w", err))
+	}
+
+	names, err := s.list()
+	if err != nil {
+		return 0, log.Error(err)
+	}
+
+	values := make(map[string][]byte, len(names))
+	for _, name := range names {
+		value, err := s.read(s.key, name)
+		if err != nil {
+			if _, newErr := s.read(newKey, name); newErr == nil {
+				log.Debugf("skipping secret %s, it is already encrypted with the new key", name)
+				continue
+			}
+			return 0, log.Error(err)
+		}
+		values[name] = value
+	}
+
+	for _, name := range names {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		if err := s.write(newKey, name, value); err != nil {
+			return 0, log.Error(fmt.Errorf("the key rotation was interrupted, repeat it to re-encrypt the remaining secrets: %w", err))
+		}
+	}
+
+	s.key = newKey
+	return len(values), nil
+}
+
+// list returns the names of the secrets in the store.
+func (s *Store) list() ([]string, error) {
+	entries, err := s.config.FileSystem.ReadDir(s.secretDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not list the secrets in %s: %w", s.secretDir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || filepath.Ext(entry.Name()) != secretFileExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), secretFileExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// read and decrypt the named secret.
+func (s *Store) read(key *Key, name string) ([]byte, error) {
+	path, err := s.secretPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.config.FileSystem.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("secret %s not found", name)
+		}
+		return nil, fmt.Errorf("error reading secret %s from the filesystem: %w", name, err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("invalid secret file %s: %w", path, err)
+	}
+	value, err := key.open(name, env)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt secret %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// write encrypts the named secret and replaces its file.
+func (s *Store) write(key *Key, name string, value []byte) error {
+	path, err := s.secretPath(name)
+	if err != nil {
+		return err
+	}
+
+	env, err := key.seal(name, value)
+	if err != nil {
+		return fmt.Errorf("could not encrypt secret %s: %w", name, err)
+	}
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode secret %s: %w", name, err)
+	}
+
+	// Write to a temporary file then rename it, so that other processes
+	// never read a partially written secret.
+	tmp, err := s.config.FileSystem.TempFile(s.secretDir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("error writing secret %s to the filesystem: %w", name, err)
+	}
+	tmpPath := tmp.Name()
+	defer s.config.FileSystem.Remove(tmpPath) //nolint:errcheck
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = s.config.FileSystem.Chmod(tmpPath, FileModeSensitiveWritable)
+	}
+	if err == nil {
+		err = s.config.FileSystem.Rename(tmpPath, path)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing secret %s to the filesystem: %w", name, err)
+	}
+	return nil
+}
+
+// secretPath returns the path to the file of the named secret.
+func (s *Store) secretPath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+	return filepath.Join(s.secretDir, name+secretFileExt), nil
+}
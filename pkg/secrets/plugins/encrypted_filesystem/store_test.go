@@ -0,0 +1,240 @@
+package encrypted_filesystem_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/secrets/plugins/encrypted_filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secretDir = "/home/myuser/.porter/encrypted-secrets"
+
+func newTestStore(t *testing.T, c *config.TestConfig, src encrypted_filesystem.KeySource) *encrypted_filesystem.Store {
+	s := encrypted_filesystem.NewStore(c.Config, encrypted_filesystem.PluginConfig{KeySource: src})
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func generateKey(t *testing.T, c *config.TestConfig, path string) encrypted_filesystem.KeySource {
+	require.NoError(t, encrypted_filesystem.GenerateKeyFile(c.Context, path))
+	return encrypted_filesystem.KeySource{KeyFile: path}
+}
+
+func TestStore_DataOperation(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	key := generateKey(t, c, "/porter.key")
+
+	s := newTestStore(t, c, key)
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+
+	value, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err)
+	assert.Equal(t, "supersecret", value)
+
+	info, err := c.FileSystem.Stat(secretDir)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted_filesystem.FileModeSensitiveDirectory, info.Mode().Perm())
+
+	secretFile := filepath.Join(secretDir, "password.json")
+	info, err = c.FileSystem.Stat(secretFile)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted_filesystem.FileModeSensitiveWritable, info.Mode().Perm())
+	data, err := c.FileSystem.ReadFile(secretFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "supersecret", "the secret should be encrypted")
+
+	t.Run("another process with the key", func(t *testing.T) {
+		value, err := newTestStore(t, c, key).Resolve(ctx, secrets.SourceSecret, "password")
+		require.NoError(t, err)
+		assert.Equal(t, "supersecret", value)
+	})
+
+	t.Run("different key", func(t *testing.T) {
+		_, err := newTestStore(t, c, generateKey(t, c, "/other.key")).Resolve(ctx, secrets.SourceSecret, "password")
+		require.ErrorContains(t, err, "encrypted with a different key")
+	})
+
+	t.Run("copied to another name", func(t *testing.T) {
+		require.NoError(t, c.FileSystem.WriteFile(filepath.Join(secretDir, "copy.json"), data, 0600))
+		_, err := s.Resolve(ctx, secrets.SourceSecret, "copy")
+		require.ErrorContains(t, err, "could not decrypt secret copy")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := s.Resolve(ctx, secrets.SourceSecret, "missing")
+		require.ErrorContains(t, err, "secret missing not found")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		err := s.Create(ctx, secrets.SourceSecret, "../password", "oops")
+		require.ErrorContains(t, err, "invalid secret name")
+	})
+
+	t.Run("unsupported key name", func(t *testing.T) {
+		_, err := s.Resolve(ctx, "env", "password")
+		require.ErrorContains(t, err, "unsupported keyName")
+	})
+}
+
+func TestStore_DeleteAndList(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	s := newTestStore(t, c, generateKey(t, c, "/porter.key"))
+
+	for _, key := range []string{"run1-password", "run1-token", "run2-password"} {
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, key, "supersecret"))
+	}
+
+	keys, err := s.List(ctx, secrets.SourceSecret, "run1-")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run1-password", "run1-token"}, keys)
+
+	require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "run1-password"))
+	require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "run1-password"), "deleting a missing secret should not fail")
+
+	keys, err = s.List(ctx, secrets.SourceSecret, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run1-token", "run2-password"}, keys)
+}
+
+func TestStore_KeyEnv(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	c.Setenv("PORTER_SECRETS_KEY", "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+
+	s := newTestStore(t, c, encrypted_filesystem.KeySource{KeyEnv: "PORTER_SECRETS_KEY"})
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+	value, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err)
+	assert.Equal(t, "supersecret", value)
+
+	t.Run("unset", func(t *testing.T) {
+		_, err := newTestStore(t, c, encrypted_filesystem.KeySource{KeyEnv: "MISSING_KEY"}).Resolve(ctx, secrets.SourceSecret, "password")
+		require.ErrorContains(t, err, "the environment variable MISSING_KEY with the key is not set")
+	})
+
+	t.Run("wrong size", func(t *testing.T) {
+		c.Setenv("SHORT_KEY", "c2hvcnQ=")
+		_, err := newTestStore(t, c, encrypted_filesystem.KeySource{KeyEnv: "SHORT_KEY"}).Resolve(ctx, secrets.SourceSecret, "password")
+		require.ErrorContains(t, err, "the key must be 32 bytes but was 5 bytes")
+	})
+}
+
+func TestStore_Passphrase(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	c.Setenv("PORTER_SECRETS_PASSPHRASE", "correct horse battery staple")
+	key := encrypted_filesystem.KeySource{PassphraseEnv: "PORTER_SECRETS_PASSPHRASE"}
+
+	s := newTestStore(t, c, key)
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "token", "abc123"))
+
+	value, err := newTestStore(t, c, key).Resolve(ctx, secrets.SourceSecret, "token")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", value)
+
+	c.Setenv("PORTER_SECRETS_PASSPHRASE", "wrong passphrase")
+	_, err = newTestStore(t, c, key).Resolve(ctx, secrets.SourceSecret, "password")
+	require.ErrorContains(t, err, "encrypted with a different key")
+}
+
+func TestStore_RotateKey(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	oldKey := generateKey(t, c, "/old.key")
+	newKey := generateKey(t, c, "/new.key")
+
+	s := newTestStore(t, c, oldKey)
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "token", "abc123"))
+
+	// Simulate a rotation that was interrupted after the token was re-encrypted
+	require.NoError(t, newTestStore(t, c, newKey).Create(ctx, secrets.SourceSecret, "token", "abc123"))
+
+	count, err := s.RotateKey(ctx, newKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "only the secrets encrypted with the old key should be re-encrypted")
+
+	value, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err, "the store should use the new key after the rotation")
+	assert.Equal(t, "supersecret", value)
+
+	rotated := newTestStore(t, c, newKey)
+	for name, want := range map[string]string{"password": "supersecret", "token": "abc123"} {
+		value, err := rotated.Resolve(ctx, secrets.SourceSecret, name)
+		require.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+
+	_, err = newTestStore(t, c, oldKey).Resolve(ctx, secrets.SourceSecret, "password")
+	require.ErrorContains(t, err, "encrypted with a different key")
+}
+
+func TestStore_RotateKey_FailsWithoutChanges(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	oldKey := generateKey(t, c, "/old.key")
+
+	s := newTestStore(t, c, oldKey)
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+	require.NoError(t, newTestStore(t, c, generateKey(t, c, "/unknown.key")).Create(ctx, secrets.SourceSecret, "unknown", "value"))
+
+	_, err := s.RotateKey(ctx, generateKey(t, c, "/new.key"))
+	require.ErrorContains(t, err, "could not decrypt secret unknown")
+
+	value, err := newTestStore(t, c, oldKey).Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err, "the secrets should not be modified when the rotation fails")
+	assert.Equal(t, "supersecret", value)
+}
+
+func TestParsePluginConfig(t *testing.T) {
+	testcases := []struct {
+		name    string
+		rawCfg  map[string]interface{}
+		wantErr string
+	}{
+		{name: "key file", rawCfg: map[string]interface{}{"path": "/secrets", "key-file": "/porter.key"}},
+		{name: "key env", rawCfg: map[string]interface{}{"key-env": "PORTER_SECRETS_KEY"}},
+		{name: "passphrase", rawCfg: map[string]interface{}{"passphrase-env": "PORTER_SECRETS_PASSPHRASE"}},
+		{name: "no key", rawCfg: map[string]interface{}{"path": "/secrets"}, wantErr: "exactly one of key-file, key-env or passphrase-env must be set"},
+		{name: "multiple keys", rawCfg: map[string]interface{}{"key-file": "/porter.key", "key-env": "PORTER_SECRETS_KEY"}, wantErr: "exactly one of"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := encrypted_filesystem.ParsePluginConfig(tc.rawCfg)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.rawCfg["path"], valueOrNil(cfg.Path))
+			assert.Equal(t, tc.rawCfg["key-file"], valueOrNil(cfg.KeyFile))
+		})
+	}
+}
+
+func valueOrNil(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func TestGenerateKeyFile(t *testing.T) {
+	c := config.NewTestConfig(t)
+
+	require.NoError(t, encrypted_filesystem.GenerateKeyFile(c.Context, "/porter.key"))
+	info, err := c.FileSystem.Stat("/porter.key")
+	require.NoError(t, err)
+	assert.Equal(t, encrypted_filesystem.FileModeSensitiveWritable, info.Mode().Perm())
+
+	err = encrypted_filesystem.GenerateKeyFile(c.Context, "/porter.key")
+	require.ErrorContains(t, err, "already exists", "an existing key should not be overwritten")
+}
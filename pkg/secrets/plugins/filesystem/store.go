@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"get.porter.sh/porter/pkg/config"
 	"get.porter.sh/porter/pkg/secrets"
@@ -129,3 +131,53 @@ func (s *Store) Create(ctx context.Context, keyName string, keyValue string, val
 	}
 	return nil
 }
+
+// Delete implements the Delete method on the secret plugins' interface.
+func (s *Store) Delete(ctx context.Context, keyName string, keyValue string) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	// check if the keyName is secret
+	if keyName != secrets.SourceSecret {
+		return log.Error(errors.New("invalid key name: " + keyName))
+	}
+
+	path := filepath.Join(s.secretDir, keyValue)
+	if err := s.config.FileSystem.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return log.Error(fmt.Errorf("error deleting secret from filesystem: %w", err))
+	}
+	return nil
+}
+
+// List implements the List method on the secret plugins' interface.
+func (s *Store) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	// check if the keyName is secret
+	if keyName != secrets.SourceSecret {
+		return nil, log.Errorf("unsupported keyName %s", keyName)
+	}
+
+	entries, err := s.config.FileSystem.ReadDir(s.secretDir)
+	if err != nil {
+		return nil, log.Error(fmt.Errorf("error listing secrets from filesystem: %w", err))
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
+			keys = append(keys, entry.Name())
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
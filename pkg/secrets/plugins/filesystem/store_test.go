@@ -65,3 +65,30 @@ func TestFileSystem_DataOperation(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, secretValue, data)
 }
+
+func TestFileSystem_DeleteAndList(t *testing.T) {
+	c := config.NewTestConfig(t)
+	defer c.Close()
+
+	testStore := filesystem.NewStore(c.Config)
+	defer testStore.Close()
+
+	ctx := context.Background()
+	for _, key := range []string{"run1-password", "run1-token", "run2-password"} {
+		require.NoError(t, testStore.Create(ctx, secrets.SourceSecret, key, "supersecret"))
+	}
+
+	keys, err := testStore.List(ctx, secrets.SourceSecret, "run1-")
+	require.NoError(t, err)
+	require.Equal(t, []string{"run1-password", "run1-token"}, keys)
+
+	require.NoError(t, testStore.Delete(ctx, secrets.SourceSecret, "run1-password"))
+	require.NoError(t, testStore.Delete(ctx, secrets.SourceSecret, "run1-password"), "deleting a missing secret should not fail")
+
+	_, err = testStore.Resolve(ctx, secrets.SourceSecret, "run1-password")
+	require.Error(t, err)
+
+	keys, err = testStore.List(ctx, secrets.SourceSecret, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"run1-token", "run2-password"}, keys)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"get.porter.sh/porter/pkg/secrets/plugins"
 )
@@ -50,3 +52,19 @@ func (s *Store) Create(ctx context.Context, keyName string, keyValue string, val
 	s.Secrets[keyName][keyValue] = value
 	return nil
 }
+
+func (s *Store) Delete(ctx context.Context, keyName string, keyValue string) error {
+	delete(s.Secrets[keyName], keyValue)
+	return nil
+}
+
+func (s *Store) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	var keys []string
+	for key := range s.Secrets[keyName] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
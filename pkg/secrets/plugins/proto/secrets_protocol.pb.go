@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.0
+// 	protoc-gen-go v1.36.11
 // 	protoc        v3.19.4
 // source: pkg/secrets/plugins/proto/secrets_protocol.proto
 
@@ -9,6 +9,7 @@ package proto
 import (
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -22,21 +23,18 @@ const (
 )
 
 type ResolveRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyName       string                 `protobuf:"bytes,1,opt,name=KeyName,proto3" json:"KeyName,omitempty"`
+	KeyValue      string                 `protobuf:"bytes,2,opt,name=KeyValue,proto3" json:"KeyValue,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	KeyName  string `protobuf:"bytes,1,opt,name=KeyName,proto3" json:"KeyName,omitempty"`
-	KeyValue string `protobuf:"bytes,2,opt,name=KeyValue,proto3" json:"KeyValue,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResolveRequest) Reset() {
 	*x = ResolveRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ResolveRequest) String() string {
@@ -47,7 +45,7 @@ func (*ResolveRequest) ProtoMessage() {}
 
 func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -77,22 +75,19 @@ func (x *ResolveRequest) GetKeyValue() string {
 }
 
 type CreateRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyName       string                 `protobuf:"bytes,1,opt,name=KeyName,proto3" json:"KeyName,omitempty"`
+	KeyValue      string                 `protobuf:"bytes,2,opt,name=KeyValue,proto3" json:"KeyValue,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	KeyName  string `protobuf:"bytes,1,opt,name=KeyName,proto3" json:"KeyName,omitempty"`
-	KeyValue string `protobuf:"bytes,2,opt,name=KeyValue,proto3" json:"KeyValue,omitempty"`
-	Value    string `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateRequest) Reset() {
 	*x = CreateRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CreateRequest) String() string {
@@ -103,7 +98,7 @@ func (*CreateRequest) ProtoMessage() {}
 
 func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -140,20 +135,17 @@ func (x *CreateRequest) GetValue() string {
 }
 
 type ResolveResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Value string `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResolveResponse) Reset() {
 	*x = ResolveResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ResolveResponse) String() string {
@@ -164,7 +156,7 @@ func (*ResolveResponse) ProtoMessage() {}
 
 func (x *ResolveResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -187,18 +179,16 @@ func (x *ResolveResponse) GetValue() string {
 }
 
 type CreateResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateResponse) Reset() {
 	*x = CreateResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CreateResponse) String() string {
@@ -209,7 +199,7 @@ func (*CreateResponse) ProtoMessage() {}
 
 func (x *CreateResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -224,68 +214,254 @@ func (*CreateResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescGZIP(), []int{3}
 }
 
-var File_pkg_secrets_plugins_proto_secrets_protocol_proto protoreflect.FileDescriptor
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyName       string                 `protobuf:"bytes,1,opt,name=KeyName,proto3" json:"KeyName,omitempty"`
+	KeyValue      string                 `protobuf:"bytes,2,opt,name=KeyValue,proto3" json:"KeyValue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteRequest) GetKeyName() string {
+	if x != nil {
+		return x.KeyName
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetKeyValue() string {
+	if x != nil {
+		return x.KeyValue
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescGZIP(), []int{5}
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyName       string                 `protobuf:"bytes,1,opt,name=KeyName,proto3" json:"KeyName,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRequest) GetKeyName() string {
+	if x != nil {
+		return x.KeyName
+	}
+	return ""
+}
 
-var file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc = []byte{
-	0x0a, 0x30, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2f, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x22, 0x46, 0x0a, 0x0e, 0x52,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
-	0x07, 0x4b, 0x65, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x4b, 0x65, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x5b, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4b, 0x65, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x27, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x8a, 0x01, 0x0a, 0x0f,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
-	0x3c, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x17, 0x2e, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
-	0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x17, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x65, 0x74, 0x2e,
-	0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x73, 0x68, 0x2f, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72,
-	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2f, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *ListRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyValues     []string               `protobuf:"bytes,1,rep,name=KeyValues,proto3" json:"KeyValues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListResponse) GetKeyValues() []string {
+	if x != nil {
+		return x.KeyValues
+	}
+	return nil
+}
+
+var File_pkg_secrets_plugins_proto_secrets_protocol_proto protoreflect.FileDescriptor
+
+const file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc = "" +
+	"\n" +
+	"0pkg/secrets/plugins/proto/secrets_protocol.proto\x12\aplugins\"F\n" +
+	"\x0eResolveRequest\x12\x18\n" +
+	"\aKeyName\x18\x01 \x01(\tR\aKeyName\x12\x1a\n" +
+	"\bKeyValue\x18\x02 \x01(\tR\bKeyValue\"[\n" +
+	"\rCreateRequest\x12\x18\n" +
+	"\aKeyName\x18\x01 \x01(\tR\aKeyName\x12\x1a\n" +
+	"\bKeyValue\x18\x02 \x01(\tR\bKeyValue\x12\x14\n" +
+	"\x05Value\x18\x03 \x01(\tR\x05Value\"'\n" +
+	"\x0fResolveResponse\x12\x14\n" +
+	"\x05Value\x18\x01 \x01(\tR\x05Value\"\x10\n" +
+	"\x0eCreateResponse\"E\n" +
+	"\rDeleteRequest\x12\x18\n" +
+	"\aKeyName\x18\x01 \x01(\tR\aKeyName\x12\x1a\n" +
+	"\bKeyValue\x18\x02 \x01(\tR\bKeyValue\"\x10\n" +
+	"\x0eDeleteResponse\"?\n" +
+	"\vListRequest\x12\x18\n" +
+	"\aKeyName\x18\x01 \x01(\tR\aKeyName\x12\x16\n" +
+	"\x06Prefix\x18\x02 \x01(\tR\x06Prefix\",\n" +
+	"\fListResponse\x12\x1c\n" +
+	"\tKeyValues\x18\x01 \x03(\tR\tKeyValues2\xfa\x01\n" +
+	"\x0fSecretsProtocol\x12<\n" +
+	"\aResolve\x12\x17.plugins.ResolveRequest\x1a\x18.plugins.ResolveResponse\x129\n" +
+	"\x06Create\x12\x16.plugins.CreateRequest\x1a\x17.plugins.CreateResponse\x129\n" +
+	"\x06Delete\x12\x16.plugins.DeleteRequest\x1a\x17.plugins.DeleteResponse\x123\n" +
+	"\x04List\x12\x14.plugins.ListRequest\x1a\x15.plugins.ListResponseB0Z.get.porter.sh/porter/pkg/secrets/plugins/protob\x06proto3"
+
 var (
 	file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescOnce sync.Once
-	file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescData = file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc
+	file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescData []byte
 )
 
 func file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescGZIP() []byte {
 	file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescOnce.Do(func() {
-		file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescData)
+		file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc), len(file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc)))
 	})
 	return file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDescData
 }
 
-var file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_pkg_secrets_plugins_proto_secrets_protocol_proto_goTypes = []interface{}{
+var file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pkg_secrets_plugins_proto_secrets_protocol_proto_goTypes = []any{
 	(*ResolveRequest)(nil),  // 0: plugins.ResolveRequest
 	(*CreateRequest)(nil),   // 1: plugins.CreateRequest
 	(*ResolveResponse)(nil), // 2: plugins.ResolveResponse
 	(*CreateResponse)(nil),  // 3: plugins.CreateResponse
+	(*DeleteRequest)(nil),   // 4: plugins.DeleteRequest
+	(*DeleteResponse)(nil),  // 5: plugins.DeleteResponse
+	(*ListRequest)(nil),     // 6: plugins.ListRequest
+	(*ListResponse)(nil),    // 7: plugins.ListResponse
 }
 var file_pkg_secrets_plugins_proto_secrets_protocol_proto_depIdxs = []int32{
 	0, // 0: plugins.SecretsProtocol.Resolve:input_type -> plugins.ResolveRequest
 	1, // 1: plugins.SecretsProtocol.Create:input_type -> plugins.CreateRequest
-	2, // 2: plugins.SecretsProtocol.Resolve:output_type -> plugins.ResolveResponse
-	3, // 3: plugins.SecretsProtocol.Create:output_type -> plugins.CreateResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	4, // 2: plugins.SecretsProtocol.Delete:input_type -> plugins.DeleteRequest
+	6, // 3: plugins.SecretsProtocol.List:input_type -> plugins.ListRequest
+	2, // 4: plugins.SecretsProtocol.Resolve:output_type -> plugins.ResolveResponse
+	3, // 5: plugins.SecretsProtocol.Create:output_type -> plugins.CreateResponse
+	5, // 6: plugins.SecretsProtocol.Delete:output_type -> plugins.DeleteResponse
+	7, // 7: plugins.SecretsProtocol.List:output_type -> plugins.ListResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -296,63 +472,13 @@ func file_pkg_secrets_plugins_proto_secrets_protocol_proto_init() {
 	if File_pkg_secrets_plugins_proto_secrets_protocol_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResolveResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc), len(file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -361,7 +487,6 @@ func file_pkg_secrets_plugins_proto_secrets_protocol_proto_init() {
 		MessageInfos:      file_pkg_secrets_plugins_proto_secrets_protocol_proto_msgTypes,
 	}.Build()
 	File_pkg_secrets_plugins_proto_secrets_protocol_proto = out.File
-	file_pkg_secrets_plugins_proto_secrets_protocol_proto_rawDesc = nil
 	file_pkg_secrets_plugins_proto_secrets_protocol_proto_goTypes = nil
 	file_pkg_secrets_plugins_proto_secrets_protocol_proto_depIdxs = nil
 }
@@ -24,4 +24,18 @@ type SecretsProtocol interface {
 	// - keyName=key, keyValue=conn-string, value=redis://foo
 	// - keyName=path, keyValue=/tmp/connstring.txt, value=redis://foo
 	Create(ctx context.Context, keyName string, keyValue string, value string) error
+
+	// Delete removes a secret from a secret store. Deleting a secret that
+	// does not exist is not an error.
+	// - keyName is name of the key where the secret can be found.
+	// - keyValue is the value of the key.
+	// Plugins that cannot delete secrets return ErrNotImplemented.
+	Delete(ctx context.Context, keyName string, keyValue string) error
+
+	// List returns the values of the keys of the secrets in a secret store
+	// that start with the prefix. All secrets are listed when prefix is empty.
+	// - keyName is name of the key where the secrets can be found.
+	// Listing secrets is optional, plugins that cannot list secrets return
+	// ErrNotImplemented.
+	List(ctx context.Context, keyName string, prefix string) ([]string, error)
 }
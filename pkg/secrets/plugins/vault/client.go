@@ -0,0 +1,215 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRenewWindow is how long before its lease expires that a token from an
+// AppRole login is replaced with a new one.
+const tokenRenewWindow = 30 * time.Second
+
+// responseError is returned when Vault responds with an unsuccessful status code.
+type responseError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func (e responseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("vault responded with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("vault responded with status %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// isStatus reports whether err is a response from Vault with the status code.
+func isStatus(err error, statusCode int) bool {
+	var respErr responseError
+	return errors.As(err, &respErr) && respErr.StatusCode == statusCode
+}
+
+// isCASMismatch reports whether err is a response from Vault rejecting a
+// write because the secret was changed since the check-and-set version was read.
+func isCASMismatch(err error) bool {
+	var respErr responseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	for _, msg := range respErr.Errors {
+		if strings.Contains(msg, "check-and-set") {
+			return true
+		}
+	}
+	return false
+}
+
+// client makes authenticated requests to the HTTP API of Vault.
+type client struct {
+	cfg        PluginConfig
+	httpClient *http.Client
+
+	lock sync.Mutex
+
+	// token used to authenticate requests.
+	token string
+
+	// tokenExpires is when the token from an AppRole login must be replaced,
+	// and is zero for a configured token.
+	tokenExpires time.Time
+}
+
+// newClient creates a client for the configured Vault server. The CA
+// certificate, when configured, is read with readFile.
+func newClient(cfg PluginConfig, readFile func(string) ([]byte, error)) (*client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CACert != "" {
+		pem, err := readFile(cfg.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the Vault CA certificate %s: %w", cfg.CACert, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates were found in the Vault CA certificate %s", cfg.CACert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &client{
+		cfg:        cfg,
+		httpClient: &http.Client{Transport: transport, Timeout: time.Duration(cfg.Timeout) * time.Second},
+		token:      cfg.Token,
+	}, nil
+}
+
+// usesAppRole reports whether the client logs in with AppRole.
+func (c *client) usesAppRole() bool {
+	return c.cfg.RoleID != ""
+}
+
+// do sends an authenticated request to the API path, for example
+// secret/data/mysecret, and decodes the JSON response into out.
+// When the token from an AppRole login was revoked, the client logs in
+// again and repeats the request once.
+func (c *client) do(ctx context.Context, method string, apiPath string, query url.Values, in interface{}, out interface{}) error {
+	token, err := c.getToken(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	err = c.send(ctx, method, apiPath, query, token, in, out)
+	if isStatus(err, http.StatusForbidden) && c.usesAppRole() {
+		if token, err = c.getToken(ctx, true); err != nil {
+			return err
+		}
+		err = c.send(ctx, method, apiPath, query, token, in, out)
+	}
+	return err
+}
+
+// getToken returns the token used to authenticate requests, logging in with
+// AppRole when there is no valid token or refresh is set.
+func (c *client) getToken(ctx context.Context, refresh bool) (string, error) {
+	if !c.usesAppRole() {
+		return c.token, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token != "" && !refresh && time.Now().Before(c.tokenExpires) {
+		return c.token, nil
+	}
+
+	login := map[string]string{"role_id": c.cfg.RoleID, "secret_id": c.cfg.SecretID}
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	loginPath := "auth/" + strings.Trim(c.cfg.AppRoleMount, "/") + "/login"
+	if err := c.send(ctx, http.MethodPost, loginPath, nil, "", login, &resp); err != nil {
+		return "", fmt.Errorf("could not log in to Vault with AppRole: %w", err)
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", errors.New("could not log in to Vault with AppRole: the response did not include a token")
+	}
+
+	c.token = resp.Auth.ClientToken
+	c.tokenExpires = time.Now().Add(time.Duration(resp.Auth.LeaseDuration)*time.Second - tokenRenewWindow)
+	if resp.Auth.LeaseDuration == 0 {
+		// The token does not expire
+		c.tokenExpires = time.Now().Add(100 * 365 * 24 * time.Hour)
+	}
+	return c.token, nil
+}
+
+func (c *client) send(ctx context.Context, method string, apiPath string, query url.Values, token string, in interface{}, out interface{}) error {
+	u, err := url.Parse(strings.TrimRight(c.cfg.Address, "/") + "/v1/" + apiPath)
+	if err != nil {
+		return fmt.Errorf("invalid Vault address %s: %w", c.cfg.Address, err)
+	}
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.cfg.Namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request to Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respErr := responseError{StatusCode: resp.StatusCode}
+		var errResp struct {
+			Errors []string `json:"errors"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil {
+			respErr.Errors = errResp.Errors
+		}
+		return respErr
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("could not parse the response from Vault: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package vault provides a plugin implementing the secret plugin protocol that
+// stores and resolves secrets with the KV version 2 secrets engine of
+// HashiCorp Vault, or a server that is compatible with its HTTP API.
+package vault
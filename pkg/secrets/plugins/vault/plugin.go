@@ -0,0 +1,116 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/secrets/pluginstore"
+	"github.com/hashicorp/go-plugin"
+	"github.com/mitchellh/mapstructure"
+)
+
+// PluginKey is the identifier of the internal vault secrets plugin.
+const PluginKey = plugins.PluginInterface + ".porter.vault"
+
+const (
+	// DefaultMount is the mount path of the KV secrets engine when one is not configured.
+	DefaultMount = "secret"
+
+	// DefaultAppRoleMount is the mount path of the AppRole auth method when one is not configured.
+	DefaultAppRoleMount = "approle"
+
+	// DefaultTimeout is the timeout in seconds of requests to Vault when one is not configured.
+	DefaultTimeout = 10
+)
+
+// PluginConfig supported by the vault plugin as defined in porter.yaml
+type PluginConfig struct {
+	// Address of the Vault server, for example https://vault.example.com:8200.
+	// Defaults to the VAULT_ADDR environment variable.
+	Address string `mapstructure:"address,omitempty"`
+
+	// Namespace to use with Vault Enterprise.
+	// Defaults to the VAULT_NAMESPACE environment variable.
+	Namespace string `mapstructure:"namespace,omitempty"`
+
+	// Mount path of the KV version 2 secrets engine. Defaults to secret.
+	Mount string `mapstructure:"mount,omitempty"`
+
+	// PathPrefix is prepended to the path of every secret.
+	PathPrefix string `mapstructure:"path-prefix,omitempty"`
+
+	// Token used to authenticate with Vault. Defaults to the VAULT_TOKEN
+	// environment variable when AppRole authentication is not configured.
+	Token string `mapstructure:"token,omitempty"`
+
+	// RoleID of the AppRole used to authenticate with Vault.
+	RoleID string `mapstructure:"role-id,omitempty"`
+
+	// SecretID of the AppRole used to authenticate with Vault.
+	SecretID string `mapstructure:"secret-id,omitempty"`
+
+	// AppRoleMount is the mount path of the AppRole auth method. Defaults to approle.
+	AppRoleMount string `mapstructure:"approle-mount,omitempty"`
+
+	// CACert is the path to a PEM encoded certificate authority used to verify
+	// the certificate of the Vault server, in addition to the system certificates.
+	CACert string `mapstructure:"ca-cert,omitempty"`
+
+	// Timeout of requests to Vault in seconds. Defaults to 10.
+	Timeout int `mapstructure:"timeout,omitempty"`
+}
+
+// NewPlugin creates an instance of the secrets.porter.vault plugin
+func NewPlugin(c *config.Config, rawCfg interface{}) (plugin.Plugin, error) {
+	cfg := PluginConfig{}
+	if err := mapstructure.Decode(rawCfg, &cfg); err != nil {
+		return nil, fmt.Errorf("error reading plugin configuration: %w", err)
+	}
+
+	store, err := NewStore(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return pluginstore.NewPlugin(c.Context, store), nil
+}
+
+// applyDefaults sets the default values of the configuration, reading the
+// address, namespace and token from the standard Vault environment variables.
+func (cfg *PluginConfig) applyDefaults(getenv func(string) string) error {
+	if cfg.Address == "" {
+		cfg.Address = getenv("VAULT_ADDR")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = getenv("VAULT_NAMESPACE")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = DefaultMount
+	}
+	if cfg.AppRoleMount == "" {
+		cfg.AppRoleMount = DefaultAppRoleMount
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	useAppRole := cfg.RoleID != "" || cfg.SecretID != ""
+	if useAppRole && cfg.Token != "" {
+		return errors.New("configure either token or role-id and secret-id, not both")
+	}
+	if useAppRole && (cfg.RoleID == "" || cfg.SecretID == "") {
+		return errors.New("both role-id and secret-id are required to authenticate with AppRole")
+	}
+	if !useAppRole && cfg.Token == "" {
+		cfg.Token = getenv("VAULT_TOKEN")
+	}
+
+	if cfg.Address == "" {
+		return errors.New("the address of the Vault server is not configured, set address in the plugin configuration or the VAULT_ADDR environment variable")
+	}
+	if !useAppRole && cfg.Token == "" {
+		return errors.New("no Vault credentials are configured, set token, or role-id and secret-id, in the plugin configuration or the VAULT_TOKEN environment variable")
+	}
+	return nil
+}
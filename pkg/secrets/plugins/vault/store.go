@@ -0,0 +1,350 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/secrets/plugins"
+	"get.porter.sh/porter/pkg/tracing"
+)
+
+var _ plugins.SecretsProtocol = &Store{}
+
+const (
+	// DefaultField is the field of a secret that holds its value when the
+	// secret name does not select a field.
+	DefaultField = "value"
+
+	// fieldSeparator separates the path of a secret from the field that holds
+	// its value, for example database/prod#password.
+	fieldSeparator = "#"
+
+	// maxUpdateAttempts is the number of times a secret is read and saved
+	// again when it was changed by another client in the meantime.
+	maxUpdateAttempts = 5
+)
+
+// Store resolves and creates secrets in the KV version 2 secrets engine of Vault.
+// A secret name is the path of the secret in the engine, relative to the
+// configured path prefix, optionally followed by #FIELD to select the field
+// that holds the value.
+type Store struct {
+	config *config.Config
+	cfg    PluginConfig
+	client *client
+}
+
+// NewStore creates a new instance of the vault secret store.
+func NewStore(c *config.Config, cfg PluginConfig) (*Store, error) {
+	if err := cfg.applyDefaults(c.Getenv); err != nil {
+		return nil, fmt.Errorf("invalid plugin configuration: %w", err)
+	}
+	return &Store{
+		config: c,
+		cfg:    cfg,
+	}, nil
+}
+
+// Connect initializes the plugin for use.
+// The plugin itself is responsible for ensuring it was called.
+// Close is called automatically when the plugin is used by Porter.
+func (s *Store) Connect(ctx context.Context) error {
+	if s.client != nil {
+		return nil
+	}
+
+	_, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	client, err := newClient(s.cfg, s.config.FileSystem.ReadFile)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	s.client = client
+	log.Debugf("using the Vault KV secrets engine at %s/v1/%s", strings.TrimRight(s.cfg.Address, "/"), s.cfg.Mount)
+	return nil
+}
+
+// Close implements the Close method on the secret plugins' interface.
+func (s *Store) Close() error {
+	if s.client != nil {
+		s.client.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Resolve implements the Resolve method on the secret plugins' interface.
+func (s *Store) Resolve(ctx context.Context, keyName string, keyValue string) (string, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return "", err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return "", log.Errorf("unsupported keyName %s", keyName)
+	}
+
+	secretPath, field, err := s.parseName(keyValue)
+	if err != nil {
+		return "", log.Error(err)
+	}
+
+	var resp struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	err = s.client.do(ctx, http.MethodGet, s.apiPath("data", secretPath), nil, nil, &resp)
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return "", log.Errorf("secret %s not found", keyValue)
+		}
+		return "", log.Error(fmt.Errorf("error reading secret %s from Vault: %w", keyValue, err))
+	}
+
+	value, ok := resp.Data.Data[field]
+	if !ok {
+		return "", log.Errorf("secret %s does not have a field named %s", keyValue, field)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	// Return structured values as json, the same as the vault CLI
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", log.Error(fmt.Errorf("could not encode the field %s of secret %s: %w", field, keyValue, err))
+	}
+	return string(data), nil
+}
+
+// Create implements the Create method on the secret plugins' interface.
+// The value is saved to its field in a new version of the secret, keeping
+// the other fields of the secret.
+func (s *Store) Create(ctx context.Context, keyName string, keyValue string, value string) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return log.Errorf("invalid key name: %s", keyName)
+	}
+
+	secretPath, field, err := s.parseName(keyValue)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	err = s.updateSecret(ctx, secretPath, func(data map[string]interface{}) bool {
+		data[field] = value
+		return true
+	})
+	if err != nil {
+		return log.Error(fmt.Errorf("error writing secret %s to Vault: %w", keyValue, err))
+	}
+	return nil
+}
+
+// Delete implements the Delete method on the secret plugins' interface.
+// When the name selects a field, only that field is removed, and the secret
+// is deleted once it has no fields left. Otherwise every version of the
+// secret is deleted.
+func (s *Store) Delete(ctx context.Context, keyName string, keyValue string) error {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return log.Errorf("invalid key name: %s", keyName)
+	}
+
+	secretPath, field, err := s.parseName(keyValue)
+	if err != nil {
+		return log.Error(err)
+	}
+
+	deleteSecret := !strings.Contains(keyValue, fieldSeparator)
+	if !deleteSecret {
+		err = s.updateSecret(ctx, secretPath, func(data map[string]interface{}) bool {
+			if _, ok := data[field]; !ok {
+				return false
+			}
+			delete(data, field)
+			if len(data) == 0 {
+				deleteSecret = true
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return log.Error(fmt.Errorf("error deleting secret %s from Vault: %w", keyValue, err))
+		}
+	}
+
+	if deleteSecret {
+		err = s.client.do(ctx, http.MethodDelete, s.apiPath("metadata", secretPath), nil, nil, nil)
+		if err != nil && !isStatus(err, http.StatusNotFound) {
+			return log.Error(fmt.Errorf("error deleting secret %s from Vault: %w", keyValue, err))
+		}
+	}
+	return nil
+}
+
+// updateSecret reads the fields of the current version of a secret, changes
+// them with update, and saves them as a new version of the secret when
+// update returns true. The new version is only saved when the secret was
+// not changed after it was read, otherwise the update is repeated.
+func (s *Store) updateSecret(ctx context.Context, secretPath string, update func(data map[string]interface{}) bool) error {
+	for attempt := 1; ; attempt++ {
+		data, version, err := s.readSecret(ctx, secretPath)
+		if err != nil {
+			return err
+		}
+		if !update(data) {
+			return nil
+		}
+
+		body := map[string]interface{}{
+			"options": map[string]int{"cas": version},
+			"data":    data,
+		}
+		err = s.client.do(ctx, http.MethodPost, s.apiPath("data", secretPath), nil, body, nil)
+		if err == nil || !isCASMismatch(err) || attempt >= maxUpdateAttempts {
+			return err
+		}
+	}
+}
+
+// readSecret returns the fields of the current version of a secret, and the
+// version, which is 0 when the secret does not exist.
+func (s *Store) readSecret(ctx context.Context, secretPath string) (map[string]interface{}, int, error) {
+	var resp struct {
+		Data struct {
+			Data     map[string]interface{} `json:"data"`
+			Metadata struct {
+				Version int `json:"version"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	err := s.client.do(ctx, http.MethodGet, s.apiPath("data", secretPath), nil, nil, &resp)
+	if err == nil {
+		data := resp.Data.Data
+		if data == nil {
+			data = make(map[string]interface{})
+		}
+		return data, resp.Data.Metadata.Version, nil
+	}
+	if !isStatus(err, http.StatusNotFound) {
+		return nil, 0, err
+	}
+
+	// The secret does not exist, or its current version was deleted
+	var metadata struct {
+		Data struct {
+			CurrentVersion int `json:"current_version"`
+		} `json:"data"`
+	}
+	err = s.client.do(ctx, http.MethodGet, s.apiPath("metadata", secretPath), nil, nil, &metadata)
+	if err != nil && !isStatus(err, http.StatusNotFound) {
+		return nil, 0, err
+	}
+	return make(map[string]interface{}), metadata.Data.CurrentVersion, nil
+}
+
+// List implements the List method on the secret plugins' interface.
+// Secrets in sub-directories of the prefix are not included.
+func (s *Store) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	ctx, log := tracing.StartSpan(ctx)
+	defer log.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	if keyName != secrets.SourceSecret {
+		return nil, log.Errorf("unsupported keyName %s", keyName)
+	}
+
+	// Vault lists the contents of a directory, so list the directory of the
+	// prefix and filter the names in it
+	dir, namePrefix := "", prefix
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir, namePrefix = prefix[:i], prefix[i+1:]
+	}
+
+	var resp struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	query := url.Values{"list": []string{"true"}}
+	err := s.client.do(ctx, http.MethodGet, s.apiPath("metadata", dir), query, nil, &resp)
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, log.Error(fmt.Errorf("error listing secrets in Vault: %w", err))
+	}
+
+	var keys []string
+	for _, key := range resp.Data.Keys {
+		if strings.HasSuffix(key, "/") || !strings.HasPrefix(key, namePrefix) {
+			continue
+		}
+		if dir != "" {
+			key = dir + "/" + key
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// parseName splits a secret name into the path of the secret and the field
+// that holds its value.
+func (s *Store) parseName(name string) (string, string, error) {
+	secretPath, field := name, DefaultField
+	if i := strings.LastIndex(name, fieldSeparator); i >= 0 {
+		secretPath, field = name[:i], name[i+1:]
+	}
+
+	if secretPath == "" || field == "" {
+		return "", "", fmt.Errorf("invalid secret name %q", name)
+	}
+	for _, segment := range strings.Split(secretPath, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", "", fmt.Errorf("invalid secret name %q", name)
+		}
+	}
+	return secretPath, field, nil
+}
+
+// apiPath returns the path of the API of the secrets engine for the secret
+// path, for example secret/data/PREFIX/PATH, with each segment escaped.
+func (s *Store) apiPath(api string, secretPath string) string {
+	segments := []string{strings.Trim(s.cfg.Mount, "/"), api}
+	for _, p := range []string{s.cfg.PathPrefix, secretPath} {
+		for _, segment := range strings.Split(strings.Trim(p, "/"), "/") {
+			if segment != "" {
+				segments = append(segments, url.PathEscape(segment))
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,394 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault implements the parts of the Vault HTTP API used by the plugin:
+// the KV version 2 secrets engine mounted at secret and the AppRole auth
+// method mounted at approle.
+type fakeVault struct {
+	t *testing.T
+
+	lock sync.Mutex
+
+	// secrets by their path in the engine
+	secrets map[string]map[string]interface{}
+
+	// versions of the secrets by their path in the engine
+	versions map[string]int
+
+	// conflicts is the number of writes that are rejected, as if the secret
+	// was changed by another client after it was read
+	conflicts int
+
+	// tokens that are accepted
+	tokens map[string]bool
+
+	// logins counts the AppRole logins
+	logins int
+
+	// namespaces of the requests that were received
+	namespaces []string
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	v := &fakeVault{
+		t:        t,
+		secrets:  make(map[string]map[string]interface{}),
+		versions: make(map[string]int),
+		tokens:   map[string]bool{"root-token": true},
+	}
+	srv := httptest.NewServer(v)
+	t.Cleanup(srv.Close)
+	return v, srv
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.namespaces = append(v.namespaces, r.Header.Get("X-Vault-Namespace"))
+
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var login map[string]string
+		require.NoError(v.t, json.NewDecoder(r.Body).Decode(&login))
+		if login["role_id"] != "porter-role" || login["secret_id"] != "porter-secret" {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		v.logins++
+		token := "approle-token-" + string(rune('0'+v.logins))
+		v.tokens[token] = true
+		writeJSON(w, http.StatusOK, map[string]interface{}{"auth": map[string]interface{}{"client_token": token, "lease_duration": 3600}})
+		return
+	}
+
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"errors": []string{"permission denied"}})
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+		secretPath := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := v.secrets[secretPath]
+			if !ok {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"data":     data,
+				"metadata": map[string]interface{}{"version": v.versions[secretPath]},
+			}})
+		case http.MethodPost:
+			var body struct {
+				Options struct {
+					CAS *int `json:"cas"`
+				} `json:"options"`
+				Data map[string]interface{} `json:"data"`
+			}
+			require.NoError(v.t, json.NewDecoder(r.Body).Decode(&body))
+			if v.conflicts > 0 {
+				v.conflicts--
+				v.versions[secretPath]++
+			}
+			if body.Options.CAS != nil && *body.Options.CAS != v.versions[secretPath] {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"check-and-set parameter did not match the current version"}})
+				return
+			}
+			v.secrets[secretPath] = body.Data
+			v.versions[secretPath]++
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"version": v.versions[secretPath]}})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata"):
+		secretPath := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata"), "/")
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(v.secrets, secretPath)
+			delete(v.versions, secretPath)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Query().Get("list") == "":
+			version, ok := v.versions[secretPath]
+			if !ok {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"current_version": version}})
+		case r.Method == http.MethodGet && r.URL.Query().Get("list") == "true":
+			keys := v.list(secretPath)
+			if len(keys) == 0 {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{"no handler for route"}})
+	}
+}
+
+// list the secrets and sub-directories in a directory, like Vault.
+func (v *fakeVault) list(dir string) []string {
+	if dir != "" {
+		dir += "/"
+	}
+	found := map[string]bool{}
+	for secretPath := range v.secrets {
+		if !strings.HasPrefix(secretPath, dir) {
+			continue
+		}
+		name := strings.TrimPrefix(secretPath, dir)
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[:i+1]
+		}
+		found[name] = true
+	}
+	var keys []string
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
+
+func newTestStore(t *testing.T, cfg PluginConfig) *Store {
+	c := config.NewTestConfig(t)
+	s, err := NewStore(c.Config, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStore_DataOperation(t *testing.T) {
+	ctx := context.Background()
+	v, srv := newFakeVault(t)
+	s := newTestStore(t, PluginConfig{Address: srv.URL, Token: "root-token", PathPrefix: "porter/"})
+
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+	assert.Equal(t, map[string]interface{}{"value": "supersecret"}, v.secrets["porter/password"])
+
+	value, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err)
+	assert.Equal(t, "supersecret", value)
+
+	t.Run("field", func(t *testing.T) {
+		v.secrets["porter/db/prod"] = map[string]interface{}{"username": "admin", "password": "dbpassword", "ports": []interface{}{5432.0}}
+
+		value, err := s.Resolve(ctx, secrets.SourceSecret, "db/prod#password")
+		require.NoError(t, err)
+		assert.Equal(t, "dbpassword", value)
+
+		value, err = s.Resolve(ctx, secrets.SourceSecret, "db/prod#ports")
+		require.NoError(t, err)
+		assert.Equal(t, "[5432]", value, "structured values should be returned as json")
+
+		_, err = s.Resolve(ctx, secrets.SourceSecret, "db/prod")
+		require.ErrorContains(t, err, "secret db/prod does not have a field named value")
+	})
+
+	t.Run("create field", func(t *testing.T) {
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "api#token", "abc123"))
+		assert.Equal(t, map[string]interface{}{"token": "abc123"}, v.secrets["porter/api"])
+	})
+
+	t.Run("create fields in the same secret", func(t *testing.T) {
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "mysql#username", "admin"))
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "mysql#password", "dbpassword"))
+		assert.Equal(t, map[string]interface{}{"username": "admin", "password": "dbpassword"}, v.secrets["porter/mysql"],
+			"writing a field should keep the other fields of the secret")
+
+		// Another client changes the secret after it is read
+		v.conflicts = 1
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "mysql#password", "newpassword"))
+		assert.Equal(t, map[string]interface{}{"username": "admin", "password": "newpassword"}, v.secrets["porter/mysql"],
+			"the write should be repeated when the secret was changed")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := s.Resolve(ctx, secrets.SourceSecret, "missing")
+		require.ErrorContains(t, err, "secret missing not found")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		err := s.Create(ctx, secrets.SourceSecret, "../password", "oops")
+		require.ErrorContains(t, err, "invalid secret name")
+	})
+
+	t.Run("escaped name", func(t *testing.T) {
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "my secret?", "value"))
+		assert.Contains(t, v.secrets, "porter/my secret?")
+	})
+
+	t.Run("unsupported key name", func(t *testing.T) {
+		_, err := s.Resolve(ctx, "env", "password")
+		require.ErrorContains(t, err, "unsupported keyName")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		s := newTestStore(t, PluginConfig{Address: srv.URL, Token: "wrong-token"})
+		_, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+		require.ErrorContains(t, err, "vault responded with status 403: permission denied")
+	})
+}
+
+func TestStore_DeleteAndList(t *testing.T) {
+	ctx := context.Background()
+	v, srv := newFakeVault(t)
+	s := newTestStore(t, PluginConfig{Address: srv.URL, Token: "root-token"})
+
+	for _, key := range []string{"run1-password", "run1-token", "run2-password", "run1-dir/nested"} {
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, key, "supersecret"))
+	}
+
+	keys, err := s.List(ctx, secrets.SourceSecret, "run1-")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run1-password", "run1-token"}, keys, "secrets in sub-directories should not be listed")
+
+	keys, err = s.List(ctx, secrets.SourceSecret, "run1-dir/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run1-dir/nested"}, keys)
+
+	require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "run1-password"))
+	assert.NotContains(t, v.secrets, "run1-password")
+	require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "run1-password"), "deleting a missing secret should not fail")
+
+	keys, err = s.List(ctx, secrets.SourceSecret, "missing/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	t.Run("delete field", func(t *testing.T) {
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "mysql#username", "admin"))
+		require.NoError(t, s.Create(ctx, secrets.SourceSecret, "mysql#password", "dbpassword"))
+
+		require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "mysql#password"))
+		assert.Equal(t, map[string]interface{}{"username": "admin"}, v.secrets["mysql"], "only the field should be deleted")
+		require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "mysql#password"), "deleting a missing field should not fail")
+
+		require.NoError(t, s.Delete(ctx, secrets.SourceSecret, "mysql#username"))
+		assert.NotContains(t, v.secrets, "mysql", "the secret should be deleted once it has no fields")
+		assert.NotContains(t, v.versions, "mysql", "every version of the secret should be deleted")
+	})
+}
+
+func TestStore_AppRole(t *testing.T) {
+	ctx := context.Background()
+	v, srv := newFakeVault(t)
+	s := newTestStore(t, PluginConfig{Address: srv.URL, RoleID: "porter-role", SecretID: "porter-secret", Namespace: "team1"})
+
+	require.NoError(t, s.Create(ctx, secrets.SourceSecret, "password", "supersecret"))
+	value, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+	require.NoError(t, err)
+	assert.Equal(t, "supersecret", value)
+	assert.Equal(t, 1, v.logins, "the token should be reused until it expires")
+
+	for _, ns := range v.namespaces {
+		assert.Equal(t, "team1", ns, "every request should include the namespace")
+	}
+
+	t.Run("revoked token", func(t *testing.T) {
+		v.tokens = map[string]bool{}
+		value, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+		require.NoError(t, err)
+		assert.Equal(t, "supersecret", value)
+		assert.Equal(t, 2, v.logins, "the plugin should log in again when the token is rejected")
+	})
+
+	t.Run("invalid secret id", func(t *testing.T) {
+		s := newTestStore(t, PluginConfig{Address: srv.URL, RoleID: "porter-role", SecretID: "wrong"})
+		_, err := s.Resolve(ctx, secrets.SourceSecret, "password")
+		require.ErrorContains(t, err, "could not log in to Vault with AppRole: vault responded with status 400: invalid role or secret ID")
+	})
+}
+
+func TestNewStore_Config(t *testing.T) {
+	testcases := []struct {
+		name    string
+		cfg     PluginConfig
+		env     map[string]string
+		want    PluginConfig
+		wantErr string
+	}{
+		{
+			name: "defaults from the environment",
+			env:  map[string]string{"VAULT_ADDR": "https://vault:8200", "VAULT_TOKEN": "env-token", "VAULT_NAMESPACE": "team1"},
+			want: PluginConfig{Address: "https://vault:8200", Token: "env-token", Namespace: "team1", Mount: "secret", AppRoleMount: "approle", Timeout: 10},
+		},
+		{
+			name: "configured",
+			cfg:  PluginConfig{Address: "https://vault:8200", Mount: "kv", RoleID: "role", SecretID: "secret", AppRoleMount: "ci", Timeout: 30},
+			env:  map[string]string{"VAULT_TOKEN": "env-token"},
+			want: PluginConfig{Address: "https://vault:8200", Mount: "kv", RoleID: "role", SecretID: "secret", AppRoleMount: "ci", Timeout: 30},
+		},
+		{
+			name:    "no address",
+			cfg:     PluginConfig{Token: "token"},
+			wantErr: "the address of the Vault server is not configured",
+		},
+		{
+			name:    "no credentials",
+			cfg:     PluginConfig{Address: "https://vault:8200"},
+			wantErr: "no Vault credentials are configured",
+		},
+		{
+			name:    "incomplete approle",
+			cfg:     PluginConfig{Address: "https://vault:8200", RoleID: "role"},
+			wantErr: "both role-id and secret-id are required",
+		},
+		{
+			name:    "token and approle",
+			cfg:     PluginConfig{Address: "https://vault:8200", Token: "token", RoleID: "role", SecretID: "secret"},
+			wantErr: "configure either token or role-id and secret-id, not both",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := config.NewTestConfig(t)
+			for key, value := range tc.env {
+				c.Setenv(key, value)
+			}
+
+			s, err := NewStore(c.Config, tc.cfg)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, s.cfg)
+		})
+	}
+}
+
+func TestNewStore_CACert(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	s, err := NewStore(c.Config, PluginConfig{Address: "https://vault:8200", Token: "token", CACert: "/missing.pem"})
+	require.NoError(t, err)
+
+	err = s.Connect(ctx)
+	require.ErrorContains(t, err, "could not read the Vault CA certificate /missing.pem")
+}
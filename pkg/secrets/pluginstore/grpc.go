@@ -2,10 +2,15 @@ package pluginstore
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"get.porter.sh/porter/pkg/portercontext"
 	"get.porter.sh/porter/pkg/secrets/plugins"
 	"get.porter.sh/porter/pkg/secrets/plugins/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var _ plugins.SecretsProtocol = &GClient{}
@@ -39,6 +44,46 @@ func (m *GClient) Create(ctx context.Context, keyName string, keyValue string, v
 		Value:    value,
 	}
 	_, err := m.client.Create(ctx, req)
+	return fromStatus(err)
+}
+
+func (m *GClient) Delete(ctx context.Context, keyName string, keyValue string) error {
+	req := &proto.DeleteRequest{
+		KeyName:  keyName,
+		KeyValue: keyValue,
+	}
+	_, err := m.client.Delete(ctx, req)
+	return fromStatus(err)
+}
+
+func (m *GClient) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	req := &proto.ListRequest{
+		KeyName: keyName,
+		Prefix:  prefix,
+	}
+	resp, err := m.client.List(ctx, req)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return resp.KeyValues, nil
+}
+
+// fromStatus converts the Unimplemented status back into plugins.ErrNotImplemented.
+// Plugins built before a method was added to the protocol return Unimplemented too.
+func fromStatus(err error) error {
+	if err == nil || status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	msg := strings.TrimSuffix(status.Convert(err).Message(), ": "+plugins.ErrNotImplemented.Error())
+	return fmt.Errorf("%s: %w", msg, plugins.ErrNotImplemented)
+}
+
+// toStatus returns plugins.ErrNotImplemented as the Unimplemented status, so
+// that the client can identify it.
+func toStatus(err error) error {
+	if errors.Is(err, plugins.ErrNotImplemented) {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
 	return err
 }
 
@@ -64,7 +109,23 @@ func (m *GServer) Resolve(ctx context.Context, request *proto.ResolveRequest) (*
 func (m *GServer) Create(ctx context.Context, request *proto.CreateRequest) (*proto.CreateResponse, error) {
 	err := m.impl.Create(ctx, request.KeyName, request.KeyValue, request.Value)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &proto.CreateResponse{}, nil
 }
+
+func (m *GServer) Delete(ctx context.Context, request *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	err := m.impl.Delete(ctx, request.KeyName, request.KeyValue)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.DeleteResponse{}, nil
+}
+
+func (m *GServer) List(ctx context.Context, request *proto.ListRequest) (*proto.ListResponse, error) {
+	keys, err := m.impl.List(ctx, request.KeyName, request.Prefix)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &proto.ListResponse{KeyValues: keys}, nil
+}
@@ -0,0 +1,50 @@
+package pluginstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"get.porter.sh/porter/pkg/secrets/plugins"
+	inmemory "get.porter.sh/porter/pkg/secrets/plugins/in-memory"
+	"get.porter.sh/porter/pkg/secrets/plugins/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readOnlyStore is a secrets plugin that does not support deleting secrets.
+type readOnlyStore struct {
+	*inmemory.Store
+}
+
+func (s readOnlyStore) Delete(ctx context.Context, keyName string, keyValue string) error {
+	return fmt.Errorf("the read-only plugin does not support deleting secrets: %w", plugins.ErrNotImplemented)
+}
+
+func TestNotImplementedStatus(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(nil, readOnlyStore{inmemory.NewStore()})
+
+	_, err := srv.Delete(ctx, &proto.DeleteRequest{KeyName: "secret", KeyValue: "password"})
+	require.Equal(t, codes.Unimplemented, status.Code(err), "ErrNotImplemented should be returned as the Unimplemented status")
+
+	err = fromStatus(err)
+	require.True(t, errors.Is(err, plugins.ErrNotImplemented), "the Unimplemented status should be converted back to ErrNotImplemented")
+	assert.EqualError(t, err, "the read-only plugin does not support deleting secrets: not implemented")
+
+	t.Run("plugin without the method", func(t *testing.T) {
+		_, err := proto.UnimplementedSecretsProtocolServer{}.List(ctx, &proto.ListRequest{})
+		err = fromStatus(err)
+		require.True(t, errors.Is(err, plugins.ErrNotImplemented))
+		assert.EqualError(t, err, "method List not implemented: not implemented")
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		err := status.Error(codes.Unknown, "secret not found")
+		assert.Same(t, err, fromStatus(err))
+		assert.NoError(t, fromStatus(nil))
+	})
+}
@@ -84,6 +84,41 @@ func (s *Store) Create(ctx context.Context, keyName string, keyValue string, val
 	return span.Error(err)
 }
 
+func (s *Store) Delete(ctx context.Context, keyName string, keyValue string) error {
+	ctx, span := tracing.StartSpan(ctx,
+		attribute.String("keyName", keyName),
+		attribute.String("keyValue", keyValue))
+	defer span.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	err := s.plugin.Delete(ctx, keyName, keyValue)
+	if errors.Is(err, plugins.ErrNotImplemented) {
+		// Porter handles plugins that cannot delete secrets, so it is not recorded as an error
+		return err
+	}
+	return span.Error(err)
+}
+
+func (s *Store) List(ctx context.Context, keyName string, prefix string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx,
+		attribute.String("keyName", keyName),
+		attribute.String("prefix", prefix))
+	defer span.EndSpan()
+
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.plugin.List(ctx, keyName, prefix)
+	if errors.Is(err, plugins.ErrNotImplemented) {
+		return nil, err
+	}
+	return keys, span.Error(err)
+}
+
 // Connect initializes the plugin for use.
 // The plugin itself is responsible for ensuring it was called.
 // Close is called automatically when the plugin is used by Porter.
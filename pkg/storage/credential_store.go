@@ -2,13 +2,10 @@ package storage
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"get.porter.sh/porter/pkg/secrets"
 	hostSecrets "get.porter.sh/porter/pkg/secrets/plugins/host"
 	"get.porter.sh/porter/pkg/tracing"
-	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -65,6 +62,7 @@ func (s CredentialStore) ResolveAll(ctx context.Context, creds CredentialSet, ke
 	defer span.EndSpan()
 
 	resolvedCreds := make(secrets.Set)
+	resolver := newSourceResolver(s.Secrets, s.HostSecrets, creds.Credentials)
 	var resolveErrors *multierror.Error
 
 	for _, key := range keys {
@@ -74,13 +72,7 @@ func (s CredentialStore) ResolveAll(ctx context.Context, creds CredentialSet, ke
 			continue
 		}
 
-		var value string
-		var err error
-		if isHandledByHostPlugin(cred.Source.Strategy) {
-			value, err = s.HostSecrets.Resolve(ctx, cred.Source.Strategy, cred.Source.Hint)
-		} else {
-			value, err = s.Secrets.Resolve(ctx, cred.Source.Strategy, cred.Source.Hint)
-		}
+		value, err := resolver.Resolve(ctx, *cred)
 		if err != nil {
 			resolveErrors = multierror.Append(resolveErrors, span.Errorf("unable to resolve credential %s.%s from %s %s: %w", creds.Name, cred.Name, cred.Source.Strategy, cred.Source.Hint, err))
 		}
@@ -92,26 +84,10 @@ func (s CredentialStore) ResolveAll(ctx context.Context, creds CredentialSet, ke
 }
 
 func (s CredentialStore) Validate(ctx context.Context, creds CredentialSet) error {
-	validSources := []string{secrets.SourceSecret, host.SourceValue, host.SourceEnv, host.SourcePath, host.SourceCommand}
 	var errors error
-
-	for _, cs := range creds.Credentials {
-		valid := false
-		for _, validSource := range validSources {
-			if cs.Source.Strategy == validSource {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			errors = multierror.Append(errors, fmt.Errorf(
-				"%s is not a valid source. Valid sources are: %s",
-				cs.Source.Strategy,
-				strings.Join(validSources, ", "),
-			))
-		}
+	for _, err := range validateSources(creds.Credentials) {
+		errors = multierror.Append(errors, err)
 	}
-
 	return errors
 }
 
@@ -111,3 +111,38 @@ func TestCredentialStorage_Validate_BadSources(t *testing.T) {
 	err := s.Validate(context.Background(), testCreds)
 	require.Error(t, err, "Validate returned errors")
 }
+
+func TestCredentialStorage_ResolveAll_CompositeSources(t *testing.T) {
+	cp := NewTestCredentialProvider(t)
+	defer cp.Close()
+
+	cp.AddSecret("kubeconfig-b64", "YXBpVmVyc2lvbjogdjE=")
+	cp.AddSecret("registry", `{"auths": {"example.com": {"username": "ci", "password": "token"}}}`)
+	creds := NewCredentialSet("dev", "mycreds",
+		secrets.SourceMap{Name: "encoded-kubeconfig", Source: secrets.Source{Strategy: "secret", Hint: "kubeconfig-b64"}},
+		secrets.SourceMap{Name: "kubeconfig", Source: secrets.Source{Strategy: "base64", Hint: "encoded-kubeconfig"}},
+		secrets.SourceMap{Name: "registry-auth", Source: secrets.Source{Strategy: "secret", Hint: "registry"}},
+		secrets.SourceMap{Name: "registry-login", Source: secrets.Source{Strategy: "template", Hint: "{{registry-user}}:{{registry-password}}"}},
+		secrets.SourceMap{Name: "registry-user", Source: secrets.Source{Strategy: "json", Hint: `registry-auth#$.auths["example.com"].username`}},
+		secrets.SourceMap{Name: "registry-password", Source: secrets.Source{Strategy: "json", Hint: `registry-auth#$.auths["example.com"].password`}},
+	)
+
+	resolved, err := cp.ResolveAll(context.Background(), creds, []string{"kubeconfig", "registry-login"})
+	require.NoError(t, err)
+	assert.Equal(t, secrets.Set{"kubeconfig": "apiVersion: v1", "registry-login": "ci:token"}, resolved)
+}
+
+func TestCredentialStorage_ResolveAll_TemplateDottedNames(t *testing.T) {
+	cp := NewTestCredentialProvider(t)
+	defer cp.Close()
+
+	creds := NewCredentialSet("dev", "mycreds",
+		secrets.SourceMap{Name: "db.user", Source: secrets.Source{Strategy: "value", Hint: "admin"}},
+		secrets.SourceMap{Name: "db", Source: secrets.Source{Strategy: "value", Hint: "not-an-object"}},
+		secrets.SourceMap{Name: "conn", Source: secrets.Source{Strategy: "template", Hint: "{{db.user}}@{{db}}"}},
+	)
+
+	resolved, err := cp.ResolveAll(context.Background(), creds, []string{"conn"})
+	require.NoError(t, err)
+	assert.Equal(t, "admin@not-an-object", resolved["conn"], "names with a dot should be used as-is instead of a nested lookup")
+}
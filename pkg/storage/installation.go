@@ -66,6 +66,12 @@ type InstallationSpec struct {
 	// Parameters specified by the user through overrides.
 	// Does not include defaults, or values resolved from parameter sources.
 	Parameters ParameterSet `json:"parameters,omitempty"`
+
+	// DependsOn lists the installations that must be applied before this
+	// installation when a batch of installations is applied. Each entry is
+	// either an installation name, NAMESPACE/NAME, or a label selector
+	// formatted as KEY=VALUE.
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 func (i InstallationSpec) String() string {
@@ -149,6 +155,7 @@ func (i *InstallationSpec) Apply(input InstallationSpec) {
 	i.CredentialSets = input.CredentialSets
 	i.ParameterSets = input.ParameterSets
 	i.Labels = input.Labels
+	i.DependsOn = input.DependsOn
 }
 
 // Validate the installation document and report the first error.
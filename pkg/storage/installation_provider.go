@@ -18,6 +18,9 @@ type InstallationProvider interface {
 	// InsertOutput saves a new Output document.
 	InsertOutput(ctx context.Context, output Output) error
 
+	// InsertLogChunk saves a new LogChunk document.
+	InsertLogChunk(ctx context.Context, chunk LogChunk) error
+
 	// UpdateInstallation saves changes to an existing Installation document.
 	UpdateInstallation(ctx context.Context, installation Installation) error
 
@@ -77,9 +80,19 @@ type InstallationProvider interface {
 	// RemoveInstallation by its name.
 	RemoveInstallation(ctx context.Context, namespace string, name string) error
 
+	// RemoveRun by its ID, along with its results, outputs and log chunks.
+	RemoveRun(ctx context.Context, id string) error
+
+	// RemoveLogChunks of a run, once its logs have been saved as an output.
+	RemoveLogChunks(ctx context.Context, runID string) error
+
 	// GetLogs returns the logs from the specified Run.
 	GetLogs(ctx context.Context, runID string) (logs string, hasLogs bool, err error)
 
+	// ListLogChunks returns the LogChunk documents of a run with a sequence
+	// greater than afterSequence, sorted in ascending order by sequence.
+	ListLogChunks(ctx context.Context, runID string, afterSequence int) ([]LogChunk, error)
+
 	// GetLastLogs returns the logs from the last run of an Installation.
 	GetLastLogs(ctx context.Context, namespace string, installation string) (logs string, hasLogs bool, err error)
 }
@@ -14,6 +14,7 @@ const (
 	CollectionRuns          = "runs"
 	CollectionResults       = "results"
 	CollectionOutputs       = "outputs"
+	CollectionLogs          = "logs"
 )
 
 var _ InstallationProvider = InstallationStore{}
@@ -58,6 +59,10 @@ func EnsureInstallationIndices(ctx context.Context, store Store) error {
 			{Collection: CollectionOutputs, Keys: []string{"resultId", "name"}, Unique: true},
 			// query most recent outputs by name for an installation
 			{Collection: CollectionOutputs, Keys: []string{"namespace", "installation", "name", "-resultId"}},
+			// query log chunks by run (porter logs show --follow)
+			{Collection: CollectionLogs, Keys: []string{"runId", "sequence"}, Unique: true},
+			// query log chunks by installation (delete)
+			{Collection: CollectionLogs, Keys: []string{"namespace", "installation"}},
 		},
 	}
 
@@ -336,6 +341,21 @@ func (s InstallationStore) GetLastLogs(ctx context.Context, namespace string, in
 	return string(out.Value), err == nil, err
 }
 
+// ListLogChunks returns the log chunks of a run with a sequence greater than
+// afterSequence, sorted in ascending order by sequence.
+func (s InstallationStore) ListLogChunks(ctx context.Context, runID string, afterSequence int) ([]LogChunk, error) {
+	var out []LogChunk
+	opts := FindOptions{
+		Sort: []string{"sequence"},
+		Filter: bson.M{
+			"runId":    runID,
+			"sequence": bson.M{"$gt": afterSequence},
+		},
+	}
+	err := s.store.Find(ctx, CollectionLogs, opts, &out)
+	return out, err
+}
+
 func (s InstallationStore) InsertInstallation(ctx context.Context, installation Installation) error {
 	installation.SchemaVersion = DefaultInstallationSchemaVersion
 	opts := InsertOptions{
@@ -365,6 +385,13 @@ func (s InstallationStore) InsertOutput(ctx context.Context, output Output) erro
 	return s.store.Insert(ctx, CollectionOutputs, opts)
 }
 
+func (s InstallationStore) InsertLogChunk(ctx context.Context, chunk LogChunk) error {
+	opts := InsertOptions{
+		Documents: []interface{}{chunk},
+	}
+	return s.store.Insert(ctx, CollectionLogs, opts)
+}
+
 func (s InstallationStore) UpdateInstallation(ctx context.Context, installation Installation) error {
 	installation.SchemaVersion = DefaultInstallationSchemaVersion
 	opts := UpdateOptions{
@@ -430,9 +457,51 @@ func (s InstallationStore) RemoveInstallation(ctx context.Context, namespace str
 		return err
 	}
 
+	// Delete log chunks
+	err = s.store.Remove(ctx, CollectionLogs, removeChildDocs)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (s InstallationStore) RemoveRun(ctx context.Context, id string) error {
+	err := s.store.Remove(ctx, CollectionRuns, RemoveOptions{Filter: bson.M{"_id": id}})
+	if err != nil {
+		return err
+	}
+
+	removeChildDocs := RemoveOptions{
+		Filter: bson.M{"runId": id},
+		All:    true,
+	}
+
+	// Delete results
+	err = s.store.Remove(ctx, CollectionResults, removeChildDocs)
+	if err != nil {
+		return err
+	}
+
+	// Delete outputs, which includes the logs from the run
+	err = s.store.Remove(ctx, CollectionOutputs, removeChildDocs)
+	if err != nil {
+		return err
+	}
+
+	// Delete log chunks
+	return s.store.Remove(ctx, CollectionLogs, removeChildDocs)
+}
+
+// RemoveLogChunks removes the log chunks of a run, once its logs have been saved as an output.
+func (s InstallationStore) RemoveLogChunks(ctx context.Context, runID string) error {
+	opts := RemoveOptions{
+		Filter: bson.M{"runId": runID},
+		All:    true,
+	}
+	return s.store.Remove(ctx, CollectionLogs, opts)
+}
+
 // EncryptionHandler is a function that transforms data by encrypting or decrypting it.
 type EncryptionHandler func([]byte) ([]byte, error)
 
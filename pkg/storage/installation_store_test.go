@@ -268,6 +268,69 @@ func TestInstallationStorageProvider_DeleteInstallation(t *testing.T) {
 	require.ErrorIs(t, err, ErrNotFound{})
 }
 
+func TestInstallationStorageProvider_RemoveRun(t *testing.T) {
+	cp := generateInstallationData(t)
+	defer cp.Close()
+
+	runs, _, err := cp.ListRuns(context.Background(), "dev", "foo")
+	require.NoError(t, err, "ListRuns failed")
+	upgrade := runs[1]
+
+	err = cp.RemoveRun(context.Background(), upgrade.ID)
+	require.NoError(t, err, "RemoveRun failed")
+
+	_, err = cp.GetRun(context.Background(), upgrade.ID)
+	require.ErrorIs(t, err, ErrNotFound{})
+
+	results, err := cp.ListResults(context.Background(), upgrade.ID)
+	require.NoError(t, err, "ListResults failed")
+	assert.Empty(t, results, "expected the results of the run to be deleted")
+
+	outputs, err := cp.GetOutputs(context.Background(), upgrade.ID)
+	require.NoError(t, err, "GetOutputs failed")
+	assert.Equal(t, 0, outputs.Len(), "expected the outputs of the run to be deleted")
+
+	runs, _, err = cp.ListRuns(context.Background(), "dev", "foo")
+	require.NoError(t, err, "ListRuns failed")
+	assert.Len(t, runs, 3, "expected only the upgrade run to be deleted")
+}
+
+func TestInstallationStorageProvider_LogChunks(t *testing.T) {
+	cp := generateInstallationData(t)
+	defer cp.Close()
+
+	runs, _, err := cp.ListRuns(context.Background(), "dev", "foo")
+	require.NoError(t, err, "ListRuns failed")
+	upgrade := runs[1]
+
+	for i, data := range []string{"first ", "second ", "third"} {
+		err = cp.InsertLogChunk(context.Background(), upgrade.NewLogChunk(i+1, []byte(data)))
+		require.NoError(t, err, "InsertLogChunk failed")
+	}
+
+	chunks, err := cp.ListLogChunks(context.Background(), upgrade.ID, 0)
+	require.NoError(t, err, "ListLogChunks failed")
+	require.Len(t, chunks, 3)
+	assert.Equal(t, "first ", string(chunks[0].Data))
+	assert.Equal(t, "dev", chunks[0].Namespace)
+	assert.Equal(t, "foo", chunks[0].Installation)
+
+	chunks, err = cp.ListLogChunks(context.Background(), upgrade.ID, 2)
+	require.NoError(t, err, "ListLogChunks failed")
+	require.Len(t, chunks, 1, "only the chunks after the specified sequence should be returned")
+	assert.Equal(t, 3, chunks[0].Sequence)
+
+	chunks, err = cp.ListLogChunks(context.Background(), runs[0].ID, 0)
+	require.NoError(t, err, "ListLogChunks failed")
+	assert.Empty(t, chunks, "only the chunks for the specified run should be returned")
+
+	err = cp.RemoveRun(context.Background(), upgrade.ID)
+	require.NoError(t, err, "RemoveRun failed")
+	chunks, err = cp.ListLogChunks(context.Background(), upgrade.ID, 0)
+	require.NoError(t, err, "ListLogChunks failed")
+	assert.Empty(t, chunks, "expected the log chunks of the run to be deleted")
+}
+
 func TestInstallationStorageProvider_Run(t *testing.T) {
 	cp := generateInstallationData(t)
 
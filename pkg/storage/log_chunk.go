@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+)
+
+var _ Document = LogChunk{}
+
+// LogChunk is a portion of the logs from a bundle run. Chunks are persisted
+// periodically while the run is in progress so that the logs can be followed
+// before the run completes and the logs are saved as an output.
+type LogChunk struct {
+	// SchemaVersion of the document.
+	SchemaVersion cnab.SchemaVersion `json:"schemaVersion"`
+
+	// ID of the log chunk.
+	ID string `json:"_id"`
+
+	// Created timestamp of the log chunk.
+	Created time.Time `json:"created"`
+
+	// Namespace of the installation.
+	Namespace string `json:"namespace"`
+
+	// Installation name that owns this log chunk.
+	Installation string `json:"installation"`
+
+	// RunID of the run that generated the logs.
+	RunID string `json:"runId"`
+
+	// Sequence orders the chunks of a run, starting at 1.
+	Sequence int `json:"sequence"`
+
+	// Data is the portion of the logs written since the previous chunk.
+	Data []byte `json:"data"`
+}
+
+func (c LogChunk) DefaultDocumentFilter() map[string]interface{} {
+	return map[string]interface{}{"_id": c.ID}
+}
+
+// NewLogChunk creates a chunk of the logs written by the run.
+func (r Run) NewLogChunk(sequence int, data []byte) LogChunk {
+	return LogChunk{
+		SchemaVersion: DefaultLogChunkSchemaVersion,
+		ID:            cnab.NewULID(),
+		Created:       time.Now(),
+		Namespace:     r.Namespace,
+		Installation:  r.Installation,
+		RunID:         r.ID,
+		Sequence:      sequence,
+		Data:          data,
+	}
+}
@@ -98,6 +98,16 @@ Once your data has been backed up, run the following command to perform the migr
 		}
 		m.initialized = true
 
+		// Databases created before log chunks were persisted only need the
+		// logs collection indices, record that they support the log chunk schema
+		if m.schema.ID != "" && m.schema.Logs == "" {
+			m.schema.Logs = storage.DefaultLogChunkSchemaVersion
+			err := m.store.Update(ctx, CollectionConfig, storage.UpdateOptions{Document: m.schema})
+			if err != nil {
+				return span.Error(fmt.Errorf("could not update the storage schema document: %w", err))
+			}
+		}
+
 		err := storage.EnsureInstallationIndices(ctx, m.store)
 		if err != nil {
 			return err
@@ -76,6 +76,27 @@ func TestManager_NoMigrationEmptyHome(t *testing.T) {
 	require.NoError(t, err, "List credentials failed")
 }
 
+func TestManager_Connect_RecordsLogChunkSchema(t *testing.T) {
+	c := config.NewTestConfig(t)
+	mgr := NewTestManager(c)
+	defer mgr.Close()
+
+	// Save a schema from before log chunks were persisted
+	schema := storage.NewSchema()
+	schema.Logs = ""
+	err := mgr.store.Update(context.Background(), CollectionConfig, storage.UpdateOptions{Document: schema, Upsert: true})
+	require.NoError(t, err, "Save schema failed")
+
+	claimStore := storage.NewInstallationStore(mgr)
+	_, err = claimStore.ListInstallations(context.Background(), storage.ListOptions{})
+	require.NoError(t, err, "a migration should not be required to use log chunks")
+
+	var saved storage.Schema
+	err = mgr.store.Get(context.Background(), CollectionConfig, storage.GetOptions{ID: "schema"}, &saved)
+	require.NoError(t, err)
+	assert.Equal(t, storage.DefaultLogChunkSchemaVersion, saved.Logs, "the log chunk schema should be recorded")
+}
+
 func TestInstallationStorage_HaltOnMigrationRequired(t *testing.T) {
 	t.Parallel()
 
@@ -95,11 +116,11 @@ func TestInstallationStorage_HaltOnMigrationRequired(t *testing.T) {
 
 		wantVersionComp := `Porter  uses the following database schema:
 
-storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"1.1.0"}
+storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"1.1.0", Logs:"1.0.0"}
 
 Your database schema is:
 
-storage.Schema{ID:"schema", Installations:"needs-migration", Credentials:"1.0.1", Parameters:"1.1.0"}`
+storage.Schema{ID:"schema", Installations:"needs-migration", Credentials:"1.0.1", Parameters:"1.1.0", Logs:"1.0.0"}`
 		assert.Contains(t, err.Error(), wantVersionComp, "the migration error should contain the current and expected db schema")
 	}
 
@@ -150,11 +171,11 @@ func TestCredentialStorage_HaltOnMigrationRequired(t *testing.T) {
 
 		wantVersionComp := `Porter  uses the following database schema:
 
-storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"1.1.0"}
+storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"1.1.0", Logs:"1.0.0"}
 
 Your database schema is:
 
-storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"needs-migration", Parameters:"1.1.0"}`
+storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"needs-migration", Parameters:"1.1.0", Logs:"1.0.0"}`
 		assert.Contains(t, err.Error(), wantVersionComp, "the migration error should contain the current and expected db schema")
 	}
 
@@ -209,11 +230,11 @@ func TestParameterStorage_HaltOnMigrationRequired(t *testing.T) {
 
 		wantVersionComp := `Porter  uses the following database schema:
 
-storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"1.1.0"}
+storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"1.1.0", Logs:"1.0.0"}
 
 Your database schema is:
 
-storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"needs-migration"}`
+storage.Schema{ID:"schema", Installations:"1.0.2", Credentials:"1.0.1", Parameters:"needs-migration", Logs:"1.0.0"}`
 		assert.Contains(t, err.Error(), wantVersionComp, "the migration error should contain the current and expected db schema")
 	}
 
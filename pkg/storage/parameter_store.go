@@ -4,12 +4,10 @@ import (
 	"context"
 	"fmt"
 	"slices"
-	"strings"
 
 	"get.porter.sh/porter/pkg/secrets"
 	hostSecrets "get.porter.sh/porter/pkg/secrets/plugins/host"
 	"get.porter.sh/porter/pkg/tracing"
-	"github.com/cnabio/cnab-go/secrets/host"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -57,6 +55,7 @@ func (s ParameterStore) GetDataStore() Store {
 
 func (s ParameterStore) ResolveAll(ctx context.Context, params ParameterSet, keys []string) (secrets.Set, error) {
 	resolvedParams := make(secrets.Set)
+	resolver := newSourceResolver(s.Secrets, s.HostSecrets, params.Parameters)
 	var resolveErrors error
 
 	for _, param := range params.Parameters {
@@ -64,13 +63,7 @@ func (s ParameterStore) ResolveAll(ctx context.Context, params ParameterSet, key
 			continue
 		}
 
-		var value string
-		var err error
-		if isHandledByHostPlugin(param.Source.Strategy) {
-			value, err = s.HostSecrets.Resolve(ctx, param.Source.Strategy, param.Source.Hint)
-		} else {
-			value, err = s.Secrets.Resolve(ctx, param.Source.Strategy, param.Source.Hint)
-		}
+		value, err := resolver.Resolve(ctx, param)
 		if err != nil {
 			resolveErrors = multierror.Append(resolveErrors, fmt.Errorf("unable to resolve parameter %s.%s from %s %s: %w", params.Name, param.Name, param.Source.Strategy, param.Source.Hint, err))
 		}
@@ -82,26 +75,10 @@ func (s ParameterStore) ResolveAll(ctx context.Context, params ParameterSet, key
 }
 
 func (s ParameterStore) Validate(ctx context.Context, params ParameterSet) error {
-	validSources := []string{secrets.SourceSecret, host.SourceValue, host.SourceEnv, host.SourcePath, host.SourceCommand}
 	var errors error
-
-	for _, cs := range params.Parameters {
-		valid := false
-		for _, validSource := range validSources {
-			if cs.Source.Strategy == validSource {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			errors = multierror.Append(errors, fmt.Errorf(
-				"%s is not a valid source. Valid sources are: %s",
-				cs.Source.Strategy,
-				strings.Join(validSources, ", "),
-			))
-		}
+	for _, err := range validateSources(params.Parameters) {
+		errors = multierror.Append(errors, err)
 	}
-
 	return errors
 }
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"get.porter.sh/porter/pkg/secrets"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -232,6 +233,35 @@ func TestParameterStorage_Validate(t *testing.T) {
 		require.NoError(t, err, "Validate did not return errors")
 	})
 
+	t.Run("composite sources", func(t *testing.T) {
+		s := ParameterStore{}
+
+		testParameterSet := NewParameterSet("", "myparams",
+			secrets.SourceMap{Name: "user", Source: secrets.Source{Strategy: "env", Hint: "USER"}},
+			secrets.SourceMap{Name: "config", Source: secrets.Source{Strategy: "secret", Hint: "config"}},
+			secrets.SourceMap{Name: "greeting", Source: secrets.Source{Strategy: "template", Hint: "hello {{user}}{{! ignored}}"}},
+			secrets.SourceMap{Name: "host", Source: secrets.Source{Strategy: "json", Hint: "config#host"}},
+			secrets.SourceMap{Name: "cert", Source: secrets.Source{Strategy: "base64", Hint: "config"}},
+		)
+
+		err := s.Validate(context.Background(), testParameterSet)
+		require.NoError(t, err, "Validate did not return errors")
+	})
+
+	t.Run("undefined references", func(t *testing.T) {
+		s := ParameterStore{}
+
+		testParameterSet := NewParameterSet("", "myparams",
+			secrets.SourceMap{Name: "greeting", Source: secrets.Source{Strategy: "template", Hint: "hello {{user}}"}},
+			secrets.SourceMap{Name: "host", Source: secrets.Source{Strategy: "json", Hint: "config"}},
+		)
+
+		err := s.Validate(context.Background(), testParameterSet)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "greeting uses user, which is not defined in the set")
+		assert.Contains(t, err.Error(), "invalid source for host: invalid json source \"config\", it must be in the format NAME#JSONPATH")
+	})
+
 	t.Run("invalid sources", func(t *testing.T) {
 		s := ParameterStore{}
 		testParameterSet := NewParameterSet("", "myparams",
@@ -252,3 +282,61 @@ func TestParameterStorage_Validate(t *testing.T) {
 		require.Error(t, err, "Validate returned errors")
 	})
 }
+
+func TestParameterStorage_ResolveAll_CompositeSources(t *testing.T) {
+	testParameterSet := NewParameterSet("", "myparamset",
+		secrets.SourceMap{Name: "username", Source: secrets.Source{Strategy: "value", Hint: "admin"}},
+		secrets.SourceMap{Name: "db-password", Source: secrets.Source{Strategy: "secret", Hint: "db-password"}},
+		secrets.SourceMap{Name: "connstr", Source: secrets.Source{Strategy: "template", Hint: "postgres://{{username}}:{{ db-password }}@db:5432/app"}},
+		secrets.SourceMap{Name: "db-config", Source: secrets.Source{Strategy: "secret", Hint: "db-config"}},
+		secrets.SourceMap{Name: "db-host", Source: secrets.Source{Strategy: "json", Hint: "db-config#$.host"}},
+		secrets.SourceMap{Name: "db-port", Source: secrets.Source{Strategy: "json", Hint: "db-config#port"}},
+		secrets.SourceMap{Name: "db-replicas", Source: secrets.Source{Strategy: "json", Hint: "db-config#replicas"}},
+		secrets.SourceMap{Name: "encoded-cert", Source: secrets.Source{Strategy: "value", Hint: "LS0tLS1CRUdJTi0tLS0t"}},
+		secrets.SourceMap{Name: "cert", Source: secrets.Source{Strategy: "base64", Hint: "encoded-cert"}},
+	)
+
+	paramStore := NewTestParameterProvider(t)
+	defer paramStore.Close()
+
+	paramStore.AddSecret("db-password", "p@ssword")
+	paramStore.AddSecret("db-config", `{"host": "db.example.com", "port": 5432, "replicas": ["db1", "db2"]}`)
+
+	t.Run("resolve composite sources", func(t *testing.T) {
+		keys := []string{"connstr", "db-host", "db-port", "db-replicas", "cert"}
+		resolved, err := paramStore.ResolveAll(context.Background(), testParameterSet, keys)
+		require.NoError(t, err)
+		require.Equal(t, secrets.Set{
+			"connstr":     "postgres://admin:p@ssword@db:5432/app",
+			"db-host":     "db.example.com",
+			"db-port":     "5432",
+			"db-replicas": `["db1","db2"]`,
+			"cert":        "-----BEGIN-----",
+		}, resolved, "only the requested keys should be returned")
+	})
+
+	t.Run("resolve composite source failure", func(t *testing.T) {
+		pset := NewParameterSet("", "myparamset",
+			secrets.SourceMap{Name: "token", Source: secrets.Source{Strategy: "secret", Hint: "missing-token"}},
+			secrets.SourceMap{Name: "header", Source: secrets.Source{Strategy: "template", Hint: "Bearer {{token}}"}},
+			secrets.SourceMap{Name: "undefined", Source: secrets.Source{Strategy: "base64", Hint: "missing"}},
+			secrets.SourceMap{Name: "invalid", Source: secrets.Source{Strategy: "json", Hint: "header#$.token"}},
+		)
+
+		_, err := paramStore.ResolveAll(context.Background(), pset, pset.Keys())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to resolve parameter myparamset.header from template Bearer {{token}}: unable to resolve token from secret missing-token: secret not found")
+		assert.Contains(t, err.Error(), "unable to resolve parameter myparamset.undefined from base64 missing: missing is not defined in the set")
+		assert.Contains(t, err.Error(), "unable to resolve parameter myparamset.invalid from json header#$.token: unable to resolve header")
+	})
+
+	t.Run("circular reference", func(t *testing.T) {
+		pset := NewParameterSet("", "myparamset",
+			secrets.SourceMap{Name: "a", Source: secrets.Source{Strategy: "template", Hint: "{{b}}"}},
+			secrets.SourceMap{Name: "b", Source: secrets.Source{Strategy: "base64", Hint: "a"}},
+		)
+
+		_, err := paramStore.ResolveAll(context.Background(), pset, []string{"a"})
+		require.ErrorContains(t, err, "a has a circular reference to itself")
+	})
+}
@@ -0,0 +1,4 @@
+// Package filesystem implements the plugins.StorageProtocol interface, storing
+// each document as a JSON file in PORTER_HOME/data so that Porter's data can be
+// inspected, diffed and committed to source control.
+package filesystem
@@ -0,0 +1,31 @@
+package filesystem
+
+import (
+	"fmt"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/storage/plugins"
+	"get.porter.sh/porter/pkg/storage/pluginstore"
+	"github.com/hashicorp/go-plugin"
+	"github.com/mitchellh/mapstructure"
+)
+
+// PluginKey is the identifier of the internal filesystem storage plugin.
+const PluginKey = plugins.PluginInterface + ".porter.filesystem"
+
+// PluginConfig supported by the filesystem plugin as defined in porter.yaml
+type PluginConfig struct {
+	// Path to the directory where documents are stored. Defaults to PORTER_HOME/data.
+	Path string `mapstructure:"path,omitempty"`
+}
+
+// NewPlugin creates an instance of the storage.porter.filesystem plugin
+func NewPlugin(c *config.Config, rawCfg interface{}) (plugin.Plugin, error) {
+	cfg := PluginConfig{}
+	if err := mapstructure.Decode(rawCfg, &cfg); err != nil {
+		return nil, fmt.Errorf("error reading plugin configuration: %w", err)
+	}
+
+	store := NewStore(c, cfg)
+	return pluginstore.NewPlugin(c.Context, store), nil
+}
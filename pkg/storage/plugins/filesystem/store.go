@@ -0,0 +1,584 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/storage/plugins"
+	"get.porter.sh/porter/pkg/storage/plugins/query"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var _ plugins.StorageProtocol = &Store{}
+
+const (
+	// DataDirectory is the name of the directory created in PORTER_HOME when a
+	// path is not configured.
+	DataDirectory = "data"
+
+	// GlobalNamespaceDirectory is the name of the directory that holds documents
+	// in the global namespace, or that are not namespaced.
+	GlobalNamespaceDirectory = "_global"
+
+	FileModeDirectory os.FileMode = 0700
+	FileModeWritable  os.FileMode = 0600
+)
+
+// Store is a storage plugin for porter that saves each document as a JSON file
+// in PORTER_HOME/data/COLLECTION/NAMESPACE/ID.json.
+//
+// Queries are evaluated in-process using the query package, after reading
+// every document in the collection, or in the namespace when the query
+// filters on a single namespace. It is intended for small installations, such
+// as air-gapped machines, or when the data should be kept in source control.
+type Store struct {
+	config  *config.Config
+	path    string
+	dataDir string
+
+	// uniqueIndices are the fields of the unique indices defined on each collection
+	uniqueIndices map[string][][]string
+
+	// lock serializes operations that modify documents
+	lock sync.Mutex
+}
+
+// entry is a document and the file in which it is stored.
+type entry struct {
+	path string
+	doc  query.Document
+}
+
+// NewStore creates a new storage engine that uses the filesystem.
+func NewStore(c *config.Config, cfg PluginConfig) *Store {
+	return &Store{
+		config:        c,
+		path:          cfg.Path,
+		uniqueIndices: make(map[string][][]string),
+	}
+}
+
+// Connect initializes the plugin for use.
+// The plugin itself is responsible for ensuring it was called.
+// Close is called automatically when the plugin is used by Porter.
+func (s *Store) Connect(ctx context.Context) error {
+	if s.dataDir != "" {
+		return nil
+	}
+
+	_, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+
+	dataDir, err := s.GetDataDir()
+	if err != nil {
+		return span.Error(err)
+	}
+	span.SetAttributes(attribute.String("path", dataDir))
+
+	if err := s.config.FileSystem.MkdirAll(dataDir, FileModeDirectory); err != nil && !errors.Is(err, os.ErrExist) {
+		return span.Error(fmt.Errorf("could not create the data directory %s: %w", dataDir, err))
+	}
+
+	span.Debugf("storing data in %s", dataDir)
+	s.dataDir = dataDir
+	return nil
+}
+
+// GetDataDir returns the directory where documents are stored.
+func (s *Store) GetDataDir() (string, error) {
+	if s.path != "" {
+		return s.path, nil
+	}
+
+	home, err := s.config.GetHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get the porter home directory: %w", err)
+	}
+	return filepath.Join(home, DataDirectory), nil
+}
+
+func (s *Store) Close() error {
+	s.dataDir = ""
+	return nil
+}
+
+// EnsureIndex records the unique indices so that they are enforced when
+// documents are written. Other indices are ignored since every query reads
+// the documents from disk.
+func (s *Store) EnsureIndex(ctx context.Context, opts plugins.EnsureIndexOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, index := range opts.Indices {
+		if !index.Unique {
+			continue
+		}
+
+		keys := make([]string, len(index.Keys))
+		for i, key := range index.Keys {
+			keys[i] = key.Key
+		}
+
+		exists := false
+		for _, existing := range s.uniqueIndices[index.Collection] {
+			if strings.Join(existing, ",") == strings.Join(keys, ",") {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			s.uniqueIndices[index.Collection] = append(s.uniqueIndices[index.Collection], keys)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) Aggregate(ctx context.Context, opts plugins.AggregateOptions) ([]bson.Raw, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	docs, err := s.findDocuments(opts.Collection, nil)
+	if err != nil {
+		return nil, span.Error(err)
+	}
+
+	load := func(collection string) ([]query.Document, error) {
+		return s.findDocuments(collection, nil)
+	}
+	results, err := query.Aggregate(docs, opts.Pipeline, load)
+	if err != nil {
+		return nil, span.Error(err)
+	}
+
+	raw, err := toRawList(results)
+	return raw, span.Error(err)
+}
+
+func (s *Store) Count(ctx context.Context, opts plugins.CountOptions) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return 0, err
+	}
+
+	docs, err := s.findDocuments(opts.Collection, opts.Filter)
+	if err != nil {
+		return 0, span.Error(err)
+	}
+	return int64(len(docs)), nil
+}
+
+func (s *Store) Find(ctx context.Context, opts plugins.FindOptions) ([]bson.Raw, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	docs, err := s.findDocuments(opts.Collection, opts.Filter)
+	if err != nil {
+		return nil, span.Error(err)
+	}
+
+	if err = query.Sort(docs, opts.Sort); err != nil {
+		return nil, span.Error(err)
+	}
+	docs = query.Page(docs, opts.Skip, opts.Limit)
+
+	if len(opts.Select) > 0 {
+		projection := query.Normalize(opts.Select).(map[string]interface{})
+		for i, doc := range docs {
+			if docs[i], err = query.Project(doc, projection); err != nil {
+				return nil, span.Error(err)
+			}
+		}
+	}
+
+	raw, err := toRawList(docs)
+	return raw, span.Error(err)
+}
+
+func (s *Store) Insert(ctx context.Context, opts plugins.InsertOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, err := s.find(opts.Collection, nil)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	// Validate every document before writing any of them
+	docs := make([]query.Document, len(opts.Documents))
+	for i, rawDoc := range opts.Documents {
+		doc := query.NormalizeDocument(rawDoc)
+
+		// Porter relies upon the generated _id of documents sorting in the order
+		// in which they were created, which a ULID provides
+		if _, ok := doc["_id"]; !ok {
+			doc["_id"] = cnab.NewULID()
+		}
+
+		if err = s.checkDuplicates(opts.Collection, existing, nil, doc); err != nil {
+			return span.Error(err)
+		}
+		existing = append(existing, entry{doc: doc})
+		docs[i] = doc
+	}
+
+	for _, doc := range docs {
+		if err = s.write(opts.Collection, "", doc); err != nil {
+			return span.Error(err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) Patch(ctx context.Context, opts plugins.PatchOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, err := s.find(opts.Collection, nil)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	match, err := s.findFirst(existing, opts.QueryDocument)
+	if err != nil || match == nil {
+		return span.Error(err)
+	}
+
+	doc, err := query.Update(match.doc, opts.Transformation)
+	if err != nil {
+		return span.Error(err)
+	}
+	if err = s.checkDuplicates(opts.Collection, existing, match, doc); err != nil {
+		return span.Error(err)
+	}
+	return span.Error(s.write(opts.Collection, match.path, doc))
+}
+
+func (s *Store) Remove(ctx context.Context, opts plugins.RemoveOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, err := s.find(opts.Collection, opts.Filter)
+	if err != nil {
+		return span.Error(err)
+	}
+	if !opts.All && len(entries) > 1 {
+		entries = entries[:1]
+	}
+
+	for _, e := range entries {
+		if err = s.config.FileSystem.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return span.Error(fmt.Errorf("could not remove document %s: %w", e.path, err))
+		}
+	}
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, opts plugins.UpdateOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, err := s.find(opts.Collection, nil)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	match, err := s.findFirst(existing, opts.Filter)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	doc := query.NormalizeDocument(opts.Document)
+	if match == nil {
+		if !opts.Upsert {
+			return nil
+		}
+
+		// Use the _id from the filter when the replacement document doesn't have one
+		if _, ok := doc["_id"]; !ok {
+			if id, ok := opts.Filter["_id"]; ok {
+				doc["_id"] = query.Normalize(id)
+			} else {
+				doc["_id"] = cnab.NewULID()
+			}
+		}
+		if err = s.checkDuplicates(opts.Collection, existing, nil, doc); err != nil {
+			return span.Error(err)
+		}
+		return span.Error(s.write(opts.Collection, "", doc))
+	}
+
+	existingID := match.doc["_id"]
+	if id, ok := doc["_id"]; ok && !query.Equal(id, existingID) {
+		return span.Errorf("cannot change the _id of a document in the %s collection", opts.Collection)
+	}
+	doc["_id"] = existingID
+
+	if err = s.checkDuplicates(opts.Collection, existing, match, doc); err != nil {
+		return span.Error(err)
+	}
+	return span.Error(s.write(opts.Collection, match.path, doc))
+}
+
+// findDocuments returns the documents in a collection that match the filter.
+func (s *Store) findDocuments(collection string, filter bson.M) ([]query.Document, error) {
+	entries, err := s.find(collection, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]query.Document, len(entries))
+	for i, e := range entries {
+		docs[i] = e.doc
+	}
+	return docs, nil
+}
+
+// find the documents in a collection that match the filter, sorted by _id so
+// that documents are returned in the order in which they were created.
+func (s *Store) find(collection string, filter bson.M) ([]entry, error) {
+	if collection == "" {
+		return nil, errors.New("no collection was specified")
+	}
+
+	// Only read the namespace directory when the filter selects a single namespace
+	collectionDir := filepath.Join(s.dataDir, escapePathSegment(collection))
+	searchDirs, err := s.listDirectories(collectionDir)
+	if err != nil {
+		return nil, err
+	}
+	if ns, ok := query.Normalize(filter["namespace"]).(string); ok {
+		searchDirs = []string{filepath.Join(collectionDir, namespaceDirectory(ns))}
+	}
+
+	var entries []entry
+	for _, dir := range searchDirs {
+		files, err := s.config.FileSystem.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("could not read the %s directory: %w", dir, err)
+		}
+
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+				continue
+			}
+
+			path := filepath.Join(dir, file.Name())
+			data, err := s.config.FileSystem.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("could not read document %s: %w", path, err)
+			}
+
+			var doc query.Document
+			if err = json.Unmarshal(data, &doc); err != nil {
+				return nil, fmt.Errorf("could not parse document %s: %w", path, err)
+			}
+
+			matched, err := query.Match(doc, filter)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				entries = append(entries, entry{path: path, doc: doc})
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return query.Compare(entries[i].doc["_id"], entries[j].doc["_id"]) < 0
+	})
+	return entries, nil
+}
+
+// findFirst returns the first document that matches the filter, or nil when
+// there isn't a match.
+func (s *Store) findFirst(entries []entry, filter bson.M) (*entry, error) {
+	for i, e := range entries {
+		matched, err := query.Match(e.doc, filter)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// listDirectories returns the subdirectories of the specified directory.
+func (s *Store) listDirectories(dir string) ([]string, error) {
+	files, err := s.config.FileSystem.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read the %s directory: %w", dir, err)
+	}
+
+	var dirs []string
+	for _, file := range files {
+		if file.IsDir() {
+			dirs = append(dirs, filepath.Join(dir, file.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// checkDuplicates validates that writing the document does not violate the
+// uniqueness of the _id or the unique indices defined on the collection.
+// The replaced document, if any, is excluded from the check.
+func (s *Store) checkDuplicates(collection string, entries []entry, replaced *entry, doc query.Document) error {
+	for i := range entries {
+		if replaced != nil && &entries[i] == replaced {
+			continue
+		}
+
+		existing := entries[i].doc
+		if query.Equal(existing["_id"], doc["_id"]) {
+			return fmt.Errorf("duplicate key error: a document with _id %v already exists in the %s collection", doc["_id"], collection)
+		}
+
+		for _, keys := range s.uniqueIndices[collection] {
+			duplicate := true
+			for _, key := range keys {
+				a, _ := query.GetPath(existing, key)
+				b, _ := query.GetPath(doc, key)
+				if !query.Equal(a, b) {
+					duplicate = false
+					break
+				}
+			}
+			if duplicate {
+				return fmt.Errorf("duplicate key error: a document with the same %s already exists in the %s collection", strings.Join(keys, ", "), collection)
+			}
+		}
+	}
+	return nil
+}
+
+// write saves a document to its file, removing the previous file when the
+// document was moved to another namespace. The file is written to a temporary
+// file first so that a failed write does not leave a partial document behind.
+func (s *Store) write(collection string, previousPath string, doc query.Document) error {
+	ns, _ := doc["namespace"].(string)
+	dir := filepath.Join(s.dataDir, escapePathSegment(collection), namespaceDirectory(ns))
+	path := filepath.Join(dir, escapePathSegment(documentKey(doc["_id"]))+".json")
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal document for the %s collection: %w", collection, err)
+	}
+	data = append(data, '\n')
+
+	if err = s.config.FileSystem.MkdirAll(dir, FileModeDirectory); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("could not create the directory %s: %w", dir, err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err = s.config.FileSystem.WriteFile(tmpPath, data, FileModeWritable); err != nil {
+		return fmt.Errorf("could not write document %s: %w", path, err)
+	}
+	if err = s.config.FileSystem.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("could not write document %s: %w", path, err)
+	}
+
+	if previousPath != "" && previousPath != path {
+		if err = s.config.FileSystem.Remove(previousPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not remove document %s: %w", previousPath, err)
+		}
+	}
+	return nil
+}
+
+// namespaceDirectory returns the name of the directory for a namespace.
+func namespaceDirectory(namespace string) string {
+	if namespace == "" {
+		return GlobalNamespaceDirectory
+	}
+	return escapePathSegment(namespace)
+}
+
+// escapePathSegment converts a value into a safe file or directory name.
+func escapePathSegment(value string) string {
+	switch value {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return url.PathEscape(value)
+}
+
+// documentKey converts a document _id to the name of its file.
+func documentKey(id interface{}) string {
+	if s, ok := id.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(id)
+	return string(data)
+}
+
+func toRawList(docs []query.Document) ([]bson.Raw, error) {
+	results := make([]bson.Raw, len(docs))
+	for i, doc := range docs {
+		data, err := bson.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert document to bson: %w", err)
+		}
+		results[i] = data
+	}
+	return results, nil
+}
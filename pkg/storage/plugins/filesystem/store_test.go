@@ -0,0 +1,200 @@
+package filesystem_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/storage/plugins/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newTestStore(t *testing.T) (*config.TestConfig, storage.Store) {
+	c := config.NewTestConfig(t)
+	impl := filesystem.NewStore(c.Config, filesystem.PluginConfig{})
+	t.Cleanup(func() { impl.Close() })
+
+	store := storage.NewPluginAdapter(impl)
+	require.NoError(t, storage.EnsureInstallationIndices(context.Background(), store))
+	return c, store
+}
+
+func TestStore_GetDataDir(t *testing.T) {
+	c := config.NewTestConfig(t)
+
+	s := filesystem.NewStore(c.Config, filesystem.PluginConfig{})
+	dir, err := s.GetDataDir()
+	require.NoError(t, err)
+	require.Equal(t, filepath.FromSlash("/home/myuser/.porter/data"), dir)
+
+	s = filesystem.NewStore(c.Config, filesystem.PluginConfig{Path: "/porter-data"})
+	dir, err = s.GetDataDir()
+	require.NoError(t, err)
+	require.Equal(t, "/porter-data", dir)
+}
+
+func TestStore_DocumentFiles(t *testing.T) {
+	ctx := context.Background()
+	c, store := newTestStore(t)
+	installations := storage.NewInstallationStore(store)
+
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql")))
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("", "mysql")))
+
+	devFiles, err := c.FileSystem.ReadDir("/home/myuser/.porter/data/installations/dev")
+	require.NoError(t, err)
+	require.Len(t, devFiles, 1, "expected the installation to be saved in the dev namespace directory")
+
+	globalFiles, err := c.FileSystem.ReadDir("/home/myuser/.porter/data/installations/_global")
+	require.NoError(t, err)
+	require.Len(t, globalFiles, 1, "expected the global installation to be saved in the _global directory")
+
+	data, err := c.FileSystem.ReadFile(filepath.Join("/home/myuser/.porter/data/installations/dev", devFiles[0].Name()))
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc), "the document should be saved as json")
+	assert.Equal(t, "mysql", doc["name"])
+	assert.Equal(t, doc["_id"].(string)+".json", devFiles[0].Name(), "the file should be named after the document _id")
+
+	t.Run("move namespace", func(t *testing.T) {
+		err := store.Patch(ctx, storage.CollectionInstallations, storage.PatchOptions{
+			QueryDocument:  bson.M{"namespace": "dev", "name": "mysql"},
+			Transformation: bson.D{{Key: "$set", Value: bson.M{"namespace": "test"}}},
+		})
+		require.NoError(t, err)
+
+		devFiles, err := c.FileSystem.ReadDir("/home/myuser/.porter/data/installations/dev")
+		require.NoError(t, err)
+		assert.Empty(t, devFiles, "the document should be removed from its previous namespace directory")
+
+		_, err = installations.GetInstallation(ctx, "test", "mysql")
+		require.NoError(t, err)
+	})
+}
+
+func TestStore_Installations(t *testing.T) {
+	ctx := context.Background()
+	_, store := newTestStore(t)
+	installations := storage.NewInstallationStore(store)
+
+	mysql := storage.NewInstallation("dev", "mysql")
+	mysql.Labels = map[string]string{"team": "data"}
+	mysql.CredentialSets = []string{"azure"}
+	require.NoError(t, installations.InsertInstallation(ctx, mysql))
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "wordpress")))
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("", "mysql")))
+
+	err := installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql"))
+	require.ErrorContains(t, err, "duplicate key error", "the unique index on namespace and name should be enforced")
+
+	t.Run("list", func(t *testing.T) {
+		results, err := installations.ListInstallations(ctx, storage.ListOptions{Namespace: "dev"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "mysql", results[0].Name)
+		assert.Equal(t, "wordpress", results[1].Name)
+
+		results, err = installations.ListInstallations(ctx, storage.ListOptions{Namespace: "*", Name: "sql"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "", results[0].Namespace, "the results should be sorted by namespace")
+
+		results, err = installations.ListInstallations(ctx, storage.ListOptions{Namespace: "*", Labels: map[string]string{"team": "data"}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+	})
+
+	t.Run("query array field", func(t *testing.T) {
+		results, err := installations.FindInstallations(ctx, storage.FindOptions{Filter: bson.M{"credentialSets": "azure"}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "mysql", results[0].Name)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mysql.Uninstalled = true
+		require.NoError(t, installations.UpdateInstallation(ctx, mysql))
+
+		got, err := installations.GetInstallation(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		assert.True(t, got.Uninstalled)
+		assert.Equal(t, mysql.Labels, got.Labels)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		require.NoError(t, installations.UpsertInstallation(ctx, storage.NewInstallation("test", "redis")))
+
+		count, err := store.Count(ctx, storage.CollectionInstallations, storage.CountOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := installations.GetInstallation(ctx, "dev", "missing")
+		require.ErrorIs(t, err, storage.ErrNotFound{})
+	})
+}
+
+func TestStore_Runs(t *testing.T) {
+	ctx := context.Background()
+	_, store := newTestStore(t)
+	installations := storage.NewInstallationStore(store)
+
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql")))
+
+	run1 := storage.NewRun("dev", "mysql")
+	run1.Action = cnab.ActionInstall
+	require.NoError(t, installations.InsertRun(ctx, run1))
+	result1 := run1.NewResult(cnab.StatusSucceeded)
+	require.NoError(t, installations.InsertResult(ctx, result1))
+	require.NoError(t, installations.InsertOutput(ctx, result1.NewOutput("connstr", []byte("v1"))))
+	require.NoError(t, installations.InsertOutput(ctx, result1.NewOutput("port", []byte("3306"))))
+
+	run2 := storage.NewRun("dev", "mysql")
+	run2.Action = cnab.ActionUpgrade
+	require.NoError(t, installations.InsertRun(ctx, run2))
+	result2 := run2.NewResult(cnab.StatusRunning)
+	require.NoError(t, installations.InsertResult(ctx, result2))
+	require.NoError(t, installations.InsertOutput(ctx, result2.NewOutput("connstr", []byte("v2"))))
+
+	t.Run("active runs", func(t *testing.T) {
+		runs, err := installations.GetActiveRuns(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, run2.ID, runs[0].ID)
+	})
+
+	t.Run("list runs", func(t *testing.T) {
+		runs, results, err := installations.ListRuns(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, run1.ID, runs[0].ID, "runs should be listed in the order they were created")
+		assert.Len(t, results[run1.ID], 1)
+	})
+
+	t.Run("last outputs", func(t *testing.T) {
+		outputs, err := installations.GetLastOutputs(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		require.Equal(t, 2, outputs.Len())
+
+		connstr, ok := outputs.GetByName("connstr")
+		require.True(t, ok)
+		assert.Equal(t, "v2", string(connstr.Value))
+	})
+
+	t.Run("remove installation", func(t *testing.T) {
+		require.NoError(t, installations.RemoveInstallation(ctx, "dev", "mysql"))
+
+		for _, collection := range []string{storage.CollectionRuns, storage.CollectionResults, storage.CollectionOutputs} {
+			count, err := store.Count(ctx, collection, storage.CountOptions{})
+			require.NoError(t, err)
+			assert.Zero(t, count, "expected all documents in %s to be removed", collection)
+		}
+	})
+}
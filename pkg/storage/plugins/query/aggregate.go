@@ -0,0 +1,314 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CollectionLoader returns all the documents in a collection.
+// It is used to resolve $lookup stages in an aggregation pipeline.
+type CollectionLoader func(collection string) ([]Document, error)
+
+// Aggregate executes an aggregation pipeline against a set of documents.
+// Supports the $match, $sort, $skip, $limit, $project, $lookup, $unwind and
+// $group stages.
+// See https://docs.mongodb.com/manual/reference/operator/aggregation-pipeline/
+func Aggregate(docs []Document, pipeline []bson.D, load CollectionLoader) ([]Document, error) {
+	var err error
+	for _, stage := range pipeline {
+		for _, step := range stage {
+			docs, err = executeStage(docs, step, load)
+			if err != nil {
+				return nil, fmt.Errorf("error executing the %s pipeline stage: %w", step.Key, err)
+			}
+		}
+	}
+	return docs, nil
+}
+
+func executeStage(docs []Document, step bson.E, load CollectionLoader) ([]Document, error) {
+	switch step.Key {
+	case "$match":
+		filter, ok := Normalize(step.Value).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the stage requires a query document")
+		}
+		return Filter(docs, filter)
+	case "$sort":
+		keys, err := toOrderedMap(step.Value)
+		if err != nil {
+			return nil, err
+		}
+		return docs, Sort(docs, keys)
+	case "$skip", "$limit":
+		n, ok := Normalize(step.Value).(float64)
+		if !ok {
+			return nil, fmt.Errorf("the stage requires a number")
+		}
+		if step.Key == "$skip" {
+			return Page(docs, int64(n), 0), nil
+		}
+		return Page(docs, 0, int64(n)), nil
+	case "$project":
+		projection, ok := Normalize(step.Value).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the stage requires a projection document")
+		}
+		results := make([]Document, len(docs))
+		for i, doc := range docs {
+			projected, err := Project(doc, projection)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = projected
+		}
+		return results, nil
+	case "$lookup":
+		return lookup(docs, step.Value, load)
+	case "$unwind":
+		return unwind(docs, step.Value)
+	case "$group":
+		return group(docs, step.Value)
+	default:
+		return nil, fmt.Errorf("unsupported pipeline stage")
+	}
+}
+
+// toOrderedMap converts a stage's value to an ordered document. When the order
+// was lost, e.g. a bson.M was used, the keys are sorted so that the results are
+// deterministic.
+func toOrderedMap(value interface{}) (bson.D, error) {
+	switch t := value.(type) {
+	case bson.D:
+		return t, nil
+	case []interface{}:
+		// bson.D represented as a list of single key documents
+		result := make(bson.D, 0, len(t))
+		for _, item := range t {
+			m, ok := Normalize(item).(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected a document but got %T", item)
+			}
+			for _, k := range sortedKeys(m) {
+				result = append(result, bson.E{Key: k, Value: m[k]})
+			}
+		}
+		return result, nil
+	default:
+		m, ok := Normalize(value).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a document but got %T", value)
+		}
+		result := make(bson.D, 0, len(m))
+		for _, k := range sortedKeys(m) {
+			result = append(result, bson.E{Key: k, Value: m[k]})
+		}
+		return result, nil
+	}
+}
+
+func lookup(docs []Document, value interface{}, load CollectionLoader) ([]Document, error) {
+	spec, ok := Normalize(value).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the stage requires a document")
+	}
+
+	from, _ := spec["from"].(string)
+	localField, _ := spec["localField"].(string)
+	foreignField, _ := spec["foreignField"].(string)
+	as, _ := spec["as"].(string)
+	if from == "" || localField == "" || foreignField == "" || as == "" {
+		return nil, fmt.Errorf("from, localField, foreignField and as are required")
+	}
+	if load == nil {
+		return nil, fmt.Errorf("cannot load the %s collection", from)
+	}
+
+	foreignDocs, err := load(from)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Document, len(docs))
+	for i, doc := range docs {
+		localValues, found := lookupPath(doc, strings.Split(localField, "."))
+		if !found {
+			localValues = []interface{}{nil}
+		}
+
+		var joined []interface{}
+		for _, foreignDoc := range foreignDocs {
+			foreignValues, foreignFound := lookupPath(foreignDoc, strings.Split(foreignField, "."))
+			for _, localValue := range expandArrays(localValues) {
+				if matchEq(foreignValues, foreignFound, localValue) {
+					joined = append(joined, CloneDocument(foreignDoc))
+					break
+				}
+			}
+		}
+
+		result := CloneDocument(doc)
+		if joined == nil {
+			joined = []interface{}{}
+		}
+		SetPath(result, as, joined)
+		results[i] = result
+	}
+	return results, nil
+}
+
+func unwind(docs []Document, value interface{}) ([]Document, error) {
+	var path string
+	preserveEmpty := false
+	switch t := Normalize(value).(type) {
+	case string:
+		path = t
+	case map[string]interface{}:
+		path, _ = t["path"].(string)
+		preserveEmpty, _ = t["preserveNullAndEmptyArrays"].(bool)
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("the path must be a field path prefixed with $")
+	}
+	path = strings.TrimPrefix(path, "$")
+
+	var results []Document
+	for _, doc := range docs {
+		current, _ := GetPath(doc, path)
+		list, isList := current.([]interface{})
+		switch {
+		case isList && len(list) > 0:
+			for _, item := range list {
+				result := CloneDocument(doc)
+				SetPath(result, path, clone(item))
+				results = append(results, result)
+			}
+		case isList || current == nil:
+			if preserveEmpty {
+				result := CloneDocument(doc)
+				UnsetPath(result, path)
+				results = append(results, result)
+			}
+		default:
+			results = append(results, doc)
+		}
+	}
+	return results, nil
+}
+
+type accumulator struct {
+	field    string
+	operator string
+	expr     interface{}
+}
+
+func group(docs []Document, value interface{}) ([]Document, error) {
+	spec, err := toOrderedMap(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var idExpr interface{}
+	var accumulators []accumulator
+	for _, e := range spec {
+		if e.Key == "_id" {
+			idExpr = Normalize(e.Value)
+			continue
+		}
+
+		acc, ok := Normalize(e.Value).(map[string]interface{})
+		if !ok || len(acc) != 1 {
+			return nil, fmt.Errorf("the %s field must specify a single accumulator", e.Key)
+		}
+		for op, expr := range acc {
+			accumulators = append(accumulators, accumulator{field: e.Key, operator: op, expr: expr})
+		}
+	}
+
+	// Keep the groups in the order in which they were first seen
+	var groupKeys []string
+	groups := map[string]Document{}
+	for _, doc := range docs {
+		id := evaluateExpression(doc, idExpr)
+		key, err := json.Marshal(id)
+		if err != nil {
+			return nil, err
+		}
+
+		g, ok := groups[string(key)]
+		if !ok {
+			g = Document{"_id": id}
+			groups[string(key)] = g
+			groupKeys = append(groupKeys, string(key))
+		}
+
+		for _, acc := range accumulators {
+			v := evaluateExpression(doc, acc.expr)
+			current, seen := g[acc.field]
+			switch acc.operator {
+			case "$first":
+				if !seen {
+					g[acc.field] = v
+				}
+			case "$last":
+				g[acc.field] = v
+			case "$push", "$addToSet":
+				list, _ := current.([]interface{})
+				if acc.operator == "$addToSet" && matchEq(list, true, v) {
+					continue
+				}
+				g[acc.field] = append(list, v)
+			case "$sum":
+				n, _ := current.(float64)
+				if inc, ok := v.(float64); ok {
+					n += inc
+				}
+				g[acc.field] = n
+			case "$min", "$max":
+				if v == nil {
+					continue
+				}
+				c := Compare(v, current)
+				if !seen || current == nil || (acc.operator == "$min" && c < 0) || (acc.operator == "$max" && c > 0) {
+					g[acc.field] = v
+				}
+			default:
+				return nil, fmt.Errorf("unsupported accumulator %s", acc.operator)
+			}
+		}
+	}
+
+	results := make([]Document, len(groupKeys))
+	for i, key := range groupKeys {
+		results[i] = groups[key]
+	}
+	return results, nil
+}
+
+// evaluateExpression resolves an aggregation expression against a document,
+// where "$$ROOT" is the entire document, "$field" is the value of a field, a
+// document is evaluated for each field, and anything else is a literal.
+func evaluateExpression(doc Document, expr interface{}) interface{} {
+	switch t := expr.(type) {
+	case string:
+		if t == "$$ROOT" {
+			return CloneDocument(doc)
+		}
+		if strings.HasPrefix(t, "$") {
+			v, _ := GetPath(doc, strings.TrimPrefix(t, "$"))
+			return clone(v)
+		}
+		return t
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for _, k := range sortedKeys(t) {
+			result[k] = evaluateExpression(doc, t[k])
+		}
+		return result
+	default:
+		return t
+	}
+}
@@ -0,0 +1,5 @@
+// Package query evaluates the subset of MongoDB query, projection, sort, update
+// and aggregation documents that Porter sends to its storage plugins, against
+// documents held in memory. It is used by the storage plugins that are not
+// backed by a MongoDB server, such as the sqlite plugin.
+package query
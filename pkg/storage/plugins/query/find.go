@@ -0,0 +1,183 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Filter returns the documents that match the query filter document.
+func Filter(docs []Document, filter map[string]interface{}) ([]Document, error) {
+	results := make([]Document, 0, len(docs))
+	for _, doc := range docs {
+		matched, err := Match(doc, filter)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			results = append(results, doc)
+		}
+	}
+	return results, nil
+}
+
+// Sort orders documents in place using a sort document, such as
+// {{Key: "namespace", Value: 1}, {Key: "_id", Value: -1}}.
+func Sort(docs []Document, keys bson.D) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	directions := make([]int, len(keys))
+	for i, key := range keys {
+		direction, ok := Normalize(key.Value).(float64)
+		if !ok || (direction != 1 && direction != -1) {
+			return fmt.Errorf("invalid sort direction for %s: %v", key.Key, key.Value)
+		}
+		directions[i] = int(direction)
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		for k, key := range keys {
+			a, _ := GetPath(docs[i], key.Key)
+			b, _ := GetPath(docs[j], key.Key)
+			if c := Compare(a, b); c != 0 {
+				return c*directions[k] < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// Page applies skip and limit to a set of documents.
+func Page(docs []Document, skip int64, limit int64) []Document {
+	if skip > 0 {
+		if skip >= int64(len(docs)) {
+			return []Document{}
+		}
+		docs = docs[skip:]
+	}
+	if limit > 0 && limit < int64(len(docs)) {
+		docs = docs[:limit]
+	}
+	return docs
+}
+
+// Project returns a copy of the document with only the selected fields,
+// following the rules of a MongoDB projection document: either a list of fields
+// to include, e.g. {"name": 1}, or to exclude, e.g. {"results": 0}.
+// The _id field is included unless it is explicitly excluded.
+// See https://docs.mongodb.com/manual/tutorial/project-fields-from-query-results/
+func Project(doc Document, projection map[string]interface{}) (Document, error) {
+	if len(projection) == 0 {
+		return doc, nil
+	}
+
+	include := false
+	for field, value := range projection {
+		if field == "_id" {
+			continue
+		}
+		if isTruthy(value) {
+			include = true
+		} else if include {
+			return nil, fmt.Errorf("cannot mix inclusion and exclusion in a projection")
+		}
+	}
+
+	if !include {
+		result := CloneDocument(doc)
+		for field, value := range projection {
+			if !isTruthy(value) {
+				UnsetPath(result, field)
+			}
+		}
+		return result, nil
+	}
+
+	result := Document{}
+	if idValue, ok := projection["_id"]; !ok || isTruthy(idValue) {
+		if id, ok := doc["_id"]; ok {
+			result["_id"] = id
+		}
+	}
+	for field, value := range projection {
+		if field == "_id" || !isTruthy(value) {
+			continue
+		}
+		if v, ok := GetPath(doc, field); ok {
+			SetPath(result, field, clone(v))
+		}
+	}
+	return result, nil
+}
+
+func isTruthy(value interface{}) bool {
+	switch t := Normalize(value).(type) {
+	case bool:
+		return t
+	case float64:
+		return t != 0
+	default:
+		return value != nil
+	}
+}
+
+// Update applies the update operators in a transformation document, e.g.
+// {"$set": {"status.installed": true}}, to a copy of the document.
+// Supports $set, $unset, $inc, $push and $addToSet.
+func Update(doc Document, transformation bson.D) (Document, error) {
+	result := CloneDocument(doc)
+	for _, op := range transformation {
+		fields, ok := Normalize(op.Value).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s requires a document", op.Key)
+		}
+
+		for path, value := range fields {
+			if path == "_id" || strings.HasPrefix(path, "_id.") {
+				return nil, fmt.Errorf("cannot modify the _id field")
+			}
+
+			switch op.Key {
+			case "$set":
+				SetPath(result, path, value)
+			case "$unset":
+				UnsetPath(result, path)
+			case "$inc":
+				inc, ok := value.(float64)
+				if !ok {
+					return nil, fmt.Errorf("$inc requires a number for %s", path)
+				}
+				current, _ := GetPath(result, path)
+				if current == nil {
+					current = float64(0)
+				}
+				n, ok := current.(float64)
+				if !ok {
+					return nil, fmt.Errorf("cannot apply $inc to the non-numeric field %s", path)
+				}
+				SetPath(result, path, n+inc)
+			case "$push", "$addToSet":
+				current, _ := GetPath(result, path)
+				if current == nil {
+					current = []interface{}{}
+				}
+				list, ok := current.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("cannot apply %s to the non-array field %s", op.Key, path)
+				}
+				if op.Key == "$addToSet" && matchEq(list, true, value) {
+					continue
+				}
+				SetPath(result, path, append(list, value))
+			default:
+				return nil, fmt.Errorf("unsupported update operator %s", op.Key)
+			}
+		}
+	}
+	return result, nil
+}
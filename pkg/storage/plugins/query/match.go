@@ -0,0 +1,261 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Match determines if a document matches a query filter document.
+// The filter supports the logical operators $and, $or, $nor and the field
+// operators $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $exists, $regex, $size
+// and $not.
+// See https://docs.mongodb.com/manual/core/document/#std-label-document-query-filter
+func Match(doc Document, filter map[string]interface{}) (bool, error) {
+	for key, cond := range filter {
+		var matched bool
+		var err error
+
+		switch key {
+		case "$and", "$or", "$nor":
+			matched, err = matchLogical(doc, key, cond)
+		default:
+			if strings.HasPrefix(key, "$") {
+				return false, fmt.Errorf("unsupported query operator %s", key)
+			}
+			matched, err = matchField(doc, key, Normalize(cond))
+		}
+
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchLogical(doc Document, op string, cond interface{}) (bool, error) {
+	clauses, ok := Normalize(cond).([]interface{})
+	if !ok {
+		return false, fmt.Errorf("%s must be an array of query documents", op)
+	}
+
+	for _, clause := range clauses {
+		filter, ok := clause.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("%s must be an array of query documents", op)
+		}
+
+		matched, err := Match(doc, filter)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case op == "$and" && !matched:
+			return false, nil
+		case op == "$or" && matched:
+			return true, nil
+		case op == "$nor" && matched:
+			return false, nil
+		}
+	}
+
+	// $and and $nor match when no clause short-circuited, $or requires at least one match
+	return op != "$or", nil
+}
+
+// isOperatorExpression determines if a value is a set of operators such as
+// {"$ne": true}, instead of a literal document to compare against.
+func isOperatorExpression(cond interface{}) (map[string]interface{}, bool) {
+	ops, ok := cond.(map[string]interface{})
+	if !ok || len(ops) == 0 {
+		return nil, false
+	}
+	for key := range ops {
+		if !strings.HasPrefix(key, "$") {
+			return nil, false
+		}
+	}
+	return ops, true
+}
+
+func matchField(doc Document, path string, cond interface{}) (bool, error) {
+	values, found := lookupPath(doc, strings.Split(path, "."))
+
+	ops, ok := isOperatorExpression(cond)
+	if !ok {
+		return matchEq(values, found, cond), nil
+	}
+
+	return matchOperators(values, found, ops)
+}
+
+func matchOperators(values []interface{}, found bool, ops map[string]interface{}) (bool, error) {
+	for op, operand := range ops {
+		var matched bool
+		switch op {
+		case "$eq":
+			matched = matchEq(values, found, operand)
+		case "$ne":
+			matched = !matchEq(values, found, operand)
+		case "$gt", "$gte", "$lt", "$lte":
+			matched = matchComparison(values, op, operand)
+		case "$in", "$nin":
+			list, ok := operand.([]interface{})
+			if !ok {
+				return false, fmt.Errorf("%s requires an array", op)
+			}
+			matched = false
+			for _, item := range list {
+				if matchEq(values, found, item) {
+					matched = true
+					break
+				}
+			}
+			if op == "$nin" {
+				matched = !matched
+			}
+		case "$exists":
+			want, ok := operand.(bool)
+			if !ok {
+				want = operand != nil && operand != float64(0)
+			}
+			matched = found == want
+		case "$regex":
+			options, _ := ops["$options"].(string)
+			re, err := compileRegex(operand, options)
+			if err != nil {
+				return false, err
+			}
+			for _, value := range expandArrays(values) {
+				if s, ok := value.(string); ok && re.MatchString(s) {
+					matched = true
+					break
+				}
+			}
+		case "$options":
+			// Handled by $regex
+			continue
+		case "$size":
+			size, ok := operand.(float64)
+			if !ok {
+				return false, fmt.Errorf("$size requires a number")
+			}
+			for _, value := range values {
+				if list, ok := value.([]interface{}); ok && float64(len(list)) == size {
+					matched = true
+					break
+				}
+			}
+		case "$not":
+			var err error
+			if notOps, ok := isOperatorExpression(operand); ok {
+				matched, err = matchOperators(values, found, notOps)
+			} else if _, isRegex := operand.(string); isRegex {
+				matched, err = matchOperators(values, found, map[string]interface{}{"$regex": operand})
+			} else {
+				err = fmt.Errorf("$not requires an operator expression")
+			}
+			if err != nil {
+				return false, err
+			}
+			matched = !matched
+		default:
+			return false, fmt.Errorf("unsupported query operator %s", op)
+		}
+
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// expandArrays returns the set of values that a condition is compared against,
+// which includes both any arrays and their elements.
+func expandArrays(values []interface{}) []interface{} {
+	expanded := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		expanded = append(expanded, value)
+		if list, ok := value.([]interface{}); ok {
+			expanded = append(expanded, list...)
+		}
+	}
+	return expanded
+}
+
+func matchEq(values []interface{}, found bool, want interface{}) bool {
+	// {field: null} matches documents where the field is null or missing
+	if want == nil && !found {
+		return true
+	}
+
+	for _, value := range expandArrays(values) {
+		if Equal(value, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchComparison(values []interface{}, op string, operand interface{}) bool {
+	for _, value := range expandArrays(values) {
+		// Comparison operators only match values of the same type
+		if typeOrder(value) != typeOrder(operand) {
+			continue
+		}
+
+		c := Compare(value, operand)
+		switch op {
+		case "$gt":
+			if c > 0 {
+				return true
+			}
+		case "$gte":
+			if c >= 0 {
+				return true
+			}
+		case "$lt":
+			if c < 0 {
+				return true
+			}
+		case "$lte":
+			if c <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compileRegex(pattern interface{}, options string) (*regexp.Regexp, error) {
+	if re, ok := pattern.(map[string]interface{}); ok {
+		// A primitive.Regex that was normalized
+		pattern = re["$regex"]
+		if opts, ok := re["$options"].(string); ok && options == "" {
+			options = opts
+		}
+	}
+
+	expr, ok := pattern.(string)
+	if !ok {
+		return nil, fmt.Errorf("$regex requires a string")
+	}
+
+	var flags string
+	for _, opt := range options {
+		switch opt {
+		case 'i', 'm', 's':
+			flags += string(opt)
+		}
+	}
+	if flags != "" {
+		expr = "(?" + flags + ")" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $regex %q: %w", expr, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,176 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func testDocuments() []Document {
+	return []Document{
+		NormalizeDocument(bson.M{"_id": "1", "namespace": "", "name": "mysql", "credentialSets": []string{"azure"}, "labels": bson.M{"team": "red"}}),
+		NormalizeDocument(bson.M{"_id": "2", "namespace": "dev", "name": "wordpress", "uninstalled": true, "credentialSets": []string{"azure", "aws"}}),
+		NormalizeDocument(bson.M{"_id": "3", "namespace": "dev", "name": "mysql", "revision": 3}),
+	}
+}
+
+func ids(docs []Document) []interface{} {
+	result := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		result[i] = doc["_id"]
+	}
+	return result
+}
+
+func TestMatch(t *testing.T) {
+	testcases := []struct {
+		name   string
+		filter bson.M
+		want   []interface{}
+	}{
+		{name: "empty", filter: bson.M{}, want: []interface{}{"1", "2", "3"}},
+		{name: "equality", filter: bson.M{"namespace": "dev", "name": "mysql"}, want: []interface{}{"3"}},
+		{name: "array contains", filter: bson.M{"credentialSets": "aws"}, want: []interface{}{"2"}},
+		{name: "nested field", filter: bson.M{"labels.team": "red"}, want: []interface{}{"1"}},
+		{name: "$ne missing field", filter: bson.M{"uninstalled": bson.M{"$ne": true}}, want: []interface{}{"1", "3"}},
+		{name: "$or", filter: bson.M{"name": "mysql", "$or": []bson.M{{"namespace": ""}, {"namespace": "test"}}}, want: []interface{}{"1"}},
+		{name: "$in", filter: bson.M{"name": bson.M{"$in": []string{"wordpress", "redis"}}}, want: []interface{}{"2"}},
+		{name: "$nin", filter: bson.M{"credentialSets": bson.M{"$nin": []string{"azure"}}}, want: []interface{}{"3"}},
+		{name: "$regex", filter: bson.M{"name": bson.M{"$regex": "sq"}}, want: []interface{}{"1", "3"}},
+		{name: "$exists", filter: bson.M{"revision": bson.M{"$exists": true}}, want: []interface{}{"3"}},
+		{name: "$gte", filter: bson.M{"revision": bson.M{"$gte": 2}}, want: []interface{}{"3"}},
+		{name: "null matches missing", filter: bson.M{"labels": nil}, want: []interface{}{"2", "3"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := Filter(testDocuments(), tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ids(results))
+		})
+	}
+
+	t.Run("unsupported operator", func(t *testing.T) {
+		_, err := Filter(testDocuments(), bson.M{"name": bson.M{"$where": "true"}})
+		require.ErrorContains(t, err, "unsupported query operator $where")
+	})
+}
+
+func TestSort(t *testing.T) {
+	docs := testDocuments()
+	err := Sort(docs, bson.D{{Key: "name", Value: 1}, {Key: "namespace", Value: -1}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"3", "1", "2"}, ids(docs))
+
+	assert.Equal(t, []interface{}{"1"}, ids(Page(docs, 1, 1)))
+	assert.Empty(t, Page(docs, 5, 0))
+}
+
+func TestNormalize_Times(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	base := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		base.Add(120 * time.Millisecond),
+		base.Add(100 * time.Millisecond),
+		base.In(est),
+		base.Add(time.Hour).In(est),
+	}
+
+	docs := make([]Document, len(times))
+	for i, tm := range times {
+		docs[i] = NormalizeDocument(bson.M{"_id": i, "created": tm})
+	}
+	assert.Equal(t, "2024-01-02T10:00:00.100000000Z", docs[1]["created"])
+
+	require.NoError(t, Sort(docs, bson.D{{Key: "created", Value: 1}}))
+	assert.Equal(t, []interface{}{2.0, 1.0, 0.0, 3.0}, ids(docs))
+
+	matches, err := Filter(docs, bson.M{"created": bson.M{"$gt": base.Add(110 * time.Millisecond)}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{0.0, 3.0}, ids(matches))
+}
+
+func TestProject(t *testing.T) {
+	doc := testDocuments()[0]
+
+	excluded, err := Project(doc, bson.M{"labels": 0, "credentialSets": 0})
+	require.NoError(t, err)
+	assert.Equal(t, Document{"_id": "1", "namespace": "", "name": "mysql"}, excluded)
+	assert.Contains(t, doc, "labels", "the original document should not be modified")
+
+	included, err := Project(doc, bson.M{"name": 1, "labels.team": 1})
+	require.NoError(t, err)
+	assert.Equal(t, Document{"_id": "1", "name": "mysql", "labels": map[string]interface{}{"team": "red"}}, included)
+}
+
+func TestUpdate(t *testing.T) {
+	doc := testDocuments()[2]
+
+	updated, err := Update(doc, bson.D{
+		{Key: "$set", Value: bson.M{"status.installed": true}},
+		{Key: "$inc", Value: bson.M{"revision": 1}},
+		{Key: "$push", Value: bson.M{"credentialSets": "aws"}},
+		{Key: "$unset", Value: bson.M{"namespace": ""}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Document{
+		"_id":            "3",
+		"name":           "mysql",
+		"revision":       float64(4),
+		"status":         map[string]interface{}{"installed": true},
+		"credentialSets": []interface{}{"aws"},
+	}, updated)
+
+	_, err = Update(doc, bson.D{{Key: "$set", Value: bson.M{"_id": "4"}}})
+	require.ErrorContains(t, err, "cannot modify the _id field")
+}
+
+func TestAggregate(t *testing.T) {
+	runs := []Document{
+		NormalizeDocument(bson.M{"_id": "run1", "installation": "mysql"}),
+		NormalizeDocument(bson.M{"_id": "run2", "installation": "mysql"}),
+		NormalizeDocument(bson.M{"_id": "run3", "installation": "mysql"}),
+	}
+	results := []Document{
+		NormalizeDocument(bson.M{"_id": "result1", "runId": "run1", "status": "running"}),
+		NormalizeDocument(bson.M{"_id": "result2", "runId": "run1", "status": "succeeded"}),
+		NormalizeDocument(bson.M{"_id": "result3", "runId": "run2", "status": "running"}),
+	}
+	load := func(collection string) ([]Document, error) {
+		require.Equal(t, "results", collection)
+		return results, nil
+	}
+
+	t.Run("lookup", func(t *testing.T) {
+		pipeline := []bson.D{
+			{{Key: "$match", Value: bson.M{"installation": "mysql"}}},
+			{{Key: "$lookup", Value: bson.M{"from": "results", "localField": "_id", "foreignField": "runId", "as": "results"}}},
+			{{Key: "$match", Value: bson.M{"results.status": bson.M{"$nin": []string{"succeeded", "failed"}}}}},
+			{{Key: "$project", Value: bson.M{"results": 0}}},
+		}
+		active, err := Aggregate(runs, pipeline, load)
+		require.NoError(t, err)
+		assert.Equal(t, []Document{{"_id": "run2", "installation": "mysql"}, {"_id": "run3", "installation": "mysql"}}, active)
+	})
+
+	t.Run("group", func(t *testing.T) {
+		pipeline := []bson.D{
+			{{Key: "$sort", Value: bson.D{{Key: "runId", Value: 1}, {Key: "_id", Value: -1}}}},
+			{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: "$runId"},
+				{Key: "last", Value: bson.M{"$first": "$$ROOT"}},
+				{Key: "count", Value: bson.M{"$sum": 1}},
+			}}},
+		}
+		grouped, err := Aggregate(results, pipeline, nil)
+		require.NoError(t, err)
+		require.Len(t, grouped, 2)
+		assert.Equal(t, "run1", grouped[0]["_id"])
+		assert.Equal(t, "result2", grouped[0]["last"].(map[string]interface{})["_id"])
+		assert.Equal(t, float64(2), grouped[0]["count"])
+		assert.Equal(t, "run2", grouped[1]["_id"])
+	})
+}
@@ -0,0 +1,350 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimeLayout is the format of the times in a normalized document. Times are
+// converted to UTC and always have nine fractional digits, so that comparing
+// the formatted times as strings orders them chronologically.
+const TimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// Document is the generic representation of a stored document, using the same
+// types as encoding/json: map[string]interface{}, []interface{}, string,
+// float64, bool and nil.
+type Document = map[string]interface{}
+
+// Normalize converts a value built from bson types, or the typed values
+// that come out of a round trip through the plugin protocol, into the generic
+// representation used by encoding/json so that values can be compared
+// regardless of how they were constructed.
+//
+// Ordered maps (bson.D) are converted to plain maps, so only use Normalize on
+// values where the order of the keys is not significant.
+func Normalize(src interface{}) interface{} {
+	switch t := src.(type) {
+	case nil:
+		return nil
+	case string, bool, float64:
+		return t
+	case map[string]interface{}:
+		dest := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			dest[k] = Normalize(v)
+		}
+		return dest
+	case bson.M:
+		return Normalize(map[string]interface{}(t))
+	case bson.D:
+		dest := make(map[string]interface{}, len(t))
+		for _, e := range t {
+			dest[e.Key] = Normalize(e.Value)
+		}
+		return dest
+	case bson.E:
+		return map[string]interface{}{t.Key: Normalize(t.Value)}
+	case bson.A:
+		return Normalize([]interface{}(t))
+	case []interface{}:
+		dest := make([]interface{}, len(t))
+		for i, v := range t {
+			dest[i] = Normalize(v)
+		}
+		return dest
+	case []byte:
+		// Match how encoding/json represents binary data
+		return Normalize(marshalJSONValue(t))
+	case time.Time:
+		return t.UTC().Format(TimeLayout)
+	case primitive.DateTime:
+		return t.Time().UTC().Format(TimeLayout)
+	case primitive.ObjectID:
+		return t.Hex()
+	case primitive.Regex:
+		return map[string]interface{}{"$regex": t.Pattern, "$options": t.Options}
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return t.String()
+		}
+		return f
+	}
+
+	// Handle the remaining numeric types and typed collections, e.g. []string
+	rv := reflect.ValueOf(src)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice, reflect.Array:
+		dest := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			dest[i] = Normalize(rv.Index(i).Interface())
+		}
+		return dest
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			dest := make(map[string]interface{}, rv.Len())
+			iter := rv.MapRange()
+			for iter.Next() {
+				dest[iter.Key().String()] = Normalize(iter.Value().Interface())
+			}
+			return dest
+		}
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return Normalize(rv.Elem().Interface())
+	}
+
+	// Fall back to the value's json representation, which is how Porter
+	// serializes its documents in the first place.
+	return marshalJSONValue(src)
+}
+
+// NormalizeDocument converts a document into its generic representation.
+func NormalizeDocument(src bson.M) Document {
+	doc, _ := Normalize(map[string]interface{}(src)).(map[string]interface{})
+	if doc == nil {
+		doc = Document{}
+	}
+	return doc
+}
+
+func marshalJSONValue(src interface{}) interface{} {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return nil
+	}
+	var dest interface{}
+	if err = json.Unmarshal(data, &dest); err != nil {
+		return nil
+	}
+	return dest
+}
+
+// lookupPath resolves a dotted path, e.g. "status.runId" against a value,
+// following MongoDB semantics where a path traverses into each element of an
+// array that it encounters. Returns the matched values and if the path was found.
+func lookupPath(src interface{}, path []string) ([]interface{}, bool) {
+	if len(path) == 0 {
+		return []interface{}{src}, true
+	}
+
+	switch t := src.(type) {
+	case map[string]interface{}:
+		child, ok := t[path[0]]
+		if !ok {
+			return nil, false
+		}
+		return lookupPath(child, path[1:])
+	case []interface{}:
+		// A numeric path segment refers to a position in the array
+		if i, err := strconv.Atoi(path[0]); err == nil {
+			if i >= 0 && i < len(t) {
+				return lookupPath(t[i], path[1:])
+			}
+			return nil, false
+		}
+
+		var results []interface{}
+		found := false
+		for _, item := range t {
+			if _, isDoc := item.(map[string]interface{}); !isDoc {
+				continue
+			}
+			values, ok := lookupPath(item, path)
+			if ok {
+				found = true
+				results = append(results, values...)
+			}
+		}
+		return results, found
+	default:
+		return nil, false
+	}
+}
+
+// GetPath returns the value at the specified dotted path in a document.
+func GetPath(doc Document, path string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// SetPath sets the value at the specified dotted path in a document,
+// creating intermediate documents as necessary.
+func SetPath(doc Document, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	current := doc
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+// UnsetPath removes the value at the specified dotted path in a document.
+func UnsetPath(doc Document, path string) {
+	keys := strings.Split(path, ".")
+	current := doc
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, keys[len(keys)-1])
+}
+
+// Equal compares two normalized values.
+func Equal(a interface{}, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// typeOrder ranks values of different types the same way that MongoDB orders them.
+// See https://www.mongodb.com/docs/manual/reference/bson-type-comparison-order/
+func typeOrder(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 1
+	case float64:
+		return 2
+	case string:
+		return 3
+	case map[string]interface{}:
+		return 4
+	case []interface{}:
+		return 5
+	case bool:
+		return 8
+	default:
+		return 100
+	}
+}
+
+// Compare two normalized values, returning -1, 0 or 1.
+// Values of different types are ordered following MongoDB's comparison order.
+func Compare(a interface{}, b interface{}) int {
+	ta, tb := typeOrder(a), typeOrder(b)
+	if ta != tb {
+		if ta < tb {
+			return -1
+		}
+		return 1
+	}
+
+	switch av := a.(type) {
+	case nil:
+		return 0
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		default:
+			return 0
+		}
+	case string:
+		return strings.Compare(av, b.(string))
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		default:
+			return 1
+		}
+	case []interface{}:
+		bv := b.([]interface{})
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if c := Compare(av[i], bv[i]); c != 0 {
+				return c
+			}
+		}
+		return Compare(float64(len(av)), float64(len(bv)))
+	case map[string]interface{}:
+		bv := b.(map[string]interface{})
+		akeys := sortedKeys(av)
+		bkeys := sortedKeys(bv)
+		for i := 0; i < len(akeys) && i < len(bkeys); i++ {
+			if c := strings.Compare(akeys[i], bkeys[i]); c != 0 {
+				return c
+			}
+			if c := Compare(av[akeys[i]], bv[bkeys[i]]); c != 0 {
+				return c
+			}
+		}
+		return Compare(float64(len(akeys)), float64(len(bkeys)))
+	default:
+		return 0
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CloneDocument makes a deep copy of a document.
+func CloneDocument(doc Document) Document {
+	cloned, _ := clone(doc).(map[string]interface{})
+	return cloned
+}
+
+func clone(src interface{}) interface{} {
+	switch t := src.(type) {
+	case map[string]interface{}:
+		dest := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			dest[k] = clone(v)
+		}
+		return dest
+	case []interface{}:
+		dest := make([]interface{}, len(t))
+		for i, v := range t {
+			dest[i] = clone(v)
+		}
+		return dest
+	default:
+		return t
+	}
+}
@@ -0,0 +1,4 @@
+// Package sqlite implements the plugins.StorageProtocol interface, storing data
+// in a single SQLite database file in PORTER_HOME. Documents are stored as JSON
+// and queried in-process, so no database server or container is required.
+package sqlite
@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/storage/plugins"
+	"get.porter.sh/porter/pkg/storage/pluginstore"
+	"github.com/hashicorp/go-plugin"
+	"github.com/mitchellh/mapstructure"
+)
+
+// PluginKey is the identifier of the internal sqlite storage plugin.
+const PluginKey = plugins.PluginInterface + ".porter.sqlite"
+
+// PluginConfig supported by the sqlite plugin as defined in porter.yaml
+type PluginConfig struct {
+	// Path to the database file. Defaults to PORTER_HOME/porter.db.
+	Path string `mapstructure:"path,omitempty"`
+
+	// Timeout in seconds to wait for another process to release a lock on the database.
+	Timeout int `mapstructure:"timeout,omitempty"`
+}
+
+// NewPlugin creates an instance of the storage.porter.sqlite plugin
+func NewPlugin(c *config.Config, rawCfg interface{}) (plugin.Plugin, error) {
+	cfg := PluginConfig{
+		Timeout: 10,
+	}
+	if err := mapstructure.Decode(rawCfg, &cfg); err != nil {
+		return nil, fmt.Errorf("error reading plugin configuration: %w", err)
+	}
+
+	store := NewStore(c, cfg)
+	return pluginstore.NewPlugin(c.Context, store), nil
+}
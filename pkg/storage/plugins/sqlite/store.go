@@ -0,0 +1,549 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/storage/plugins"
+	"get.porter.sh/porter/pkg/storage/plugins/query"
+	"get.porter.sh/porter/pkg/tracing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
+
+	// Register the pure-go sqlite database driver
+	_ "modernc.org/sqlite"
+)
+
+var _ plugins.StorageProtocol = &Store{}
+
+const (
+	// DatabaseFile is the name of the database file created in PORTER_HOME
+	// when a path is not configured.
+	DatabaseFile = "porter.db"
+
+	// InMemoryDatabase is the path that configures the plugin to keep all data
+	// in memory, which is useful for testing.
+	InMemoryDatabase = ":memory:"
+)
+
+// fieldNamePattern limits which field names are embedded in SQL statements,
+// as json paths for indices or filters.
+var fieldNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// Store is a storage plugin for porter that saves its data to a SQLite
+// database file, suitable for machines without Docker or a MongoDB server.
+//
+// Each collection is a table with the document's _id and its json
+// representation. Top-level equality conditions are pushed down to SQLite, and
+// the complete query is evaluated in-process using the query package.
+type Store struct {
+	config  *config.Config
+	path    string
+	timeout time.Duration
+	db      *sql.DB
+
+	// tables that are known to exist
+	tables     map[string]bool
+	tablesLock sync.Mutex
+}
+
+// NewStore creates a new storage engine that uses SQLite.
+func NewStore(c *config.Config, cfg PluginConfig) *Store {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 // default to 10 seconds
+	}
+	return &Store{
+		config:  c,
+		path:    cfg.Path,
+		timeout: time.Duration(timeout) * time.Second,
+		tables:  make(map[string]bool),
+	}
+}
+
+// Connect initializes the plugin for use.
+// The plugin itself is responsible for ensuring it was called.
+// Close is called automatically when the plugin is used by Porter.
+func (s *Store) Connect(ctx context.Context) error {
+	if s.db != nil {
+		return nil
+	}
+
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+
+	path, err := s.GetDatabasePath()
+	if err != nil {
+		return span.Error(err)
+	}
+	span.SetAttributes(attribute.String("path", path))
+
+	if path != InMemoryDatabase {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return span.Error(fmt.Errorf("could not create the directory for the sqlite database %s: %w", path, err))
+		}
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", s.timeout.Milliseconds()))
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "synchronous(NORMAL)")
+	// Take the write lock when a transaction starts so that read-modify-write
+	// operations from concurrent porter processes are serialized
+	params.Add("_txlock", "immediate")
+	dsn := fmt.Sprintf("file:%s?%s", filepath.ToSlash(path), params.Encode())
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return span.Error(fmt.Errorf("could not open the sqlite database %s: %w", path, err))
+	}
+
+	// Porter is the only client of the database in this process, and an
+	// in-memory database only exists for the lifetime of its connection.
+	db.SetMaxOpenConns(1)
+
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return span.Error(fmt.Errorf("could not connect to the sqlite database %s: %w", path, err))
+	}
+
+	span.Debugf("storing data in %s", path)
+	s.db = db
+	return nil
+}
+
+// GetDatabasePath returns the location of the database file.
+func (s *Store) GetDatabasePath() (string, error) {
+	if s.path != "" {
+		return s.path, nil
+	}
+
+	home, err := s.config.GetHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, DatabaseFile), nil
+}
+
+func (s *Store) Close() error {
+	if s.db != nil {
+		err := s.db.Close()
+		s.db = nil
+		s.tables = make(map[string]bool)
+		return err
+	}
+	return nil
+}
+
+// EnsureIndex makes sure that the specified indexes exist and are
+// defined appropriately.
+func (s *Store) EnsureIndex(ctx context.Context, opts plugins.EnsureIndexOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	for _, index := range opts.Indices {
+		if err := s.ensureCollection(ctx, index.Collection); err != nil {
+			return span.Error(err)
+		}
+
+		nameParts := []string{index.Collection}
+		columns := make([]string, 0, len(index.Keys))
+		for _, key := range index.Keys {
+			if !fieldNamePattern.MatchString(key.Key) {
+				return span.Errorf("invalid index key %q on the %s collection", key.Key, index.Collection)
+			}
+
+			column := jsonField(key.Key)
+			nameParts = append(nameParts, strings.ReplaceAll(key.Key, ".", "_"))
+			if direction, ok := query.Normalize(key.Value).(float64); ok && direction < 0 {
+				column += " DESC"
+				nameParts = append(nameParts, "desc")
+			}
+			columns = append(columns, column)
+		}
+
+		createIndex := "CREATE INDEX"
+		if index.Unique {
+			createIndex = "CREATE UNIQUE INDEX"
+			nameParts = append(nameParts, "unique")
+		}
+		stmt := fmt.Sprintf("%s IF NOT EXISTS %s ON %s (%s)", createIndex,
+			quoteIdentifier(strings.Join(nameParts, "_")), quoteIdentifier(index.Collection), strings.Join(columns, ", "))
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return span.Error(fmt.Errorf("invalid index specified on the %s collection: %w", index.Collection, err))
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) Aggregate(ctx context.Context, opts plugins.AggregateOptions) ([]bson.Raw, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	docs, err := s.find(ctx, s.db, opts.Collection, nil)
+	if err != nil {
+		return nil, span.Error(err)
+	}
+
+	load := func(collection string) ([]query.Document, error) {
+		return s.find(ctx, s.db, collection, nil)
+	}
+	results, err := query.Aggregate(docs, opts.Pipeline, load)
+	if err != nil {
+		return nil, span.Error(err)
+	}
+
+	raw, err := toRawList(results)
+	return raw, span.Error(err)
+}
+
+func (s *Store) Count(ctx context.Context, opts plugins.CountOptions) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return 0, err
+	}
+
+	docs, err := s.find(ctx, s.db, opts.Collection, opts.Filter)
+	if err != nil {
+		return 0, span.Error(err)
+	}
+	return int64(len(docs)), nil
+}
+
+func (s *Store) Find(ctx context.Context, opts plugins.FindOptions) ([]bson.Raw, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	docs, err := s.find(ctx, s.db, opts.Collection, opts.Filter)
+	if err != nil {
+		return nil, span.Error(err)
+	}
+
+	if err = query.Sort(docs, opts.Sort); err != nil {
+		return nil, span.Error(err)
+	}
+	docs = query.Page(docs, opts.Skip, opts.Limit)
+
+	if len(opts.Select) > 0 {
+		projection := query.Normalize(opts.Select).(map[string]interface{})
+		for i, doc := range docs {
+			if docs[i], err = query.Project(doc, projection); err != nil {
+				return nil, span.Error(err)
+			}
+		}
+	}
+
+	raw, err := toRawList(docs)
+	return raw, span.Error(err)
+}
+
+func (s *Store) Insert(ctx context.Context, opts plugins.InsertOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	err := s.withTransaction(ctx, opts.Collection, func(tx *sql.Tx) error {
+		for _, rawDoc := range opts.Documents {
+			doc := query.NormalizeDocument(rawDoc)
+			if err := s.insert(ctx, tx, opts.Collection, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return span.Error(err)
+}
+
+func (s *Store) Patch(ctx context.Context, opts plugins.PatchOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	err := s.withTransaction(ctx, opts.Collection, func(tx *sql.Tx) error {
+		docs, err := s.find(ctx, tx, opts.Collection, opts.QueryDocument)
+		if err != nil || len(docs) == 0 {
+			return err
+		}
+
+		doc, err := query.Update(docs[0], opts.Transformation)
+		if err != nil {
+			return err
+		}
+		return s.replace(ctx, tx, opts.Collection, docs[0]["_id"], doc)
+	})
+	return span.Error(err)
+}
+
+func (s *Store) Remove(ctx context.Context, opts plugins.RemoveOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	err := s.withTransaction(ctx, opts.Collection, func(tx *sql.Tx) error {
+		docs, err := s.find(ctx, tx, opts.Collection, opts.Filter)
+		if err != nil {
+			return err
+		}
+		if !opts.All && len(docs) > 1 {
+			docs = docs[:1]
+		}
+
+		stmt := fmt.Sprintf("DELETE FROM %s WHERE id = ?", quoteIdentifier(opts.Collection))
+		for _, doc := range docs {
+			if _, err = tx.ExecContext(ctx, stmt, documentKey(doc["_id"])); err != nil {
+				return fmt.Errorf("could not remove document from the %s collection: %w", opts.Collection, err)
+			}
+		}
+		return nil
+	})
+	return span.Error(err)
+}
+
+func (s *Store) Update(ctx context.Context, opts plugins.UpdateOptions) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.EndSpan()
+	if err := s.Connect(ctx); err != nil {
+		return err
+	}
+
+	err := s.withTransaction(ctx, opts.Collection, func(tx *sql.Tx) error {
+		docs, err := s.find(ctx, tx, opts.Collection, opts.Filter)
+		if err != nil {
+			return err
+		}
+
+		doc := query.NormalizeDocument(opts.Document)
+		if len(docs) == 0 {
+			if !opts.Upsert {
+				return nil
+			}
+
+			// Use the _id from the filter when the replacement document doesn't have one
+			if _, ok := doc["_id"]; !ok {
+				if id, ok := opts.Filter["_id"]; ok {
+					doc["_id"] = query.Normalize(id)
+				}
+			}
+			return s.insert(ctx, tx, opts.Collection, doc)
+		}
+
+		existingID := docs[0]["_id"]
+		if id, ok := doc["_id"]; ok && !query.Equal(id, existingID) {
+			return fmt.Errorf("cannot change the _id of a document in the %s collection", opts.Collection)
+		}
+		return s.replace(ctx, tx, opts.Collection, existingID, doc)
+	})
+	return span.Error(err)
+}
+
+// queryer can execute a query against either the database or a transaction.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// find the documents in a collection that match the filter, in the order in which
+// they were inserted.
+func (s *Store) find(ctx context.Context, db queryer, collection string, filter bson.M) ([]query.Document, error) {
+	if err := s.ensureCollection(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	where, args := buildWhereClause(filter)
+	stmt := fmt.Sprintf("SELECT doc FROM %s%s ORDER BY rowid", quoteIdentifier(collection), where)
+	rows, err := db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not query the %s collection: %w", collection, err)
+	}
+	defer rows.Close()
+
+	var docs []query.Document
+	for rows.Next() {
+		var data string
+		if err = rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var doc query.Document
+		if err = json.Unmarshal([]byte(data), &doc); err != nil {
+			return nil, fmt.Errorf("could not parse a document from the %s collection: %w", collection, err)
+		}
+		docs = append(docs, doc)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(filter) == 0 {
+		return docs, nil
+	}
+	return query.Filter(docs, filter)
+}
+
+// buildWhereClause narrows down the rows returned from the database using the
+// top-level equality conditions in the filter. It is only an optimization, the
+// returned documents must still be matched against the entire filter.
+func buildWhereClause(filter bson.M) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for key, value := range filter {
+		if !fieldNamePattern.MatchString(key) || strings.Contains(key, ".") {
+			continue
+		}
+
+		switch v := query.Normalize(value).(type) {
+		case string, float64, bool:
+			if key == "_id" {
+				conditions = append(conditions, "id = ?")
+				args = append(args, documentKey(v))
+				continue
+			}
+
+			// A condition on an array field matches when any element is equal,
+			// so only rule out documents where the field is not an array.
+			conditions = append(conditions, fmt.Sprintf("(%s = ? OR json_type(doc, '%s') = 'array')", jsonField(key), jsonPath(key)))
+			args = append(args, v)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (s *Store) insert(ctx context.Context, tx *sql.Tx, collection string, doc query.Document) error {
+	// Porter relies upon the generated _id of documents sorting in the order
+	// in which they were created, which a ULID provides
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = cnab.NewULID()
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("could not marshal document for the %s collection: %w", collection, err)
+	}
+
+	stmt := fmt.Sprintf("INSERT INTO %s (id, doc) VALUES (?, ?)", quoteIdentifier(collection))
+	if _, err = tx.ExecContext(ctx, stmt, documentKey(doc["_id"]), string(data)); err != nil {
+		return fmt.Errorf("could not insert document into the %s collection: %w", collection, err)
+	}
+	return nil
+}
+
+func (s *Store) replace(ctx context.Context, tx *sql.Tx, collection string, id interface{}, doc query.Document) error {
+	doc["_id"] = id
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("could not marshal document for the %s collection: %w", collection, err)
+	}
+
+	stmt := fmt.Sprintf("UPDATE %s SET doc = ? WHERE id = ?", quoteIdentifier(collection))
+	if _, err = tx.ExecContext(ctx, stmt, string(data), documentKey(id)); err != nil {
+		return fmt.Errorf("could not update document in the %s collection: %w", collection, err)
+	}
+	return nil
+}
+
+// withTransaction runs the specified function in a transaction, committing
+// when it is successful and otherwise rolling back.
+func (s *Store) withTransaction(ctx context.Context, collection string, fn func(tx *sql.Tx) error) error {
+	if err := s.ensureCollection(ctx, collection); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not start a transaction: %w", err)
+	}
+
+	if err = fn(tx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// ensureCollection creates the table for a collection if it doesn't exist.
+func (s *Store) ensureCollection(ctx context.Context, collection string) error {
+	s.tablesLock.Lock()
+	defer s.tablesLock.Unlock()
+
+	if s.tables[collection] {
+		return nil
+	}
+
+	if collection == "" {
+		return errors.New("no collection was specified")
+	}
+
+	stmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, doc TEXT NOT NULL)", quoteIdentifier(collection))
+	if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("could not create the %s collection: %w", collection, err)
+	}
+
+	s.tables[collection] = true
+	return nil
+}
+
+// documentKey converts a document _id to the value stored in the id column.
+func documentKey(id interface{}) string {
+	if s, ok := id.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(id)
+	return string(data)
+}
+
+func jsonField(key string) string {
+	return fmt.Sprintf("json_extract(doc, '%s')", jsonPath(key))
+}
+
+// jsonPath converts a field name, such as status.runId, to a json path with
+// each segment quoted, so that names such as -resultId are valid.
+func jsonPath(key string) string {
+	return `$."` + strings.ReplaceAll(key, ".", `"."`) + `"`
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func toRawList(docs []query.Document) ([]bson.Raw, error) {
+	results := make([]bson.Raw, len(docs))
+	for i, doc := range docs {
+		data, err := bson.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert document to bson: %w", err)
+		}
+		results[i] = data
+	}
+	return results, nil
+}
@@ -0,0 +1,241 @@
+package sqlite_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"get.porter.sh/porter/pkg/cnab"
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/storage"
+	"get.porter.sh/porter/pkg/storage/plugins/proto"
+	"get.porter.sh/porter/pkg/storage/plugins/sqlite"
+	"get.porter.sh/porter/pkg/storage/pluginstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func newTestStore(t *testing.T) storage.Store {
+	c := config.NewTestConfig(t)
+	impl := sqlite.NewStore(c.Config, sqlite.PluginConfig{Path: filepath.Join(t.TempDir(), sqlite.DatabaseFile)})
+	t.Cleanup(func() { impl.Close() })
+
+	store := storage.NewPluginAdapter(impl)
+	require.NoError(t, storage.EnsureInstallationIndices(context.Background(), store))
+	return store
+}
+
+func TestStore_GetDatabasePath(t *testing.T) {
+	c := config.NewTestConfig(t)
+
+	s := sqlite.NewStore(c.Config, sqlite.PluginConfig{})
+	path, err := s.GetDatabasePath()
+	require.NoError(t, err)
+	require.Equal(t, filepath.FromSlash("/home/myuser/.porter/porter.db"), path)
+}
+
+func TestStore_Installations(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	installations := storage.NewInstallationStore(store)
+
+	mysql := storage.NewInstallation("dev", "mysql")
+	mysql.Labels = map[string]string{"team": "data"}
+	mysql.CredentialSets = []string{"azure"}
+	require.NoError(t, installations.InsertInstallation(ctx, mysql))
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "wordpress")))
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("", "mysql")))
+
+	err := installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql"))
+	require.ErrorContains(t, err, "UNIQUE constraint failed", "the unique index on namespace and name should be enforced")
+
+	t.Run("list", func(t *testing.T) {
+		results, err := installations.ListInstallations(ctx, storage.ListOptions{Namespace: "dev"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "mysql", results[0].Name)
+		assert.Equal(t, "wordpress", results[1].Name)
+
+		results, err = installations.ListInstallations(ctx, storage.ListOptions{Namespace: "*", Name: "sql"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "", results[0].Namespace, "the results should be sorted by namespace")
+
+		results, err = installations.ListInstallations(ctx, storage.ListOptions{Namespace: "*", Labels: map[string]string{"team": "data"}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+	})
+
+	t.Run("query array field", func(t *testing.T) {
+		results, err := installations.FindInstallations(ctx, storage.FindOptions{Filter: bson.M{"credentialSets": "azure"}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "mysql", results[0].Name)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mysql.Uninstalled = true
+		require.NoError(t, installations.UpdateInstallation(ctx, mysql))
+
+		got, err := installations.GetInstallation(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		assert.True(t, got.Uninstalled)
+		assert.Equal(t, mysql.Labels, got.Labels)
+
+		results, err := installations.FindInstallations(ctx, storage.FindOptions{Filter: bson.M{"uninstalled": bson.M{"$ne": true}}})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		require.NoError(t, installations.UpsertInstallation(ctx, storage.NewInstallation("test", "redis")))
+
+		count, err := store.Count(ctx, storage.CollectionInstallations, storage.CountOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := installations.GetInstallation(ctx, "dev", "missing")
+		require.ErrorIs(t, err, storage.ErrNotFound{})
+	})
+}
+
+func TestStore_Runs(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	installations := storage.NewInstallationStore(store)
+
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql")))
+
+	run1 := storage.NewRun("dev", "mysql")
+	run1.Action = cnab.ActionInstall
+	require.NoError(t, installations.InsertRun(ctx, run1))
+	result1 := run1.NewResult(cnab.StatusRunning)
+	require.NoError(t, installations.InsertResult(ctx, result1))
+	result2 := run1.NewResult(cnab.StatusSucceeded)
+	require.NoError(t, installations.InsertResult(ctx, result2))
+	require.NoError(t, installations.InsertOutput(ctx, result2.NewOutput("connstr", []byte("v1"))))
+	require.NoError(t, installations.InsertOutput(ctx, result2.NewOutput("port", []byte("3306"))))
+
+	run2 := storage.NewRun("dev", "mysql")
+	run2.Action = cnab.ActionUpgrade
+	require.NoError(t, installations.InsertRun(ctx, run2))
+	result3 := run2.NewResult(cnab.StatusRunning)
+	require.NoError(t, installations.InsertResult(ctx, result3))
+	require.NoError(t, installations.InsertOutput(ctx, result3.NewOutput("connstr", []byte("v2"))))
+
+	t.Run("active runs", func(t *testing.T) {
+		runs, err := installations.GetActiveRuns(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, run2.ID, runs[0].ID)
+	})
+
+	t.Run("last run", func(t *testing.T) {
+		run, err := installations.GetLastRun(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		assert.Equal(t, run2.ID, run.ID)
+	})
+
+	t.Run("list runs", func(t *testing.T) {
+		runs, results, err := installations.ListRuns(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Len(t, results[run1.ID], 2)
+		assert.Len(t, results[run2.ID], 1)
+	})
+
+	t.Run("last outputs", func(t *testing.T) {
+		outputs, err := installations.GetLastOutputs(ctx, "dev", "mysql")
+		require.NoError(t, err)
+		require.Equal(t, 2, outputs.Len())
+
+		connstr, ok := outputs.GetByName("connstr")
+		require.True(t, ok)
+		assert.Equal(t, "v2", string(connstr.Value))
+	})
+
+	t.Run("remove installation", func(t *testing.T) {
+		require.NoError(t, installations.RemoveInstallation(ctx, "dev", "mysql"))
+
+		for _, collection := range []string{storage.CollectionRuns, storage.CollectionResults, storage.CollectionOutputs} {
+			count, err := store.Count(ctx, collection, storage.CountOptions{})
+			require.NoError(t, err)
+			assert.Zero(t, count, "expected all documents in %s to be removed", collection)
+		}
+	})
+}
+
+func TestStore_Patch(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	installations := storage.NewInstallationStore(store)
+
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql")))
+
+	err := store.Patch(ctx, storage.CollectionInstallations, storage.PatchOptions{
+		QueryDocument:  bson.M{"namespace": "dev", "name": "mysql"},
+		Transformation: bson.D{{Key: "$set", Value: bson.M{"labels.team": "data"}}},
+	})
+	require.NoError(t, err)
+
+	got, err := installations.GetInstallation(ctx, "dev", "mysql")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "data"}, got.Labels)
+}
+
+func TestStore_Persisted(t *testing.T) {
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	cfg := sqlite.PluginConfig{Path: filepath.Join(t.TempDir(), "data", sqlite.DatabaseFile)}
+
+	impl := sqlite.NewStore(c.Config, cfg)
+	require.NoError(t, storage.NewInstallationStore(storage.NewPluginAdapter(impl)).InsertInstallation(ctx, storage.NewInstallation("dev", "mysql")))
+	require.NoError(t, impl.Close())
+
+	impl = sqlite.NewStore(c.Config, cfg)
+	defer impl.Close()
+	_, err := storage.NewInstallationStore(storage.NewPluginAdapter(impl)).GetInstallation(ctx, "dev", "mysql")
+	require.NoError(t, err, "the installation should be read back from the database file")
+}
+
+func TestStore_OverGRPC(t *testing.T) {
+	// Porter always talks to the plugin over grpc, so check that the queries
+	// it makes survive the round trip
+	ctx := context.Background()
+	c := config.NewTestConfig(t)
+	impl := sqlite.NewStore(c.Config, sqlite.PluginConfig{Path: filepath.Join(t.TempDir(), sqlite.DatabaseFile)})
+	defer impl.Close()
+
+	lis, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	proto.RegisterStorageProtocolServer(grpcServer, pluginstore.NewServer(c.Context, impl))
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	store := storage.NewPluginAdapter(pluginstore.NewClient(proto.NewStorageProtocolClient(conn)))
+	require.NoError(t, storage.EnsureInstallationIndices(ctx, store))
+	installations := storage.NewInstallationStore(store)
+
+	require.NoError(t, installations.InsertInstallation(ctx, storage.NewInstallation("dev", "mysql")))
+	run1 := storage.NewRun("dev", "mysql")
+	require.NoError(t, installations.InsertRun(ctx, run1))
+	require.NoError(t, installations.InsertResult(ctx, run1.NewResult(cnab.StatusFailed)))
+	run2 := storage.NewRun("dev", "mysql")
+	require.NoError(t, installations.InsertRun(ctx, run2))
+
+	runs, err := installations.GetActiveRuns(ctx, "dev", "mysql")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, run2.ID, runs[0].ID)
+}
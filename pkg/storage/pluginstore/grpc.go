@@ -277,6 +277,25 @@ func ConvertBsonToPrimitives(src interface{}) interface{} {
 			raw[k] = ConvertBsonToPrimitives(v)
 		}
 		return raw
+	case map[string]interface{}:
+		raw := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			raw[k] = ConvertBsonToPrimitives(v)
+		}
+		return raw
+	case []interface{}:
+		raw := make([]interface{}, len(t))
+		for i, item := range t {
+			raw[i] = ConvertBsonToPrimitives(item)
+		}
+		return raw
+	case []string:
+		// protobuf only understands []interface{}, e.g. {"$in": []string{"a", "b"}}
+		raw := make([]interface{}, len(t))
+		for i, item := range t {
+			raw[i] = item
+		}
+		return raw
 	default:
 		return src
 	}
@@ -293,10 +312,14 @@ func ConvertSliceToBsonD(src interface{}) interface{} {
 		toBson := make(bson.D, 0, len(tv))
 		for _, item := range tv {
 			converted := ConvertSliceToBsonD(item)
-			if m, ok := converted.(map[string]interface{}); ok {
-				for k, v := range m {
-					toBson = append(toBson, bson.E{Key: k, Value: v})
-				}
+			m, ok := converted.(map[string]interface{})
+			if !ok {
+				// Only a list of documents represents a bson.D, leave arrays of
+				// values, such as the operand of $in, as-is.
+				return tv
+			}
+			for k, v := range m {
+				toBson = append(toBson, bson.E{Key: k, Value: v})
 			}
 		}
 		return toBson
@@ -79,3 +79,21 @@ func TestConvertBsonD(t *testing.T) {
 	}
 	require.Equal(t, wantDest, dest)
 }
+
+func TestNewPipeline_ArrayOperands(t *testing.T) {
+	src := []bson.D{
+		{{Key: "$match", Value: bson.M{
+			"status": bson.M{"$nin": []string{"succeeded", "failed"}},
+		}}},
+	}
+
+	tmp := NewPipeline(src)
+	dest := AsOrderedMapList(tmp)
+
+	wantDest := []bson.D{
+		{{Key: "$match", Value: map[string]interface{}{
+			"status": map[string]interface{}{"$nin": []interface{}{"succeeded", "failed"}},
+		}}},
+	}
+	require.Equal(t, wantDest, dest)
+}
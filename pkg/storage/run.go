@@ -99,6 +99,16 @@ type Run struct {
 	// are re-resolved. The value should contain the hash type, e.g. sha256:abc123...
 	// This is a status/audit field and is not used to resolve credentials for a Run.
 	CredentialsDigest string `json:"credentialsDigest,omitempty"`
+
+	// RollbackTo is the ID of the previous run whose bundle and parameters were
+	// restored by this run when the installation was rolled back.
+	// This is a status/audit field and is not used when executing a Run.
+	RollbackTo string `json:"rollbackTo,omitempty"`
+
+	// ResumedFrom is the ID of the failed run that was resumed by this run.
+	// The steps completed by the failed run were skipped by this run.
+	// This is a status/audit field and is not used when executing a Run.
+	ResumedFrom string `json:"resumedFrom,omitempty"`
 }
 
 // rawRun is an alias for Run that does not have a json marshal functions defined,
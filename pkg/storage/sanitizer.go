@@ -2,11 +2,14 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"get.porter.sh/porter/pkg/cnab"
 	"get.porter.sh/porter/pkg/secrets"
+	"get.porter.sh/porter/pkg/secrets/plugins"
 	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/hashicorp/go-multierror"
 )
 
 // Sanitizer identifies sensitive data in a database record, and replaces it with
@@ -99,6 +102,20 @@ func sanitizedParam(param secrets.SourceMap, id string) secrets.SourceMap {
 	return param
 }
 
+// SanitizedParameterSecrets returns the keys of the secrets created by the
+// Sanitizer for the sensitive parameters of the run or installation with the
+// specified id. Secrets that are referenced by the parameters but were created
+// by the user, for example in a parameter set, are not included.
+func SanitizedParameterSecrets(params []secrets.SourceMap, id string) []string {
+	var keys []string
+	for _, param := range params {
+		if param.Source.Strategy == secrets.SourceSecret && param.Source.Hint == sanitizedParam(param, id).Source.Hint {
+			keys = append(keys, param.Source.Hint)
+		}
+	}
+	return keys
+}
+
 // RestoreParameterSet resolves the raw parameter data from a secrets store.
 func (s *Sanitizer) RestoreParameterSet(ctx context.Context, pset ParameterSet, bun cnab.ExtendedBundle) (map[string]interface{}, error) {
 	params, err := s.parameter.ResolveAll(ctx, pset, pset.Keys())
@@ -158,6 +175,23 @@ func sanitizedOutput(output Output) Output {
 
 }
 
+// RemoveSecrets deletes the secrets created by the Sanitizer with the specified
+// keys. When the secrets plugin does not support deleting secrets, an error
+// wrapping plugins.ErrNotImplemented is returned without deleting any secrets.
+func (s *Sanitizer) RemoveSecrets(ctx context.Context, keys []string) error {
+	var errs error
+	for _, key := range keys {
+		err := s.secrets.Delete(ctx, secrets.SourceSecret, key)
+		if errors.Is(err, plugins.ErrNotImplemented) {
+			return err
+		}
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("failed to delete secret %q: %w", key, err))
+		}
+	}
+	return errs
+}
+
 // RestoreOutputs retrieves all raw output value and return the restored outputs
 // record.
 func (s *Sanitizer) RestoreOutputs(ctx context.Context, o Outputs) (Outputs, error) {
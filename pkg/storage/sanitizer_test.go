@@ -170,3 +170,27 @@ func TestSanitizer_Output(t *testing.T) {
 	require.Truef(t, reflect.DeepEqual(expectedOutputs, resolved), "expected outputs: %v, got outputs: %v", expectedOutputs, resolved)
 
 }
+
+func TestSanitizer_RemoveSecrets(t *testing.T) {
+	ctx := context.Background()
+	r := porter.NewTestPorter(t)
+	defer r.Close()
+
+	params := []secrets.SourceMap{
+		{Name: "password", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: "RUN_ID-password"}},
+		{Name: "token", Source: secrets.Source{Strategy: secrets.SourceSecret, Hint: "my-token"}},
+		{Name: "region", Source: secrets.Source{Strategy: host.SourceValue, Hint: "RUN_ID-region"}},
+	}
+	keys := storage.SanitizedParameterSecrets(params, "RUN_ID")
+	require.Equal(t, []string{"RUN_ID-password"}, keys, "only the secrets created by the sanitizer should be returned")
+
+	require.NoError(t, r.TestSecrets.Create(ctx, secrets.SourceSecret, "RUN_ID-password", "supersecret"))
+	require.NoError(t, r.TestSecrets.Create(ctx, secrets.SourceSecret, "my-token", "abc123"))
+
+	require.NoError(t, r.TestSanitizer.RemoveSecrets(ctx, append(keys, "RUN_ID-missing")))
+
+	_, err := r.TestSecrets.Resolve(ctx, secrets.SourceSecret, "RUN_ID-password")
+	require.Error(t, err, "the secret should have been deleted")
+	_, err = r.TestSecrets.Resolve(ctx, secrets.SourceSecret, "my-token")
+	require.NoError(t, err, "secrets that were not specified should be kept")
+}
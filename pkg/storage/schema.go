@@ -26,6 +26,10 @@ const (
 	// for all installation documents: installations, runs, results and outputs.
 	DefaultInstallationSchemaVersion = cnab.SchemaVersion("1.0.2")
 
+	// DefaultLogChunkSchemaVersion represents the version associated with the schema
+	// for log chunk documents.
+	DefaultLogChunkSchemaVersion = cnab.SchemaVersion("1.0.0")
+
 	// DefaultParameterSetSchemaVersion represents the version associated with the schema
 	//	// for parameter set documents.
 	DefaultParameterSetSchemaVersion = cnab.SchemaVersion("1.1.0")
@@ -38,6 +42,9 @@ var (
 	// DefaultInstallationSemverSchemaVersion is the semver representation of the DefaultInstallationSchemaVersion that is suitable for doing semver comparisons.
 	DefaultInstallationSemverSchemaVersion = semver.MustParse(string(DefaultInstallationSchemaVersion))
 
+	// DefaultLogChunkSemverSchemaVersion is the semver representation of the DefaultLogChunkSchemaVersion that is suitable for doing semver comparisons.
+	DefaultLogChunkSemverSchemaVersion = semver.MustParse(string(DefaultLogChunkSchemaVersion))
+
 	// DefaultParameterSetSemverSchemaVersion is the semver representation of the DefaultParameterSetSchemaVersion  that is suitable for doing semver comparisons.
 	DefaultParameterSetSemverSchemaVersion = semver.MustParse(string(DefaultParameterSetSchemaVersion))
 
@@ -47,6 +54,9 @@ var (
 	// SupportedInstallationSchemaVersions represents the set of allowed schema versions for Installation documents.
 	SupportedInstallationSchemaVersions = schema.MustParseConstraint("1.0.2")
 
+	// SupportedLogChunkSchemaVersions represents the set of allowed schema versions for LogChunk documents.
+	SupportedLogChunkSchemaVersions = schema.MustParseConstraint("1.0.0")
+
 	// SupportedParameterSetSchemaVersions represents the set of allowed schema versions for ParameterSet documents.
 	SupportedParameterSetSchemaVersions = schema.MustParseConstraint("1.0.1 || 1.1.0")
 )
@@ -62,6 +72,9 @@ type Schema struct {
 
 	// Parameters is the schema for the parameter spec documents.
 	Parameters cnab.SchemaVersion `json:"parameters"`
+
+	// Logs is the schema for the log chunk documents.
+	Logs cnab.SchemaVersion `json:"logs,omitempty"`
 }
 
 // NewSchema creates a schema document with the currently supported version for all subsystems.
@@ -71,6 +84,7 @@ func NewSchema() Schema {
 		Installations: DefaultInstallationSchemaVersion,
 		Credentials:   DefaultCredentialSetSchemaVersion,
 		Parameters:    DefaultParameterSetSchemaVersion,
+		Logs:          DefaultLogChunkSchemaVersion,
 	}
 }
 
@@ -79,7 +93,7 @@ func (s Schema) DefaultDocumentFilter() map[string]interface{} {
 }
 
 func (s Schema) IsOutOfDate() bool {
-	return s.ShouldMigrateInstallations() || s.ShouldMigrateCredentialSets() || s.ShouldMigrateParameterSets()
+	return s.ShouldMigrateInstallations() || s.ShouldMigrateCredentialSets() || s.ShouldMigrateParameterSets() || s.ShouldMigrateLogChunks()
 }
 
 // ShouldMigrateInstallations checks if the minimum version of the installation resources in the database is unsupported and requires a migration to work with this version of Porter.
@@ -105,3 +119,14 @@ func (s Schema) ShouldMigrateParameterSets() bool {
 	warnOnly, err := schema.ValidateSchemaVersion(schema.CheckStrategyExact, SupportedParameterSetSchemaVersions, string(s.Parameters), DefaultParameterSetSemverSchemaVersion)
 	return !warnOnly && err != nil
 }
+
+// ShouldMigrateLogChunks checks if the version of the log chunk resources in the database is unsupported and requires a migration to work with this version of Porter.
+// Databases created before log chunks were persisted do not have a version for them, and there are no log chunks to migrate.
+func (s Schema) ShouldMigrateLogChunks() bool {
+	if s.Logs == "" {
+		return false
+	}
+
+	warnOnly, err := schema.ValidateSchemaVersion(schema.CheckStrategyExact, SupportedLogChunkSchemaVersions, string(s.Logs), DefaultLogChunkSemverSchemaVersion)
+	return !warnOnly && err != nil
+}
@@ -0,0 +1,332 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"get.porter.sh/porter/pkg/secrets"
+	hostSecrets "get.porter.sh/porter/pkg/secrets/plugins/host"
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/cbroglie/mustache"
+	"github.com/cnabio/cnab-go/secrets/host"
+)
+
+// templateTagRegex matches the tags in a template, capturing the sigil of the
+// tag, such as # for a section, and the name of the value that it uses.
+var templateTagRegex = regexp.MustCompile(`{{{?\s*([#^/!>=&]?)\s*([^{}\s]+)\s*}?}}`)
+
+// disallowMissingVariables makes an unresolved reference in a template an
+// error, instead of an empty value. It is only set once, because sets may be
+// resolved at the same time.
+var disallowMissingVariables sync.Once
+
+// validSources are the source strategies supported in parameter and credential sets.
+var validSources = []string{secrets.SourceSecret, host.SourceValue, host.SourceEnv, host.SourcePath, host.SourceCommand,
+	secrets.SourceTemplate, secrets.SourceJSON, secrets.SourceBase64}
+
+// isCompositeSource determines if the value of a source is built from the
+// values of other parameters or credentials in the same set.
+func isCompositeSource(strategy string) bool {
+	return strategy == secrets.SourceTemplate || strategy == secrets.SourceJSON || strategy == secrets.SourceBase64
+}
+
+// sourceResolver resolves the values of the parameters or credentials in a set.
+// Composite sources are resolved from the values of the other entries in the
+// set, which are resolved once even when they are used by multiple sources.
+type sourceResolver struct {
+	secrets     secrets.Store
+	hostSecrets hostSecrets.Store
+	sources     secrets.StrategyList
+
+	// resolved values by the name of the parameter or credential.
+	resolved map[string]string
+
+	// resolving holds the names of the composite sources that are being
+	// resolved, to detect circular references.
+	resolving map[string]bool
+}
+
+func newSourceResolver(secretStore secrets.Store, hostStore hostSecrets.Store, sources secrets.StrategyList) *sourceResolver {
+	return &sourceResolver{
+		secrets:     secretStore,
+		hostSecrets: hostStore,
+		sources:     sources,
+		resolved:    make(map[string]string),
+		resolving:   make(map[string]bool),
+	}
+}
+
+// Resolve the value of a source in the set.
+func (r *sourceResolver) Resolve(ctx context.Context, source secrets.SourceMap) (string, error) {
+	if value, ok := r.resolved[source.Name]; ok {
+		return value, nil
+	}
+
+	var value string
+	var err error
+	switch {
+	case isCompositeSource(source.Source.Strategy):
+		if r.resolving[source.Name] {
+			return "", fmt.Errorf("%s has a circular reference to itself", source.Name)
+		}
+		r.resolving[source.Name] = true
+		value, err = r.resolveComposite(ctx, source.Source)
+		delete(r.resolving, source.Name)
+	case isHandledByHostPlugin(source.Source.Strategy):
+		value, err = r.hostSecrets.Resolve(ctx, source.Source.Strategy, source.Source.Hint)
+	default:
+		value, err = r.secrets.Resolve(ctx, source.Source.Strategy, source.Source.Hint)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	r.resolved[source.Name] = value
+	return value, nil
+}
+
+// resolveReference resolves the value of another entry in the set.
+func (r *sourceResolver) resolveReference(ctx context.Context, name string) (string, error) {
+	for _, source := range r.sources {
+		if source.Name == name {
+			value, err := r.Resolve(ctx, source)
+			if err != nil {
+				return "", fmt.Errorf("unable to resolve %s from %s %s: %w", name, source.Source.Strategy, source.Source.Hint, err)
+			}
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not defined in the set", name)
+}
+
+func (r *sourceResolver) resolveComposite(ctx context.Context, source secrets.Source) (string, error) {
+	refs, err := parseSourceReferences(source)
+	if err != nil {
+		return "", err
+	}
+
+	values := make(map[string]interface{}, len(refs))
+	for _, ref := range refs {
+		value, err := r.resolveReference(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		values[ref] = value
+	}
+
+	switch source.Strategy {
+	case secrets.SourceTemplate:
+		disallowMissingVariables.Do(func() { mustache.AllowMissingVariables = false })
+
+		// Render the values by their position in refs, instead of by name, so
+		// that names containing a dot are not treated as a nested lookup
+		tmplValues := make(map[string]interface{}, len(refs))
+		tmpl := renameSourceReferences(source, func(name string) string {
+			placeholder := fmt.Sprintf("ref%d", slices.Index(refs, name))
+			tmplValues[placeholder] = values[name]
+			return placeholder
+		})
+		value, err := mustache.RenderRaw(tmpl.Hint, true, tmplValues)
+		if err != nil {
+			return "", fmt.Errorf("unable to render template: %w", err)
+		}
+		return value, nil
+	case secrets.SourceJSON:
+		_, path, _ := splitJSONHint(source.Hint)
+		return queryJSON(values[refs[0]].(string), path)
+	case secrets.SourceBase64:
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(values[refs[0]].(string)))
+		if err != nil {
+			return "", fmt.Errorf("the value of %s is not base64 encoded: %w", refs[0], err)
+		}
+		return string(value), nil
+	default:
+		return "", fmt.Errorf("unsupported source strategy %s", source.Strategy)
+	}
+}
+
+// parseSourceReferences returns the names of the other entries in the set that
+// are used by a composite source.
+func parseSourceReferences(source secrets.Source) ([]string, error) {
+	switch source.Strategy {
+	case secrets.SourceTemplate:
+		var refs []string
+		for _, match := range templateTagRegex.FindAllStringSubmatch(source.Hint, -1) {
+			sigil, name := match[1], match[2]
+			if sigil == "/" || sigil == "!" || sigil == ">" || sigil == "=" || name == "." {
+				continue
+			}
+			if !slices.Contains(refs, name) {
+				refs = append(refs, name)
+			}
+		}
+		return refs, nil
+	case secrets.SourceJSON:
+		name, _, err := splitJSONHint(source.Hint)
+		if err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	case secrets.SourceBase64:
+		name := strings.TrimSpace(source.Hint)
+		if name == "" {
+			return nil, errors.New("the name of the base64 encoded value is not set")
+		}
+		return []string{name}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// splitJSONHint splits the hint of a json source, NAME#JSONPATH, into the name
+// of the entry with the JSON document and the JSONPath of the value.
+// The leading $. of the path is optional.
+func splitJSONHint(hint string) (string, string, error) {
+	name, path, ok := strings.Cut(hint, "#")
+	name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+	if !ok || name == "" || path == "" {
+		return "", "", fmt.Errorf("invalid json source %q, it must be in the format NAME#JSONPATH", hint)
+	}
+	if !strings.HasPrefix(path, "$") {
+		path = "$." + path
+	}
+	return name, path, nil
+}
+
+// queryJSON evaluates a JSONPath against a JSON document. Strings, numbers
+// and booleans are returned as-is, other values are returned as JSON.
+func queryJSON(doc string, path string) (string, error) {
+	var data interface{}
+	d := json.NewDecoder(bytes.NewBufferString(doc))
+	d.UseNumber()
+	if err := d.Decode(&data); err != nil {
+		return "", fmt.Errorf("the value is not valid json: %w", err)
+	}
+
+	value, err := jsonpath.Get(path, data)
+	if err != nil {
+		return "", fmt.Errorf("error evaluating jsonpath %q: %w", path, err)
+	}
+
+	switch t := value.(type) {
+	case map[string]interface{}, []interface{}:
+		result, err := json.Marshal(t)
+		if err != nil {
+			return "", fmt.Errorf("error marshaling jsonpath result for %q: %w", path, err)
+		}
+		return string(result), nil
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprintf("%v", t), nil
+	}
+}
+
+// SourceReferences returns the names of the other entries in the set that are
+// used, directly or through other composite sources, by the source of the
+// named entry. Entries that are not defined in the set are not included.
+func SourceReferences(sources secrets.StrategyList, name string) []string {
+	var refs []string
+	pending := []string{name}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		for _, source := range sources {
+			if source.Name != current {
+				continue
+			}
+			// Invalid sources are reported when the set is validated or resolved
+			names, _ := parseSourceReferences(source.Source)
+			for _, ref := range names {
+				if ref != name && !slices.Contains(refs, ref) && sources.Contains(ref) {
+					refs = append(refs, ref)
+					pending = append(pending, ref)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// ScopeSourceReferences returns the named entry and the other entries in the
+// set that are used to build its value. The other entries are renamed to
+// SCOPE:NAME, so that they are resolved from this set when they are combined
+// with entries from other sets that have the same name.
+func ScopeSourceReferences(sources secrets.StrategyList, name string, scope string) secrets.StrategyList {
+	refs := SourceReferences(sources, name)
+	rename := func(ref string) string {
+		if slices.Contains(refs, ref) {
+			return scope + ":" + ref
+		}
+		return ref
+	}
+
+	var scoped secrets.StrategyList
+	for _, source := range sources {
+		if source.Name != name && !slices.Contains(refs, source.Name) {
+			continue
+		}
+		source.Name = rename(source.Name)
+		source.Source = renameSourceReferences(source.Source, rename)
+		scoped = append(scoped, source)
+	}
+	return scoped
+}
+
+// renameSourceReferences updates a composite source to use the renamed entries.
+func renameSourceReferences(source secrets.Source, rename func(string) string) secrets.Source {
+	switch source.Strategy {
+	case secrets.SourceTemplate:
+		source.Hint = templateTagRegex.ReplaceAllStringFunc(source.Hint, func(tag string) string {
+			match := templateTagRegex.FindStringSubmatchIndex(tag)
+			sigil, name := tag[match[2]:match[3]], tag[match[4]:match[5]]
+			if sigil == "!" || sigil == ">" || sigil == "=" || name == "." {
+				return tag
+			}
+			return tag[:match[4]] + rename(name) + tag[match[5]:]
+		})
+	case secrets.SourceJSON:
+		if name, path, ok := strings.Cut(source.Hint, "#"); ok {
+			source.Hint = rename(strings.TrimSpace(name)) + "#" + path
+		}
+	case secrets.SourceBase64:
+		source.Hint = rename(strings.TrimSpace(source.Hint))
+	}
+	return source
+}
+
+// validateSources checks that the source strategies in a set are supported,
+// and that composite sources only use entries that are defined in the set.
+func validateSources(sources secrets.StrategyList) []error {
+	var errs []error
+	for _, source := range sources {
+		if !slices.Contains(validSources, source.Source.Strategy) {
+			errs = append(errs, fmt.Errorf(
+				"%s is not a valid source. Valid sources are: %s",
+				source.Source.Strategy,
+				strings.Join(validSources, ", "),
+			))
+			continue
+		}
+
+		refs, err := parseSourceReferences(source.Source)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid source for %s: %w", source.Name, err))
+			continue
+		}
+		for _, ref := range refs {
+			if !sources.Contains(ref) {
+				errs = append(errs, fmt.Errorf("%s uses %s, which is not defined in the set", source.Name, ref))
+			}
+		}
+	}
+	return errs
+}